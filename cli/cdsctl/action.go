@@ -11,6 +11,7 @@ import (
 	"github.com/ovh/cds/cli"
 	"github.com/ovh/cds/sdk"
 	actionSDK "github.com/ovh/cds/sdk/action"
+	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/exportentities"
 	"github.com/ovh/cds/sdk/slug"
 )
@@ -33,6 +34,9 @@ func action() *cobra.Command {
 		cli.NewCommand(actionDocCmd, actionDocRun, nil),
 		cli.NewCommand(actionImportCmd, actionImportRun, nil),
 		cli.NewCommand(actionExportCmd, actionExportRun, nil),
+		cli.NewCommand(actionPublishCmd, actionPublishRun, nil),
+		cli.NewListCommand(actionVersionsCmd, actionVersionsRun, nil),
+		cli.NewListCommand(actionUsageCmd, actionUsageRun, nil),
 		cli.NewCommand(actionBuiltinCmd, nil, []*cobra.Command{
 			cli.NewListCommand(actionBuiltinListCmd, actionBuiltinListRun, nil),
 			cli.NewGetCommand(actionBuiltinShowCmd, actionBuiltinShowRun, nil),
@@ -46,6 +50,9 @@ func newActionDisplay(a sdk.Action) actionDisplay {
 	if a.Group != nil {
 		name = fmt.Sprintf("%s/%s", a.Group.Name, a.Name)
 	}
+	if a.Version != "" {
+		name = fmt.Sprintf("%s@%s", name, a.Version)
+	}
 
 	ad := actionDisplay{
 		Fullname: name,
@@ -223,6 +230,10 @@ var actionExportCmd = cli.Command{
 			Usage:   "Specify export format (json or yaml)",
 			Default: "yaml",
 		},
+		{
+			Name:  "version",
+			Usage: "Export a published version of the action",
+		},
 	},
 }
 
@@ -232,7 +243,12 @@ func actionExportRun(v cli.Values) error {
 		return err
 	}
 
-	b, err := client.ActionExport(groupName, actionName, v.GetString("format"))
+	var mods []cdsclient.RequestModifier
+	if version := v.GetString("version"); version != "" {
+		mods = append(mods, cdsclient.WithVersion(version))
+	}
+
+	b, err := client.ActionExport(groupName, actionName, v.GetString("format"), mods...)
 	if err != nil {
 		return err
 	}
@@ -242,6 +258,110 @@ func actionExportRun(v cli.Values) error {
 	return nil
 }
 
+var actionPublishCmd = cli.Command{
+	Name:  "publish",
+	Short: "Publish an immutable version of a CDS action",
+	Long: `Publish the current content of an action as an immutable version. A published version can be used in a step with the name of the action suffixed by the version:
+
+	cdsctl action publish myGroup/myAction v1
+
+	# in a pipeline
+	steps:
+	- myGroup/myAction@v1:
+	    param1: value1
+`,
+	Args: []cli.Arg{
+		{Name: "action-path"},
+		{Name: "version"},
+	},
+}
+
+func actionPublishRun(v cli.Values) error {
+	groupName, actionName, err := cli.ParsePath(v.GetString("action-path"))
+	if err != nil {
+		return err
+	}
+
+	a, err := client.ActionPublish(groupName, actionName, v.GetString("version"))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Action %s/%s@%s published\n", groupName, a.Name, a.Version)
+	return nil
+}
+
+var actionVersionsCmd = cli.Command{
+	Name:  "versions",
+	Short: "List published versions of a CDS action",
+	Args: []cli.Arg{
+		{Name: "action-path"},
+	},
+}
+
+func actionVersionsRun(v cli.Values) (cli.ListResult, error) {
+	groupName, actionName, err := cli.ParsePath(v.GetString("action-path"))
+	if err != nil {
+		return nil, err
+	}
+
+	as, err := client.ActionVersionList(groupName, actionName)
+	if err != nil {
+		return nil, err
+	}
+
+	ads := make([]actionDisplay, len(as))
+	for i := range as {
+		ads[i] = newActionDisplay(as[i])
+	}
+
+	return cli.AsListResult(ads), nil
+}
+
+type actionUsageDisplay struct {
+	Type    string `cli:"Type"`
+	Path    string `cli:"Path,Key"`
+	Version string `cli:"Version"`
+}
+
+var actionUsageCmd = cli.Command{
+	Name:  "usage",
+	Short: "List pipelines and actions using a CDS action, with the version they use",
+	Args: []cli.Arg{
+		{Name: "action-path"},
+	},
+}
+
+func actionUsageRun(v cli.Values) (cli.ListResult, error) {
+	groupName, actionName, err := cli.ParsePath(v.GetString("action-path"))
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := client.ActionUsage(groupName, actionName)
+	if err != nil {
+		return nil, err
+	}
+
+	uds := make([]actionUsageDisplay, 0, len(u.Pipelines)+len(u.Actions))
+	for _, p := range u.Pipelines {
+		uds = append(uds, actionUsageDisplay{
+			Type:    "pipeline",
+			Path:    fmt.Sprintf("%s/%s/%s/%s", p.ProjectKey, p.PipelineName, p.StageName, p.JobName),
+			Version: p.ActionVersion,
+		})
+	}
+	for _, a := range u.Actions {
+		uds = append(uds, actionUsageDisplay{
+			Type:    "action",
+			Path:    fmt.Sprintf("%s/%s", a.GroupName, a.ParentActionName),
+			Version: a.ActionVersion,
+		})
+	}
+
+	return cli.AsListResult(uds), nil
+}
+
 var actionBuiltinListCmd = cli.Command{
 	Name:  "list",
 	Short: "List CDS builtin actions",
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"time"
 
@@ -27,6 +28,11 @@ func adminHooks() *cobra.Command {
 		cli.NewCommand(adminHooksTaskExecutionDeleteAllCmd, adminHooksTaskExecutionDeleteAllRun, nil),
 		cli.NewCommand(adminHooksTaskExecutionStartAllCmd, adminHooksTaskExecutionStartAllRun, nil),
 		cli.NewCommand(adminHooksTaskExecutionStopAllCmd, adminHooksTaskExecutionStopAllRun, nil),
+		cli.NewListCommand(adminHooksDeadLetterListCmd, adminHooksDeadLetterListRun, nil),
+		cli.NewCommand(adminHooksDeadLetterShowCmd, adminHooksDeadLetterShowRun, nil),
+		cli.NewCommand(adminHooksDeadLetterEditCmd, adminHooksDeadLetterEditRun, nil),
+		cli.NewCommand(adminHooksDeadLetterReplayCmd, adminHooksDeadLetterReplayRun, nil),
+		cli.NewCommand(adminHooksDeadLetterDeleteCmd, adminHooksDeadLetterDeleteRun, nil),
 	})
 }
 
@@ -36,7 +42,7 @@ var adminHooksTaskListCmd = cli.Command{
 	Flags: []cli.Flag{
 		{
 			Name:    "sort",
-			Usage:   "Sort task by nb_executions_total,nb_executions_todo",
+			Usage:   "Sort task by nb_executions_total,nb_executions_todo,nb_dead_letters",
 			Default: "",
 		},
 	},
@@ -71,6 +77,7 @@ func adminHooksTaskListRun(v cli.Values) (cli.ListResult, error) {
 		Cron              string `cli:"Cron"`
 		NbExecutionsTotal int    `cli:"Execs_Total"`
 		NbExecutionsTodo  int    `cli:"Execs_Todo"`
+		NbDeadLetters     int    `cli:"Dead_Letters"`
 	}
 
 	tss := []TaskDisplay{}
@@ -87,6 +94,7 @@ func adminHooksTaskListRun(v cli.Values) (cli.ListResult, error) {
 			Cron:              p.Config["cron"].Value,
 			NbExecutionsTotal: p.NbExecutionsTotal,
 			NbExecutionsTodo:  p.NbExecutionsTodo,
+			NbDeadLetters:     p.NbDeadLetters,
 		})
 	}
 
@@ -197,3 +205,122 @@ func adminHooksTaskExecutionStartAllRun(v cli.Values) error {
 	_, err := client.ServiceCallGET("hooks", "/task/bulk/start")
 	return err
 }
+
+var adminHooksDeadLetterListCmd = cli.Command{
+	Name:    "deadletters",
+	Short:   "List the executions of a task that have exhausted their retries",
+	Example: "cdsctl admin hooks deadletters 5178ce1f-2f76-45c5-a203-58c10c3e2c73",
+	Args: []cli.Arg{
+		{Name: "uuid"},
+	},
+}
+
+func adminHooksDeadLetterListRun(v cli.Values) (cli.ListResult, error) {
+	btes, err := client.ServiceCallGET("hooks", fmt.Sprintf("/task/%s/deadletter", v.GetString("uuid")))
+	if err != nil {
+		return nil, err
+	}
+	type DeadLetterDisplay struct {
+		sdk.TaskExecution
+		TimestampH string `cli:"Timestamp H"`
+	}
+	execs := []sdk.TaskExecution{}
+	if err := json.Unmarshal(btes, &execs); err != nil {
+		return nil, err
+	}
+	dls := make([]DeadLetterDisplay, 0, len(execs))
+	for _, e := range execs {
+		dls = append(dls, DeadLetterDisplay{
+			TaskExecution: e,
+			TimestampH:    time.Unix(0, e.Timestamp).Format(time.RFC3339),
+		})
+	}
+
+	return cli.AsListResult(dls), nil
+}
+
+var adminHooksDeadLetterShowCmd = cli.Command{
+	Name:    "deadletter-show",
+	Short:   "Show an execution of the dead letter of a task with its payload",
+	Example: "cdsctl admin hooks deadletter-show 5178ce1f-2f76-45c5-a203-58c10c3e2c73 1587114211283582000 > execution.json",
+	Args: []cli.Arg{
+		{Name: "uuid"},
+		{Name: "timestamp"},
+	},
+}
+
+func adminHooksDeadLetterShowRun(v cli.Values) error {
+	btes, err := client.ServiceCallGET("hooks", fmt.Sprintf("/task/%s/deadletter/%s", v.GetString("uuid"), v.GetString("timestamp")))
+	if err != nil {
+		return err
+	}
+	var e sdk.TaskExecution
+	if err := json.Unmarshal(btes, &e); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal: %v", err)
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+var adminHooksDeadLetterEditCmd = cli.Command{
+	Name:    "deadletter-edit",
+	Short:   "Edit the payload of an execution of the dead letter of a task",
+	Long:    "Update the payload of the execution from a JSON file, as given by the deadletter-show command",
+	Example: "cdsctl admin hooks deadletter-edit 5178ce1f-2f76-45c5-a203-58c10c3e2c73 1587114211283582000 execution.json",
+	Args: []cli.Arg{
+		{Name: "uuid"},
+		{Name: "timestamp"},
+		{Name: "file"},
+	},
+}
+
+func adminHooksDeadLetterEditRun(v cli.Values) error {
+	b, err := ioutil.ReadFile(v.GetString("file"))
+	if err != nil {
+		return fmt.Errorf("unable to read file %s: %v", v.GetString("file"), err)
+	}
+	var e sdk.TaskExecution
+	if err := json.Unmarshal(b, &e); err != nil {
+		return fmt.Errorf("unable to load file: %v", err)
+	}
+	_, err = client.ServiceCallPUT("hooks", fmt.Sprintf("/task/%s/deadletter/%s", v.GetString("uuid"), v.GetString("timestamp")), b)
+	return err
+}
+
+var adminHooksDeadLetterReplayCmd = cli.Command{
+	Name:    "deadletter-replay",
+	Short:   "Replay an execution of the dead letter of a task",
+	Example: "cdsctl admin hooks deadletter-replay 5178ce1f-2f76-45c5-a203-58c10c3e2c73 1587114211283582000",
+	Args: []cli.Arg{
+		{Name: "uuid"},
+		{Name: "timestamp"},
+	},
+}
+
+func adminHooksDeadLetterReplayRun(v cli.Values) error {
+	_, err := client.ServiceCallPOST("hooks", fmt.Sprintf("/task/%s/deadletter/%s/replay", v.GetString("uuid"), v.GetString("timestamp")), nil)
+	return err
+}
+
+var adminHooksDeadLetterDeleteCmd = cli.Command{
+	Name:    "deadletter-delete",
+	Short:   "Delete an execution of the dead letter of a task, or all of them if no timestamp is given",
+	Example: "cdsctl admin hooks deadletter-delete 5178ce1f-2f76-45c5-a203-58c10c3e2c73 1587114211283582000",
+	Args: []cli.Arg{
+		{Name: "uuid"},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "timestamp"},
+	},
+}
+
+func adminHooksDeadLetterDeleteRun(v cli.Values) error {
+	if ts := v.GetString("timestamp"); ts != "" {
+		return client.ServiceCallDELETE("hooks", fmt.Sprintf("/task/%s/deadletter/%s", v.GetString("uuid"), ts))
+	}
+	return client.ServiceCallDELETE("hooks", fmt.Sprintf("/task/%s/deadletter", v.GetString("uuid")))
+}
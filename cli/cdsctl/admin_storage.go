@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var adminStorageCmd = cli.Command{
+	Name:  "storage",
+	Short: "Manage CDS projects storage",
+}
+
+func adminStorage() *cobra.Command {
+	return cli.NewCommand(adminStorageCmd, nil, []*cobra.Command{
+		cli.NewListCommand(adminStorageUsageCmd, adminStorageUsageRun, nil),
+		cli.NewCommand(adminStorageQuotaCmd, adminStorageQuotaRun, nil),
+	})
+}
+
+var adminStorageUsageCmd = cli.Command{
+	Name:  "usage",
+	Short: "List projects by storage usage in bytes",
+}
+
+func adminStorageUsageRun(_ cli.Values) (cli.ListResult, error) {
+	usages, err := client.AdminStorageUsages()
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(usages), nil
+}
+
+var adminStorageQuotaCmd = cli.Command{
+	Name:  "quota",
+	Short: "Set the storage quotas of a project",
+	Long: `Set the storage quotas of a project in MB. A quota not given or set to 0 uses the default quota of the API, a negative quota means unlimited.
+
+	cdsctl admin storage quota MY_PROJECT --artifacts 10240 --static-files 1024 --cache -1
+`,
+	Args: []cli.Arg{
+		{Name: "project-key"},
+	},
+	Flags: []cli.Flag{
+		{Name: "artifacts", Usage: "Artifacts quota in MB"},
+		{Name: "static-files", Usage: "Static files quota in MB"},
+		{Name: "cache", Usage: "Worker cache quota in MB"},
+	},
+}
+
+func adminStorageQuotaRun(v cli.Values) error {
+	const mb = 1024 * 1024
+	var quota sdk.ProjectStorageQuota
+	for _, f := range []struct {
+		name  string
+		value *int64
+	}{
+		{"artifacts", &quota.Artifacts},
+		{"static-files", &quota.StaticFiles},
+		{"cache", &quota.Cache},
+	} {
+		n, err := v.GetInt64(f.name)
+		if err != nil {
+			return err
+		}
+		*f.value = n * mb
+	}
+
+	usage, err := client.AdminStorageQuotaUpdate(v.GetString("project-key"), quota)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Storage quotas of project %s updated: artifacts %s, static files %s, cache %s\n", usage.ProjectKey,
+		formatQuota(usage.Quota.Artifacts), formatQuota(usage.Quota.StaticFiles), formatQuota(usage.Quota.Cache))
+	return nil
+}
+
+func formatQuota(q int64) string {
+	if q <= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%dMB", q/(1024*1024))
+}
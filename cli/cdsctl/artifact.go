@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var artifactCmd = cli.Command{
+	Name:  "artifact",
+	Short: "Manage the provenance of CDS artifacts",
+}
+
+func artifact() *cobra.Command {
+	return cli.NewCommand(artifactCmd, nil, []*cobra.Command{
+		cli.NewCommand(artifactProvenanceCmd, artifactProvenanceRun, nil, withAllCommandModifiers()...),
+		cli.NewGetCommand(artifactKeyCmd, artifactKeyRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(artifactVerifyCmd, artifactVerifyRun, nil),
+	})
+}
+
+var artifactProvenanceCmd = cli.Command{
+	Name:  "provenance",
+	Short: "Download the signed provenance of an artifact of one Workflow Run",
+	Long: `Download the signed provenance of an artifact in the file <artifact-name>.intoto.json:
+
+	cdsctl artifact provenance MYPROJ myworkflow 42 my-binary
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+		{Name: "artefact-name"},
+	},
+}
+
+func artifactProvenanceRun(v cli.Values) error {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("number parameter have to be an integer")
+	}
+
+	artifacts, err := client.WorkflowRunArtifacts(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range artifacts {
+		if a.Name != v.GetString("artefact-name") {
+			continue
+		}
+		envelope, err := client.WorkflowNodeRunArtifactProvenance(v.GetString(_ProjectKey), v.GetString(_WorkflowName), a.ID)
+		if err != nil {
+			return err
+		}
+		btes, err := json.MarshalIndent(envelope, "", "  ")
+		if err != nil {
+			return err
+		}
+		name := a.Name + ".intoto.json"
+		if err := ioutil.WriteFile(name, btes, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Provenance of %s written in %s\n", a.Name, name)
+		return nil
+	}
+
+	return fmt.Errorf("no artifact %s found", v.GetString("artefact-name"))
+}
+
+var artifactKeyCmd = cli.Command{
+	Name:  "key",
+	Short: "Get the public key used to sign the provenance of the artifacts of a project",
+	Long: `Get the public key used to sign the provenance of the artifacts of a project, to verify them offline:
+
+	cdsctl artifact key MYPROJ --format json | jq -r .public_key > cds-provenance.pem
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+}
+
+func artifactKeyRun(v cli.Values) (interface{}, error) {
+	return client.ProjectProvenanceKey(v.GetString(_ProjectKey))
+}
+
+var artifactVerifyCmd = cli.Command{
+	Name:  "verify",
+	Short: "Verify the signed provenance of an artifact, without connecting to CDS",
+	Long: `Check that the provenance is signed by the given public key and that it describes the artifact:
+
+	cdsctl artifact verify my-binary my-binary.intoto.json cds-provenance.pem
+`,
+	Args: []cli.Arg{
+		{Name: "file"},
+		{Name: "provenance"},
+		{Name: "public-key"},
+	},
+}
+
+func artifactVerifyRun(v cli.Values) error {
+	keyContent, err := ioutil.ReadFile(v.GetString("public-key"))
+	if err != nil {
+		return err
+	}
+	pub, err := sdk.ParseProvenancePublicKey(keyContent)
+	if err != nil {
+		return err
+	}
+
+	envelopeContent, err := ioutil.ReadFile(v.GetString("provenance"))
+	if err != nil {
+		return err
+	}
+	var envelope sdk.ProvenanceEnvelope
+	if err := json.Unmarshal(envelopeContent, &envelope); err != nil {
+		return fmt.Errorf("invalid provenance file: %v", err)
+	}
+
+	statement, err := envelope.Verify(pub)
+	if err != nil {
+		return err
+	}
+
+	sha512sum, err := sdk.FileSHA512sum(v.GetString("file"))
+	if err != nil {
+		return err
+	}
+	name, err := statement.CheckSubject(sha512sum)
+	if err != nil {
+		return err
+	}
+
+	p := statement.Predicate
+	fmt.Printf("Provenance verified for %s\n", v.GetString("file"))
+	fmt.Printf("  artifact: %s\n", name)
+	fmt.Printf("  builder: %s\n", p.Builder.ID)
+	fmt.Printf("  build: %s\n", p.Metadata.BuildInvocationID)
+	fmt.Printf("  entry point: %s\n", p.Invocation.ConfigSource.EntryPoint)
+	if p.Invocation.ConfigSource.URI != "" {
+		fmt.Printf("  source: %s (%s)\n", p.Invocation.ConfigSource.URI, p.Invocation.ConfigSource.Digest["sha1"])
+	}
+	keys := make([]string, 0, len(p.Invocation.Environment))
+	for k := range p.Invocation.Environment {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Printf("  %s: %s\n", k, p.Invocation.Environment[k])
+	}
+	return nil
+}
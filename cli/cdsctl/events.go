@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -21,20 +22,33 @@ var eventsCmd = cli.Command{
 func events() *cobra.Command {
 	return cli.NewCommand(eventsCmd, nil, []*cobra.Command{
 		cli.NewCommand(eventsListenCmd, eventsListenRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(eventsHistoryCmd, eventsHistoryRun, nil, withAllCommandModifiers()...),
 	})
 }
 
 var eventsListenCmd = cli.Command{
 	Name:  "listen",
 	Short: "Listen CDS events",
+	Flags: []cli.Flag{
+		{
+			Name:  "since",
+			Usage: "id of the last received event, the events published after it are sent first",
+			Type:  cli.FlagString,
+		},
+	},
 }
 
 func eventsListenRun(v cli.Values) error {
+	since, err := v.GetInt64("since")
+	if err != nil {
+		return err
+	}
+
 	ctx := context.Background()
 	chanSSE := make(chan cdsclient.SSEvent)
 
 	sdk.GoRoutine(ctx, "EventsListenCmd", func(ctx context.Context) {
-		client.EventsListen(ctx, chanSSE)
+		client.EventsListenFrom(ctx, since, chanSSE)
 	})
 
 	for {
@@ -42,13 +56,76 @@ func eventsListenRun(v cli.Values) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case evt := <-chanSSE:
-			var e sdk.Event
 			content, _ := ioutil.ReadAll(evt.Data)
+			if evt.Type == sdk.EventReplayTruncated {
+				fmt.Printf("too many missed events, run 'cdsctl events history %s' to list the events not replayed\n", content)
+				continue
+			}
+			var e sdk.Event
 			_ = json.Unmarshal(content, &e)
 			if e.EventType == "" {
 				continue
 			}
-			fmt.Printf("%s: %s %s %s\n", e.EventType, e.ProjectKey, e.WorkflowName, e.Status)
+			fmt.Printf("%d %s: %s %s %s\n", e.ID, e.EventType, e.ProjectKey, e.WorkflowName, e.Status)
+		}
+	}
+}
+
+var eventsHistoryCmd = cli.Command{
+	Name:  "history",
+	Short: "List CDS events published after the given event id",
+	Args: []cli.Arg{
+		{Name: "since"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "limit",
+			Usage:   "maximum number of events to list",
+			Type:    cli.FlagString,
+			Default: "100",
+		},
+	},
+}
+
+func eventsHistoryRun(v cli.Values) (cli.ListResult, error) {
+	since, err := v.GetInt64("since")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+
+	type eventCLI struct {
+		ID        int64  `cli:"id,key"`
+		Timestamp string `cli:"timestamp"`
+		Type      string `cli:"type"`
+		Project   string `cli:"project"`
+		Workflow  string `cli:"workflow"`
+		Status    string `cli:"status"`
+	}
+	var res []eventCLI
+	// Pages can be empty if the consumer can't read their events, the next cursor is used until the limit is reached
+	for int64(len(res)) < limit {
+		history, err := client.EventsHistory(since, int(limit)-len(res))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range history.Events {
+			res = append(res, eventCLI{
+				ID:        e.ID,
+				Timestamp: e.Timestamp.Format(time.RFC3339),
+				Type:      e.EventType,
+				Project:   e.ProjectKey,
+				Workflow:  e.WorkflowName,
+				Status:    e.Status,
+			})
+		}
+		if history.Next == 0 {
+			break
 		}
+		since = history.Next
 	}
+	return cli.AsListResult(res), nil
 }
@@ -24,6 +24,7 @@ func main() {
 		action(),
 		admin(),
 		application(),
+		artifact(),
 		consumer(),
 		encrypt(),
 		contexts(),
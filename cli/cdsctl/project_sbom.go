@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var projectSBOMCmd = cli.Command{
+	Name:  "sbom",
+	Short: "Search the components of the Software Bills of Materials of a CDS project",
+}
+
+func projectSBOM() *cobra.Command {
+	return cli.NewCommand(projectSBOMCmd, nil, []*cobra.Command{
+		cli.NewListCommand(projectSBOMSearchCmd, projectSBOMSearchRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var projectSBOMSearchCmd = cli.Command{
+	Name:  "search",
+	Short: "List the workflow runs and applications with a SBOM that contains a component",
+	Long: `The component is searched by name, case insensitive, or by package URL:
+
+	cdsctl project sbom search MY-PROJECT log4j-core 2.14.1
+	cdsctl project sbom search MY-PROJECT pkg:npm/lodash@4.17.20
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+	},
+	Args: []cli.Arg{
+		{Name: "component"},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "version"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "limit",
+			Usage:   "maximum number of results",
+			Type:    cli.FlagString,
+			Default: "100",
+		},
+	},
+}
+
+func projectSBOMSearchRun(v cli.Values) (cli.ListResult, error) {
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+	results, err := client.ProjectSBOMSearch(v.GetString(_ProjectKey), v.GetString("component"), v.GetString("version"), int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(results), nil
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/ovh/cds/cli"
+)
+
+var workflowMergeQueueCmd = cli.Command{
+	Name:  "merge-queue",
+	Short: "List the pull requests of the workflow merge queue",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+}
+
+func workflowMergeQueueRun(v cli.Values) (cli.ListResult, error) {
+	entries, err := client.WorkflowMergeQueueList(v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(entries), nil
+}
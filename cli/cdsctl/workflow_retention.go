@@ -0,0 +1,52 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowRetentionCmd = cli.Command{
+	Name:  "retention",
+	Short: "Manage Workflow retention policy",
+}
+
+func workflowRetention() *cobra.Command {
+	return cli.NewCommand(workflowRetentionCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowRetentionDryRunCmd, workflowRetentionDryRunRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowRetentionDryRunCmd = cli.Command{
+	Name:  "dry-run",
+	Short: "Show the workflow runs that would be purged by the retention policy",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:  "purged-only",
+			Usage: "only display the workflow runs that would be purged",
+			Type:  cli.FlagBool,
+		},
+	},
+}
+
+func workflowRetentionDryRunRun(v cli.Values) (cli.ListResult, error) {
+	results, err := client.WorkflowRetentionDryRun(v.GetString(_ProjectKey), v.GetString(_WorkflowName))
+	if err != nil {
+		return nil, err
+	}
+	if v.GetBool("purged-only") {
+		filtered := make([]sdk.WorkflowRetentionResult, 0, len(results))
+		for _, r := range results {
+			if !r.Keep {
+				filtered = append(filtered, r)
+			}
+		}
+		results = filtered
+	}
+	return cli.AsListResult(results), nil
+}
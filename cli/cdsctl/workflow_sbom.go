@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowSBOMCmd = cli.Command{
+	Name:  "sbom",
+	Short: "Manage the Software Bills of Materials of a Workflow Run",
+}
+
+func workflowSBOM() *cobra.Command {
+	return cli.NewCommand(workflowSBOMCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowSBOMListCmd, workflowSBOMListRun, nil, withAllCommandModifiers()...),
+		cli.NewCommand(workflowSBOMDownloadCmd, workflowSBOMDownloadRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowSBOMListCmd = cli.Command{
+	Name:  "list",
+	Short: "List the SBOMs of one Workflow Run",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+}
+
+func workflowSBOMListRun(v cli.Values) (cli.ListResult, error) {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("number parameter have to be an integer")
+	}
+	sboms, err := client.WorkflowRunSBOMList(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(sboms), nil
+}
+
+var workflowSBOMDownloadCmd = cli.Command{
+	Name:  "download",
+	Short: "Download the SBOMs of one Workflow Run",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "number"},
+	},
+	OptionalArgs: []cli.Arg{
+		{Name: "id"},
+	},
+	Flags: []cli.Flag{
+		{
+			Name:    "output-dir",
+			Usage:   "directory where the SBOMs are written",
+			Default: ".",
+		},
+	},
+}
+
+func workflowSBOMDownloadRun(v cli.Values) error {
+	number, err := strconv.ParseInt(v.GetString("number"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("number parameter have to be an integer")
+	}
+
+	sboms, err := client.WorkflowRunSBOMList(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+	for _, s := range sboms {
+		if v.GetString("id") != "" && v.GetString("id") != strconv.FormatInt(s.ID, 10) {
+			continue
+		}
+		// SBOMs of several nodes can have the same name
+		name := filepath.Join(v.GetString("output-dir"), fmt.Sprintf("%s-%d-%s", s.WorkflowNodeName, s.ID, s.Name))
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Downloading %s...\n", name)
+		if err := client.WorkflowRunSBOMDownload(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number, s.ID, f); err != nil {
+			f.Close() // nolint
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		ok = true
+	}
+
+	if !ok {
+		return fmt.Errorf("no sbom found")
+	}
+	return nil
+}
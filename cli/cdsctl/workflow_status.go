@@ -170,8 +170,9 @@ func workflowStatusRunWithoutTrack(v cli.Values) (interface{}, error) {
 
 	type wtags struct {
 		sdk.WorkflowRun
-		Payload string `cli:"payload"`
-		Tags    string `cli:"tags"`
+		Payload  string `cli:"payload"`
+		Tags     string `cli:"tags"`
+		Attempts string `cli:"attempts"`
 	}
 
 	var payload []string
@@ -197,6 +198,38 @@ func workflowStatusRunWithoutTrack(v cli.Values) (interface{}, error) {
 		}
 		payload = append(payload)
 	}
-	wt := &wtags{*run, strings.Join(payload, " "), strings.Join(tags, " ")}
+	wt := &wtags{*run, strings.Join(payload, " "), strings.Join(tags, " "), strings.Join(workflowRunJobAttempts(run), " ")}
 	return *wt, nil
 }
+
+// workflowRunJobAttempts returns the attempts of the retried jobs for the last run of each node.
+func workflowRunJobAttempts(run *sdk.WorkflowRun) []string {
+	var attempts []string
+	for _, nodeRuns := range run.WorkflowNodeRuns {
+		if len(nodeRuns) == 0 {
+			continue
+		}
+		lastNodeRun := nodeRuns[0]
+		for _, nr := range nodeRuns {
+			if nr.SubNumber > lastNodeRun.SubNumber {
+				lastNodeRun = nr
+			}
+		}
+		for _, s := range lastNodeRun.Stages {
+			for _, rj := range s.RunJobs {
+				var n int
+				for _, info := range rj.SpawnInfos {
+					if info.Message.ID == sdk.MsgSpawnInfoJobRetry.ID {
+						n++
+					}
+				}
+				if n == 0 || rj.Job.RetryPolicy == nil {
+					continue
+				}
+				attempts = append(attempts, fmt.Sprintf("%s/%s:%d/%d", lastNodeRun.WorkflowNodeName, rj.Job.Action.Name, n+1, rj.Job.RetryPolicy.MaxAttempts))
+			}
+		}
+	}
+	sort.Strings(attempts)
+	return attempts
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/cli"
+	"github.com/ovh/cds/sdk"
+)
+
+var workflowTestsCmd = cli.Command{
+	Name:  "tests",
+	Short: "Show the history of the workflow tests results",
+}
+
+func workflowTests() *cobra.Command {
+	return cli.NewCommand(workflowTestsCmd, nil, []*cobra.Command{
+		cli.NewListCommand(workflowTestsHistoryCmd, workflowTestsHistoryRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowTestsFlakyCmd, workflowTestsFlakyRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowTestsTrendsCmd, workflowTestsTrendsRun, nil, withAllCommandModifiers()...),
+		cli.NewListCommand(workflowTestsDiffCmd, workflowTestsDiffRun, nil, withAllCommandModifiers()...),
+	})
+}
+
+var workflowTestsBranchFlag = cli.Flag{
+	Name:  "branch",
+	Usage: "only show the results of the runs on this branch",
+	Type:  cli.FlagString,
+}
+
+var workflowTestsHistoryCmd = cli.Command{
+	Name:  "history",
+	Short: "Show the latest results of a test",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "suite"},
+		{Name: "name"},
+	},
+	Flags: []cli.Flag{
+		workflowTestsBranchFlag,
+		{
+			Name:    "limit",
+			Usage:   "maximum number of results",
+			Type:    cli.FlagString,
+			Default: "50",
+		},
+	},
+}
+
+func workflowTestsHistoryRun(v cli.Values) (cli.ListResult, error) {
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+	results, err := client.WorkflowTestsHistory(v.GetString(_ProjectKey), v.GetString(_WorkflowName), v.GetString("suite"), v.GetString("name"), v.GetString("branch"), int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(results), nil
+}
+
+var workflowTestsFlakyCmd = cli.Command{
+	Name:  "flaky",
+	Short: "List the tests that passed and failed on a same commit, from the flakiest",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		workflowTestsBranchFlag,
+		{
+			Name:    "runs",
+			Usage:   "number of latest runs used to compute the flaky scores",
+			Type:    cli.FlagString,
+			Default: "100",
+		},
+	},
+}
+
+func workflowTestsFlakyRun(v cli.Values) (cli.ListResult, error) {
+	runs, err := v.GetInt64("runs")
+	if err != nil {
+		return nil, err
+	}
+	results, err := client.WorkflowTestsFlaky(v.GetString(_ProjectKey), v.GetString(_WorkflowName), v.GetString("branch"), int(runs))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(results), nil
+}
+
+var workflowTestsTrendsCmd = cli.Command{
+	Name:  "trends",
+	Short: "Show the number of successful, failed and skipped tests of the latest runs",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Flags: []cli.Flag{
+		workflowTestsBranchFlag,
+		{
+			Name:    "limit",
+			Usage:   "maximum number of runs",
+			Type:    cli.FlagString,
+			Default: "50",
+		},
+	},
+}
+
+func workflowTestsTrendsRun(v cli.Values) (cli.ListResult, error) {
+	limit, err := v.GetInt64("limit")
+	if err != nil {
+		return nil, err
+	}
+	trends, err := client.WorkflowTestsTrends(v.GetString(_ProjectKey), v.GetString(_WorkflowName), v.GetString("branch"), int(limit))
+	if err != nil {
+		return nil, err
+	}
+	return cli.AsListResult(trends), nil
+}
+
+var workflowTestsDiffCmd = cli.Command{
+	Name:  "diff",
+	Short: "List the failing tests of a run compared to the previous run on the same branch",
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{Name: "run-number"},
+	},
+}
+
+type workflowTestsDiffLine struct {
+	Change   string `cli:"change"`
+	Previous int64  `cli:"previous_run"`
+	Node     string `cli:"node"`
+	Suite    string `cli:"suite"`
+	Name     string `cli:"name,key"`
+}
+
+func workflowTestsDiffRun(v cli.Values) (cli.ListResult, error) {
+	number, err := v.GetInt64("run-number")
+	if err != nil {
+		return nil, err
+	}
+	diff, err := client.WorkflowRunTestsDiff(v.GetString(_ProjectKey), v.GetString(_WorkflowName), number)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []workflowTestsDiffLine{}
+	add := func(change string, results []sdk.WorkflowTestResult) {
+		for _, r := range results {
+			lines = append(lines, workflowTestsDiffLine{
+				Change:   change,
+				Previous: diff.PreviousRunNumber,
+				Node:     r.NodeName,
+				Suite:    r.Suite,
+				Name:     r.Name,
+			})
+		}
+	}
+	add("newly failing", diff.NewlyFailing)
+	add("already failing", diff.AlreadyFailing)
+	add("fixed", diff.Fixed)
+	return cli.AsListResult(lines), nil
+}
@@ -502,7 +502,7 @@ func (api *API) postActionAuditRollbackHandler() service.Handler {
 		// if no group name given for child, first search an action for shared.infra for backward compatibility
 		// else search a builtin or plugin action
 		for i := range data.Actions {
-			a, err := action.RetrieveForGroupAndName(ctx, tx, data.Actions[i].Group, data.Actions[i].Name)
+			a, err := action.RetrieveForGroupAndName(ctx, tx, data.Actions[i].Group, data.Actions[i].Name, data.Actions[i].Version)
 			if err != nil {
 				return err
 			}
@@ -588,7 +588,12 @@ func (api *API) getActionExportHandler() service.Handler {
 			return err
 		}
 
-		a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, api.mustDB(), actionName, g.ID, action.LoadOptions.Default)
+		var a *sdk.Action
+		if version := FormString(r, "version"); version != "" {
+			a, err = action.LoadTypePublishedByNameGroupIDAndVersion(ctx, api.mustDB(), actionName, g.ID, version, action.LoadOptions.Default)
+		} else {
+			a, err = action.LoadTypeDefaultByNameAndGroupID(ctx, api.mustDB(), actionName, g.ID, action.LoadOptions.Default)
+		}
 		if err != nil {
 			return err
 		}
@@ -615,6 +620,81 @@ func (api *API) getActionExportHandler() service.Handler {
 	}
 }
 
+func (api *API) getActionVersionsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["permGroupName"]
+		actionName := vars["permActionName"]
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName)
+		if err != nil {
+			return err
+		}
+
+		a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, api.mustDB(), actionName, g.ID)
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			return sdk.WithStack(sdk.ErrNoAction)
+		}
+
+		versions, err := action.LoadAllTypePublishedByPublishedFromID(ctx, api.mustDB(), a.ID, action.LoadOptions.Default)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, versions, http.StatusOK)
+	}
+}
+
+// postActionVersionHandler publishes an immutable version of an action.
+func (api *API) postActionVersionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+
+		groupName := vars["permGroupName"]
+		actionName := vars["permActionName"]
+		version := vars["version"]
+
+		g, err := group.LoadByName(ctx, api.mustDB(), groupName)
+		if err != nil {
+			return err
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		a, err := action.LoadTypeDefaultByNameAndGroupID(ctx, tx, actionName, g.ID)
+		if err != nil {
+			return err
+		}
+		if a == nil {
+			return sdk.WithStack(sdk.ErrNoAction)
+		}
+
+		published, err := action.Publish(ctx, tx, a.ID, version)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		newAction, err := action.LoadByID(ctx, api.mustDB(), published.ID, action.LoadOptions.Default)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, newAction, http.StatusCreated)
+	}
+}
+
 // importActionHandler insert OR update an existing action.
 func (api *API) importActionHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
@@ -675,7 +755,7 @@ func (api *API) importActionHandler() service.Handler {
 		// if no group name given for child, first search an action for shared.infra for backward compatibility
 		// else search a builtin or plugin action
 		for i := range data.Actions {
-			a, err := action.RetrieveForGroupAndName(ctx, tx, data.Actions[i].Group, data.Actions[i].Name)
+			a, err := action.RetrieveForGroupAndName(ctx, tx, data.Actions[i].Group, data.Actions[i].Name, data.Actions[i].Version)
 			if err != nil {
 				return err
 			}
@@ -718,6 +798,19 @@ func (api *API) importActionHandler() service.Handler {
 			}
 		}
 
+		// the version is published unless the same content was already published for it, so that the file can be imported again
+		if ea.PublishedVersion != "" {
+			published, err := action.IsPublished(ctx, tx, data.ID, ea.PublishedVersion)
+			if err != nil {
+				return err
+			}
+			if !published {
+				if _, err := action.Publish(ctx, tx, data.ID, ea.PublishedVersion); err != nil {
+					return err
+				}
+			}
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WithStack(err)
 		}
@@ -823,8 +916,8 @@ func (api *API) getActionBuiltinUsageHandler() service.Handler {
 	}
 }
 
-func getActionUsage(ctx context.Context, db gorp.SqlExecutor, store cache.Store, a *sdk.Action) (action.Usage, error) {
-	var usage action.Usage
+func getActionUsage(ctx context.Context, db gorp.SqlExecutor, store cache.Store, a *sdk.Action) (sdk.ActionUsage, error) {
+	var usage sdk.ActionUsage
 	var err error
 	usage.Pipelines, err = action.GetPipelineUsages(db, group.SharedInfraGroup.ID, a.ID)
 	if err != nil {
@@ -848,7 +941,7 @@ func getActionUsage(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 			mProjectIDs[ps[i].ID] = struct{}{}
 		}
 
-		filteredPipelines := make([]action.UsagePipeline, 0, len(usage.Pipelines))
+		filteredPipelines := make([]sdk.ActionUsagePipeline, 0, len(usage.Pipelines))
 		for i := range usage.Pipelines {
 			if _, ok := mProjectIDs[usage.Pipelines[i].PipelineID]; ok {
 				filteredPipelines = append(filteredPipelines, usage.Pipelines[i])
@@ -863,7 +956,7 @@ func getActionUsage(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 			mGroupIDs[groupIDs[i]] = struct{}{}
 		}
 
-		filteredActions := make([]action.UsageAction, 0, len(usage.Actions))
+		filteredActions := make([]sdk.ActionUsageAction, 0, len(usage.Actions))
 		for i := range usage.Actions {
 			if _, ok := mGroupIDs[usage.Actions[i].GroupID]; ok {
 				filteredActions = append(filteredActions, usage.Actions[i])
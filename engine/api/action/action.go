@@ -2,6 +2,7 @@ package action
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/go-gorp/gorp"
 
@@ -98,8 +99,147 @@ func Update(db gorp.SqlExecutor, a *sdk.Action) error {
 	return nil
 }
 
-// RetrieveForGroupAndName try to find an action for given group and name.
-func RetrieveForGroupAndName(ctx context.Context, db gorp.SqlExecutor, g *sdk.Group, name string) (*sdk.Action, error) {
+// Publish stores an immutable copy of given default action for given version.
+// Its children must be builtin, plugin or published actions so that the copy never changes.
+func Publish(ctx context.Context, db gorp.SqlExecutor, actionID int64, version string) (*sdk.Action, error) {
+	if err := sdk.IsValidActionVersion(version); err != nil {
+		return nil, err
+	}
+
+	src, err := loadPublishSource(ctx, db, actionID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := LoadTypePublishedByNameGroupIDAndVersion(ctx, db, src.Name, *src.GroupID, version)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrAlreadyExist, "version %s of action %s is already published", version, src.Name)
+	}
+
+	for i := range src.Actions {
+		if src.Actions[i].Type == sdk.DefaultAction {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "child action %s of action %s must be used with a published version", src.Actions[i].Name, src.Name)
+		}
+	}
+
+	published := *src
+	published.ID = 0
+	published.Type = sdk.PublishedAction
+	published.Version = version
+	published.PublishedFromID = &src.ID
+	if err := Insert(db, &published); err != nil {
+		return nil, err
+	}
+
+	return &published, nil
+}
+
+// IsPublished returns true if given version of the default action is published with the current content of the action.
+func IsPublished(ctx context.Context, db gorp.SqlExecutor, actionID int64, version string) (bool, error) {
+	src, err := loadPublishSource(ctx, db, actionID)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := LoadTypePublishedByNameGroupIDAndVersion(ctx, db, src.Name, *src.GroupID, version, LoadOptions.WithParameters, LoadOptions.WithChildren)
+	if err != nil {
+		return false, err
+	}
+	if existing == nil {
+		return false, nil
+	}
+	existing.Requirements = nil
+	if err := loadRequirements(ctx, db, existing); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(publishedContent(*src), publishedContent(*existing)), nil
+}
+
+// loadPublishSource loads the default action to publish with its parameters, children and its own requirements.
+func loadPublishSource(ctx context.Context, db gorp.SqlExecutor, actionID int64) (*sdk.Action, error) {
+	src, err := LoadByID(ctx, db, actionID, LoadOptions.WithParameters, LoadOptions.WithChildren)
+	if err != nil {
+		return nil, err
+	}
+	if src == nil || src.Type != sdk.DefaultAction {
+		return nil, sdk.NewErrorFrom(sdk.ErrNoAction, "only a default action can be published")
+	}
+
+	// children loading flattens their requirements in the action, reload its own requirements only
+	src.Requirements = nil
+	if err := loadRequirements(ctx, db, src); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// publishedContent returns the part of the action that is copied when it is published, without ids.
+func publishedContent(a sdk.Action) sdk.Action {
+	c := sdk.Action{
+		Name:         a.Name,
+		Description:  a.Description,
+		Enabled:      a.Enabled,
+		Parameters:   make([]sdk.Parameter, len(a.Parameters)),
+		Requirements: make(sdk.RequirementList, len(a.Requirements)),
+		Actions:      make([]sdk.Action, len(a.Actions)),
+	}
+	for i, p := range a.Parameters {
+		p.ID = 0
+		c.Parameters[i] = p
+	}
+	for i, r := range a.Requirements {
+		r.ID, r.ActionID = 0, 0
+		c.Requirements[i] = r
+	}
+	for i, child := range a.Actions {
+		c.Actions[i] = sdk.Action{
+			ID:             child.ID,
+			StepName:       child.StepName,
+			Optional:       child.Optional,
+			AlwaysExecuted: child.AlwaysExecuted,
+			Enabled:        child.Enabled,
+			Parameters:     make([]sdk.Parameter, len(child.Parameters)),
+		}
+		for j, p := range child.Parameters {
+			p.ID = 0
+			c.Actions[i].Parameters[j] = p
+		}
+	}
+	return c
+}
+
+// RetrieveForGroupAndName try to find an action for given group, name and version.
+// If a version is given the published action for this version is returned.
+func RetrieveForGroupAndName(ctx context.Context, db gorp.SqlExecutor, g *sdk.Group, name, version string) (*sdk.Action, error) {
+	if version != "" {
+		grp := group.SharedInfraGroup
+		if g != nil {
+			var err error
+			grp, err = group.LoadByName(ctx, db, g.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		a, err := LoadTypePublishedByNameGroupIDAndVersion(ctx, db, name, grp.ID, version,
+			LoadOptions.WithRequirements,
+			LoadOptions.WithParameters,
+			LoadOptions.WithGroup,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if a == nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrNoAction, "invalid given action %s@%s for group %s", name, version, grp.Name)
+		}
+
+		return a, nil
+	}
+
 	if g != nil {
 		grp, err := group.LoadByName(ctx, db, g.Name)
 		if err != nil {
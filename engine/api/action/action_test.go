@@ -177,16 +177,143 @@ func Test_RetrieveForGroupAndName(t *testing.T) {
 	}()
 
 	// Retrieve builtin action
-	result, err := action.RetrieveForGroupAndName(context.TODO(), db, nil, "Script")
+	result, err := action.RetrieveForGroupAndName(context.TODO(), db, nil, "Script", "")
 	require.NoError(t, err)
 	assert.Equal(t, scriptAction.ID, result.ID)
 
 	// Retrieve default action
-	result, err = action.RetrieveForGroupAndName(context.TODO(), db, grp1, act.Name)
+	result, err = action.RetrieveForGroupAndName(context.TODO(), db, grp1, act.Name, "")
 	require.NoError(t, err)
 	assert.Equal(t, act.ID, result.ID)
 }
 
+func Test_Publish(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	grp1 := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	defer func() {
+		assets.DeleteTestGroup(t, db, grp1)
+	}()
+
+	scriptAction := assets.GetBuiltinOrPluginActionByName(t, db, "Script")
+
+	act := sdk.Action{
+		GroupID: &grp1.ID,
+		Type:    sdk.DefaultAction,
+		Name:    sdk.RandomString(10),
+		Parameters: []sdk.Parameter{
+			{Name: "my-string", Type: sdk.StringParameter, Value: "v1"},
+		},
+		Actions: []sdk.Action{
+			{ID: scriptAction.ID, Enabled: true, Parameters: []sdk.Parameter{{Name: "script", Value: "echo v1"}}},
+		},
+	}
+	require.NoError(t, action.Insert(db, &act))
+	defer func() {
+		assert.NoError(t, action.Delete(db, &act))
+	}()
+
+	_, err := action.Publish(context.TODO(), db, act.ID, "v1/invalid")
+	require.Error(t, err)
+
+	v1, err := action.Publish(context.TODO(), db, act.ID, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, sdk.PublishedAction, v1.Type)
+	assert.Equal(t, act.ID, *v1.PublishedFromID)
+
+	_, err = action.Publish(context.TODO(), db, act.ID, "v1")
+	require.Error(t, err)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrAlreadyExist))
+
+	published, err := action.IsPublished(context.TODO(), db, act.ID, "v1")
+	require.NoError(t, err)
+	assert.True(t, published)
+	published, err = action.IsPublished(context.TODO(), db, act.ID, "v2")
+	require.NoError(t, err)
+	assert.False(t, published)
+
+	// updating the action should not change the published version
+	act.Parameters[0].Value = "v2"
+	act.Actions[0].Parameters[0].Value = "echo v2"
+	require.NoError(t, action.Update(db, &act))
+
+	published, err = action.IsPublished(context.TODO(), db, act.ID, "v1")
+	require.NoError(t, err)
+	assert.False(t, published)
+
+	result, err := action.RetrieveForGroupAndName(context.TODO(), db, grp1, act.Name, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, v1.ID, result.ID)
+	require.Len(t, result.Parameters, 1)
+	assert.Equal(t, "v1", result.Parameters[0].Value)
+
+	result, err = action.LoadByID(context.TODO(), db, v1.ID, action.LoadOptions.WithChildren)
+	require.NoError(t, err)
+	require.Len(t, result.Actions, 1)
+	assert.Equal(t, "echo v1", sdk.ParameterFind(result.Actions[0].Parameters, "script").Value)
+
+	_, err = action.RetrieveForGroupAndName(context.TODO(), db, grp1, act.Name, "v2")
+	require.Error(t, err)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrNoAction))
+
+	versions, err := action.LoadAllTypePublishedByPublishedFromID(context.TODO(), db, act.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, "v1", versions[0].Version)
+}
+
+func Test_PublishWithDefaultChild(t *testing.T) {
+	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+
+	grp1 := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+	defer func() {
+		assets.DeleteTestGroup(t, db, grp1)
+	}()
+
+	child := sdk.Action{
+		GroupID: &grp1.ID,
+		Type:    sdk.DefaultAction,
+		Name:    sdk.RandomString(10),
+	}
+	require.NoError(t, action.Insert(db, &child))
+	defer func() {
+		assert.NoError(t, action.Delete(db, &child))
+	}()
+	childV1, err := action.Publish(context.TODO(), db, child.ID, "v1")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, action.Delete(db, childV1))
+	}()
+
+	parent := sdk.Action{
+		GroupID: &grp1.ID,
+		Type:    sdk.DefaultAction,
+		Name:    sdk.RandomString(10),
+		Actions: []sdk.Action{{ID: child.ID, Enabled: true}},
+	}
+	require.NoError(t, action.Insert(db, &parent))
+	defer func() {
+		assert.NoError(t, action.Delete(db, &parent))
+	}()
+
+	// the child can be updated, the parent can't be published with it
+	_, err = action.Publish(context.TODO(), db, parent.ID, "v1")
+	require.Error(t, err)
+	assert.True(t, sdk.ErrorIs(err, sdk.ErrWrongRequest))
+
+	// the parent can be published once it uses a published version of the child
+	parent.Actions = []sdk.Action{{ID: childV1.ID, Enabled: true}}
+	require.NoError(t, action.Update(db, &parent))
+
+	parentV1, err := action.Publish(context.TODO(), db, parent.ID, "v1")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, action.Delete(db, parentV1))
+	}()
+}
+
 func Test_CheckChildrenForGroupIDsWithLoop(t *testing.T) {
 	db, _, end := test.SetupPG(t, bootstrap.InitiliazeDB)
 	defer end()
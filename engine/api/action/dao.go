@@ -89,9 +89,9 @@ func LoadAllTypeBuiltInOrPluginOrDefaultForGroupIDs(ctx context.Context, db gorp
 }
 
 // LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs returns all actions for given ids. Action should be
-// of type builtin, plugin or default. Default action should be in given group ids list.
+// of type builtin, plugin, default or published. Default and published actions should be in given group ids list.
 func LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs(ctx context.Context, db gorp.SqlExecutor, ids, groupIDs []int64, opts ...LoadOptionFunc) ([]sdk.Action, error) {
-	// children should be builtin, plugin or default with group matching
+	// children should be builtin, plugin or default/published with group matching
 	query := gorpmapping.NewQuery(`
     SELECT *
     FROM action
@@ -100,13 +100,14 @@ func LoadAllByIDsWithTypeBuiltinOrPluginOrDefaultInGroupIDs(ctx context.Context,
       AND (
         type = $2
         OR type = $3
-        OR (type = $4 AND group_id = ANY(string_to_array($5, ',')::int[]))
+        OR ((type = $4 OR type = $5) AND group_id = ANY(string_to_array($6, ',')::int[]))
       )
   `).Args(
 		gorpmapping.IDsToQueryString(ids),
 		sdk.BuiltinAction,
 		sdk.PluginAction,
 		sdk.DefaultAction,
+		sdk.PublishedAction,
 		gorpmapping.IDsToQueryString(groupIDs),
 	)
 	return getAll(ctx, db, query, opts...)
@@ -120,6 +121,22 @@ func LoadTypeDefaultByNameAndGroupID(ctx context.Context, db gorp.SqlExecutor, n
 	return get(ctx, db, query, opts...)
 }
 
+// LoadTypePublishedByNameGroupIDAndVersion returns a published action from database with given name, group id and version.
+func LoadTypePublishedByNameGroupIDAndVersion(ctx context.Context, db gorp.SqlExecutor, name string, groupID int64, version string, opts ...LoadOptionFunc) (*sdk.Action, error) {
+	query := gorpmapping.NewQuery(
+		"SELECT * FROM action WHERE type = $1 AND lower(name) = lower($2) AND group_id = $3 AND version = $4",
+	).Args(sdk.PublishedAction, name, groupID, version)
+	return get(ctx, db, query, opts...)
+}
+
+// LoadAllTypePublishedByPublishedFromID returns all published versions of given action from the newest to the oldest.
+func LoadAllTypePublishedByPublishedFromID(ctx context.Context, db gorp.SqlExecutor, actionID int64, opts ...LoadOptionFunc) ([]sdk.Action, error) {
+	query := gorpmapping.NewQuery(
+		"SELECT * FROM action WHERE type = $1 AND published_from_id = $2 ORDER BY id DESC",
+	).Args(sdk.PublishedAction, actionID)
+	return getAll(ctx, db, query, opts...)
+}
+
 // LoadByTypesAndName returns an action from database with given name and type in list.
 func LoadByTypesAndName(ctx context.Context, db gorp.SqlExecutor, types []string, name string, opts ...LoadOptionFunc) (*sdk.Action, error) {
 	query := gorpmapping.NewQuery(
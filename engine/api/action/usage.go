@@ -6,37 +6,15 @@ import (
 	"github.com/ovh/cds/sdk"
 )
 
-// Usage for action.
-type Usage struct {
-	Pipelines []UsagePipeline `json:"pipelines"`
-	Actions   []UsageAction   `json:"actions"`
-}
-
-// UsagePipeline represent a pipeline using an action.
-type UsagePipeline struct {
-	ProjectID    string `json:"project_id"`
-	ProjectKey   string `json:"project_key"`
-	ProjectName  string `json:"project_name"`
-	PipelineID   int64  `json:"pipeline_id"`
-	PipelineName string `json:"pipeline_name"`
-	StageID      int64  `json:"stage_id"`
-	StageName    string `json:"stage_name"`
-	JobID        int64  `json:"job_id"`
-	JobName      string `json:"job_name"`
-	ActionID     int64  `json:"action_id"`
-	ActionName   string `json:"action_name"`
-	Warning      bool   `json:"warning"`
-}
-
-// GetPipelineUsages returns the list of pipelines using an action
-func GetPipelineUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64) ([]UsagePipeline, error) {
+// GetPipelineUsages returns the list of pipelines using an action or one of its published versions.
+func GetPipelineUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64) ([]sdk.ActionUsagePipeline, error) {
 	rows, err := db.Query(`
     SELECT DISTINCT
       project.id, project.projectKey, project.name,
       pipeline.id, pipeline.name,
       pipeline_stage.id, pipeline_stage.name,
       parent.id, parent.name,
-      action.id, action.name,
+      action.id, action.name, action.version,
       CAST((CASE WHEN project_group.role IS NOT NULL OR action.group_id = $1 OR action.group_id IS NULL THEN 0 ELSE 1 END) AS BIT)
 		FROM action
     INNER JOIN action_edge ON action_edge.child_id = action.id
@@ -46,23 +24,24 @@ func GetPipelineUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64)
 		LEFT JOIN pipeline ON pipeline.id = pipeline_stage.pipeline_id
     LEFT JOIN project ON pipeline.project_id = project.id
     LEFT JOIN project_group ON project_group.project_id = project.id AND project_group.group_id = action.group_id
-		WHERE action.id = $2
-		ORDER BY project.projectKey, pipeline.name, action.name;
+		WHERE action.id = $2 OR action.published_from_id = $2
+		ORDER BY project.projectKey, pipeline.name, action.name, action.version;
 	`, sharedInfraGroupID, actionID)
 	if err != nil {
 		return nil, sdk.WrapError(err, "cannot load pipeline usages for action with id %d", actionID)
 	}
 	defer rows.Close()
 
-	us := []UsagePipeline{}
+	us := []sdk.ActionUsagePipeline{}
 	for rows.Next() {
-		var u UsagePipeline
+		var u sdk.ActionUsagePipeline
+		// The parent action selected before the action is the job of the pipeline
 		if err := rows.Scan(
 			&u.ProjectID, &u.ProjectKey, &u.ProjectName,
 			&u.PipelineID, &u.PipelineName,
 			&u.StageID, &u.StageName,
-			&u.ActionID, &u.ActionName,
 			&u.JobID, &u.JobName,
+			&u.ActionID, &u.ActionName, &u.ActionVersion,
 			&u.Warning,
 		); err != nil {
 			return nil, sdk.WrapError(err, "cannot scan sql rows")
@@ -73,44 +52,33 @@ func GetPipelineUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64)
 	return us, nil
 }
 
-// UsageAction represent a action using an action.
-type UsageAction struct {
-	GroupID          int64  `json:"group_id"`
-	GroupName        string `json:"group_name"`
-	ParentActionID   int64  `json:"parent_action_id"`
-	ParentActionName string `json:"parent_action_name"`
-	ActionID         int64  `json:"action_id"`
-	ActionName       string `json:"action_name"`
-	Warning          bool   `json:"warning"`
-}
-
-// GetActionUsages returns the list of actions using an action
-func GetActionUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64) ([]UsageAction, error) {
+// GetActionUsages returns the list of actions using an action or one of its published versions.
+func GetActionUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64) ([]sdk.ActionUsageAction, error) {
 	rows, err := db.Query(`
     SELECT DISTINCT
 			"group".id, "group".name,
 			parent.id, parent.name,
-      action.id, action.name,
+      action.id, action.name, action.version,
       CAST((CASE WHEN action.group_id = parent.group_id OR action.group_id = $1 OR action.group_id IS NULL THEN 0 ELSE 1 END) AS BIT)
 		FROM action
 		INNER JOIN action_edge ON action_edge.child_id = action.id
 		LEFT JOIN action as parent ON parent.id = action_edge.parent_id
 		LEFT JOIN "group" ON "group".id = parent.group_id
-		WHERE action.id = $2 AND parent.group_id IS NOT NULL
-		ORDER BY parent.name, action.name;
+		WHERE (action.id = $2 OR action.published_from_id = $2) AND parent.group_id IS NOT NULL
+		ORDER BY parent.name, action.name, action.version;
 	`, sharedInfraGroupID, actionID)
 	if err != nil {
 		return nil, sdk.WrapError(err, "cannot load pipeline usages for action with id %d", actionID)
 	}
 	defer rows.Close()
 
-	us := []UsageAction{}
+	us := []sdk.ActionUsageAction{}
 	for rows.Next() {
-		var u UsageAction
+		var u sdk.ActionUsageAction
 		if err := rows.Scan(
 			&u.GroupID, &u.GroupName,
 			&u.ParentActionID, &u.ParentActionName,
-			&u.ActionID, &u.ActionName,
+			&u.ActionID, &u.ActionName, &u.ActionVersion,
 			&u.Warning,
 		); err != nil {
 			return nil, sdk.WrapError(err, "cannot scan sql rows")
@@ -121,7 +89,7 @@ func GetActionUsages(db gorp.SqlExecutor, sharedInfraGroupID, actionID int64) ([
 	return us, nil
 }
 
-// Used checks if action is used in another action or in a pipeline.
+// Used checks if action or one of its published versions is used in another action or in a pipeline.
 func Used(db gorp.SqlExecutor, actionID int64) (bool, error) {
 	var count int
 
@@ -132,7 +100,10 @@ func Used(db gorp.SqlExecutor, actionID int64) (bool, error) {
 		return true, nil
 	}
 
-	if err := db.QueryRow(`SELECT COUNT(id) FROM action_edge WHERE child_id = $1`, actionID).Scan(&count); err != nil {
+	if err := db.QueryRow(`
+		SELECT COUNT(action_edge.id) FROM action_edge
+		JOIN action ON action.id = action_edge.child_id
+		WHERE action.id = $1 OR action.published_from_id = $1`, actionID).Scan(&count); err != nil {
 		return false, sdk.WithStack(err)
 	}
 	return count > 0, nil
@@ -15,6 +15,7 @@ import (
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/engine/api/pipeline"
 	"github.com/ovh/cds/engine/api/test"
 	"github.com/ovh/cds/engine/api/test/assets"
 	"github.com/ovh/cds/sdk"
@@ -54,6 +55,119 @@ func Test_getActionExportHandler(t *testing.T) {
 	t.Logf(">>%s", rec.Body.String())
 }
 
+func Test_postActionVersionHandler(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	_, jwt := assets.InsertAdminUser(t, db)
+
+	grp := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+
+	a := sdk.Action{
+		GroupID: &grp.ID,
+		Type:    sdk.DefaultAction,
+		Name:    "myAction",
+	}
+	require.NoError(t, action.Insert(db, &a))
+
+	vars := map[string]string{
+		"permGroupName":  grp.Name,
+		"permActionName": a.Name,
+		"version":        "v1",
+	}
+	uri := api.Router.GetRoute("POST", api.postActionVersionHandler, vars)
+	test.NotEmpty(t, uri)
+	req := assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, nil)
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 201, rec.Code)
+
+	var published sdk.Action
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &published))
+	assert.Equal(t, sdk.PublishedAction, published.Type)
+	assert.Equal(t, "v1", published.Version)
+
+	// a version can't be published twice
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "POST", uri, nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	assert.Equal(t, 409, rec.Code)
+
+	uri = api.Router.GetRoute("GET", api.getActionVersionsHandler, vars)
+	test.NotEmpty(t, uri)
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "GET", uri, nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	var versions []sdk.Action
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &versions))
+	require.Len(t, versions, 1)
+	assert.Equal(t, published.ID, versions[0].ID)
+
+	uri = api.Router.GetRoute("GET", api.getActionExportHandler, vars)
+	test.NotEmpty(t, uri)
+	req = assets.NewJWTAuthentifiedRequest(t, jwt, "GET", uri+"?version=v1", nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+	assert.Contains(t, rec.Body.String(), "published_version: v1")
+}
+
+func Test_getActionUsageHandler(t *testing.T) {
+	api, db, _, end := newTestAPI(t)
+	defer end()
+
+	_, jwt := assets.InsertAdminUser(t, db)
+
+	grp := assets.InsertTestGroup(t, db, sdk.RandomString(10))
+
+	a := sdk.Action{
+		GroupID: &grp.ID,
+		Type:    sdk.DefaultAction,
+		Name:    sdk.RandomString(10),
+	}
+	require.NoError(t, action.Insert(db, &a))
+
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10))
+	pip := &sdk.Pipeline{Name: sdk.RandomString(10), ProjectKey: proj.Key, ProjectID: proj.ID}
+	require.NoError(t, pipeline.InsertPipeline(db, api.Cache, proj, pip))
+	stage := &sdk.Stage{BuildOrder: 1, Enabled: true, Name: "stage1", PipelineID: pip.ID}
+	require.NoError(t, pipeline.InsertStage(db, stage))
+	job := &sdk.Job{
+		Enabled:         true,
+		PipelineStageID: stage.ID,
+		Action: sdk.Action{
+			Enabled: true,
+			Name:    "job1",
+			Actions: []sdk.Action{{ID: a.ID, Enabled: true}},
+		},
+	}
+	require.NoError(t, pipeline.InsertJob(db, job, stage.ID, pip))
+
+	vars := map[string]string{
+		"permGroupName":  grp.Name,
+		"permActionName": a.Name,
+	}
+	uri := api.Router.GetRoute("GET", api.getActionUsageHandler, vars)
+	test.NotEmpty(t, uri)
+	req := assets.NewJWTAuthentifiedRequest(t, jwt, "GET", uri, nil)
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	// The job is the parent of the action in the action edges, it should not be returned as the action
+	var usage sdk.ActionUsage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &usage))
+	require.Len(t, usage.Pipelines, 1)
+	assert.Equal(t, pip.Name, usage.Pipelines[0].PipelineName)
+	assert.Equal(t, "stage1", usage.Pipelines[0].StageName)
+	assert.Equal(t, job.Action.ID, usage.Pipelines[0].JobID)
+	assert.Equal(t, "job1", usage.Pipelines[0].JobName)
+	assert.Equal(t, a.ID, usage.Pipelines[0].ActionID)
+	assert.Equal(t, a.Name, usage.Pipelines[0].ActionName)
+}
+
 func Test_postActionImportHandler(t *testing.T) {
 	api, db, _, end := newTestAPI(t)
 	defer end()
@@ -144,7 +144,7 @@ type Configuration struct {
 		From     string `toml:"from" default:"no-reply@cds.local" json:"from"`
 	} `toml:"smtp" comment:"#####################\n# CDS SMTP Settings \n####################" json:"smtp"`
 	Artifact struct {
-		Mode  string `toml:"mode" default:"local" comment:"swift, awss3 or local" json:"mode"`
+		Mode  string `toml:"mode" default:"local" comment:"swift, awss3, gcs, azure or local" json:"mode"`
 		Local struct {
 			BaseDirectory string `toml:"baseDirectory" default:"/var/lib/cds-engine/artifacts" json:"baseDirectory"`
 		} `toml:"local"`
@@ -172,6 +172,25 @@ type Configuration struct {
 			DisableSSL          bool   `toml:"disableSSL" json:"disableSSL" commented:"true"`                                  //optional
 			ForcePathStyle      bool   `toml:"forcePathStyle" json:"forcePathStyle" commented:"true"`                          //optional
 		} `toml:"awss3" json:"awss3"`
+		GCS struct {
+			BucketName      string `toml:"bucketName" json:"bucketName" comment:"Name of the Google Cloud Storage bucket to use when storing artifacts"`
+			Prefix          string `toml:"prefix" json:"prefix" comment:"A subfolder of the bucket to store objects in, if left empty will store at the root of the bucket"`
+			CredentialsFile string `toml:"credentialsFile" json:"credentialsFile" comment:"The path of a service account JSON key, application default credentials are used if empty. A service account key is needed for temporary URLs"`
+			Endpoint        string `toml:"endpoint" json:"endpoint" comment:"GCS API Endpoint (optional)" commented:"true"` //optional
+		} `toml:"gcs" json:"gcs"`
+		Azure struct {
+			AccountName         string `toml:"accountName" json:"accountName" comment:"Name of the Azure storage account"`
+			AccountKey          string `toml:"accountKey" json:"-" comment:"Access key of the Azure storage account"`
+			ContainerName       string `toml:"containerName" json:"containerName" comment:"Name of the blob container to use when storing artifacts"`
+			Prefix              string `toml:"prefix" json:"prefix" comment:"A subfolder of the container to store blobs in, if left empty will store at the root of the container"`
+			StaticContainerName string `toml:"staticContainerName" json:"staticContainerName" comment:"Name of the blob container with public access used to serve static files (optional)" commented:"true"` //optional
+			Endpoint            string `toml:"endpoint" json:"endpoint" comment:"Azure storage base URL (optional, default: core.windows.net)" commented:"true"`                                              //optional
+		} `toml:"azure" json:"azure"`
+		Quota struct {
+			Artifacts   int64 `toml:"artifacts" default:"0" comment:"Default artifacts quota per project in MB, 0 for unlimited" json:"artifacts"`
+			StaticFiles int64 `toml:"staticFiles" default:"0" comment:"Default static files quota per project in MB, 0 for unlimited" json:"staticFiles"`
+			Cache       int64 `toml:"cache" default:"0" comment:"Default worker cache quota per project in MB, 0 for unlimited" json:"cache"`
+		} `toml:"quota" comment:"Default storage quotas per project, they can be overridden for a project with 'cdsctl admin storage quota'" json:"quota"`
 	} `toml:"artifact" comment:"Either filesystem local storage or Openstack Swift Storage are supported" json:"artifact"`
 	Features struct {
 		Izanami struct {
@@ -193,6 +212,9 @@ type Configuration struct {
 		StepMaxSize    int64 `toml:"stepMaxSize" default:"15728640" comment:"Max step logs size in bytes (default: 15MB)" json:"stepMaxSize"`
 		ServiceMaxSize int64 `toml:"serviceMaxSize" default:"15728640" comment:"Max service logs size in bytes (default: 15MB)" json:"serviceMaxSize"`
 	} `toml:"log" json:"log" comment:"###########################\n Log settings.\n##########################"`
+	Events struct {
+		LogRetention int64 `toml:"logRetention" default:"72" comment:"Number of hours the events are kept in database to be replayed by the clients after a disconnection" json:"logRetention"`
+	} `toml:"events" json:"events" comment:"###########################\n Events settings.\n##########################"`
 }
 
 // ServiceConfiguration is the configuration of external service
@@ -270,6 +292,7 @@ type API struct {
 		WorkflowRunsMarkToDelete *stats.Int64Measure
 		WorkflowRunsDeleted      *stats.Int64Measure
 		DatabaseConns            *stats.Int64Measure
+		ProjectStorageUsage      *stats.Int64Measure
 	}
 	AuthenticationDrivers map[sdk.AuthConsumerType]sdk.AuthDriver
 }
@@ -326,7 +349,7 @@ func (a *API) CheckConfiguration(config interface{}) error {
 	}
 
 	switch aConfig.Artifact.Mode {
-	case "local", "awss3", "openstack", "swift":
+	case "local", "awss3", "gcs", "azure", "openstack", "swift":
 	default:
 		return fmt.Errorf("Invalid artifact mode")
 	}
@@ -447,6 +470,10 @@ func (a *API) Serve(ctx context.Context) error {
 		objectstoreKind = objectstore.Swift
 	case "awss3":
 		objectstoreKind = objectstore.AWSS3
+	case "gcs":
+		objectstoreKind = objectstore.GCS
+	case "azure":
+		objectstoreKind = objectstore.AzureBlob
 	case "filesystem", "local":
 		objectstoreKind = objectstore.Filesystem
 	default:
@@ -470,6 +497,20 @@ func (a *API) Serve(ctx context.Context) error {
 				DisableSSL:          a.Config.Artifact.AWSS3.DisableSSL,
 				ForcePathStyle:      a.Config.Artifact.AWSS3.ForcePathStyle,
 			},
+			GCS: objectstore.ConfigOptionsGCS{
+				BucketName:      a.Config.Artifact.GCS.BucketName,
+				Prefix:          a.Config.Artifact.GCS.Prefix,
+				CredentialsFile: a.Config.Artifact.GCS.CredentialsFile,
+				Endpoint:        a.Config.Artifact.GCS.Endpoint,
+			},
+			AzureBlob: objectstore.ConfigOptionsAzureBlob{
+				AccountName:         a.Config.Artifact.Azure.AccountName,
+				AccountKey:          a.Config.Artifact.Azure.AccountKey,
+				ContainerName:       a.Config.Artifact.Azure.ContainerName,
+				Prefix:              a.Config.Artifact.Azure.Prefix,
+				StaticContainerName: a.Config.Artifact.Azure.StaticContainerName,
+				Endpoint:            a.Config.Artifact.Azure.Endpoint,
+			},
 			Openstack: objectstore.ConfigOptionsOpenstack{
 				Address:         a.Config.Artifact.Openstack.URL,
 				Username:        a.Config.Artifact.Openstack.Username,
@@ -768,6 +809,18 @@ func (a *API) Serve(ctx context.Context) error {
 		func(ctx context.Context) {
 			workflow.Initialize(ctx, a.DBConnectionFactory.GetDBMap, a.Cache, a.Config.URL.UI, a.Config.DefaultOS, a.Config.DefaultArch)
 		}, a.PanicDump())
+	sdk.GoRoutine(ctx, "api.mergeQueueRoutine",
+		func(ctx context.Context) {
+			a.mergeQueueRoutine(ctx)
+		}, a.PanicDump())
+	sdk.GoRoutine(ctx, "api.concurrencyGroupsRoutine",
+		func(ctx context.Context) {
+			a.concurrencyGroupsRoutine(ctx)
+		}, a.PanicDump())
+	sdk.GoRoutine(ctx, "event.PurgeLogRoutine",
+		func(ctx context.Context) {
+			event.PurgeLogRoutine(ctx, a.DBConnectionFactory.GetDBMap, time.Duration(a.Config.Events.LogRetention)*time.Hour)
+		}, a.PanicDump())
 	sdk.GoRoutine(ctx, "PushInElasticSearch",
 		func(ctx context.Context) {
 			event.PushInElasticSearch(ctx, a.mustDB(), a.Cache)
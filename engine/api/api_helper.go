@@ -75,6 +75,14 @@ func isHatchery(ctx context.Context) bool {
 	return c.Service != nil && c.Service.Type == services.TypeHatchery
 }
 
+func isHooks(ctx context.Context) bool {
+	c := getAPIConsumer(ctx)
+	if c == nil {
+		return false
+	}
+	return c.Service != nil && c.Service.Type == services.TypeHooks
+}
+
 func getAPIConsumer(c context.Context) *sdk.AuthConsumer {
 	i := c.Value(contextAPIConsumer)
 	if i == nil {
@@ -61,6 +61,8 @@ func (api *API) InitRouter() {
 	r.Handle("/action/import", Scope(sdk.AuthConsumerScopeAction), r.POST(api.importActionHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionHandler), r.PUT(api.putActionHandler), r.DELETE(api.deleteActionHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/usage", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionUsageHandler))
+	r.Handle("/action/{permGroupName}/{permActionName}/version", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionVersionsHandler))
+	r.Handle("/action/{permGroupName}/{permActionName}/version/{version}", Scope(sdk.AuthConsumerScopeAction), r.POST(api.postActionVersionHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/export", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionExportHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/audit", Scope(sdk.AuthConsumerScopeAction), r.GET(api.getActionAuditHandler))
 	r.Handle("/action/{permGroupName}/{permActionName}/audit/{auditID}/rollback", Scope(sdk.AuthConsumerScopeAction), r.POST(api.postActionAuditRollbackHandler))
@@ -94,6 +96,8 @@ func (api *API) InitRouter() {
 
 	// Admin service
 	r.Handle("/admin/service/{name}", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminServiceHandler, NeedAdmin(true)), r.DELETE(api.deleteAdminServiceHandler, NeedAdmin(true)))
+	r.Handle("/admin/storage/usage", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminStorageUsagesHandler, NeedAdmin(true)))
+	r.Handle("/admin/storage/quota/{key}", Scope(sdk.AuthConsumerScopeAdmin), r.PUT(api.putAdminProjectStorageQuotaHandler, NeedAdmin(true)))
 	r.Handle("/admin/services", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminServicesHandler, NeedAdmin(true)))
 	r.Handle("/admin/services/call", Scope(sdk.AuthConsumerScopeAdmin), r.GET(api.getAdminServiceCallHandler, NeedAdmin(true)), r.POST(api.postAdminServiceCallHandler, NeedAdmin(true)), r.PUT(api.putAdminServiceCallHandler, NeedAdmin(true)), r.DELETE(api.deleteAdminServiceCallHandler, NeedAdmin(true)))
 
@@ -168,6 +172,9 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/notifications", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectNotificationsHandler, DEPRECATED))
 	r.Handle("/project/{permProjectKey}/keys", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getKeysInProjectHandler), r.POST(api.addKeyInProjectHandler))
 	r.Handle("/project/{permProjectKey}/keys/{name}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteKeyInProjectHandler))
+	r.Handle("/project/{permProjectKey}/vulnerability/suppression", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectVulnerabilitySuppressionsHandler), r.POST(api.postProjectVulnerabilitySuppressionHandler))
+	r.Handle("/project/{permProjectKey}/provenance/key", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectProvenanceKeyHandler))
+	r.Handle("/project/{permProjectKey}/vulnerability/suppression/{cve}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteProjectVulnerabilitySuppressionHandler))
 
 	// As Code
 	r.Handle("/project/{key}/ascode/events/resync", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postResyncPRAsCodeHandler, EnableTracing()))
@@ -231,6 +238,13 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/label", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowLabelHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/label/{labelID}", Scope(sdk.AuthConsumerScopeProject), r.DELETE(api.deleteWorkflowLabelHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/rollback/{auditID}", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowRollbackHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/retention/dryrun", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowRetentionDryRunHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/tests/history", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowTestsHistoryHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/tests/flaky", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowTestsFlakyHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/tests/trends", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowTestsTrendsHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/mergequeue", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowMergeQueueHandler), r.POSTEXECUTE(api.postWorkflowMergeQueueHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/pullrequest/command", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postWorkflowPullRequestCommandHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/repository/head", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRepositoryHeadHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/notifications/conditions", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowNotificationsConditionsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/groups", Scope(sdk.AuthConsumerScopeProject), r.POST(api.postWorkflowGroupHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/groups/{groupName}", Scope(sdk.AuthConsumerScopeProject), r.PUT(api.putWorkflowGroupHandler), r.DELETE(api.deleteWorkflowGroupHandler))
@@ -257,6 +271,7 @@ func (api *API) InitRouter() {
 	// Workflows run
 	r.Handle("/project/{permProjectKey}/runs", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getWorkflowAllRunsHandler, EnableTracing()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getDownloadArtifactHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/artifact/{artifactId}/provenance", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowArtifactProvenanceHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunsHandler, EnableTracing()), r.POSTEXECUTE(api.postWorkflowRunHandler /*, AllowServices(true)*/, EnableTracing()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/branch/{branch}", Scope(sdk.AuthConsumerScopeRun), r.DELETE(api.deleteWorkflowRunsBranchHandler /*, NeedService()*/))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/latest", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getLatestWorkflowRunHandler))
@@ -266,6 +281,10 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.stopWorkflowRunHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/vcs/resync", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.postResyncVCSWorkflowRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/artifacts", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunArtifactsHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/tests/diff", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunTestsDiffHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/sbom", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunSBOMsHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/sbom/{sbomID}/download", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowRunSBOMDownloadHandler))
+	r.Handle("/project/{permProjectKey}/sbom/search", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectSBOMSearchHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/stop", Scope(sdk.AuthConsumerScopeRun), r.POSTEXECUTE(api.stopWorkflowNodeRunHandler, MaintenanceAware()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeID}/history", Scope(sdk.AuthConsumerScopeRun), r.GET(api.getWorkflowNodeRunHistoryHandler))
@@ -299,15 +318,21 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/export/environment/{environmentName}", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getEnvironmentExportHandler))
 
 	// Project storage
+	r.Handle("/project/{permProjectKey}/storage/usage", Scope(sdk.AuthConsumerScopeProject), r.GET(api.getProjectStorageUsageHandler))
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getArtifactsStoreHandler))
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}/artifact/{ref}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobArtifactHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}/artifact/{ref}/url", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobArtifacWithTempURLHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/project/{permProjectKey}/storage/{integrationName}/artifact/{ref}/blob", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobArtifactBlobHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}/artifact/{ref}/url/callback", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobArtifactWithTempURLCallbackHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}/staticfiles/{name}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobStaticFilesHandler, EnableTracing(), MaintenanceAware()))
 
 	// Cache
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}/cache/{tag}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postPushCacheHandler, MaintenanceAware()), r.GET(api.getPullCacheHandler))
 	r.Handle("/project/{permProjectKey}/storage/{integrationName}/cache/{tag}/url", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postPushCacheWithTempURLHandler, MaintenanceAware()), r.GET(api.getPullCacheWithTempURLHandler))
+	r.Handle("/project/{permProjectKey}/storage/{integrationName}/cache/{tag}/manifest", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postPushCacheManifestHandler, MaintenanceAware()), r.GET(api.getPullCacheManifestHandler))
+	r.Handle("/project/{permProjectKey}/storage/{integrationName}/cache", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getCacheLookupHandler))
+	r.Handle("/project/{permProjectKey}/storage/{integrationName}/cachelayers", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postCacheLayersMissingHandler, MaintenanceAware()))
+	r.Handle("/project/{permProjectKey}/storage/{integrationName}/cachelayers/{digest}", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postPushCacheLayerHandler, MaintenanceAware()), r.GET(api.getPullCacheLayerHandler))
 
 	//Workflow queue
 	r.Handle("/queue/workflows", Scope(sdk.AuthConsumerScopeRun, sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkflowJobQueueHandler, EnableTracing(), MaintenanceAware()))
@@ -316,11 +341,15 @@ func (api *API) InitRouter() {
 	r.Handle("/queue/workflows/{permJobID}/book", Scope(sdk.AuthConsumerScopeRunExecution), r.POST(api.postBookWorkflowJobHandler, EnableTracing(), MaintenanceAware()), r.DELETE(api.deleteBookWorkflowJobHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/infos", Scope(sdk.AuthConsumerScopeRunExecution), r.GET(api.getWorkflowJobHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/vulnerability", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postVulnerabilityReportHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/staticanalysis", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postStaticAnalysisReportHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/sbom", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobSBOMHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/artifact/{ref}/provenance", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobArtifactProvenanceHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/spawn/infos", Scope(sdk.AuthConsumerScopeRunExecution), r.POST(r.Asynchronous(api.postSpawnInfosWorkflowJobHandler, 1), EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/result", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobResultHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/log", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobLogsHandler, MaintenanceAware()))
 	r.Handle("/queue/workflows/log/service", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(r.Asynchronous(api.postWorkflowJobServiceLogsHandler, 1), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/coverage", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobCoverageResultsHandler, EnableTracing(), MaintenanceAware()))
+	r.Handle("/queue/workflows/{permJobID}/coverage/files", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobCoverageFilesHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/test", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobTestsResultsHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/tag", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobTagsHandler, EnableTracing(), MaintenanceAware()))
 	r.Handle("/queue/workflows/{permJobID}/step", Scope(sdk.AuthConsumerScopeRunExecution), r.POSTEXECUTE(api.postWorkflowJobStepStatusHandler, EnableTracing(), MaintenanceAware()))
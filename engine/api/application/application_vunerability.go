@@ -2,6 +2,7 @@ package application
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/go-gorp/gorp"
 
@@ -33,23 +34,65 @@ func LoadVulnerabilitiesSummary(db gorp.SqlExecutor, appID int64) (map[string]fl
 	return summary, nil
 }
 
-// InsertVulnerabilities Insert vulnerabilities
+// InsertVulnerabilities saves the vulnerabilities of the given type for an application. Vulnerabilities already
+// known from a previous run are updated, so they keep their id and their ignored flag, and the ones that are not
+// reported anymore are removed.
 func InsertVulnerabilities(db gorp.SqlExecutor, vs []sdk.Vulnerability, appID int64, t string) error {
-	if _, err := db.Exec("DELETE FROM application_vulnerability WHERE application_id = $1 AND type = $2", appID, t); err != nil {
-		return sdk.WrapError(err, "Unable to remove old vulnerabilities")
+	var olds []dbApplicationVulnerability
+	query := `SELECT *
+            FROM application_vulnerability
+            WHERE application_id = $1 AND type = $2`
+	if _, err := db.Select(&olds, query, appID, t); err != nil {
+		return sdk.WrapError(err, "unable to load vulnerabilities for application %d", appID)
+	}
+	known := make(map[string]sdk.Vulnerability, len(olds))
+	for i := range olds {
+		old := sdk.Vulnerability(olds[i])
+		if _, ok := known[old.Key()]; ok {
+			// Remove the duplicates inserted before vulnerabilities were deduplicated
+			if _, err := db.Exec("DELETE FROM application_vulnerability WHERE id = $1", old.ID); err != nil {
+				return sdk.WrapError(err, "Unable to remove duplicated vulnerability")
+			}
+			continue
+		}
+		known[old.Key()] = old
 	}
-	for _, v := range vs {
+
+	for _, v := range sdk.DedupVulnerabilities(vs) {
 		v.ApplicationID = appID
 		v.Type = t
+		// Suppressions are applied when vulnerabilities are loaded, so they are not ignored anymore when the rule expires
+		if v.Suppression != nil {
+			v.Ignored = false
+			v.Suppression = nil
+		}
+		old, ok := known[v.Key()]
+		if !ok {
+			dbVuln := dbApplicationVulnerability(v)
+			if err := db.Insert(&dbVuln); err != nil {
+				return sdk.WrapError(err, "Unable to insert vulnerabilities")
+			}
+			continue
+		}
+		delete(known, v.Key())
+		v.ID = old.ID
+		v.Ignored = v.Ignored || old.Ignored
 		dbVuln := dbApplicationVulnerability(v)
-		if err := db.Insert(&dbVuln); err != nil {
-			return sdk.WrapError(err, "Unable to insert vulnerabilities")
+		if _, err := db.Update(&dbVuln); err != nil {
+			return sdk.WrapError(err, "Unable to update vulnerabilities")
+		}
+	}
+
+	for _, old := range known {
+		if _, err := db.Exec("DELETE FROM application_vulnerability WHERE id = $1", old.ID); err != nil {
+			return sdk.WrapError(err, "Unable to remove old vulnerabilities")
 		}
 	}
 	return nil
 }
 
-// LoadVulnerabilities load vulnerabilities for the given application
+// LoadVulnerabilities load vulnerabilities for the given application, the vulnerabilities suppressed
+// by a rule of the project are flagged as ignored.
 func LoadVulnerabilities(db gorp.SqlExecutor, appID int64) ([]sdk.Vulnerability, error) {
 	results := make([]dbApplicationVulnerability, 0)
 	query := `SELECT *
@@ -65,6 +108,13 @@ func LoadVulnerabilities(db gorp.SqlExecutor, appID int64) ([]sdk.Vulnerability,
 	for i := range results {
 		vulnerabilities[i] = sdk.Vulnerability(results[i])
 	}
+
+	rules, err := LoadActiveVulnerabilitySuppressionsByApplicationID(db, appID)
+	if err != nil {
+		return nil, err
+	}
+	sdk.ApplyVulnerabilitySuppressions(vulnerabilities, rules, time.Now())
+
 	return vulnerabilities, nil
 }
 
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+// Vulnerability suppressions are project rules but they are stored here because they are applied
+// on the vulnerabilities of the applications.
+
+func getVulnerabilitySuppressions(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.VulnerabilitySuppression, error) {
+	var dbRules []dbVulnerabilitySuppression
+	if err := gorpmapping.GetAll(ctx, db, q, &dbRules); err != nil {
+		return nil, sdk.WrapError(err, "cannot get vulnerability suppressions")
+	}
+	rules := make([]sdk.VulnerabilitySuppression, len(dbRules))
+	for i := range dbRules {
+		rules[i] = sdk.VulnerabilitySuppression(dbRules[i])
+	}
+	return rules, nil
+}
+
+// LoadVulnerabilitySuppressionsByProjectID returns all the vulnerability suppressions of a project
+func LoadVulnerabilitySuppressionsByProjectID(ctx context.Context, db gorp.SqlExecutor, projectID int64) ([]sdk.VulnerabilitySuppression, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM project_vulnerability_suppression
+		WHERE project_id = $1
+		ORDER BY cve
+	`).Args(projectID)
+	return getVulnerabilitySuppressions(ctx, db, query)
+}
+
+// LoadActiveVulnerabilitySuppressionsByApplicationID returns the vulnerability suppressions of the project
+// of an application that are not expired.
+func LoadActiveVulnerabilitySuppressionsByApplicationID(db gorp.SqlExecutor, appID int64) ([]sdk.VulnerabilitySuppression, error) {
+	var dbRules []dbVulnerabilitySuppression
+	query := `
+		SELECT project_vulnerability_suppression.* FROM project_vulnerability_suppression
+		JOIN application ON application.project_id = project_vulnerability_suppression.project_id
+		WHERE application.id = $1 AND project_vulnerability_suppression.expiry > $2
+	`
+	if _, err := db.Select(&dbRules, query, appID, time.Now()); err != nil {
+		return nil, sdk.WrapError(err, "unable to load vulnerability suppressions for application %d", appID)
+	}
+	rules := make([]sdk.VulnerabilitySuppression, len(dbRules))
+	for i := range dbRules {
+		rules[i] = sdk.VulnerabilitySuppression(dbRules[i])
+	}
+	return rules, nil
+}
+
+// LoadVulnerabilitySuppression returns a vulnerability suppression of a project by its CVE id
+func LoadVulnerabilitySuppression(ctx context.Context, db gorp.SqlExecutor, projectID int64, cve string) (*sdk.VulnerabilitySuppression, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM project_vulnerability_suppression
+		WHERE project_id = $1 AND upper(cve) = upper($2)
+	`).Args(projectID, cve)
+	var dbRule dbVulnerabilitySuppression
+	found, err := gorpmapping.Get(ctx, db, query, &dbRule)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get vulnerability suppression")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	rule := sdk.VulnerabilitySuppression(dbRule)
+	return &rule, nil
+}
+
+// InsertVulnerabilitySuppression inserts a vulnerability suppression, a project can't have two suppressions for the same CVE
+func InsertVulnerabilitySuppression(db gorp.SqlExecutor, rule *sdk.VulnerabilitySuppression) error {
+	rule.Created = time.Now()
+	dbRule := dbVulnerabilitySuppression(*rule)
+	if err := gorpmapping.Insert(db, &dbRule); err != nil {
+		return sdk.WrapError(err, "cannot insert vulnerability suppression")
+	}
+	*rule = sdk.VulnerabilitySuppression(dbRule)
+	return nil
+}
+
+// DeleteVulnerabilitySuppression deletes a vulnerability suppression
+func DeleteVulnerabilitySuppression(db gorp.SqlExecutor, rule sdk.VulnerabilitySuppression) error {
+	dbRule := dbVulnerabilitySuppression(rule)
+	if err := gorpmapping.Delete(db, &dbRule); err != nil {
+		return sdk.WrapError(err, "cannot delete vulnerability suppression")
+	}
+	return nil
+}
@@ -28,11 +28,14 @@ func (e dbApplicationKey) Canonical() gorpmapping.CanonicalForms {
 
 type dbApplicationVulnerability sdk.Vulnerability
 
+type dbVulnerabilitySuppression sdk.VulnerabilitySuppression
+
 func init() {
 	gorpmapping.Register(gorpmapping.New(dbApplication{}, "application", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbApplicationVariableAudit{}, "application_variable_audit", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbApplicationKey{}, "application_key", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbApplicationVulnerability{}, "application_vulnerability", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbVulnerabilitySuppression{}, "project_vulnerability_suppression", true, "id"))
 }
 
 type sqlApplicationJSON struct {
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -38,6 +39,17 @@ func (api *API) postVulnerabilityHandler() service.Handler {
 			return sdk.WrapError(errV, "Unable to load vulnerability")
 		}
 
+		// A vulnerability suppressed by a rule of the project stays ignored until the rule expires
+		if !v.Ignored {
+			rule, err := application.LoadVulnerabilitySuppression(ctx, api.mustDB(), app.ProjectID, vulnDB.CVE)
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return err
+			}
+			if rule != nil && rule.IsActive(time.Now()) {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "vulnerability %s is suppressed until %s: %s", vulnDB.CVE, rule.Expiry.Format(time.RFC3339), rule.Justification)
+			}
+		}
+
 		old := vulnDB
 
 		vulnDB.Ignored = v.Ignored
@@ -8,8 +8,10 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
 )
 
 func (api *API) postPushCacheHandler() service.Handler {
@@ -43,11 +45,49 @@ func (api *API) postPushCacheHandler() service.Handler {
 			return err
 		}
 
-		if _, err := storageDriver.Store(&cacheObject, r.Body); err != nil {
+		proj, err := project.Load(api.mustDB(), api.Cache, vars[permProjectKey])
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", vars[permProjectKey])
+		}
+		integrationID := storageDriver.GetProjectIntegration().ID
+
+		previousSize, err := project.LoadStorageCacheSize(api.mustDB(), proj.ID, integrationID, tag)
+		if err != nil {
+			return err
+		}
+		if r.ContentLength > 0 {
+			if err := api.checkStorageQuota(api.mustDB(), proj.ID, sdk.StorageKindCache, r.ContentLength-previousSize); err != nil {
+				return err
+			}
+		}
+
+		// The upload is stopped as soon as it exceeds the quota, the content length is unknown for chunked uploads
+		left, err := api.storageQuotaLeft(api.mustDB(), proj.ID, sdk.StorageKindCache)
+		if err != nil {
+			return err
+		}
+		body := &quotaReadCloser{ReadCloser: r.Body, max: -1}
+		if left >= 0 && r.ContentLength > 0 {
+			// The content length has already been added to the usage of the project
+			body.max = left + r.ContentLength
+		} else if left >= 0 {
+			body.max = left + previousSize
+		}
+		if _, err := storageDriver.Store(&cacheObject, body); err != nil {
+			if body.exceeded() {
+				if err := storageDriver.Delete(ctx, &cacheObject); err != nil {
+					log.Error(ctx, "postPushCacheHandler> cannot delete cache %s: %v", tag, err)
+				}
+				return sdk.NewErrorFrom(sdk.ErrStorageQuotaExceeded, "cache %s exceeds the storage quota of project %s", tag, proj.Key)
+			}
 			return sdk.WrapError(err, "cannot store cache")
 		}
+		api.deleteCacheManifest(ctx, storageDriver, vars[permProjectKey], tag)
+		if r.ContentLength <= 0 {
+			api.addStorageUsage(proj.ID, sdk.StorageKindCache, body.n-previousSize)
+		}
 
-		return nil
+		return project.UpsertStorageCache(api.mustDB(), proj.ID, integrationID, tag, body.n)
 	}
 }
 
@@ -121,6 +161,11 @@ func (api *API) postPushCacheWithTempURLHandler() service.Handler {
 			return sdk.WithStack(sdk.ErrInvalidName)
 		}
 
+		var req sdk.Cache
+		if err := service.UnmarshalBody(r, &req); err != nil {
+			return err
+		}
+
 		storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars[permProjectKey], vars["integrationName"])
 		if err != nil {
 			return err
@@ -131,6 +176,20 @@ func (api *API) postPushCacheWithTempURLHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrNotImplemented, "cast error")
 		}
 
+		proj, err := project.Load(api.mustDB(), api.Cache, vars[permProjectKey])
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", vars[permProjectKey])
+		}
+		integrationID := storageDriver.GetProjectIntegration().ID
+
+		// The upload is done by the worker, the size given in the request is recorded as the cache size
+		if err := api.checkCacheQuota(api.mustDB(), proj.ID, integrationID, tag, req.Size); err != nil {
+			return err
+		}
+		if err := project.UpsertStorageCache(api.mustDB(), proj.ID, integrationID, tag, req.Size); err != nil {
+			return err
+		}
+
 		cacheObject := sdk.Cache{
 			Name:    "cache.tar",
 			Project: vars[permProjectKey],
@@ -141,6 +200,7 @@ func (api *API) postPushCacheWithTempURLHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "cannot store cache")
 		}
+		api.deleteCacheManifest(ctx, storageDriver, vars[permProjectKey], tag)
 		cacheObject.TmpURL = url
 		cacheObject.SecretKey = key
 
@@ -0,0 +1,378 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const cacheManifestName = "manifest.json"
+
+// postCacheLayersMissingHandler returns the layers of the given list that are not stored yet for the project.
+func (api *API) postCacheLayersMissingHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+
+		var layers []sdk.CacheLayer
+		if err := service.UnmarshalBody(r, &layers); err != nil {
+			return err
+		}
+		digests := make([]string, len(layers))
+		for i := range layers {
+			if !sdk.IsValidSHA256sum(layers[i].Digest) {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid cache layer digest %q", layers[i].Digest)
+			}
+			digests[i] = layers[i].Digest
+		}
+
+		storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars[permProjectKey], vars["integrationName"])
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, vars[permProjectKey])
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", vars[permProjectKey])
+		}
+
+		existing, err := project.LoadStorageCacheLayerDigests(api.mustDB(), proj.ID, storageDriver.GetProjectIntegration().ID, digests)
+		if err != nil {
+			return err
+		}
+		stored := make(map[string]struct{}, len(existing))
+		for _, d := range existing {
+			stored[d] = struct{}{}
+		}
+
+		missing := []sdk.CacheLayer{}
+		for _, l := range layers {
+			if _, ok := stored[l.Digest]; !ok {
+				stored[l.Digest] = struct{}{}
+				missing = append(missing, l)
+			}
+		}
+
+		return service.WriteJSON(w, missing, http.StatusOK)
+	}
+}
+
+func (api *API) postPushCacheLayerHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+		digest := vars["digest"]
+		if !sdk.IsValidSHA256sum(digest) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid cache layer digest %q", digest)
+		}
+
+		if r.Body == nil {
+			return sdk.WithStack(sdk.ErrWrongRequest)
+		}
+		defer r.Body.Close()
+
+		storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars[permProjectKey], vars["integrationName"])
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, vars[permProjectKey])
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", vars[permProjectKey])
+		}
+		integrationID := storageDriver.GetProjectIntegration().ID
+
+		// The layer can have been uploaded by another job in the meantime
+		existing, err := project.LoadStorageCacheLayerDigests(api.mustDB(), proj.ID, integrationID, []string{digest})
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return project.TouchStorageCacheLayers(api.mustDB(), proj.ID, integrationID, existing)
+		}
+
+		if r.ContentLength > 0 {
+			if err := api.checkStorageQuota(api.mustDB(), proj.ID, sdk.StorageKindCache, r.ContentLength); err != nil {
+				return err
+			}
+		}
+
+		// The upload is stopped as soon as it exceeds the quota, the content length is unknown for chunked uploads
+		left, err := api.storageQuotaLeft(api.mustDB(), proj.ID, sdk.StorageKindCache)
+		if err != nil {
+			return err
+		}
+		quotaBody := &quotaReadCloser{ReadCloser: r.Body, max: left}
+		if left >= 0 && r.ContentLength > 0 {
+			// The content length has already been added to the usage of the project
+			quotaBody.max = left + r.ContentLength
+		}
+
+		layer := sdk.CacheLayer{Project: vars[permProjectKey], Digest: digest}
+		body := newSHA256ReadCloser(quotaBody)
+		if _, err := storageDriver.Store(&layer, body); err != nil {
+			if quotaBody.exceeded() {
+				if err := storageDriver.Delete(ctx, &layer); err != nil {
+					log.Error(ctx, "postPushCacheLayerHandler> cannot delete cache layer %s: %v", digest, err)
+				}
+				return sdk.NewErrorFrom(sdk.ErrStorageQuotaExceeded, "cache layer %s exceeds the storage quota of project %s", digest, proj.Key)
+			}
+			return sdk.WrapError(err, "cannot store cache layer")
+		}
+		if sum := body.Sum(); sum != digest {
+			if err := storageDriver.Delete(ctx, &layer); err != nil {
+				log.Error(ctx, "postPushCacheLayerHandler> cannot delete cache layer %s: %v", digest, err)
+			}
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "cache layer digest %s doesn't match the uploaded content", digest)
+		}
+
+		if r.ContentLength <= 0 {
+			api.addStorageUsage(proj.ID, sdk.StorageKindCache, body.n)
+		}
+
+		return project.InsertStorageCacheLayer(api.mustDB(), proj.ID, integrationID, digest, body.n)
+	}
+}
+
+func (api *API) getPullCacheLayerHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+		digest := vars["digest"]
+		if !sdk.IsValidSHA256sum(digest) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid cache layer digest %q", digest)
+		}
+
+		storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars[permProjectKey], vars["integrationName"])
+		if err != nil {
+			return err
+		}
+
+		layer := sdk.CacheLayer{Project: vars[permProjectKey], Digest: digest}
+		ioread, err := storageDriver.Fetch(ctx, &layer)
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound, "cannot fetch cache layer %s", digest))
+		}
+		defer ioread.Close() // nolint
+
+		w.Header().Add("Content-Type", "application/octet-stream")
+		if _, err := io.Copy(w, ioread); err != nil {
+			return sdk.WrapError(err, "cannot stream cache layer")
+		}
+		return nil
+	}
+}
+
+// postPushCacheManifestHandler stores a cache made of layers, all the layers must have been uploaded before.
+func (api *API) postPushCacheManifestHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+		tag := vars["tag"]
+
+		// check tag name pattern
+		regexp := sdk.NamePatternRegex
+		if !regexp.MatchString(tag) {
+			return sdk.WithStack(sdk.ErrInvalidName)
+		}
+
+		var manifest sdk.CacheManifest
+		if err := service.UnmarshalBody(r, &manifest); err != nil {
+			return err
+		}
+		if err := manifest.IsValid(); err != nil {
+			return err
+		}
+		manifest.Tag = tag
+
+		storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars[permProjectKey], vars["integrationName"])
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, vars[permProjectKey])
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", vars[permProjectKey])
+		}
+		integrationID := storageDriver.GetProjectIntegration().ID
+
+		digests := make([]string, len(manifest.Layers))
+		for i := range manifest.Layers {
+			digests[i] = manifest.Layers[i].Digest
+		}
+		existing, err := project.LoadStorageCacheLayerDigests(api.mustDB(), proj.ID, integrationID, digests)
+		if err != nil {
+			return err
+		}
+		stored := make(map[string]struct{}, len(existing))
+		for _, d := range existing {
+			stored[d] = struct{}{}
+		}
+		for _, d := range digests {
+			if _, ok := stored[d]; !ok {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "cache layer %s has not been uploaded", d)
+			}
+		}
+		if err := project.TouchStorageCacheLayers(api.mustDB(), proj.ID, integrationID, digests); err != nil {
+			return err
+		}
+
+		buf, err := json.Marshal(manifest)
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		manifestObject := sdk.Cache{Name: cacheManifestName, Project: vars[permProjectKey], Tag: tag}
+		if _, err := storageDriver.Store(&manifestObject, ioutil.NopCloser(bytes.NewReader(buf))); err != nil {
+			return sdk.WrapError(err, "cannot store cache manifest")
+		}
+
+		// The size of the layers is already counted in the project storage, the previous tar of the cache is removed
+		cacheObject := sdk.Cache{Name: "cache.tar", Project: vars[permProjectKey], Tag: tag}
+		if err := storageDriver.Delete(ctx, &cacheObject); err != nil {
+			log.Debug("postPushCacheManifestHandler> no cache tar deleted for %s: %v", tag, err)
+		}
+
+		return project.UpsertStorageCache(api.mustDB(), proj.ID, integrationID, tag, 0)
+	}
+}
+
+func (api *API) getPullCacheManifestHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+		tag := vars["tag"]
+
+		// check tag name pattern
+		regexp := sdk.NamePatternRegex
+		if !regexp.MatchString(tag) {
+			return sdk.WithStack(sdk.ErrInvalidName)
+		}
+
+		storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars[permProjectKey], vars["integrationName"])
+		if err != nil {
+			return err
+		}
+
+		manifestObject := sdk.Cache{Name: cacheManifestName, Project: vars[permProjectKey], Tag: tag}
+		ioread, err := storageDriver.Fetch(ctx, &manifestObject)
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound, "cannot fetch cache manifest"))
+		}
+		defer ioread.Close() // nolint
+
+		var manifest sdk.CacheManifest
+		if err := json.NewDecoder(ioread).Decode(&manifest); err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrNotFound, "invalid cache manifest"))
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, vars[permProjectKey])
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", vars[permProjectKey])
+		}
+		digests := make([]string, len(manifest.Layers))
+		for i := range manifest.Layers {
+			digests[i] = manifest.Layers[i].Digest
+		}
+		if err := project.TouchStorageCacheLayers(api.mustDB(), proj.ID, storageDriver.GetProjectIntegration().ID, digests); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, manifest, http.StatusOK)
+	}
+}
+
+// getCacheLookupHandler returns the key of the latest cache matching one of the restore keys, restore keys are
+// prefixes of the cache keys tried in the given order.
+func (api *API) getCacheLookupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+		restoreKeys := r.URL.Query()["restore"]
+		if len(restoreKeys) == 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing restore keys")
+		}
+
+		storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars[permProjectKey], vars["integrationName"])
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, vars[permProjectKey])
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", vars[permProjectKey])
+		}
+
+		tags, err := project.LoadStorageCacheTags(api.mustDB(), proj.ID, storageDriver.GetProjectIntegration().ID)
+		if err != nil {
+			return err
+		}
+		key, found := sdk.CacheRestoreKey(tags, restoreKeys)
+		if !found {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "no cache found for restore keys %v", restoreKeys)
+		}
+
+		return service.WriteJSON(w, sdk.Cache{Project: vars[permProjectKey], Tag: sdk.CacheRef(key), Name: key}, http.StatusOK)
+	}
+}
+
+// deleteCacheManifest removes the manifest of a cache when the cache is replaced by a tar.
+func (api *API) deleteCacheManifest(ctx context.Context, storageDriver objectstore.Driver, projectKey, tag string) {
+	manifestObject := sdk.Cache{Name: cacheManifestName, Project: projectKey, Tag: tag}
+	if err := storageDriver.Delete(ctx, &manifestObject); err != nil {
+		log.Debug("deleteCacheManifest> no cache manifest deleted for %s: %v", tag, err)
+	}
+}
+
+// sha256ReadCloser computes the checksum and the size of the data read from the underlying reader.
+type sha256ReadCloser struct {
+	io.ReadCloser
+	hash hash.Hash
+	n    int64
+}
+
+func newSHA256ReadCloser(r io.ReadCloser) *sha256ReadCloser {
+	return &sha256ReadCloser{ReadCloser: r, hash: sha256.New()}
+}
+
+func (r *sha256ReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n]) // nolint
+	r.n += int64(n)
+	return n, err
+}
+
+func (r *sha256ReadCloser) Sum() string {
+	return hex.EncodeToString(r.hash.Sum(nil))
+}
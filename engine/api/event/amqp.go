@@ -0,0 +1,135 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// AMQPClient enbeddes the AMQP connection
+type AMQPClient struct {
+	options AMQPConfig
+	mutex   sync.Mutex
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+// AMQPConfig handles all config to publish on an AMQP exchange
+type AMQPConfig struct {
+	Enabled    bool
+	URI        string
+	User       string
+	Password   string
+	Exchange   string
+	RoutingKey string
+}
+
+// initialize returns broker, isInit and err if
+func (c *AMQPClient) initialize(ctx context.Context, options interface{}) (Broker, error) {
+	conf, ok := options.(AMQPConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid AMQP Initialization")
+	}
+
+	if conf.URI == "" || conf.Exchange == "" {
+		return nil, fmt.Errorf("initAMQP> Invalid AMQP Configuration")
+	}
+	c.options = conf
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// connect opens the connection and the channel used to publish events, mutex must be locked
+func (c *AMQPClient) connect() error {
+	uri := fmt.Sprintf("amqp://%s:%s@%s", c.options.User, c.options.Password, c.options.URI)
+	conn, err := amqp.Dial(uri)
+	if err != nil {
+		return fmt.Errorf("initAMQP> Error while connecting to %s user:%s: %v", c.options.URI, c.options.User, err)
+	}
+	channel, err := conn.Channel()
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("initAMQP> Error while opening channel on %s user:%s: %v", c.options.URI, c.options.User, err)
+	}
+
+	log.Debug("initAMQP> AMQP used at %s on exchange:%s", c.options.URI, c.options.Exchange)
+	c.conn = conn
+	c.channel = channel
+	return nil
+}
+
+// reset closes the current connection, the next event will open a new one, mutex must be locked
+func (c *AMQPClient) reset() {
+	if c.channel != nil {
+		_ = c.channel.Close()
+		c.channel = nil
+	}
+	if c.conn != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// close closes the connection
+func (c *AMQPClient) close(ctx context.Context) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reset()
+}
+
+// sendEvent publishes an event on the exchange, the connection is reopened if it was lost
+func (c *AMQPClient) sendEvent(event *sdk.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	msg := amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now(),
+		Type:         event.EventType,
+		Body:         data,
+	}
+
+	// An opened connection can have been closed by the broker since the last event,
+	// in this case the event is published again on a new connection
+	reconnected := c.channel == nil
+	for {
+		if c.channel == nil {
+			if err := c.connect(); err != nil {
+				return err
+			}
+		}
+		err := c.channel.Publish(c.options.Exchange, c.options.RoutingKey, false, false, msg)
+		if err == nil {
+			return nil
+		}
+		c.reset()
+		if reconnected {
+			return fmt.Errorf("sendAMQP> Error while publishing on exchange %s: %v", c.options.Exchange, err)
+		}
+		reconnected = true
+	}
+}
+
+// status: here, if c is initialized, AMQP is ok
+func (c *AMQPClient) status() string {
+	return "AMQP OK"
+}
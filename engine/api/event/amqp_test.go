@@ -0,0 +1,60 @@
+package event
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestAMQPClientInvalidConfiguration(t *testing.T) {
+	c := &AMQPClient{}
+	_, err := c.initialize(context.TODO(), NATSConfig{})
+	assert.Error(t, err)
+	_, err = c.initialize(context.TODO(), AMQPConfig{URI: "localhost:5672"})
+	assert.Error(t, err, "exchange is mandatory")
+	_, err = c.initialize(context.TODO(), AMQPConfig{Exchange: "cds-events"})
+	assert.Error(t, err, "uri is mandatory")
+}
+
+func TestAMQPClientConnectionErrors(t *testing.T) {
+	// Nothing listens on a closed listener
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	c := &AMQPClient{}
+	_, err = c.initialize(context.TODO(), AMQPConfig{URI: addr, Exchange: "cds-events"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Error while connecting to "+addr)
+
+	// The broker closes the connection during the handshake
+	l, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close() // nolint
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			header := make([]byte, 8)
+			_, _ = io.ReadFull(conn, header)
+			_ = conn.Close()
+		}
+	}()
+	_, err = c.initialize(context.TODO(), AMQPConfig{URI: l.Addr().String(), Exchange: "cds-events"})
+	require.Error(t, err)
+
+	// An event sent without connection tries to connect again and returns the error
+	c.options = AMQPConfig{URI: l.Addr().String(), Exchange: "cds-events"}
+	require.Error(t, c.sendEvent(&sdk.Event{EventType: "sdk.EventRunWorkflow"}))
+	assert.Nil(t, c.channel)
+	assert.Nil(t, c.conn)
+}
@@ -27,6 +27,12 @@ var subscribers []chan<- sdk.Event
 
 func init() {
 	subscribers = make([]chan<- sdk.Event, 0)
+	// Connections of the project brokers are closed when they expire or are reset
+	brokersConnectionCache.OnEvicted(func(_ string, i interface{}) {
+		if b, ok := i.(Broker); ok {
+			b.close(context.Background())
+		}
+	})
 }
 
 // Broker event typed
@@ -42,10 +48,123 @@ func getBroker(ctx context.Context, t string, option interface{}) (Broker, error
 	case "kafka":
 		k := &KafkaClient{}
 		return k.initialize(ctx, option)
+	case "amqp":
+		a := &AMQPClient{}
+		return a.initialize(ctx, option)
+	case "nats":
+		n := &NATSClient{}
+		return n.initialize(ctx, option)
+	case "webhook":
+		w := &WebhookClient{}
+		return w.initialize(ctx, option)
 	}
 	return nil, fmt.Errorf("Invalid Broker Type %s", t)
 }
 
+// brokerOptions returns the broker type and options for an event integration model and its configuration.
+// Public models imported by administrators can have a custom name, so the identifier is also checked.
+// Kafka is used by default as it was the only event integration available.
+func brokerOptions(model sdk.IntegrationModel, cfg sdk.IntegrationConfig) (string, interface{}) {
+	is := func(m sdk.IntegrationModel) bool { return isModel(model, m) }
+	switch {
+	case is(sdk.RabbitMQIntegration):
+		return "amqp", AMQPConfig{
+			Enabled:    true,
+			URI:        cfg["uri"].Value,
+			User:       cfg["username"].Value,
+			Password:   cfg["password"].Value,
+			Exchange:   cfg["exchange"].Value,
+			RoutingKey: cfg["routing key"].Value,
+		}
+	case is(sdk.NATSIntegration):
+		return "nats", NATSConfig{
+			Enabled:  true,
+			URL:      cfg["url"].Value,
+			User:     cfg["username"].Value,
+			Password: cfg["password"].Value,
+			Subject:  cfg["subject"].Value,
+		}
+	case is(sdk.WebhookIntegration):
+		return "webhook", WebhookConfig{
+			Enabled: true,
+			URL:     cfg["url"].Value,
+			Secret:  cfg["secret"].Value,
+		}
+	}
+	return "kafka", KafkaConfig{
+		Enabled:         true,
+		BrokerAddresses: cfg["broker url"].Value,
+		User:            cfg["username"].Value,
+		Password:        cfg["password"].Value,
+		Topic:           cfg["topic"].Value,
+		MaxMessageByte:  10000000,
+	}
+}
+
+func isModel(model, m sdk.IntegrationModel) bool {
+	return model.Name == m.Name || (model.Identifier != "" && model.Identifier == m.Identifier)
+}
+
+// IsEventIntegration returns true if events have to be published with the given integration configuration.
+// RabbitMQ integrations are also used by hooks, events are only published when an exchange is configured.
+func IsEventIntegration(model sdk.IntegrationModel, cfg sdk.IntegrationConfig) bool {
+	if !model.Event {
+		return false
+	}
+	if isModel(model, sdk.RabbitMQIntegration) {
+		return cfg["exchange"].Value != ""
+	}
+	return true
+}
+
+// newBroker returns the broker for an event integration, filtered on the configured event types
+func newBroker(ctx context.Context, model sdk.IntegrationModel, cfg sdk.IntegrationConfig) (Broker, error) {
+	t, option := brokerOptions(model, cfg)
+	b, err := getBroker(ctx, t, option)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes := parseEventTypes(cfg[sdk.IntegrationConfigEventTypes].Value)
+	if len(eventTypes) == 0 {
+		return b, nil
+	}
+	return &filteredBroker{Broker: b, eventTypes: eventTypes}, nil
+}
+
+func parseEventTypes(s string) []string {
+	var res []string
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// filteredBroker only sends the events of the given types to its broker
+type filteredBroker struct {
+	Broker
+	eventTypes []string
+}
+
+func (f *filteredBroker) sendEvent(event *sdk.Event) error {
+	if !matchEventType(f.eventTypes, event.EventType) {
+		return nil
+	}
+	return f.Broker.sendEvent(event)
+}
+
+// matchEventType checks if the event type is in the list, the package of the type can be omitted
+// in the list, ie. EventRunWorkflow matches sdk.EventRunWorkflow.
+func matchEventType(eventTypes []string, eventType string) bool {
+	for _, t := range eventTypes {
+		if t == eventType || "sdk."+t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
 func ResetPublicIntegrations(ctx context.Context, db *gorp.DbMap) error {
 	filterType := sdk.IntegrationTypeEvent
 	integrations, err := integration.LoadPublicModelsByType(db, &filterType, true)
@@ -54,22 +173,16 @@ func ResetPublicIntegrations(ctx context.Context, db *gorp.DbMap) error {
 	}
 
 	for _, integration := range integrations {
-		for _, cfg := range integration.PublicConfigurations {
-			kafkaCfg := KafkaConfig{
-				Enabled:         true,
-				BrokerAddresses: cfg["broker url"].Value,
-				User:            cfg["username"].Value,
-				Password:        cfg["password"].Value,
-				Topic:           cfg["topic"].Value,
-				MaxMessageByte:  10000000,
+		for name, cfg := range integration.PublicConfigurations {
+			if !IsEventIntegration(integration, cfg) {
+				continue
 			}
-
-			kafkaBroker, errk := getBroker(ctx, "kafka", kafkaCfg)
-			if errk != nil {
-				return sdk.WrapError(errk, "cannot get broker for %s and user %s", cfg["broker url"].Value, cfg["username"].Value)
+			broker, err := newBroker(ctx, integration, cfg)
+			if err != nil {
+				return sdk.WrapError(err, "cannot get broker for public integration %s/%s", integration.Name, name)
 			}
 
-			publicBrokersConnectionCache = append(publicBrokersConnectionCache, kafkaBroker)
+			publicBrokersConnectionCache = append(publicBrokersConnectionCache, broker)
 		}
 	}
 
@@ -90,21 +203,16 @@ func ResetEventIntegration(ctx context.Context, db gorp.SqlExecutor, eventIntegr
 	if err != nil {
 		return fmt.Errorf("cannot load project integration id %d and type event: %v", eventIntegrationID, err)
 	}
-
-	kafkaCfg := KafkaConfig{
-		Enabled:         true,
-		BrokerAddresses: projInt.Config["broker url"].Value,
-		User:            projInt.Config["username"].Value,
-		Password:        projInt.Config["password"].Value,
-		Topic:           projInt.Config["topic"].Value,
-		MaxMessageByte:  10000000,
+	if !IsEventIntegration(projInt.Model, projInt.Config) {
+		return nil
 	}
-	kafkaBroker, errk := getBroker(ctx, "kafka", kafkaCfg)
-	if errk != nil {
-		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot get broker for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, errk)
+
+	broker, err := newBroker(ctx, projInt.Model, projInt.Config)
+	if err != nil {
+		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot get broker for integration %s : %v", projInt.Name, err)
 	}
-	if err := brokersConnectionCache.Add(brokerConnectionKey, kafkaBroker, gocache.DefaultExpiration); err != nil {
-		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot add broker in cache for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, err)
+	if err := brokersConnectionCache.Add(brokerConnectionKey, broker, gocache.DefaultExpiration); err != nil {
+		return sdk.WrapError(sdk.ErrBadBrokerConfiguration, "cannot add broker in cache for integration %s : %v", projInt.Name, err)
 	}
 	return nil
 }
@@ -112,6 +220,8 @@ func ResetEventIntegration(ctx context.Context, db gorp.SqlExecutor, eventIntegr
 // Initialize initializes event system
 func Initialize(ctx context.Context, db *gorp.DbMap, cache cache.Store) error {
 	store = cache
+	eventLogDB = db
+	startLogWriter()
 	var err error
 	hostname, err = os.Hostname()
 	if err != nil {
@@ -169,28 +279,20 @@ func DequeueEvent(ctx context.Context, db *gorp.DbMap) {
 					continue
 				}
 
-				if projInt.Model.Public {
+				if projInt.Model.Public || !IsEventIntegration(projInt.Model, projInt.Config) {
 					continue
 				}
 
-				kafkaCfg := KafkaConfig{
-					Enabled:         true,
-					BrokerAddresses: projInt.Config["broker url"].Value,
-					User:            projInt.Config["username"].Value,
-					Password:        projInt.Config["password"].Value,
-					Topic:           projInt.Config["topic"].Value,
-					MaxMessageByte:  10000000,
-				}
-				kafkaBroker, errk := getBroker(ctx, "kafka", kafkaCfg)
-				if errk != nil {
-					log.Error(ctx, "Event.DequeueEvent> cannot get broker for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, errk)
+				broker, err := newBroker(ctx, projInt.Model, projInt.Config)
+				if err != nil {
+					log.Error(ctx, "Event.DequeueEvent> cannot get broker for integration %s of project %s : %v", projInt.Name, e.ProjectKey, err)
 					continue
 				}
-				if err := brokersConnectionCache.Add(brokerConnectionKey, kafkaBroker, gocache.DefaultExpiration); err != nil {
-					log.Error(ctx, "Event.DequeueEvent> cannot add broker in cache for %s and user %s : %v", projInt.Config["broker url"].Value, projInt.Config["username"].Value, err)
+				if err := brokersConnectionCache.Add(brokerConnectionKey, broker, gocache.DefaultExpiration); err != nil {
+					log.Error(ctx, "Event.DequeueEvent> cannot add broker in cache for integration %s of project %s : %v", projInt.Name, e.ProjectKey, err)
 					continue
 				}
-				brokerConnection = kafkaBroker
+				brokerConnection = broker
 			}
 
 			broker, ok := brokerConnection.(Broker)
@@ -0,0 +1,28 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestIsEventIntegration(t *testing.T) {
+	rabbitMQ := sdk.RabbitMQIntegration
+	cfg := rabbitMQ.DefaultConfig.Clone()
+
+	// A RabbitMQ integration without exchange is only used by hooks
+	assert.False(t, IsEventIntegration(rabbitMQ, cfg))
+	cfg["exchange"] = sdk.IntegrationConfigValue{Type: sdk.IntegrationConfigTypeString, Value: "cds-events"}
+	assert.True(t, IsEventIntegration(rabbitMQ, cfg))
+
+	// Public models can have a custom name
+	rabbitMQ.Name = "my-rabbitmq"
+	assert.True(t, IsEventIntegration(rabbitMQ, cfg))
+	delete(cfg, "exchange")
+	assert.False(t, IsEventIntegration(rabbitMQ, cfg))
+
+	assert.True(t, IsEventIntegration(sdk.KafkaIntegration, sdk.KafkaIntegration.DefaultConfig))
+	assert.False(t, IsEventIntegration(sdk.OpenstackIntegration, sdk.OpenstackIntegration.DefaultConfig))
+}
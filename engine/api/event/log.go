@@ -0,0 +1,122 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// eventLogDB is used to persist the published events in the events log
+var eventLogDB *gorp.DbMap
+
+type dbEventLog struct {
+	ID           int64     `db:"id"`
+	Created      time.Time `db:"created"`
+	EventType    string    `db:"event_type"`
+	ProjectKey   string    `db:"project_key"`
+	WorkflowName string    `db:"workflow_name"`
+	Data         string    `db:"data"`
+}
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbEventLog{}, "event_log", true, "id"))
+}
+
+// insertLogs persists the events in the events log with a single insert and sets their ids.
+// The ids are taken from the sequence first so that they follow the order of the events.
+func insertLogs(db gorp.SqlExecutor, events []sdk.Event) error {
+	var ids []int64
+	if _, err := db.Select(&ids, "SELECT nextval('event_log_id_seq') FROM generate_series(1, $1)", len(events)); err != nil {
+		return sdk.WrapError(err, "cannot get ids for events log")
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	values := make([]string, len(events))
+	args := make([]interface{}, 0, 6*len(events))
+	for i := range events {
+		btes, err := json.Marshal(events[i])
+		if err != nil {
+			return sdk.WrapError(err, "cannot marshal event")
+		}
+		n := len(args)
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6)
+		args = append(args, ids[i], events[i].Timestamp, events[i].EventType, events[i].ProjectKey, events[i].WorkflowName, string(btes))
+	}
+	query := "INSERT INTO event_log (id, created, event_type, project_key, workflow_name, data) VALUES " + strings.Join(values, ", ")
+	if _, err := db.Exec(query, args...); err != nil {
+		return sdk.WrapError(err, "cannot insert events in log")
+	}
+
+	for i := range events {
+		events[i].ID = ids[i]
+	}
+	return nil
+}
+
+// LoadLog returns at most limit events of the events log with an id greater than since, the oldest first.
+func LoadLog(ctx context.Context, db gorp.SqlExecutor, since int64, limit int) ([]sdk.Event, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM event_log
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`).Args(since, limit)
+	var dbEvents []dbEventLog
+	if err := gorpmapping.GetAll(ctx, db, query, &dbEvents); err != nil {
+		return nil, sdk.WrapError(err, "cannot get events from log")
+	}
+
+	events := make([]sdk.Event, len(dbEvents))
+	for i := range dbEvents {
+		if err := json.Unmarshal([]byte(dbEvents[i].Data), &events[i]); err != nil {
+			return nil, sdk.WrapError(err, "cannot unmarshal event %d", dbEvents[i].ID)
+		}
+		events[i].ID = dbEvents[i].ID
+	}
+	return events, nil
+}
+
+// PurgeLog deletes the events of the events log older than the given date.
+func PurgeLog(db gorp.SqlExecutor, before time.Time) (int64, error) {
+	res, err := db.Exec("DELETE FROM event_log WHERE created < $1", before)
+	if err != nil {
+		return 0, sdk.WrapError(err, "cannot purge events log")
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// PurgeLogRoutine deletes periodically the events of the events log older than the retention (72 hours if not set).
+func PurgeLogRoutine(ctx context.Context, DBFunc func() *gorp.DbMap, retention time.Duration) {
+	if retention <= 0 {
+		retention = 72 * time.Hour
+	}
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "Exiting event.PurgeLogRoutine: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			n, err := PurgeLog(DBFunc(), time.Now().Add(-retention))
+			if err != nil {
+				log.Warning(ctx, "event.PurgeLogRoutine> %v", err)
+				continue
+			}
+			log.Debug("event.PurgeLogRoutine> %d events deleted", n)
+		}
+	}
+}
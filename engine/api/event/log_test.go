@@ -0,0 +1,51 @@
+package event_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/bootstrap"
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestLoadLog(t *testing.T) {
+	db, cache, end := test.SetupPG(t, bootstrap.InitiliazeDB)
+	defer end()
+	require.NoError(t, event.Initialize(context.Background(), db, cache))
+
+	since, err := db.SelectInt("SELECT COALESCE(MAX(id), 0) FROM event_log")
+	require.NoError(t, err)
+
+	event.Publish(context.TODO(), sdk.EventEngine{Message: "first"}, nil)
+	event.Publish(context.TODO(), sdk.EventEngine{Message: "second"}, nil)
+
+	// The events are written in the log in background
+	var events []sdk.Event
+	for i := 0; i < 50 && len(events) < 2; i++ {
+		time.Sleep(100 * time.Millisecond)
+		events, err = event.LoadLog(context.TODO(), db, since, 10)
+		require.NoError(t, err)
+	}
+	require.Len(t, events, 2)
+	assert.Equal(t, "sdk.EventEngine", events[0].EventType)
+	assert.Equal(t, "first", events[0].Payload["Message"])
+	assert.Equal(t, "second", events[1].Payload["Message"])
+	assert.True(t, events[1].ID > events[0].ID)
+
+	events, err = event.LoadLog(context.TODO(), db, events[0].ID, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "second", events[0].Payload["Message"])
+
+	_, err = event.PurgeLog(db, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	events, err = event.LoadLog(context.TODO(), db, since, 10)
+	require.NoError(t, err)
+	assert.Len(t, events, 0)
+}
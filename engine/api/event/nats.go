@@ -0,0 +1,234 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const natsTimeout = 10 * time.Second
+
+// NATSClient enbeddes the NATS connection. Only publishing is needed to send events,
+// so the client implements the few commands of the NATS text protocol it requires.
+type NATSClient struct {
+	options NATSConfig
+	mutex   sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+}
+
+// NATSConfig handles all config to publish on a NATS subject
+type NATSConfig struct {
+	Enabled  bool
+	URL      string
+	User     string
+	Password string
+	Subject  string
+}
+
+type natsServerInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+type natsConnectOptions struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+}
+
+// initialize returns broker, isInit and err if
+func (c *NATSClient) initialize(ctx context.Context, options interface{}) (Broker, error) {
+	conf, ok := options.(NATSConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid NATS Initialization")
+	}
+
+	if conf.URL == "" || conf.Subject == "" {
+		return nil, fmt.Errorf("initNATS> Invalid NATS Configuration")
+	}
+	c.options = conf
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// connect opens the connection and sends the CONNECT command, mutex must be locked
+func (c *NATSClient) connect() error {
+	u, err := url.Parse(c.options.URL)
+	if err != nil || u.Host == "" {
+		// url without scheme, ie. your-nats:4222
+		u, err = url.Parse("nats://" + c.options.URL)
+		if err != nil {
+			return fmt.Errorf("initNATS> Invalid NATS url %s: %v", c.options.URL, err)
+		}
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	conn, err := net.DialTimeout("tcp", host, natsTimeout)
+	if err != nil {
+		return fmt.Errorf("initNATS> Error while connecting to %s: %v", host, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(natsTimeout))
+	reader := bufio.NewReader(conn)
+
+	// The server sends its INFO as soon as the connection is opened
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO ") {
+		_ = conn.Close()
+		return fmt.Errorf("initNATS> Invalid INFO received from %s: %q %v", host, line, err)
+	}
+	var info natsServerInfo
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "INFO ")), &info); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("initNATS> Invalid INFO received from %s: %v", host, err)
+	}
+
+	if info.TLSRequired || u.Scheme == "tls" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname()})
+		if err := tlsConn.Handshake(); err != nil {
+			_ = conn.Close()
+			return fmt.Errorf("initNATS> Error during TLS handshake with %s: %v", host, err)
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	opts := natsConnectOptions{
+		User:    c.options.User,
+		Pass:    c.options.Password,
+		Name:    "cds-" + cdsname,
+		Lang:    "go",
+		Version: sdk.VERSION,
+	}
+	if opts.User == "" && u.User != nil {
+		opts.User = u.User.Username()
+		opts.Pass, _ = u.User.Password()
+	}
+	buf, _ := json.Marshal(opts)
+
+	c.conn = conn
+	c.reader = reader
+	if err := c.flush([]byte("CONNECT " + string(buf) + "\r\n")); err != nil {
+		c.reset()
+		return fmt.Errorf("initNATS> Error while connecting to %s user:%s: %v", host, opts.User, err)
+	}
+
+	log.Debug("initNATS> NATS used at %s on subject:%s", host, c.options.Subject)
+	return nil
+}
+
+// flush writes the given commands followed by a PING, then waits for the PONG of the server.
+// An error sent by the server before the PONG is returned. Mutex must be locked.
+func (c *NATSClient) flush(cmd []byte) error {
+	_ = c.conn.SetDeadline(time.Now().Add(natsTimeout))
+	if _, err := c.conn.Write(append(cmd, []byte("PING\r\n")...)); err != nil {
+		return err
+	}
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := c.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return natsServerError(strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'"))
+		}
+	}
+}
+
+// reset closes the current connection, the next event will open a new one, mutex must be locked
+func (c *NATSClient) reset() {
+	if c.conn != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+		c.reader = nil
+	}
+}
+
+// close closes the connection
+func (c *NATSClient) close(ctx context.Context) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reset()
+}
+
+// sendEvent publishes an event on the subject, the connection is reopened if it was lost
+func (c *NATSClient) sendEvent(event *sdk.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cmd := append([]byte(fmt.Sprintf("PUB %s %d\r\n", c.options.Subject, len(data))), data...)
+	cmd = append(cmd, '\r', '\n')
+
+	// An opened connection can have been closed by the server since the last event,
+	// in this case the event is published again on a new connection
+	reconnected := c.conn == nil
+	for {
+		if c.conn == nil {
+			if err := c.connect(); err != nil {
+				return err
+			}
+		}
+		err := c.flush(cmd)
+		if err == nil {
+			return nil
+		}
+		c.reset()
+		if reconnected || !isNATSConnError(err) {
+			return fmt.Errorf("sendNATS> Error while publishing on subject %s: %v", c.options.Subject, err)
+		}
+		reconnected = true
+	}
+}
+
+// isNATSConnError returns true if the error comes from the connection and not from the server
+func isNATSConnError(err error) bool {
+	_, ok := err.(natsServerError)
+	return !ok
+}
+
+// natsServerError is an error sent by the server with -ERR
+type natsServerError string
+
+func (e natsServerError) Error() string {
+	return string(e)
+}
+
+// status: here, if c is initialized, NATS is ok
+func (c *NATSClient) status() string {
+	return "NATS OK"
+}
@@ -0,0 +1,210 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// fakeNATSServer implements the server side of the few commands of the NATS protocol used by the client
+type fakeNATSServer struct {
+	listener net.Listener
+	// pubError is sent instead of the PONG that follows a PUB
+	pubError string
+	// closeAfterConnect closes the first connection once the client is connected
+	closeAfterConnect bool
+
+	mutex       sync.Mutex
+	connections int
+	connects    []natsConnectOptions
+	published   map[string][][]byte
+}
+
+func startFakeNATSServer(t *testing.T, s *fakeNATSServer) *fakeNATSServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s.listener = l
+	s.published = map[string][][]byte{}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			s.mutex.Lock()
+			s.connections++
+			closeAfterConnect := s.closeAfterConnect && s.connections == 1
+			s.mutex.Unlock()
+			go s.serve(conn, closeAfterConnect)
+		}
+	}()
+	return s
+}
+
+func (s *fakeNATSServer) serve(conn net.Conn, closeAfterConnect bool) {
+	defer conn.Close() // nolint
+	if _, err := conn.Write([]byte("INFO {\"server_id\":\"fake\",\"max_payload\":1048576}\r\n")); err != nil {
+		return
+	}
+	reader := bufio.NewReader(conn)
+	var lastCmd string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "CONNECT "):
+			var opts natsConnectOptions
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "CONNECT ")), &opts); err != nil {
+				_, _ = conn.Write([]byte("-ERR 'Invalid Connect'\r\n"))
+				return
+			}
+			s.mutex.Lock()
+			s.connects = append(s.connects, opts)
+			s.mutex.Unlock()
+			lastCmd = "CONNECT"
+		case strings.HasPrefix(line, "PUB "):
+			args := strings.Fields(line)
+			size, err := strconv.Atoi(args[len(args)-1])
+			if err != nil {
+				_, _ = conn.Write([]byte("-ERR 'Unknown Protocol Operation'\r\n"))
+				return
+			}
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			s.mutex.Lock()
+			s.published[args[1]] = append(s.published[args[1]], payload[:size])
+			s.mutex.Unlock()
+			lastCmd = "PUB"
+		case line == "PING":
+			if lastCmd == "PUB" && s.pubError != "" {
+				_, _ = conn.Write([]byte("-ERR '" + s.pubError + "'\r\n"))
+				continue
+			}
+			if _, err := conn.Write([]byte("PONG\r\n")); err != nil {
+				return
+			}
+			if lastCmd == "CONNECT" && closeAfterConnect {
+				return
+			}
+		}
+	}
+}
+
+func (s *fakeNATSServer) close() {
+	_ = s.listener.Close()
+}
+
+func (s *fakeNATSServer) stats() (int, []natsConnectOptions, map[string][][]byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.connections, s.connects, s.published
+}
+
+func TestNATSClient(t *testing.T) {
+	srv := startFakeNATSServer(t, &fakeNATSServer{})
+	defer srv.close()
+
+	c := &NATSClient{}
+	b, err := c.initialize(context.TODO(), NATSConfig{URL: "nats://" + srv.listener.Addr().String(), User: "cds", Password: "secret", Subject: "cds.events"})
+	require.NoError(t, err)
+	defer b.close(context.TODO())
+
+	require.NoError(t, b.sendEvent(&sdk.Event{EventType: "sdk.EventRunWorkflow", ProjectKey: "KEY"}))
+	require.NoError(t, b.sendEvent(&sdk.Event{EventType: "sdk.EventRunWorkflowNode", ProjectKey: "KEY"}))
+
+	connections, connects, published := srv.stats()
+	assert.Equal(t, 1, connections)
+	require.Len(t, connects, 1)
+	assert.Equal(t, "cds", connects[0].User)
+	assert.Equal(t, "secret", connects[0].Pass)
+	assert.False(t, connects[0].Verbose)
+
+	require.Len(t, published["cds.events"], 2)
+	var e sdk.Event
+	require.NoError(t, json.Unmarshal(published["cds.events"][1], &e))
+	assert.Equal(t, "sdk.EventRunWorkflowNode", e.EventType)
+	assert.Equal(t, "KEY", e.ProjectKey)
+}
+
+func TestNATSClientServerError(t *testing.T) {
+	srv := startFakeNATSServer(t, &fakeNATSServer{pubError: "Permissions Violation for Publish to cds.events"})
+	defer srv.close()
+
+	c := &NATSClient{}
+	_, err := c.initialize(context.TODO(), NATSConfig{URL: srv.listener.Addr().String(), Subject: "cds.events"})
+	require.NoError(t, err)
+	defer c.close(context.TODO())
+
+	err = c.sendEvent(&sdk.Event{EventType: "sdk.EventRunWorkflow"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Permissions Violation for Publish to cds.events")
+
+	// Errors sent by the server are not retried
+	connections, _, _ := srv.stats()
+	assert.Equal(t, 1, connections)
+	assert.Nil(t, c.conn)
+}
+
+func TestNATSClientReconnect(t *testing.T) {
+	srv := startFakeNATSServer(t, &fakeNATSServer{closeAfterConnect: true})
+	defer srv.close()
+
+	c := &NATSClient{}
+	_, err := c.initialize(context.TODO(), NATSConfig{URL: srv.listener.Addr().String(), Subject: "cds.events"})
+	require.NoError(t, err)
+	defer c.close(context.TODO())
+
+	// The first connection has been closed by the server, the event is published on a new one
+	require.NoError(t, c.sendEvent(&sdk.Event{EventType: "sdk.EventRunWorkflow"}))
+
+	connections, connects, published := srv.stats()
+	assert.Equal(t, 2, connections)
+	assert.Len(t, connects, 2)
+	assert.Len(t, published["cds.events"], 1)
+}
+
+func TestNATSClientConnectionErrors(t *testing.T) {
+	c := &NATSClient{}
+	_, err := c.initialize(context.TODO(), NATSConfig{URL: "localhost:4222"})
+	assert.Error(t, err, "subject is mandatory")
+
+	// Nothing listens on a closed listener
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+	_, err = c.initialize(context.TODO(), NATSConfig{URL: addr, Subject: "cds.events"})
+	assert.Error(t, err)
+
+	// The server must start with an INFO
+	l, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close() // nolint
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("-ERR 'Authorization Violation'\r\n"))
+		_ = conn.Close()
+	}()
+	_, err = c.initialize(context.TODO(), NATSConfig{URL: l.Addr().String(), Subject: "cds.events"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid INFO")
+}
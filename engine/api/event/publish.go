@@ -4,21 +4,87 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatih/structs"
 
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
 )
 
 var store cache.Store
 
+const (
+	logWriterQueueSize = 10000
+	logWriterBatchSize = 100
+)
+
+var (
+	logWriterOnce sync.Once
+	// logWriterQueue contains the events to write in the events log before publishing them
+	logWriterQueue = make(chan sdk.Event, logWriterQueueSize)
+	// logWriterDropped counts the events not written in the events log because the queue was full
+	logWriterDropped int64
+)
+
 func publishEvent(ctx context.Context, e sdk.Event) error {
 	if store == nil {
 		return nil
 	}
 
+	// The events are persisted by the log writer to send their id to the brokers and to the SSE clients.
+	// If the log writer can't keep up, the event is sent without being written in the events log.
+	if eventLogDB != nil {
+		select {
+		case logWriterQueue <- e:
+			return nil
+		default:
+			n := atomic.AddInt64(&logWriterDropped, 1)
+			log.Warning(ctx, "event.publishEvent> too many events waiting to be written in the events log, %s not written (%d events not written)", e.EventType, n)
+		}
+	}
+	return sendEvent(ctx, e)
+}
+
+// startLogWriter starts the log writer once, it is never stopped as events can be published until the API exits
+func startLogWriter() {
+	logWriterOnce.Do(func() {
+		sdk.GoRoutine(context.Background(), "event.logWriter", logWriter)
+	})
+}
+
+// logWriter writes the published events in the events log, then sends them in the same order.
+// The events published while a batch is written are written together in the next one.
+func logWriter(ctx context.Context) {
+	batch := make([]sdk.Event, 0, logWriterBatchSize)
+	for {
+		batch = append(batch[:0], <-logWriterQueue)
+	fill:
+		for len(batch) < logWriterBatchSize {
+			select {
+			case e := <-logWriterQueue:
+				batch = append(batch, e)
+			default:
+				break fill
+			}
+		}
+
+		if err := insertLogs(eventLogDB, batch); err != nil {
+			log.Warning(ctx, "event.logWriter> unable to add %d events to the events log: %v", len(batch), err)
+		}
+		for i := range batch {
+			if err := sendEvent(ctx, batch[i]); err != nil {
+				log.Warning(ctx, "event.logWriter> unable to send event %s: %v", batch[i].EventType, err)
+			}
+		}
+	}
+}
+
+// sendEvent enqueues the event for the brokers and the repositories manager, then publishes it for the SSE clients
+func sendEvent(ctx context.Context, e sdk.Event) error {
 	if err := store.Enqueue("events", e); err != nil {
 		return err
 	}
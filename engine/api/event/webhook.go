@@ -0,0 +1,174 @@
+package event
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// Headers set on the requests sent by webhook event integrations
+const (
+	WebhookHeaderSignature = "X-CDS-Signature"
+	WebhookHeaderEventType = "X-CDS-Event"
+)
+
+// WebhookClient sends events with HTTP POST requests. Events are queued and sent in a
+// goroutine so a slow or unavailable endpoint does not block the other brokers.
+type WebhookClient struct {
+	options    WebhookConfig
+	httpClient *http.Client
+	events     chan sdk.Event
+	cancel     context.CancelFunc
+}
+
+// WebhookConfig handles all config to send events to an HTTP endpoint
+type WebhookConfig struct {
+	Enabled bool
+	URL     string
+	// Secret is used to sign the body of the requests with HMAC-SHA256
+	Secret      string
+	MaxAttempts int
+	RetryDelay  time.Duration
+	BufferSize  int
+}
+
+// initialize returns broker, isInit and err if
+func (c *WebhookClient) initialize(ctx context.Context, options interface{}) (Broker, error) {
+	conf, ok := options.(WebhookConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid Webhook Initialization")
+	}
+
+	if conf.URL == "" {
+		return nil, fmt.Errorf("initWebhook> Invalid Webhook Configuration")
+	}
+	if conf.MaxAttempts <= 0 {
+		conf.MaxAttempts = 3
+	}
+	if conf.RetryDelay <= 0 {
+		conf.RetryDelay = time.Second
+	}
+	if conf.BufferSize <= 0 {
+		conf.BufferSize = 1000
+	}
+	c.options = conf
+	c.httpClient = &http.Client{Timeout: 10 * time.Second}
+	c.events = make(chan sdk.Event, conf.BufferSize)
+
+	// The goroutine lives until the broker is closed, not until the end of the given context
+	// that can be the one of a single call
+	ctx, c.cancel = context.WithCancel(context.Background())
+	sdk.GoRoutine(ctx, "event.webhook."+conf.URL, c.run)
+
+	log.Debug("initWebhook> Webhook used at %s", conf.URL)
+	return c, nil
+}
+
+func (c *WebhookClient) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-c.events:
+			if err := c.post(ctx, &e); err != nil {
+				log.Warning(ctx, "Error while sending message [%s: %s/%s/%s/%s/%s] to webhook %s: %v", e.EventType, e.ProjectKey, e.WorkflowName, e.ApplicationName, e.PipelineName, e.EnvironmentName, c.options.URL, err)
+			}
+		}
+	}
+}
+
+// post sends the event, the request is retried with an exponential backoff
+// on network errors, server errors and too many requests responses.
+func (c *WebhookClient) post(ctx context.Context, e *sdk.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	delay := c.options.RetryDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := c.do(ctx, e.EventType, data)
+		if err == nil || !retry || attempt >= c.options.MaxAttempts {
+			return err
+		}
+		log.Debug("event.webhook> attempt %d/%d on %s failed: %v", attempt, c.options.MaxAttempts, c.options.URL, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// do sends one request and returns true with the error if the request can be retried
+func (c *WebhookClient) do(ctx context.Context, eventType string, data []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, c.options.URL, bytes.NewReader(data))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CDS/"+sdk.VERSION)
+	req.Header.Set(WebhookHeaderEventType, eventType)
+	if c.options.Secret != "" {
+		req.Header.Set(WebhookHeaderSignature, WebhookSignature(c.options.Secret, data))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		return true, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	if resp.StatusCode >= 400 {
+		return false, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+// WebhookSignature returns the value of the signature header for a request body,
+// ie. sha256=<hex encoded HMAC-SHA256 of the body with the secret>
+func WebhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// close stops the goroutine sending the events
+func (c *WebhookClient) close(ctx context.Context) {
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// sendEvent queues the event, it is dropped if too many events are waiting to be sent
+func (c *WebhookClient) sendEvent(event *sdk.Event) error {
+	select {
+	case c.events <- *event:
+		return nil
+	default:
+		return fmt.Errorf("webhook %s is too slow, event dropped", c.options.URL)
+	}
+}
+
+// status: here, if c is initialized, webhook is ok
+func (c *WebhookClient) status() string {
+	return "Webhook OK"
+}
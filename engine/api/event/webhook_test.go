@@ -0,0 +1,85 @@
+package event
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestWebhookClient(t *testing.T) {
+	var calls int32
+	received := make(chan *http.Request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		// The first attempt fails, the request must be sent again
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, WebhookSignature("my-secret", body), r.Header.Get(WebhookHeaderSignature))
+		received <- r
+	}))
+	defer srv.Close()
+
+	c := &WebhookClient{}
+	b, err := c.initialize(context.TODO(), WebhookConfig{URL: srv.URL, Secret: "my-secret", RetryDelay: time.Millisecond})
+	require.NoError(t, err)
+	defer b.close(context.TODO())
+
+	require.NoError(t, b.sendEvent(&sdk.Event{EventType: "sdk.EventRunWorkflow", ProjectKey: "KEY"}))
+
+	select {
+	case r := <-received:
+		assert.Equal(t, "sdk.EventRunWorkflow", r.Header.Get(WebhookHeaderEventType))
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not received by the webhook")
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestWebhookClientNoRetryOnClientError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := &WebhookClient{}
+	_, err := c.initialize(context.TODO(), WebhookConfig{URL: srv.URL, RetryDelay: time.Millisecond})
+	require.NoError(t, err)
+	defer c.close(context.TODO())
+
+	require.Error(t, c.post(context.TODO(), &sdk.Event{EventType: "sdk.EventRunWorkflow"}))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+type testBroker struct {
+	Broker
+	events []string
+}
+
+func (b *testBroker) sendEvent(e *sdk.Event) error {
+	b.events = append(b.events, e.EventType)
+	return nil
+}
+
+func TestFilteredBroker(t *testing.T) {
+	b := &testBroker{}
+	f := &filteredBroker{Broker: b, eventTypes: parseEventTypes(" sdk.EventRunWorkflow, EventRunWorkflowNode,,")}
+
+	for _, e := range []string{"sdk.EventRunWorkflow", "sdk.EventRunWorkflowNode", "sdk.EventRunWorkflowJob", "sdk.EventApplicationAdd"} {
+		require.NoError(t, f.sendEvent(&sdk.Event{EventType: e}))
+	}
+	assert.Equal(t, []string{"sdk.EventRunWorkflow", "sdk.EventRunWorkflowNode"}, b.events)
+}
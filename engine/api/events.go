@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/tevino/abool"
 
 	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/event"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/permission"
 	"github.com/ovh/cds/engine/api/services"
@@ -30,8 +32,23 @@ type eventsBrokerSubscribe struct {
 	isAlive  *abool.AtomicBool
 	w        http.ResponseWriter
 	mutex    sync.Mutex
+	// lastEventID is the id of the last event received by the client before its reconnection,
+	// live events up to this id and the events replayed after the reconnection are skipped
+	lastEventID      int64
+	replayedEventIDs map[int64]struct{}
+	// queue contains the live events to send to the client, in the order they were received
+	queue chan sdk.Event
+	// done is closed when the client is removed from the broker, to end its stream
+	done chan struct{}
 }
 
+const (
+	eventsClientQueueSize = 1000
+	eventsLogPageSize     = 500
+	eventsLogMaxPageSize  = 1000
+	eventsReplayMaxEvents = 10000
+)
+
 // lastUpdateBroker keeps connected client of the current route,
 type eventsBroker struct {
 	clients          map[string]*eventsBrokerSubscribe
@@ -120,7 +137,7 @@ func (b *eventsBroker) Start(ctx context.Context, panicCallback func(s string) (
 		case <-ctx.Done():
 			if b.clients != nil {
 				for uuid := range b.clients {
-					delete(b.clients, uuid)
+					b.removeClient(uuid)
 				}
 				observability.Record(b.router.Background, SSEClients, 0)
 			}
@@ -136,42 +153,81 @@ func (b *eventsBroker) Start(ctx context.Context, panicCallback func(s string) (
 					delete(b.clients, i)
 					continue
 				}
+				if !c.isAlive.IsSet() {
+					continue
+				}
 
-				// Send the event to the client sse within a goroutine
-				s := "sse-" + b.clients[i].UUID
-				sdk.GoRoutine(ctx, s,
-					func(ctx context.Context) {
-						if c.isAlive.IsSet() {
-							log.Debug("eventsBroker> send data to %s", c.UUID)
-							if err := c.Send(b.dbFunc(), receivedEvent); err != nil {
-								b.chanRemoveClient <- c.UUID
-								msg := fmt.Sprintf("%v", err)
-								for _, s := range handledEventErrors {
-									if strings.Contains(msg, s) {
-										// do not log knowned error
-										return
-									}
-								}
-								log.Error(ctx, "eventsBroker> unable to send event to %s: %v", c.UUID, err)
-							}
-						}
-					}, panicCallback,
-				)
+				// A client that can't keep up is disconnected, it will replay the missed events when it reconnects
+				select {
+				case c.queue <- receivedEvent:
+				default:
+					log.Warning(ctx, "eventsBroker> too many events waiting to be sent to %s, disconnecting it", c.UUID)
+					b.removeClient(i)
+				}
 			}
 
 		case client := <-b.chanAddClient:
 			b.clients[client.UUID] = client
+			sdk.GoRoutine(ctx, "sse-"+client.UUID,
+				func(ctx context.Context) {
+					b.sendQueuedEvents(ctx, client)
+				}, panicCallback,
+			)
 
 		case uuid := <-b.chanRemoveClient:
-			client, has := b.clients[uuid]
-			if !has {
+			b.removeClient(uuid)
+		}
+	}
+}
+
+// removeClient stops sending events to the client and ends its stream, it must be called by the broker loop only
+func (b *eventsBroker) removeClient(uuid string) {
+	client, has := b.clients[uuid]
+	if !has {
+		return
+	}
+	client.isAlive.UnSet()
+	close(client.queue)
+	close(client.done)
+	delete(b.clients, uuid)
+}
+
+// askRemoveClient asks the broker to remove the client, unless it was already removed
+func (b *eventsBroker) askRemoveClient(c *eventsBrokerSubscribe) {
+	select {
+	case b.chanRemoveClient <- c.UUID:
+	case <-c.done:
+	}
+}
+
+// sendQueuedEvents sends the events of the client queue one after the other until the client is removed,
+// so that the client receives the events in order and can resume after the last event it received.
+func (b *eventsBroker) sendQueuedEvents(ctx context.Context, c *eventsBrokerSubscribe) {
+	for e := range c.queue {
+		if !c.isAlive.IsSet() {
+			continue
+		}
+		log.Debug("eventsBroker> send data to %s", c.UUID)
+		if err := c.Send(b.dbFunc(), e); err != nil {
+			c.isAlive.UnSet()
+			b.askRemoveClient(c)
+			if isHandledEventError(err) {
+				// do not log knowned error
 				continue
 			}
+			log.Error(ctx, "eventsBroker> unable to send event to %s: %v", c.UUID, err)
+		}
+	}
+}
 
-			client.isAlive.UnSet()
-			delete(b.clients, uuid)
+func isHandledEventError(err error) bool {
+	msg := fmt.Sprintf("%v", err)
+	for _, s := range handledEventErrors {
+		if strings.Contains(msg, s) {
+			return true
 		}
 	}
+	return false
 }
 
 func (b *eventsBroker) ServeHTTP() service.Handler {
@@ -180,19 +236,40 @@ func (b *eventsBroker) ServeHTTP() service.Handler {
 	// runtime error: index out of range
 	// runtime error: slice bounds out of range
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) (err error) {
+		// GET /events?since=<id> returns the history of the events instead of the stream
+		if QueryString(r, "since") != "" {
+			return b.history(ctx, w, r)
+		}
+
 		// Make sure that the writer supports flushing.
 		f, ok := w.(http.Flusher)
 		if !ok {
 			return sdk.WrapError(fmt.Errorf("streaming unsupported"), "")
 		}
 
+		// A client that reconnects gives the id of the last event it received
+		var lastEventID int64
+		if h := r.Header.Get("Last-Event-ID"); h != "" {
+			lastEventID, err = strconv.ParseInt(h, 10, 64)
+			if err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid Last-Event-ID header %q", h)
+			}
+		}
+
 		var client = eventsBrokerSubscribe{
-			UUID:     sdk.UUID(),
-			consumer: getAPIConsumer(ctx),
-			isAlive:  abool.NewBool(true),
-			w:        w,
+			UUID:             sdk.UUID(),
+			consumer:         getAPIConsumer(ctx),
+			isAlive:          abool.NewBool(true),
+			w:                w,
+			lastEventID:      lastEventID,
+			replayedEventIDs: make(map[int64]struct{}),
+			queue:            make(chan sdk.Event, eventsClientQueueSize),
+			done:             make(chan struct{}),
 		}
 
+		// Live events are not sent to the client until the missed events are replayed
+		client.mutex.Lock()
+
 		// Add this client to the map of those that should receive updates
 		b.chanAddClient <- &client
 
@@ -203,10 +280,20 @@ func (b *eventsBroker) ServeHTTP() service.Handler {
 		w.Header().Set("X-Accel-Buffering", "no")
 
 		if _, err := w.Write([]byte(fmt.Sprintf("data: ACK: %s \n\n", client.UUID))); err != nil {
+			client.mutex.Unlock()
 			return sdk.WrapError(err, "Unable to send ACK to client")
 		}
 		f.Flush()
 
+		if lastEventID > 0 {
+			if err := client.replay(ctx, b.dbFunc(), lastEventID); err != nil {
+				client.mutex.Unlock()
+				b.askRemoveClient(&client)
+				return sdk.WrapError(err, "unable to replay events to client")
+			}
+		}
+		client.mutex.Unlock()
+
 		tick := time.NewTicker(time.Second)
 		defer tick.Stop()
 
@@ -215,11 +302,14 @@ func (b *eventsBroker) ServeHTTP() service.Handler {
 			select {
 			case <-ctx.Done():
 				log.Debug("events.Http: context done")
-				b.chanRemoveClient <- client.UUID
+				b.askRemoveClient(&client)
 				break leave
 			case <-r.Context().Done():
 				log.Debug("events.Http: client disconnected")
-				b.chanRemoveClient <- client.UUID
+				b.askRemoveClient(&client)
+				break leave
+			case <-client.done:
+				log.Debug("events.Http: client removed by the broker")
 				break leave
 			case <-tick.C:
 				_ = client.Send(nil, sdk.Event{})
@@ -230,6 +320,88 @@ func (b *eventsBroker) ServeHTTP() service.Handler {
 	}
 }
 
+// history returns a page of the events log that the consumer is allowed to read.
+func (b *eventsBroker) history(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	since, err := strconv.ParseInt(QueryString(r, "since"), 10, 64)
+	if err != nil || since < 0 {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid given since value")
+	}
+	limit, err := FormInt(r, "limit")
+	if err != nil {
+		return err
+	}
+	if limit <= 0 || limit > eventsLogMaxPageSize {
+		limit = eventsLogMaxPageSize
+	}
+
+	db := b.dbFunc()
+	events, err := event.LoadLog(ctx, db, since, limit)
+	if err != nil {
+		return err
+	}
+
+	client := eventsBrokerSubscribe{consumer: getAPIConsumer(ctx)}
+	res := sdk.EventHistory{Events: []sdk.Event{}}
+	for _, e := range events {
+		ok, err := client.manageEvent(db, e)
+		if err != nil {
+			return err
+		}
+		if ok {
+			res.Events = append(res.Events, e)
+		}
+	}
+	// The page can contain less events than the limit as some events are filtered
+	if len(events) == limit {
+		res.Next = events[len(events)-1].ID
+	}
+
+	return service.WriteJSON(w, res, http.StatusOK)
+}
+
+// replay sends to the client the events of the log published after the given id. Only the ids of
+// the replayed events are skipped afterwards, as an event with a lower id can be written later by
+// another API instance. When there are too many events the client is told where the replay stopped.
+// The client mutex must be locked.
+func (client *eventsBrokerSubscribe) replay(ctx context.Context, db gorp.SqlExecutor, since int64) error {
+	for n := 0; ; n += eventsLogPageSize {
+		if n >= eventsReplayMaxEvents {
+			return client.sendReplayTruncated(since)
+		}
+		events, err := event.LoadLog(ctx, db, since, eventsLogPageSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := client.send(db, e); err != nil {
+				return err
+			}
+			client.replayedEventIDs[e.ID] = struct{}{}
+			since = e.ID
+		}
+		if len(events) < eventsLogPageSize {
+			return nil
+		}
+	}
+}
+
+// sendReplayTruncated sends the id of the last replayed event, the client mutex must be locked
+func (client *eventsBrokerSubscribe) sendReplayTruncated(lastReplayedEventID int64) error {
+	if !client.isAlive.IsSet() {
+		return nil
+	}
+	f, ok := client.w.(http.Flusher)
+	if !ok {
+		return sdk.WrapError(fmt.Errorf("streaming unsupported"), "")
+	}
+	msg := fmt.Sprintf("event: %s\ndata: %d\n\n", sdk.EventReplayTruncated, lastReplayedEventID)
+	if _, err := client.w.Write([]byte(msg)); err != nil {
+		return sdk.WrapError(err, "unable to write to client")
+	}
+	f.Flush()
+	return nil
+}
+
 func (client *eventsBrokerSubscribe) manageEvent(db gorp.SqlExecutor, event sdk.Event) (bool, error) {
 	if strings.HasPrefix(event.EventType, "sdk.EventMaintenance") {
 		return true, nil
@@ -278,7 +450,11 @@ func (client *eventsBrokerSubscribe) manageEvent(db gorp.SqlExecutor, event sdk.
 func (client *eventsBrokerSubscribe) Send(db gorp.SqlExecutor, event sdk.Event) (err error) {
 	client.mutex.Lock()
 	defer client.mutex.Unlock()
+	return client.send(db, event)
+}
 
+// send an event to a client, the client mutex must be locked
+func (client *eventsBrokerSubscribe) send(db gorp.SqlExecutor, event sdk.Event) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("eventsBrokerSubscribe.Send recovered %v", r)
@@ -297,6 +473,13 @@ func (client *eventsBrokerSubscribe) Send(db gorp.SqlExecutor, event sdk.Event)
 
 	var buffer bytes.Buffer
 	if event.EventType != "" {
+		// Skip the events already sent before a reconnection or replayed after it
+		if event.ID > 0 {
+			if _, replayed := client.replayedEventIDs[event.ID]; replayed || event.ID <= client.lastEventID {
+				return nil
+			}
+		}
+
 		if ok, err := client.manageEvent(db, event); !ok {
 			return err
 		}
@@ -305,6 +488,9 @@ func (client *eventsBrokerSubscribe) Send(db gorp.SqlExecutor, event sdk.Event)
 		if err != nil {
 			return sdk.WrapError(err, "Unable to marshall event")
 		}
+		if event.ID > 0 {
+			buffer.WriteString("id: " + strconv.FormatInt(event.ID, 10) + "\n")
+		}
 		buffer.WriteString("data: ")
 		buffer.Write(msg)
 		buffer.WriteString("\n\n")
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/go-gorp/gorp"
 	"github.com/gorilla/mux"
 
 	"github.com/ovh/cds/engine/api/action"
@@ -15,6 +16,7 @@ import (
 	"github.com/ovh/cds/engine/api/integration"
 	"github.com/ovh/cds/engine/api/objectstore"
 	"github.com/ovh/cds/engine/api/plugin"
+	"github.com/ovh/cds/engine/api/workflow"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 )
@@ -59,6 +61,12 @@ func (api *API) postGRPCluginHandler() service.Handler {
 			}
 		}
 
+		if p.Type == sdk.GRPCPluginHook {
+			if err := upsertHookPluginModel(tx, p); err != nil {
+				return err
+			}
+		}
+
 		if err := plugin.Insert(tx, &p); err != nil {
 			return sdk.WrapError(err, "unable to insert plugin")
 		}
@@ -71,6 +79,23 @@ func (api *API) postGRPCluginHandler() service.Handler {
 	}
 }
 
+// upsertHookPluginModel creates or updates the workflow hook model served by a hook plugin.
+func upsertHookPluginModel(db gorp.SqlExecutor, p sdk.GRPCPlugin) error {
+	m := sdk.NewHookPluginModel(p)
+	old, err := workflow.LoadHookModelByName(db, p.Name)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return err
+	}
+	if old == nil {
+		return workflow.InsertHookModel(db, &m)
+	}
+	if old.Type != sdk.WorkflowHookModelPlugin {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "hook model %s already exists and is not served by a plugin", p.Name)
+	}
+	m.ID = old.ID
+	return workflow.UpdateHookModel(db, &m)
+}
+
 func (api *API) getAllGRPCluginHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 
@@ -134,6 +159,12 @@ func (api *API) putGRPCluginHandler() service.Handler {
 			}
 		}
 
+		if p.Type == sdk.GRPCPluginHook {
+			if err := upsertHookPluginModel(tx, p); err != nil {
+				return err
+			}
+		}
+
 		if err := plugin.Update(tx, &p); err != nil {
 			return sdk.WrapError(err, "unable to insert plugin")
 		}
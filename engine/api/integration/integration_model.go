@@ -12,8 +12,12 @@ var (
 	BuiltinModels = []sdk.IntegrationModel{
 		sdk.KafkaIntegration,
 		sdk.RabbitMQIntegration,
+		sdk.NATSIntegration,
+		sdk.WebhookIntegration,
 		sdk.OpenstackIntegration,
 		sdk.AWSIntegration,
+		sdk.GCSIntegration,
+		sdk.AzureBlobIntegration,
 	}
 )
 
@@ -2,8 +2,10 @@ package keys
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
@@ -104,3 +106,34 @@ func GenerateSSHKey(name string) (sdk.Key, error) {
 	k.Private = string(priv)
 	return k, nil
 }
+
+// SignRSA signs the given data with a RSA private key, with PKCS#1 v1.5 and SHA-256
+func SignRSA(privateKey string, data []byte) ([]byte, error) {
+	key, err := getSSHPrivateKey(strings.NewReader(privateKey))
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(data)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to sign data")
+	}
+	return sig, nil
+}
+
+// RSAPublicKeyPEM returns the PEM encoded public key of a RSA private key, and its identifier
+func RSAPublicKeyPEM(privateKey string) (string, string, error) {
+	key, err := getSSHPrivateKey(strings.NewReader(privateKey))
+	if err != nil {
+		return "", "", err
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", "", sdk.WrapError(err, "unable to marshal public key")
+	}
+	keyID, err := sdk.ProvenanceKeyID(&key.PublicKey)
+	if err != nil {
+		return "", "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), keyID, nil
+}
@@ -2,11 +2,15 @@ package keys
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/base64"
 	"io/ioutil"
 	"testing"
 
 	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/openpgp"
 )
@@ -100,3 +104,24 @@ func TestGenerateGPGKeyPair(t *testing.T) {
 	t.Logf(string(pub2))
 	assert.Equal(t, string([]byte(k.Public)), string(pub2))
 }
+
+func TestSignRSA(t *testing.T) {
+	k, err := GenerateSSHKey("provenance")
+	test.NoError(t, err)
+
+	sig, err := SignRSA(k.Private, []byte("I am signed"))
+	test.NoError(t, err)
+
+	pubPEM, keyID, err := RSAPublicKeyPEM(k.Private)
+	test.NoError(t, err)
+	pub, err := sdk.ParseProvenancePublicKey([]byte(pubPEM))
+	test.NoError(t, err)
+	pubKeyID, err := sdk.ProvenanceKeyID(pub)
+	test.NoError(t, err)
+	assert.Equal(t, keyID, pubKeyID)
+
+	digest := sha256.Sum256([]byte("I am signed"))
+	assert.NoError(t, rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig))
+	digest = sha256.Sum256([]byte("I am not signed"))
+	assert.Error(t, rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig))
+}
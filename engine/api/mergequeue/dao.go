@@ -0,0 +1,89 @@
+package mergequeue
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+var activeStatus = strings.Join([]string{sdk.MergeQueueStatusWaiting, sdk.MergeQueueStatusMerging, sdk.MergeQueueStatusBuilding}, ",")
+
+func getAll(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.MergeQueueEntry, error) {
+	var dbEntries []dbMergeQueueEntry
+	if err := gorpmapping.GetAll(ctx, db, q, &dbEntries); err != nil {
+		return nil, sdk.WrapError(err, "cannot get merge queue entries")
+	}
+	entries := make([]sdk.MergeQueueEntry, len(dbEntries))
+	for i := range dbEntries {
+		entries[i] = sdk.MergeQueueEntry(dbEntries[i])
+	}
+	return entries, nil
+}
+
+// LoadAllByWorkflowID returns the merge queue entries of a workflow, the latest first.
+func LoadAllByWorkflowID(ctx context.Context, db gorp.SqlExecutor, workflowID int64, limit int) ([]sdk.MergeQueueEntry, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM workflow_merge_queue
+		WHERE workflow_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`).Args(workflowID, limit)
+	return getAll(ctx, db, query)
+}
+
+// LoadAllActive returns all the entries still in a merge queue, the oldest first.
+func LoadAllActive(ctx context.Context, db gorp.SqlExecutor) ([]sdk.MergeQueueEntry, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM workflow_merge_queue
+		WHERE status = ANY(string_to_array($1, ',')::text[])
+		ORDER BY id
+	`).Args(activeStatus)
+	return getAll(ctx, db, query)
+}
+
+// LoadActiveByPullRequest returns the entry of a pull request still in the merge queue of a workflow.
+func LoadActiveByPullRequest(ctx context.Context, db gorp.SqlExecutor, workflowID int64, repo string, pullRequestID int64) (*sdk.MergeQueueEntry, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM workflow_merge_queue
+		WHERE workflow_id = $1 AND repository_fullname = $2 AND pull_request_id = $3 AND status = ANY(string_to_array($4, ',')::text[])
+		ORDER BY id DESC
+		LIMIT 1
+	`).Args(workflowID, repo, pullRequestID, activeStatus)
+	var entry dbMergeQueueEntry
+	found, err := gorpmapping.Get(ctx, db, query, &entry)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get merge queue entry")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	res := sdk.MergeQueueEntry(entry)
+	return &res, nil
+}
+
+// Insert a new entry in a merge queue.
+func Insert(db gorp.SqlExecutor, e *sdk.MergeQueueEntry) error {
+	e.Created = time.Now()
+	e.LastModified = e.Created
+	dbEntry := dbMergeQueueEntry(*e)
+	if err := gorpmapping.Insert(db, &dbEntry); err != nil {
+		return sdk.WrapError(err, "unable to insert merge queue entry")
+	}
+	e.ID = dbEntry.ID
+	return nil
+}
+
+// Update a merge queue entry.
+func Update(db gorp.SqlExecutor, e *sdk.MergeQueueEntry) error {
+	e.LastModified = time.Now()
+	dbEntry := dbMergeQueueEntry(*e)
+	if err := gorpmapping.Update(db, &dbEntry); err != nil {
+		return sdk.WrapError(err, "unable to update merge queue entry %d", e.ID)
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package mergequeue
+
+import (
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+type dbMergeQueueEntry sdk.MergeQueueEntry
+
+func init() {
+	gorpmapping.Register(gorpmapping.New(dbMergeQueueEntry{}, "workflow_merge_queue", true, "id"))
+}
@@ -175,7 +175,7 @@ func checkConditions(ctx context.Context, conditions sdk.WorkflowNodeConditions,
 	var conditionsOK bool
 	var errc error
 	if conditions.LuaScript == "" {
-		conditionsOK, errc = sdk.WorkflowCheckConditions(conditions.PlainConditions, params)
+		conditionsOK, errc = sdk.WorkflowCheckPlainConditionsAndExpression(conditions, params)
 	} else {
 		luacheck, err := luascript.NewCheck()
 		if err != nil {
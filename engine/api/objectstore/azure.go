@@ -0,0 +1,212 @@
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// AzureBlobStore implements ObjectStore interface with azure blob storage driver
+type AzureBlobStore struct {
+	projectIntegration sdk.ProjectIntegration
+	prefix             string
+	container          *storage.Container
+	staticContainer    *storage.Container
+}
+
+func newAzureBlobStore(ctx context.Context, integration sdk.ProjectIntegration, conf ConfigOptionsAzureBlob) (*AzureBlobStore, error) {
+	log.Info(ctx, "ObjectStore> Initialize Azure Blob driver for account %s and container %s", conf.AccountName, conf.ContainerName)
+	if conf.AccountName == "" || conf.AccountKey == "" || conf.ContainerName == "" {
+		return nil, fmt.Errorf("artifact storage is azure, but account name, account key or container name is not provided")
+	}
+
+	var client storage.Client
+	var err error
+	if conf.Endpoint != "" {
+		// Custom base url, used for sovereign clouds or local emulator
+		client, err = storage.NewClient(conf.AccountName, conf.AccountKey, conf.Endpoint, storage.DefaultAPIVersion, true)
+	} else {
+		client, err = storage.NewBasicClient(conf.AccountName, conf.AccountKey)
+	}
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to create azure storage client")
+	}
+
+	blobService := client.GetBlobService()
+	s := &AzureBlobStore{
+		projectIntegration: integration,
+		prefix:             conf.Prefix,
+		container:          blobService.GetContainerReference(conf.ContainerName),
+	}
+	if _, err := s.container.CreateIfNotExists(nil); err != nil {
+		return nil, sdk.WrapError(err, "unable to create azure container %s", conf.ContainerName)
+	}
+
+	// Static files need a container with public read access on blobs
+	if conf.StaticContainerName != "" {
+		s.staticContainer = blobService.GetContainerReference(conf.StaticContainerName)
+		if _, err := s.staticContainer.CreateIfNotExists(&storage.CreateContainerOptions{Access: storage.ContainerAccessTypeBlob}); err != nil {
+			return nil, sdk.WrapError(err, "unable to create azure container %s", conf.StaticContainerName)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *AzureBlobStore) getContainerPath(containerPath string) string {
+	return path.Join(s.prefix, containerPath)
+}
+
+func (s *AzureBlobStore) getObjectPath(o Object) string {
+	return path.Join(s.prefix, o.GetPath(), o.GetName())
+}
+
+// TemporaryURLSupported returns true is temporary URL are supported
+func (s *AzureBlobStore) TemporaryURLSupported() bool {
+	return true
+}
+
+// GetProjectIntegration returns current projet Integration, nil otherwise
+func (s *AzureBlobStore) GetProjectIntegration() sdk.ProjectIntegration {
+	return s.projectIntegration
+}
+
+// Status returns the status of the container
+func (s *AzureBlobStore) Status(ctx context.Context) sdk.MonitoringStatusLine {
+	if _, err := s.container.Exists(); err != nil {
+		return sdk.MonitoringStatusLine{Component: "Object-Store", Value: "Azure KO" + err.Error(), Status: sdk.MonitoringStatusAlert}
+	}
+	return sdk.MonitoringStatusLine{
+		Component: "Object-Store",
+		Value:     fmt.Sprintf("Azure OK (container %s)", s.container.Name),
+		Status:    sdk.MonitoringStatusOK,
+	}
+}
+
+// Store stores an object in the container
+func (s *AzureBlobStore) Store(o Object, data io.ReadCloser) (string, error) {
+	defer data.Close()
+	objectPath := s.getObjectPath(o)
+	log.Debug("Azure-Store> Uploading blob %s to container %s", objectPath, s.container.Name)
+	blob := s.container.GetBlobReference(objectPath)
+	blob.Properties.ContentType = "application/octet-stream"
+	if err := blob.CreateBlockBlobFromReader(data, nil); err != nil {
+		return "", sdk.WrapError(err, "Azure-Store> Unable to create blob %s", objectPath)
+	}
+	log.Debug("Azure-Store> Successfully uploaded blob %s to container %s", objectPath, s.container.Name)
+	return blob.GetURL(), nil
+}
+
+// ServeStaticFiles extracts the given tar archive in the static files container and returns
+// the public url of its entrypoint.
+func (s *AzureBlobStore) ServeStaticFiles(o Object, entrypoint string, data io.ReadCloser) (string, error) {
+	defer data.Close()
+	if s.staticContainer == nil {
+		return "", sdk.NewErrorFrom(sdk.ErrNotImplemented, "no static files container is configured for azure storage")
+	}
+	if entrypoint == "" {
+		entrypoint = "index.html"
+	}
+
+	containerPath := s.getContainerPath(o.GetPath())
+	log.Debug("Azure-Store> Serving static files in %s/%s", s.staticContainer.Name, containerPath)
+	if err := walkTarFiles(data, func(name string, r io.Reader) error {
+		blob := s.staticContainer.GetBlobReference(path.Join(containerPath, name))
+		blob.Properties.ContentType = staticFileContentType(name)
+		return sdk.WrapError(blob.CreateBlockBlobFromReader(r, nil), "Azure-Store> Unable to create blob %s", blob.Name)
+	}); err != nil {
+		return "", err
+	}
+
+	return s.staticContainer.GetBlobReference(path.Join(containerPath, entrypoint)).GetURL(), nil
+}
+
+// Fetch returns the content of a blob
+func (s *AzureBlobStore) Fetch(ctx context.Context, o Object) (io.ReadCloser, error) {
+	objectPath := s.getObjectPath(o)
+	log.Debug("Azure-Store> Fetching blob %s from container %s", objectPath, s.container.Name)
+	r, err := s.container.GetBlobReference(objectPath).Get(nil)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Azure-Store> Unable to download blob %s", objectPath)
+	}
+	return r, nil
+}
+
+// Delete deletes a blob from the container
+func (s *AzureBlobStore) Delete(ctx context.Context, o Object) error {
+	objectPath := s.getObjectPath(o)
+	log.Debug("Azure-Store> Deleting blob %s from container %s", objectPath, s.container.Name)
+	if _, err := s.container.GetBlobReference(objectPath).DeleteIfExists(nil); err != nil {
+		return sdk.WrapError(err, "Azure-Store> Unable to delete blob %s", objectPath)
+	}
+	return nil
+}
+
+// DeleteContainer deletes all the blobs of a container (= directory)
+func (s *AzureBlobStore) DeleteContainer(ctx context.Context, containerPath string) error {
+	prefix := s.getContainerPath(containerPath) + "/"
+	log.Debug("Azure-Store> Deleting container %s from container %s", prefix, s.container.Name)
+	params := storage.ListBlobsParameters{Prefix: prefix}
+	for {
+		resp, err := s.container.ListBlobs(params)
+		if err != nil {
+			return sdk.WrapError(err, "Azure-Store> Unable to list blobs in %s", prefix)
+		}
+		for i := range resp.Blobs {
+			if _, err := s.container.GetBlobReference(resp.Blobs[i].Name).DeleteIfExists(nil); err != nil {
+				return sdk.WrapError(err, "Azure-Store> Unable to delete blob %s", resp.Blobs[i].Name)
+			}
+		}
+		if resp.NextMarker == "" {
+			return nil
+		}
+		params.Marker = resp.NextMarker
+	}
+}
+
+func (s *AzureBlobStore) sasURL(objectPath string, permissions storage.BlobServiceSASPermissions) (string, error) {
+	u, err := s.container.GetBlobReference(objectPath).GetSASURI(storage.BlobSASOptions{
+		BlobServiceSASPermissions: permissions,
+		SASOptions: storage.SASOptions{
+			Start:    time.Now().Add(-5 * time.Minute),
+			Expiry:   time.Now().Add(5 * time.Minute),
+			UseHTTPS: true,
+		},
+	})
+	return u, sdk.WrapError(err, "failed to sign url")
+}
+
+// StoreURL returns a temporary url and a secret key to store an object,
+// the client has to set the header x-ms-blob-type: BlockBlob.
+func (s *AzureBlobStore) StoreURL(o Object, contentType string) (string, string, error) {
+	objectPath := s.getObjectPath(o)
+	u, err := s.sasURL(objectPath, storage.BlobServiceSASPermissions{Create: true, Write: true})
+	if err != nil {
+		return "", "", err
+	}
+	log.Debug("Azure-Store> StoreURL url:%s", u)
+	return u, objectPath, nil
+}
+
+// FetchURL returns a temporary url and a secret key to fetch an object
+func (s *AzureBlobStore) FetchURL(o Object) (string, string, error) {
+	objectPath := s.getObjectPath(o)
+	u, err := s.sasURL(objectPath, storage.BlobServiceSASPermissions{Read: true})
+	if err != nil {
+		return "", "", err
+	}
+	log.Debug("Azure-Store> FetchURL url:%s", u)
+	return u, objectPath, nil
+}
+
+// ServeStaticFilesURL is not implemented on azure because archives can't be extracted by the storage
+func (s *AzureBlobStore) ServeStaticFilesURL(o Object, entrypoint string) (string, string, error) {
+	return "", "", sdk.WithStack(sdk.ErrNotImplemented)
+}
@@ -0,0 +1,235 @@
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// GCSStore implements ObjectStore interface with google cloud storage driver
+type GCSStore struct {
+	projectIntegration sdk.ProjectIntegration
+	prefix             string
+	bucketName         string
+	client             *storage.Client
+	// Service account email and private key are needed to sign urls
+	googleAccessID string
+	privateKey     []byte
+}
+
+func newGCSStore(ctx context.Context, integration sdk.ProjectIntegration, conf ConfigOptionsGCS) (*GCSStore, error) {
+	log.Info(ctx, "ObjectStore> Initialize Google Cloud Storage driver for bucket: %s", conf.BucketName)
+	if conf.BucketName == "" {
+		return nil, fmt.Errorf("artifact storage is gcs, but bucket name is not provided")
+	}
+
+	s := &GCSStore{
+		projectIntegration: integration,
+		prefix:             conf.Prefix,
+		bucketName:         conf.BucketName,
+	}
+
+	var opts []option.ClientOption
+	credentials := []byte(conf.Credentials)
+	if len(credentials) == 0 && conf.CredentialsFile != "" {
+		var err error
+		credentials, err = ioutil.ReadFile(conf.CredentialsFile)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to read google credentials file %s", conf.CredentialsFile)
+		}
+	}
+	if len(credentials) > 0 {
+		opts = append(opts, option.WithCredentialsJSON(credentials))
+		// Signed urls can only be generated with a service account key
+		jwtConf, err := google.JWTConfigFromJSON(credentials)
+		if err != nil {
+			log.Warning(ctx, "ObjectStore> GCS credentials is not a service account key, temporary urls are disabled: %v", err)
+		} else {
+			s.googleAccessID = jwtConf.Email
+			s.privateKey = jwtConf.PrivateKey
+		}
+	}
+	if conf.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(conf.Endpoint))
+	}
+
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to create google cloud storage client")
+	}
+	s.client = client
+	return s, nil
+}
+
+func (s *GCSStore) bucket() *storage.BucketHandle {
+	return s.client.Bucket(s.bucketName)
+}
+
+func (s *GCSStore) getContainerPath(containerPath string) string {
+	return path.Join(s.prefix, containerPath)
+}
+
+func (s *GCSStore) getObjectPath(o Object) string {
+	return path.Join(s.prefix, o.GetPath(), o.GetName())
+}
+
+// TemporaryURLSupported returns true is temporary URL are supported
+func (s *GCSStore) TemporaryURLSupported() bool {
+	return s.googleAccessID != "" && len(s.privateKey) > 0
+}
+
+// GetProjectIntegration returns current projet Integration, nil otherwise
+func (s *GCSStore) GetProjectIntegration() sdk.ProjectIntegration {
+	return s.projectIntegration
+}
+
+// Status returns the status of the bucket
+func (s *GCSStore) Status(ctx context.Context) sdk.MonitoringStatusLine {
+	attrs, err := s.bucket().Attrs(ctx)
+	if err != nil {
+		return sdk.MonitoringStatusLine{Component: "Object-Store", Value: "GCS KO" + err.Error(), Status: sdk.MonitoringStatusAlert}
+	}
+	return sdk.MonitoringStatusLine{
+		Component: "Object-Store",
+		Value:     fmt.Sprintf("GCS OK (bucket %s in %s)", attrs.Name, attrs.Location),
+		Status:    sdk.MonitoringStatusOK,
+	}
+}
+
+// Store stores an object in the bucket
+func (s *GCSStore) Store(o Object, data io.ReadCloser) (string, error) {
+	defer data.Close()
+	objectPath := s.getObjectPath(o)
+	log.Debug("GCS-Store> Uploading object %s to bucket %s", objectPath, s.bucketName)
+	if err := s.write(context.Background(), objectPath, "application/octet-stream", "", data); err != nil {
+		return "", sdk.WrapError(err, "GCS-Store> Unable to create object %s", objectPath)
+	}
+	log.Debug("GCS-Store> Successfully uploaded object %s to bucket %s", objectPath, s.bucketName)
+	return objectPath, nil
+}
+
+func (s *GCSStore) write(ctx context.Context, objectPath, contentType, predefinedACL string, data io.Reader) error {
+	w := s.bucket().Object(objectPath).NewWriter(ctx)
+	w.ContentType = contentType
+	w.PredefinedACL = predefinedACL
+	if _, err := io.Copy(w, data); err != nil {
+		_ = w.Close()
+		return sdk.WithStack(err)
+	}
+	return sdk.WithStack(w.Close())
+}
+
+// ServeStaticFiles extracts the given tar archive in the bucket and returns the public url of its entrypoint,
+// the bucket should allow objects ACLs to make them public.
+func (s *GCSStore) ServeStaticFiles(o Object, entrypoint string, data io.ReadCloser) (string, error) {
+	defer data.Close()
+	if entrypoint == "" {
+		entrypoint = "index.html"
+	}
+
+	containerPath := s.getContainerPath(o.GetPath())
+	log.Debug("GCS-Store> Serving static files in %s/%s", s.bucketName, containerPath)
+	if err := walkTarFiles(data, func(name string, r io.Reader) error {
+		objectPath := path.Join(containerPath, name)
+		return sdk.WrapError(s.write(context.Background(), objectPath, staticFileContentType(name), "publicRead", r),
+			"GCS-Store> Unable to create object %s", objectPath)
+	}); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", s.bucketName, path.Join(containerPath, entrypoint)), nil
+}
+
+// Fetch returns the content of an object
+func (s *GCSStore) Fetch(ctx context.Context, o Object) (io.ReadCloser, error) {
+	objectPath := s.getObjectPath(o)
+	log.Debug("GCS-Store> Fetching object %s from bucket %s", objectPath, s.bucketName)
+	r, err := s.bucket().Object(objectPath).NewReader(ctx)
+	if err != nil {
+		return nil, sdk.WrapError(err, "GCS-Store> Unable to download object %s", objectPath)
+	}
+	return r, nil
+}
+
+// Delete deletes an object from the bucket
+func (s *GCSStore) Delete(ctx context.Context, o Object) error {
+	objectPath := s.getObjectPath(o)
+	log.Debug("GCS-Store> Deleting object %s from bucket %s", objectPath, s.bucketName)
+	if err := s.bucket().Object(objectPath).Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+		return sdk.WrapError(err, "GCS-Store> Unable to delete object %s", objectPath)
+	}
+	return nil
+}
+
+// DeleteContainer deletes all the objects of a container (= directory) from the bucket
+func (s *GCSStore) DeleteContainer(ctx context.Context, containerPath string) error {
+	prefix := s.getContainerPath(containerPath) + "/"
+	log.Debug("GCS-Store> Deleting container %s from bucket %s", prefix, s.bucketName)
+	it := s.bucket().Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return sdk.WrapError(err, "GCS-Store> Unable to list objects in %s", prefix)
+		}
+		if err := s.bucket().Object(attrs.Name).Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+			return sdk.WrapError(err, "GCS-Store> Unable to delete object %s", attrs.Name)
+		}
+	}
+	return nil
+}
+
+func (s *GCSStore) signedURL(objectPath, method, contentType string) (string, error) {
+	if !s.TemporaryURLSupported() {
+		return "", sdk.WithStack(sdk.ErrNotImplemented)
+	}
+	u, err := storage.SignedURL(s.bucketName, objectPath, &storage.SignedURLOptions{
+		GoogleAccessID: s.googleAccessID,
+		PrivateKey:     s.privateKey,
+		Method:         method,
+		ContentType:    contentType,
+		Expires:        time.Now().Add(5 * time.Minute),
+	})
+	return u, sdk.WrapError(err, "failed to sign url")
+}
+
+// StoreURL returns a temporary url and a secret key to store an object
+func (s *GCSStore) StoreURL(o Object, contentType string) (string, string, error) {
+	objectPath := s.getObjectPath(o)
+	u, err := s.signedURL(objectPath, http.MethodPut, contentType)
+	if err != nil {
+		return "", "", err
+	}
+	log.Debug("GCS-Store> StoreURL url:%s", u)
+	return u, objectPath, nil
+}
+
+// FetchURL returns a temporary url and a secret key to fetch an object
+func (s *GCSStore) FetchURL(o Object) (string, string, error) {
+	objectPath := s.getObjectPath(o)
+	u, err := s.signedURL(objectPath, http.MethodGet, "")
+	if err != nil {
+		return "", "", err
+	}
+	log.Debug("GCS-Store> FetchURL url:%s", u)
+	return u, objectPath, nil
+}
+
+// ServeStaticFilesURL is not implemented on gcs because archives can't be extracted by the storage
+func (s *GCSStore) ServeStaticFilesURL(o Object, entrypoint string) (string, string, error) {
+	return "", "", sdk.WithStack(sdk.ErrNotImplemented)
+}
@@ -1,10 +1,14 @@
 package objectstore
 
 import (
+	"archive/tar"
 	"context"
 	"fmt"
 	"io"
+	"mime"
 	"net/url"
+	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -16,6 +20,9 @@ import (
 
 // Driver allows artifact to be stored and retrieve the same way to any backend
 // - Openstack / Swift
+// - AWS S3
+// - Google Cloud Storage
+// - Azure Blob
 // - Filesystem
 type Driver interface {
 	GetProjectIntegration() sdk.ProjectIntegration
@@ -47,6 +54,8 @@ const (
 	Filesystem
 	Swift
 	AWSS3
+	GCS
+	AzureBlob
 )
 
 // Config represents all the configuration for all objectstore drivers
@@ -60,6 +69,8 @@ type Config struct {
 // ConfigOptions is used by Config
 type ConfigOptions struct {
 	AWSS3      ConfigOptionsAWSS3
+	GCS        ConfigOptionsGCS
+	AzureBlob  ConfigOptionsAzureBlob
 	Openstack  ConfigOptionsOpenstack
 	Filesystem ConfigOptionsFilesystem
 }
@@ -81,6 +92,28 @@ type ConfigOptionsAWSS3 struct {
 	ForcePathStyle      bool   //optional
 }
 
+// ConfigOptionsGCS is used by ConfigOptions
+type ConfigOptionsGCS struct {
+	BucketName string
+	Prefix     string
+	// Auth options, a service account key is needed to generate temporary urls.
+	// Application default credentials are used if none is given.
+	Credentials     string
+	CredentialsFile string
+	Endpoint        string //optional
+}
+
+// ConfigOptionsAzureBlob is used by ConfigOptions
+type ConfigOptionsAzureBlob struct {
+	AccountName   string
+	AccountKey    string
+	ContainerName string
+	Prefix        string
+	// StaticContainerName is the container with public access used to serve static files
+	StaticContainerName string //optional
+	Endpoint            string //optional
+}
+
 // ConfigOptionsOpenstack is used by ConfigOptions
 type ConfigOptionsOpenstack struct {
 	Address         string
@@ -136,6 +169,22 @@ func initDriver(ctx context.Context, db gorp.SqlExecutor, projectKey, integratio
 			cfg.ForcePathStyle, _ = strconv.ParseBool(projectIntegration.Config["force_path_style"].Value)
 		}
 		return newS3Store(ctx, projectIntegration, cfg)
+	case sdk.GCSIntegrationModel:
+		return newGCSStore(ctx, projectIntegration, ConfigOptionsGCS{
+			BucketName:  projectIntegration.Config["bucket_name"].Value,
+			Prefix:      projectIntegration.Config["prefix"].Value,
+			Credentials: projectIntegration.Config["credentials"].Value,
+			Endpoint:    projectIntegration.Config["endpoint"].Value,
+		})
+	case sdk.AzureBlobIntegrationModel:
+		return newAzureBlobStore(ctx, projectIntegration, ConfigOptionsAzureBlob{
+			AccountName:         projectIntegration.Config["account_name"].Value,
+			AccountKey:          projectIntegration.Config["account_key"].Value,
+			ContainerName:       projectIntegration.Config["container_name"].Value,
+			Prefix:              projectIntegration.Config["prefix"].Value,
+			StaticContainerName: projectIntegration.Config["static_container_name"].Value,
+			Endpoint:            projectIntegration.Config["endpoint"].Value,
+		})
 	case sdk.OpenstackIntegrationModel:
 		return newSwiftStore(ctx, projectIntegration, ConfigOptionsOpenstack{
 			Address:         projectIntegration.Config["address"].Value,
@@ -159,6 +208,10 @@ func Init(c context.Context, cfg Config) (Driver, error) {
 		return newSwiftStore(c, sdk.ProjectIntegration{Name: sdk.DefaultStorageIntegrationName}, cfg.Options.Openstack)
 	case AWSS3:
 		return newS3Store(c, sdk.ProjectIntegration{Name: sdk.DefaultStorageIntegrationName}, cfg.Options.AWSS3)
+	case GCS:
+		return newGCSStore(c, sdk.ProjectIntegration{Name: sdk.DefaultStorageIntegrationName}, cfg.Options.GCS)
+	case AzureBlob:
+		return newAzureBlobStore(c, sdk.ProjectIntegration{Name: sdk.DefaultStorageIntegrationName}, cfg.Options.AzureBlob)
 	case Filesystem:
 		return newFilesystemStore(c, sdk.ProjectIntegration{Name: sdk.DefaultStorageIntegrationName}, cfg.Options.Filesystem)
 	default:
@@ -173,3 +226,36 @@ func escape(container, object string) (string, string) {
 	object = strings.Replace(object, "/", "-", -1)
 	return container, object
 }
+
+// walkTarFiles calls fn for each regular file of a tar archive, file names are cleaned
+// and can't go outside of the archive root.
+func walkTarFiles(data io.Reader, fn func(name string, r io.Reader) error) error {
+	tr := tar.NewReader(data)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid tar archive"))
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		name := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(hdr.Name)), "/")
+		if name == "" {
+			continue
+		}
+		if err := fn(name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// staticFileContentType returns the content type of a static file from its extension.
+func staticFileContentType(name string) string {
+	if t := mime.TypeByExtension(path.Ext(name)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,46 @@
+package objectstore
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkTarFiles(t *testing.T) {
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for _, f := range []struct {
+		name     string
+		typeflag byte
+		content  string
+	}{
+		{name: "site/", typeflag: tar.TypeDir},
+		{name: "site/index.html", typeflag: tar.TypeReg, content: "<html></html>"},
+		{name: "../../etc/passwd", typeflag: tar.TypeReg, content: "root"},
+		{name: "site/link", typeflag: tar.TypeSymlink},
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: f.name, Typeflag: f.typeflag, Size: int64(len(f.content)), Mode: 0644}))
+		_, err := tw.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	files := map[string]string{}
+	require.NoError(t, walkTarFiles(buf, func(name string, r io.Reader) error {
+		b, err := ioutil.ReadAll(r)
+		files[name] = string(b)
+		return err
+	}))
+	assert.Equal(t, map[string]string{
+		"site/index.html": "<html></html>",
+		"etc/passwd":      "root",
+	}, files)
+
+	assert.Equal(t, "text/html; charset=utf-8", staticFileContentType("site/index.html"))
+	assert.Equal(t, "application/octet-stream", staticFileContentType("site/unknown"))
+}
@@ -19,6 +19,64 @@ import (
 var CacheOperationKey = cache.Key("repositories", "operation", "push")
 
 func PushOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, app *sdk.Application, wp exportentities.WorkflowPulled, branch, message string, isUpdate bool, u sdk.Identifiable) (*sdk.Operation, error) {
+	ope, err := newOperation(ctx, db, store, proj, app, u)
+	if err != nil {
+		return nil, err
+	}
+	ope.Setup.Push = sdk.OperationPush{
+		FromBranch: branch,
+		Message:    message,
+		Update:     isUpdate,
+	}
+
+	buf := new(bytes.Buffer)
+	if err := wp.Tar(ctx, buf); err != nil {
+		return nil, sdk.WrapError(err, "cannot tar pulled workflow")
+	}
+
+	multipartData := &services.MultiPartData{
+		Reader:      buf,
+		ContentType: "application/tar",
+	}
+	if err := PostRepositoryOperation(ctx, db, *proj, ope, multipartData); err != nil {
+		return nil, sdk.WrapError(err, "unable to post repository operation")
+	}
+	ope.RepositoryStrategy.SSHKeyContent = ""
+	_ = store.SetWithTTL(cache.Key(CacheOperationKey, ope.UUID), ope, 300)
+	return ope, nil
+}
+
+// MergeOperation creates a repository operation that merges a branch on another and pushes the merge commit on a new branch
+func MergeOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, app *sdk.Application, merge sdk.OperationMerge, u sdk.Identifiable) (*sdk.Operation, error) {
+	ope, err := newOperation(ctx, db, store, proj, app, u)
+	if err != nil {
+		return nil, err
+	}
+	ope.Setup.Merge = merge
+
+	if err := PostRepositoryOperation(ctx, db, *proj, ope, nil); err != nil {
+		return nil, sdk.WrapError(err, "unable to post repository operation")
+	}
+	ope.RepositoryStrategy.SSHKeyContent = ""
+	return ope, nil
+}
+
+// DeleteBranchOperation creates a repository operation that deletes a branch
+func DeleteBranchOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, app *sdk.Application, branch string, u sdk.Identifiable) (*sdk.Operation, error) {
+	ope, err := newOperation(ctx, db, store, proj, app, u)
+	if err != nil {
+		return nil, err
+	}
+	ope.Setup.DeleteBranch = sdk.OperationDeleteBranch{Branch: branch}
+
+	if err := PostRepositoryOperation(ctx, db, *proj, ope, nil); err != nil {
+		return nil, sdk.WrapError(err, "unable to post repository operation")
+	}
+	ope.RepositoryStrategy.SSHKeyContent = ""
+	return ope, nil
+}
+
+func newOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, app *sdk.Application, u sdk.Identifiable) (*sdk.Operation, error) {
 	var vcsStrategy = app.RepositoryStrategy
 	if vcsStrategy.SSHKey != "" {
 		key := proj.GetSSHKey(vcsStrategy.SSHKey)
@@ -39,13 +97,6 @@ func PushOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 		RepoFullName:       app.RepositoryFullname,
 		URL:                "",
 		RepositoryStrategy: vcsStrategy,
-		Setup: sdk.OperationSetup{
-			Push: sdk.OperationPush{
-				FromBranch: branch,
-				Message:    message,
-				Update:     isUpdate,
-			},
-		},
 	}
 	ope.User.Email = u.GetEmail()
 	ope.User.Username = u.GetFullname()
@@ -70,21 +121,6 @@ func PushOperation(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 	} else {
 		ope.URL = repo.HTTPCloneURL
 	}
-
-	buf := new(bytes.Buffer)
-	if err := wp.Tar(ctx, buf); err != nil {
-		return nil, sdk.WrapError(err, "cannot tar pulled workflow")
-	}
-
-	multipartData := &services.MultiPartData{
-		Reader:      buf,
-		ContentType: "application/tar",
-	}
-	if err := PostRepositoryOperation(ctx, db, *proj, &ope, multipartData); err != nil {
-		return nil, sdk.WrapError(err, "unable to post repository operation")
-	}
-	ope.RepositoryStrategy.SSHKeyContent = ""
-	_ = store.SetWithTTL(cache.Key(CacheOperationKey, ope.UUID), ope, 300)
 	return &ope, nil
 }
 
@@ -67,8 +67,8 @@ func InsertJob(db gorp.SqlExecutor, job *sdk.Job, stageID int64, pip *sdk.Pipeli
 	job.PipelineStageID = stage.ID
 
 	// Create pipeline action
-	query := `INSERT INTO pipeline_action (pipeline_stage_id, action_id, enabled) VALUES ($1, $2, $3) RETURNING id`
-	return sdk.WithStack(db.QueryRow(query, job.PipelineStageID, job.Action.ID, job.Enabled).Scan(&job.PipelineActionID))
+	query := `INSERT INTO pipeline_action (pipeline_stage_id, action_id, enabled, matrix, retry_policy) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	return sdk.WithStack(db.QueryRow(query, job.PipelineStageID, job.Action.ID, job.Enabled, job.Matrix, job.RetryPolicy).Scan(&job.PipelineActionID))
 }
 
 // UpdateJob  updates the job by actionData.PipelineActionID and actionData.ID
@@ -99,8 +99,8 @@ func DeleteJob(db gorp.SqlExecutor, job sdk.Job) error {
 
 // UpdatePipelineAction Update an action in a pipeline
 func UpdatePipelineAction(db gorp.SqlExecutor, job sdk.Job) error {
-	query := `UPDATE pipeline_action set action_id=$1, pipeline_stage_id=$2, enabled=$3 WHERE id=$4`
-	_, err := db.Exec(query, job.Action.ID, job.PipelineStageID, job.Enabled, job.PipelineActionID)
+	query := `UPDATE pipeline_action set action_id=$1, pipeline_stage_id=$2, enabled=$3, matrix=$4, retry_policy=$5 WHERE id=$6`
+	_, err := db.Exec(query, job.Action.ID, job.PipelineStageID, job.Enabled, job.Matrix, job.RetryPolicy, job.PipelineActionID)
 	return sdk.WithStack(err)
 }
 
@@ -115,7 +115,7 @@ func CheckJob(ctx context.Context, db gorp.SqlExecutor, job *sdk.Job) error {
 		step := &job.Action.Actions[i]
 		log.Debug("CheckJob> Checking step %s", step.Name)
 
-		a, err := action.RetrieveForGroupAndName(ctx, db, step.Group, step.Name)
+		a, err := action.RetrieveForGroupAndName(ctx, db, step.Group, step.Name, step.Version)
 		if err != nil {
 			if sdk.ErrorIs(err, sdk.ErrNoAction) {
 				errs = append(errs, sdk.NewMessage(sdk.MsgJobNotValidActionNotFound, job.Action.Name, step.Name, i+1))
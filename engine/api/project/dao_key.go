@@ -8,6 +8,7 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/keys"
 	"github.com/ovh/cds/engine/api/secret"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
@@ -122,3 +123,40 @@ func loadBuildinKey(db gorp.SqlExecutor, projectID int64) (sdk.ProjectKey, error
 
 	return k, nil
 }
+
+// ProvenanceKey is the name of the builtin key used to sign the provenance of the artifacts of a project
+const ProvenanceKey = "provenance"
+
+// LoadProvenanceKey returns the decrypted key used to sign the provenance of the artifacts, it is created on first use
+func LoadProvenanceKey(db gorp.SqlExecutor, projectID int64) (sdk.ProjectKey, error) {
+	var res dbProjectKey
+	query := "SELECT * FROM project_key WHERE project_id = $1 and builtin = true and name = $2"
+	err := db.SelectOne(&res, query, projectID, ProvenanceKey)
+	if err == sql.ErrNoRows {
+		k, err := keys.GenerateSSHKey(ProvenanceKey)
+		if err != nil {
+			return sdk.ProjectKey{}, err
+		}
+		pk := sdk.ProjectKey{Key: k, ProjectID: projectID, Builtin: true}
+		if err := InsertKey(db, &pk); err != nil {
+			if !sdk.ErrorIs(err, sdk.ErrKeyAlreadyExist) {
+				return sdk.ProjectKey{}, err
+			}
+			// The key was created by a concurrent request
+			return LoadProvenanceKey(db, projectID)
+		}
+		pk.Private = k.Private
+		return pk, nil
+	}
+	if err != nil {
+		return sdk.ProjectKey{}, sdk.WrapError(err, "cannot load provenance key")
+	}
+
+	k := sdk.ProjectKey(res)
+	decrypted, err := secret.Decrypt([]byte(k.Private))
+	if err != nil {
+		return k, sdk.WrapError(err, "unable to decrypt key")
+	}
+	k.Private = string(decrypted)
+	return k, nil
+}
@@ -0,0 +1,210 @@
+package project
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const storageUsageQuery = `
+	SELECT project.id AS project_id, project.projectkey AS project_key,
+		COALESCE((
+			SELECT SUM(workflow_node_run_artifacts.size) FROM workflow_node_run_artifacts
+			JOIN workflow_run ON workflow_run.id = workflow_node_run_artifacts.workflow_run_id
+			WHERE workflow_run.project_id = project.id AND COALESCE(workflow_node_run_artifacts.blob_container, '') = ''
+		), 0) + COALESCE((
+			SELECT SUM(workflow_node_run_artifact_blob.size) FROM workflow_node_run_artifact_blob
+			WHERE workflow_node_run_artifact_blob.project_id = project.id
+		), 0) AS artifacts,
+		COALESCE((
+			SELECT SUM(workflow_node_run_static_files.size) FROM workflow_node_run_static_files
+			JOIN workflow_node_run ON workflow_node_run.id = workflow_node_run_static_files.workflow_node_run_id
+			JOIN workflow_run ON workflow_run.id = workflow_node_run.workflow_run_id
+			WHERE workflow_run.project_id = project.id
+		), 0) AS static_files,
+		COALESCE((
+			SELECT SUM(project_storage_cache.size) FROM project_storage_cache
+			WHERE project_storage_cache.project_id = project.id
+		), 0) + COALESCE((
+			SELECT SUM(project_storage_cache_layer.size) FROM project_storage_cache_layer
+			WHERE project_storage_cache_layer.project_id = project.id
+		), 0) AS cache,
+		COALESCE(project_storage_quota.artifacts, 0) AS quota_artifacts,
+		COALESCE(project_storage_quota.static_files, 0) AS quota_static_files,
+		COALESCE(project_storage_quota.cache, 0) AS quota_cache
+	FROM project
+	LEFT JOIN project_storage_quota ON project_storage_quota.project_id = project.id`
+
+type dbStorageUsage struct {
+	ProjectID        int64  `db:"project_id"`
+	ProjectKey       string `db:"project_key"`
+	Artifacts        int64  `db:"artifacts"`
+	StaticFiles      int64  `db:"static_files"`
+	Cache            int64  `db:"cache"`
+	QuotaArtifacts   int64  `db:"quota_artifacts"`
+	QuotaStaticFiles int64  `db:"quota_static_files"`
+	QuotaCache       int64  `db:"quota_cache"`
+}
+
+func (u dbStorageUsage) toStorageUsage(defaults sdk.ProjectStorageQuota) sdk.ProjectStorageUsage {
+	quota := sdk.ProjectStorageQuota{
+		ProjectID:   u.ProjectID,
+		Artifacts:   u.QuotaArtifacts,
+		StaticFiles: u.QuotaStaticFiles,
+		Cache:       u.QuotaCache,
+	}
+	return sdk.ProjectStorageUsage{
+		ProjectID:   u.ProjectID,
+		ProjectKey:  u.ProjectKey,
+		Artifacts:   u.Artifacts,
+		StaticFiles: u.StaticFiles,
+		Cache:       u.Cache,
+		Total:       u.Artifacts + u.StaticFiles + u.Cache,
+		Quota:       quota.WithDefaults(defaults),
+	}
+}
+
+// LoadStorageUsage returns the storage used by a project with its quota, default values are used for unset quotas.
+func LoadStorageUsage(db gorp.SqlExecutor, projectID int64, defaults sdk.ProjectStorageQuota) (sdk.ProjectStorageUsage, error) {
+	var u dbStorageUsage
+	if err := db.SelectOne(&u, storageUsageQuery+" WHERE project.id = $1", projectID); err != nil {
+		if err == sql.ErrNoRows {
+			return sdk.ProjectStorageUsage{}, sdk.WithStack(sdk.ErrNoProject)
+		}
+		return sdk.ProjectStorageUsage{}, sdk.WrapError(err, "unable to load storage usage for project %d", projectID)
+	}
+	return u.toStorageUsage(defaults), nil
+}
+
+// LoadAllStorageUsages returns the storage used by all the projects sorted from the biggest to the smallest.
+func LoadAllStorageUsages(db gorp.SqlExecutor, defaults sdk.ProjectStorageQuota) ([]sdk.ProjectStorageUsage, error) {
+	var us []dbStorageUsage
+	if _, err := db.Select(&us, "SELECT * FROM ("+storageUsageQuery+") AS storage_usage ORDER BY artifacts + static_files + cache DESC, project_key"); err != nil {
+		return nil, sdk.WrapError(err, "unable to load storage usages")
+	}
+	usages := make([]sdk.ProjectStorageUsage, len(us))
+	for i := range us {
+		usages[i] = us[i].toStorageUsage(defaults)
+	}
+	return usages, nil
+}
+
+// UpsertStorageQuota sets the storage quota of a project.
+func UpsertStorageQuota(db gorp.SqlExecutor, quota sdk.ProjectStorageQuota) error {
+	query := `
+		INSERT INTO project_storage_quota (project_id, artifacts, static_files, cache)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (project_id) DO UPDATE SET artifacts = $2, static_files = $3, cache = $4`
+	if _, err := db.Exec(query, quota.ProjectID, quota.Artifacts, quota.StaticFiles, quota.Cache); err != nil {
+		return sdk.WrapError(err, "unable to set storage quota for project %d", quota.ProjectID)
+	}
+	return nil
+}
+
+// UpsertStorageCache records the size of a worker cache stored for a project.
+func UpsertStorageCache(db gorp.SqlExecutor, projectID, projectIntegrationID int64, tag string, size int64) error {
+	query := `
+		INSERT INTO project_storage_cache (project_id, project_integration_id, tag, size, last_modified)
+		VALUES ($1, $2, $3, $4, current_timestamp)
+		ON CONFLICT (project_id, project_integration_id, tag) DO UPDATE SET size = $4, last_modified = current_timestamp`
+	if _, err := db.Exec(query, projectID, projectIntegrationID, tag, size); err != nil {
+		return sdk.WrapError(err, "unable to record cache %s for project %d", tag, projectID)
+	}
+	return nil
+}
+
+// LoadStorageCacheSize returns the size of a worker cache stored for a project, zero if the cache doesn't exist.
+func LoadStorageCacheSize(db gorp.SqlExecutor, projectID, projectIntegrationID int64, tag string) (int64, error) {
+	size, err := db.SelectInt(`
+		SELECT COALESCE(SUM(size), 0) FROM project_storage_cache
+		WHERE project_id = $1 AND project_integration_id = $2 AND tag = $3`, projectID, projectIntegrationID, tag)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to load cache %s size for project %d", tag, projectID)
+	}
+	return size, nil
+}
+
+// LoadStorageCacheTags returns the tags of the worker caches stored for a project, from the latest to the oldest.
+func LoadStorageCacheTags(db gorp.SqlExecutor, projectID, projectIntegrationID int64) ([]string, error) {
+	var tags []string
+	if _, err := db.Select(&tags, `
+		SELECT tag FROM project_storage_cache
+		WHERE project_id = $1 AND project_integration_id = $2
+		ORDER BY last_modified DESC`, projectID, projectIntegrationID); err != nil {
+		return nil, sdk.WrapError(err, "unable to load cache tags for project %d", projectID)
+	}
+	return tags, nil
+}
+
+// InsertStorageCacheLayer records a worker cache layer stored for a project, or marks it as used if it already exists.
+func InsertStorageCacheLayer(db gorp.SqlExecutor, projectID, projectIntegrationID int64, digest string, size int64) error {
+	query := `
+		INSERT INTO project_storage_cache_layer (project_id, project_integration_id, digest, size, created, last_used)
+		VALUES ($1, $2, $3, $4, current_timestamp, current_timestamp)
+		ON CONFLICT (project_id, project_integration_id, digest) DO UPDATE SET last_used = current_timestamp`
+	if _, err := db.Exec(query, projectID, projectIntegrationID, digest, size); err != nil {
+		return sdk.WrapError(err, "unable to record cache layer %s for project %d", digest, projectID)
+	}
+	return nil
+}
+
+// LoadStorageCacheLayerDigests returns the digests of the given layers that are stored for a project.
+func LoadStorageCacheLayerDigests(db gorp.SqlExecutor, projectID, projectIntegrationID int64, digests []string) ([]string, error) {
+	var res []string
+	if _, err := db.Select(&res, `
+		SELECT digest FROM project_storage_cache_layer
+		WHERE project_id = $1 AND project_integration_id = $2 AND digest = ANY($3)`, projectID, projectIntegrationID, pq.StringArray(digests)); err != nil {
+		return nil, sdk.WrapError(err, "unable to load cache layers for project %d", projectID)
+	}
+	return res, nil
+}
+
+// TouchStorageCacheLayers marks the given layers as used, layers that are not used anymore are purged.
+func TouchStorageCacheLayers(db gorp.SqlExecutor, projectID, projectIntegrationID int64, digests []string) error {
+	if _, err := db.Exec(`
+		UPDATE project_storage_cache_layer SET last_used = current_timestamp
+		WHERE project_id = $1 AND project_integration_id = $2 AND digest = ANY($3)`, projectID, projectIntegrationID, pq.StringArray(digests)); err != nil {
+		return sdk.WrapError(err, "unable to update cache layers for project %d", projectID)
+	}
+	return nil
+}
+
+// StorageCacheLayer is a worker cache layer with the storage integration where it is stored.
+type StorageCacheLayer struct {
+	ID              int64  `db:"id"`
+	ProjectKey      string `db:"project_key"`
+	IntegrationName string `db:"integration_name"`
+	Digest          string `db:"digest"`
+}
+
+// LoadUnusedStorageCacheLayers returns the layers that have not been used since the given date.
+func LoadUnusedStorageCacheLayers(db gorp.SqlExecutor, before time.Time, limit int) ([]StorageCacheLayer, error) {
+	var layers []StorageCacheLayer
+	if _, err := db.Select(&layers, `
+		SELECT project_storage_cache_layer.id, project.projectkey AS project_key,
+			COALESCE(project_integration.name, $2) AS integration_name, project_storage_cache_layer.digest
+		FROM project_storage_cache_layer
+		JOIN project ON project.id = project_storage_cache_layer.project_id
+		LEFT JOIN project_integration ON project_integration.id = project_storage_cache_layer.project_integration_id
+		WHERE project_storage_cache_layer.last_used < $1
+		ORDER BY project_storage_cache_layer.last_used
+		LIMIT $3`, before, sdk.DefaultStorageIntegrationName, limit); err != nil {
+		return nil, sdk.WrapError(err, "unable to load unused cache layers")
+	}
+	return layers, nil
+}
+
+// DeleteStorageCacheLayer removes a worker cache layer if it has not been used since the given date,
+// it returns false if the layer was used in the meantime.
+func DeleteStorageCacheLayer(db gorp.SqlExecutor, id int64, before time.Time) (bool, error) {
+	res, err := db.Exec("DELETE FROM project_storage_cache_layer WHERE id = $1 AND last_used < $2", id, before)
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to delete cache layer %d", id)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// defaultStorageQuota returns the storage quota configured for all the projects, in bytes.
+func (api *API) defaultStorageQuota() sdk.ProjectStorageQuota {
+	const mb = 1024 * 1024
+	return sdk.ProjectStorageQuota{
+		Artifacts:   api.Config.Artifact.Quota.Artifacts * mb,
+		StaticFiles: api.Config.Artifact.Quota.StaticFiles * mb,
+		Cache:       api.Config.Artifact.Quota.Cache * mb,
+	}
+}
+
+// storageUsageCacheDuration is the duration during which the storage usage of a project is not computed again,
+// the content stored in the meantime is added to the cached usage.
+const storageUsageCacheDuration = time.Minute
+
+type storageUsageCache struct {
+	Usage   sdk.ProjectStorageUsage `json:"usage"`
+	Expires time.Time               `json:"expires"`
+}
+
+func storageUsageCacheKey(projectID int64) string {
+	return cache.Key("api", "storage", "usage", strconv.FormatInt(projectID, 10))
+}
+
+// loadStorageUsage returns the storage used by a project, the usage is cached to not be computed on each upload.
+func (api *API) loadStorageUsage(db gorp.SqlExecutor, projectID int64) (sdk.ProjectStorageUsage, error) {
+	var c storageUsageCache
+	find, err := api.Cache.Get(storageUsageCacheKey(projectID), &c)
+	if err != nil {
+		log.Error(context.TODO(), "loadStorageUsage> cannot get storage usage of project %d from cache: %v", projectID, err)
+	}
+	if find && time.Now().Before(c.Expires) {
+		return c.Usage, nil
+	}
+
+	usage, err := project.LoadStorageUsage(db, projectID, api.defaultStorageQuota())
+	if err != nil {
+		return usage, err
+	}
+	api.cacheStorageUsage(storageUsageCache{Usage: usage, Expires: time.Now().Add(storageUsageCacheDuration)})
+	return usage, nil
+}
+
+// addStorageUsage adds size bytes of given kind to the cached usage of a project.
+func (api *API) addStorageUsage(projectID int64, kind string, size int64) {
+	var c storageUsageCache
+	find, err := api.Cache.Get(storageUsageCacheKey(projectID), &c)
+	if err != nil || !find || !time.Now().Before(c.Expires) {
+		return
+	}
+	c.Usage.Add(kind, size)
+	api.cacheStorageUsage(c)
+}
+
+func (api *API) cacheStorageUsage(c storageUsageCache) {
+	ttl := int(time.Until(c.Expires).Seconds()) + 1
+	if err := api.Cache.SetWithTTL(storageUsageCacheKey(c.Usage.ProjectID), c, ttl); err != nil {
+		log.Error(context.TODO(), "cacheStorageUsage> cannot set storage usage of project %d in cache: %v", c.Usage.ProjectID, err)
+	}
+}
+
+// checkStorageQuota returns an error if storing size bytes of given kind exceeds the project quota.
+// Otherwise the size is added to the usage of the project, so that it is counted by the next uploads.
+func (api *API) checkStorageQuota(db gorp.SqlExecutor, projectID int64, kind string, size int64) error {
+	usage, err := api.loadStorageUsage(db, projectID)
+	if err != nil {
+		return err
+	}
+	if err := usage.CheckQuota(kind, size); err != nil {
+		return err
+	}
+	api.addStorageUsage(projectID, kind, size)
+	return nil
+}
+
+// checkCacheQuota returns an error if replacing the worker cache with given tag by size bytes exceeds the project quota.
+func (api *API) checkCacheQuota(db gorp.SqlExecutor, projectID, projectIntegrationID int64, tag string, size int64) error {
+	previousSize, err := project.LoadStorageCacheSize(db, projectID, projectIntegrationID, tag)
+	if err != nil {
+		return err
+	}
+	return api.checkStorageQuota(db, projectID, sdk.StorageKindCache, size-previousSize)
+}
+
+// storageQuotaLeft returns the number of bytes of given kind that the project can still store, -1 if unlimited.
+func (api *API) storageQuotaLeft(db gorp.SqlExecutor, projectID int64, kind string) (int64, error) {
+	usage, err := api.loadStorageUsage(db, projectID)
+	if err != nil {
+		return 0, err
+	}
+	limit := usage.Quota.Limit(kind)
+	if limit <= 0 {
+		return -1, nil
+	}
+	if left := limit - usage.Used(kind); left > 0 {
+		return left, nil
+	}
+	return 0, nil
+}
+
+// quotaReadCloser counts the bytes read from the underlying reader and fails when more than max bytes are read,
+// a negative max means unlimited. It is used for uploads of unknown size.
+type quotaReadCloser struct {
+	io.ReadCloser
+	n   int64
+	max int64
+}
+
+func (r *quotaReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	if r.exceeded() {
+		return n, sdk.NewErrorFrom(sdk.ErrStorageQuotaExceeded, "upload exceeds the storage quota of the project")
+	}
+	return n, err
+}
+
+func (r *quotaReadCloser) exceeded() bool {
+	return r.max >= 0 && r.n > r.max
+}
+
+func (api *API) getProjectStorageUsageHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %s", key)
+		}
+
+		usage, err := project.LoadStorageUsage(api.mustDB(), proj.ID, api.defaultStorageQuota())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, usage, http.StatusOK)
+	}
+}
+
+func (api *API) getAdminStorageUsagesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		usages, err := project.LoadAllStorageUsages(api.mustDB(), api.defaultStorageQuota())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, usages, http.StatusOK)
+	}
+}
+
+func (api *API) putAdminProjectStorageQuotaHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+
+		var quota sdk.ProjectStorageQuota
+		if err := service.UnmarshalBody(r, &quota); err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %s", key)
+		}
+		quota.ProjectID = proj.ID
+
+		if err := project.UpsertStorageQuota(api.mustDB(), quota); err != nil {
+			return err
+		}
+		if err := api.Cache.Delete(storageUsageCacheKey(proj.ID)); err != nil {
+			log.Error(ctx, "putAdminProjectStorageQuotaHandler> cannot delete storage usage of project %s from cache: %v", key, err)
+		}
+
+		usage, err := project.LoadStorageUsage(api.mustDB(), proj.ID, api.defaultStorageQuota())
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, usage, http.StatusOK)
+	}
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getProjectVulnerabilitySuppressionsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		rules, err := application.LoadVulnerabilitySuppressionsByProjectID(ctx, api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, rules, http.StatusOK)
+	}
+}
+
+func (api *API) postProjectVulnerabilitySuppressionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		var rule sdk.VulnerabilitySuppression
+		if err := service.UnmarshalBody(r, &rule); err != nil {
+			return err
+		}
+		rule.CVE = strings.ToUpper(strings.TrimSpace(rule.CVE))
+		if err := rule.IsValid(); err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+		rule.ProjectID = p.ID
+		rule.Author = getAPIConsumer(ctx).AuthentifiedUser.Username
+
+		if err := application.InsertVulnerabilitySuppression(api.mustDB(), &rule); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, rule, http.StatusOK)
+	}
+}
+
+func (api *API) deleteProjectVulnerabilitySuppressionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+		cve := vars["cve"]
+
+		p, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		rule, err := application.LoadVulnerabilitySuppression(ctx, api.mustDB(), p.ID, cve)
+		if err != nil {
+			return err
+		}
+
+		if err := application.DeleteVulnerabilitySuppression(api.mustDB(), *rule); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
@@ -40,6 +40,16 @@ func Initialize(ctx context.Context, store cache.Store, DBFunc func() *gorp.DbMa
 			if err := workflows(ctx, DBFunc(), store, workflowRunsMarkToDelete); err != nil {
 				log.Warning(ctx, "purge> Error on workflows : %v", err)
 			}
+
+			log.Debug("purge> Applying workflow retention policies...")
+			if err := retentionPolicies(ctx, DBFunc(), store, workflowRunsMarkToDelete); err != nil {
+				log.Warning(ctx, "purge> Error on retentionPolicies : %v", err)
+			}
+
+			log.Debug("purge> Deleting unused cache layers...")
+			if err := cacheLayers(ctx, DBFunc(), sharedStorage); err != nil {
+				log.Warning(ctx, "purge> Error on cacheLayers : %v", err)
+			}
 		}
 	}
 }
@@ -145,8 +155,45 @@ func workflows(ctx context.Context, db *gorp.DbMap, store cache.Store, workflowR
 	return nil
 }
 
+// retentionPolicies marks to delete the workflow runs that are not kept by the retention policy of their workflow
+func retentionPolicies(ctx context.Context, db *gorp.DbMap, store cache.Store, workflowRunsMarkToDelete *stats.Int64Measure) error {
+	query := "SELECT id, project_id FROM workflow WHERE retention_policy IS NOT NULL AND to_delete = false ORDER BY id ASC"
+	res := []struct {
+		ID        int64 `db:"id"`
+		ProjectID int64 `db:"project_id"`
+	}{}
+	if _, err := db.Select(&res, query); err != nil {
+		return sdk.WrapError(err, "unable to load workflows with a retention policy")
+	}
+
+	var projects = map[int64]*sdk.Project{}
+	for _, r := range res {
+		proj, has := projects[r.ProjectID]
+		if !has {
+			p, err := project.LoadByID(db, store, r.ProjectID)
+			if err != nil {
+				log.Error(ctx, "purge.retentionPolicies> unable to load project %d: %v", r.ProjectID, err)
+				continue
+			}
+			projects[r.ProjectID] = p
+			proj = p
+		}
+
+		wf, err := workflow.LoadByID(ctx, db, store, proj, r.ID, workflow.LoadOptions{})
+		if err != nil {
+			log.Error(ctx, "purge.retentionPolicies> unable to load workflow %d: %v", r.ID, err)
+			continue
+		}
+
+		if err := workflow.PurgeWorkflowRunWithRetention(ctx, db, store, *proj, *wf, workflowRunsMarkToDelete); err != nil {
+			log.Error(ctx, "purge.retentionPolicies> unable to purge workflow %d: %v", r.ID, err)
+		}
+	}
+	return nil
+}
+
 // deleteWorkflowRunsHistory is useful to delete all the workflow run marked with to delete flag in db
-func deleteWorkflowRunsHistory(ctx context.Context, db gorp.SqlExecutor, store cache.Store, sharedStorage objectstore.Driver, workflowRunsDeleted *stats.Int64Measure) error {
+func deleteWorkflowRunsHistory(ctx context.Context, db *gorp.DbMap, store cache.Store, sharedStorage objectstore.Driver, workflowRunsDeleted *stats.Int64Measure) error {
 	var workflowRunIDs []int64
 	if _, err := db.Select(&workflowRunIDs, "SELECT id FROM workflow_run WHERE to_delete = true ORDER BY id ASC LIMIT 2000"); err != nil {
 		return err
@@ -172,8 +219,43 @@ func deleteWorkflowRunsHistory(ctx context.Context, db gorp.SqlExecutor, store c
 	return nil
 }
 
-// DeleteArtifacts removes artifacts from storage
-func DeleteArtifacts(ctx context.Context, db gorp.SqlExecutor, store cache.Store, sharedStorage objectstore.Driver, workflowRunID int64) error {
+// cacheLayerRetention is the time after which a worker cache layer that is not used by any pushed or pulled cache is deleted
+const cacheLayerRetention = 30 * 24 * time.Hour
+
+// cacheLayers deletes the worker cache layers that have not been used for a while
+func cacheLayers(ctx context.Context, db gorp.SqlExecutor, sharedStorage objectstore.Driver) error {
+	before := time.Now().Add(-cacheLayerRetention)
+	layers, err := project.LoadUnusedStorageCacheLayers(db, before, 1000)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range layers {
+		deleted, err := project.DeleteStorageCacheLayer(db, l.ID, before)
+		if err != nil {
+			log.Error(ctx, "cacheLayers> unable to delete cache layer %s for project %s: %v", l.Digest, l.ProjectKey, err)
+			continue
+		}
+		if !deleted {
+			continue
+		}
+
+		storageDriver, err := objectstore.GetDriver(ctx, db, sharedStorage, l.ProjectKey, l.IntegrationName)
+		if err != nil {
+			log.Error(ctx, "cacheLayers> error while getting driver prj:%v integrationName:%v err:%v", l.ProjectKey, l.IntegrationName, err)
+			continue
+		}
+		layer := sdk.CacheLayer{Project: l.ProjectKey, Digest: l.Digest}
+		if err := storageDriver.Delete(ctx, &layer); err != nil {
+			log.Error(ctx, "cacheLayers> error while deleting cache layer prj:%v digest:%v err:%v", l.ProjectKey, l.Digest, err)
+		}
+		time.Sleep(10 * time.Millisecond) // avoid DDOS the storage
+	}
+	return nil
+}
+
+// DeleteArtifacts removes artifacts and SBOMs from storage
+func DeleteArtifacts(ctx context.Context, db *gorp.DbMap, store cache.Store, sharedStorage objectstore.Driver, workflowRunID int64) error {
 	wr, err := workflow.LoadRunByID(db, workflowRunID, workflow.LoadRunOptions{WithArtifacts: true, DisableDetailledNodeRun: false, WithDeleted: true})
 	if err != nil {
 		return sdk.WrapError(err, "error on load LoadRunByID:%d", workflowRunID)
@@ -206,6 +288,24 @@ func DeleteArtifacts(ctx context.Context, db gorp.SqlExecutor, store cache.Store
 					integrationName = sdk.DefaultStorageIntegrationName
 				}
 
+				storageDriver, err := objectstore.GetDriver(ctx, db, sharedStorage, proj.Key, integrationName)
+				if err != nil {
+					log.Error(ctx, "error while getting driver prj:%v integrationName:%v err:%v", proj.Key, integrationName, err)
+					continue
+				}
+
+				// Blobs are shared between artifacts with the same content, delete it only if it's not referenced anymore.
+				// The artifact is deleted with the decrement so that a retry of the purge doesn't dereference it again
+				if art.BlobContainer != "" {
+					if err := workflow.DecrementArtifactBlob(db, art.ID, proj.ID, storageDriver.GetProjectIntegration().ID, art.SHA512sum, func() error {
+						log.Debug("DeleteArtifacts> deleting blob %s", art.SHA512sum)
+						return storageDriver.Delete(ctx, &art)
+					}); err != nil {
+						log.Error(ctx, "error while dereferencing blob prj:%v wnr:%v sha512sum:%v err:%v", proj.Key, wnr.ID, art.SHA512sum, err)
+					}
+					continue
+				}
+
 				var found bool
 				for _, dc := range driversContainers {
 					if dc.containerPath == art.GetPath() && proj.Key == dc.projectKey && integrationName == dc.integrationName {
@@ -223,12 +323,6 @@ func DeleteArtifacts(ctx context.Context, db gorp.SqlExecutor, store cache.Store
 					})
 				}
 
-				storageDriver, err := objectstore.GetDriver(ctx, db, sharedStorage, proj.Key, integrationName)
-				if err != nil {
-					log.Error(ctx, "error while getting driver prj:%v integrationName:%v err:%v", proj.Key, integrationName, err)
-					continue
-				}
-
 				log.Debug("DeleteArtifacts> deleting %+v", art)
 				if err := storageDriver.Delete(ctx, &art); err != nil {
 					log.Error(ctx, "error while deleting container prj:%v wnr:%v name:%v err:%v", proj.Key, wnr.ID, art.GetPath(), err)
@@ -238,6 +332,17 @@ func DeleteArtifacts(ctx context.Context, db gorp.SqlExecutor, store cache.Store
 		}
 	}
 
+	// SBOMs are stored in the shared storage
+	sboms, err := workflow.LoadSBOMsByRunID(ctx, db, workflowRunID)
+	if err != nil {
+		return err
+	}
+	for i := range sboms {
+		if err := sharedStorage.Delete(ctx, &sboms[i]); err != nil {
+			log.Error(ctx, "error while deleting sbom prj:%v wnr:%v name:%v err:%v", proj.Key, sboms[i].WorkflowNodeRunID, sboms[i].Name, err)
+		}
+	}
+
 	for _, dc := range driversContainers {
 		storageDriver, err := objectstore.GetDriver(ctx, db, sharedStorage, dc.projectKey, dc.integrationName)
 		if err != nil {
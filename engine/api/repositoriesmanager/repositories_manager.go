@@ -364,6 +364,15 @@ func (c *vcsClient) CommitsBetweenRefs(ctx context.Context, fullname, base, head
 	return commits, nil
 }
 
+func (c *vcsClient) DiffBetweenRefs(ctx context.Context, fullname, base, head string) ([]sdk.VCSFileDiff, error) {
+	var diff []sdk.VCSFileDiff
+	path := fmt.Sprintf("/vcs/%s/repos/%s/diff?base=%s&head=%s", c.name, fullname, url.QueryEscape(base), url.QueryEscape(head))
+	if _, err := c.doJSONRequest(ctx, "GET", path, nil, &diff); err != nil {
+		return nil, sdk.WrapError(err, "unable to get diff on repository %s from %s", fullname, c.name)
+	}
+	return diff, nil
+}
+
 func (c *vcsClient) Commit(ctx context.Context, fullname, hash string) (sdk.VCSCommit, error) {
 	commit := sdk.VCSCommit{}
 	path := fmt.Sprintf("/vcs/%s/repos/%s/commits/%s", c.name, fullname, hash)
@@ -404,6 +413,23 @@ func (c *vcsClient) PullRequestComment(ctx context.Context, fullname string, id
 	return nil
 }
 
+func (c *vcsClient) PullRequestComments(ctx context.Context, fullname string, id int) ([]sdk.VCSPullRequestComment, error) {
+	comments := []sdk.VCSPullRequestComment{}
+	path := fmt.Sprintf("/vcs/%s/repos/%s/pullrequests/%d/comments", c.name, fullname, id)
+	if _, err := c.doJSONRequest(ctx, "GET", path, nil, &comments); err != nil {
+		return nil, sdk.WrapError(err, "unable to get pullrequest comments on repository %s from %s", fullname, c.name)
+	}
+	return comments, nil
+}
+
+func (c *vcsClient) PullRequestCommentUpdate(ctx context.Context, fullname string, id int, comment sdk.VCSPullRequestComment) error {
+	path := fmt.Sprintf("/vcs/%s/repos/%s/pullrequests/%d/comments/%d", c.name, fullname, id, comment.ID)
+	if _, err := c.doJSONRequest(ctx, "PUT", path, comment, nil); err != nil {
+		return sdk.WrapError(err, "unable to update pullrequest comment %d on repository %s from %s", comment.ID, fullname, c.name)
+	}
+	return nil
+}
+
 func (c *vcsClient) PullRequestCreate(ctx context.Context, fullname string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	path := fmt.Sprintf("/vcs/%s/repos/%s/pullrequests", c.name, fullname)
 	if _, err := c.doJSONRequest(ctx, "POST", path, pr, &pr); err != nil {
@@ -412,6 +438,14 @@ func (c *vcsClient) PullRequestCreate(ctx context.Context, fullname string, pr s
 	return pr, nil
 }
 
+func (c *vcsClient) PullRequestMerge(ctx context.Context, fullname string, id int, headHash string) error {
+	path := fmt.Sprintf("/vcs/%s/repos/%s/pullrequests/%d/merge?sha=%s", c.name, fullname, id, url.QueryEscape(headHash))
+	if _, err := c.doJSONRequest(ctx, "POST", path, nil, nil); err != nil {
+		return sdk.WrapError(err, "unable to merge pullrequest %d on repository %s from %s", id, fullname, c.name)
+	}
+	return nil
+}
+
 func (c *vcsClient) CreateHook(ctx context.Context, fullname string, hook *sdk.VCSHook) error {
 	path := fmt.Sprintf("/vcs/%s/repos/%s/hooks", c.name, fullname)
 	_, err := c.doJSONRequest(ctx, "POST", path, hook, hook)
@@ -566,6 +600,15 @@ func (c *vcsClient) GrantWritePermission(ctx context.Context, repo string) error
 	return nil
 }
 
+func (c *vcsClient) UserHasWritePermission(ctx context.Context, repo, username string) (bool, error) {
+	var canWrite bool
+	path := fmt.Sprintf("/vcs/%s/repos/%s/permissions/%s", c.name, repo, url.PathEscape(username))
+	if _, err := c.doJSONRequest(ctx, "GET", path, nil, &canWrite); err != nil {
+		return false, sdk.WrapError(err, "unable to get permission of %s on repository %s from %s", username, repo, c.name)
+	}
+	return canWrite, nil
+}
+
 func (c *vcsClient) GetAccessToken(_ context.Context) string {
 	return ""
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/ovh/cds/engine/api/mail"
 	"github.com/ovh/cds/engine/api/migrate"
 	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/api/workermodel"
 	"github.com/ovh/cds/engine/service"
@@ -77,6 +78,8 @@ var (
 	tagServiceName tag.Key
 	tagService     tag.Key
 	tagsService    []tag.Key
+	tagProjectKey  tag.Key
+	tagStorageKind tag.Key
 )
 
 // computeGlobalStatus returns global status
@@ -223,9 +226,15 @@ func (api *API) initMetrics(ctx context.Context) error {
 		fmt.Sprintf("cds/cds-api/%s/database_conn°", api.Name()),
 		"number database connections",
 		stats.UnitDimensionless)
+	api.Metrics.ProjectStorageUsage = stats.Int64(
+		"cds/cds-api/project_storage_usage",
+		"storage used by a project",
+		stats.UnitBytes)
 
 	tagRange, _ = tag.NewKey("range")
 	tagStatus, _ = tag.NewKey("status")
+	tagProjectKey, _ = tag.NewKey("project_key")
+	tagStorageKind, _ = tag.NewKey("kind")
 
 	tagServiceType := observability.MustNewKey(observability.TagServiceType)
 	tagServiceName := observability.MustNewKey(observability.TagServiceName)
@@ -250,6 +259,7 @@ func (api *API) initMetrics(ctx context.Context) error {
 		observability.NewViewCount("cds/workflow_runs_mark_to_delete", api.Metrics.WorkflowRunsMarkToDelete, tagsService),
 		observability.NewViewCount("cds/workflow_runs_deleted", api.Metrics.WorkflowRunsDeleted, tagsService),
 		observability.NewViewLast("cds/database_conn", api.Metrics.DatabaseConns, tagsService),
+		observability.NewViewLast("cds/project_storage_usage", api.Metrics.ProjectStorageUsage, []tag.Key{tagProjectKey, tagStorageKind}),
 	)
 
 	api.computeMetrics(ctx)
@@ -260,6 +270,7 @@ func (api *API) initMetrics(ctx context.Context) error {
 func (api *API) computeMetrics(ctx context.Context) {
 	sdk.GoRoutine(ctx, "api.computeMetrics", func(ctx context.Context) {
 		tick := time.NewTicker(9 * time.Second).C
+		tickStorage := time.NewTicker(5 * time.Minute).C
 		for {
 			select {
 			case <-ctx.Done():
@@ -304,6 +315,8 @@ func (api *API) computeMetrics(ctx context.Context) {
 				api.countMetricRange(ctx, "waiting", "70_more_10min", api.Metrics.queue, queryOld, now10min)
 
 				api.processStatusMetrics(ctx)
+			case <-tickStorage:
+				api.processStorageMetrics(ctx)
 			}
 		}
 	})
@@ -326,6 +339,20 @@ func (api *API) countMetricRange(ctx context.Context, status string, timerange s
 	observability.Record(ctx, v, n)
 }
 
+func (api *API) processStorageMetrics(ctx context.Context) {
+	usages, err := project.LoadAllStorageUsages(api.mustDB(), api.defaultStorageQuota())
+	if err != nil {
+		log.Warning(ctx, "metrics>Errors while fetching storage usages: %v", err)
+		return
+	}
+	for _, u := range usages {
+		for _, kind := range sdk.StorageKinds {
+			ctx, _ := tag.New(ctx, tag.Upsert(tagProjectKey, u.ProjectKey), tag.Upsert(tagStorageKind, kind))
+			observability.Record(ctx, api.Metrics.ProjectStorageUsage, u.Used(kind))
+		}
+	}
+}
+
 func (api *API) processStatusMetrics(ctx context.Context) {
 	srvs, err := services.LoadAll(ctx, api.mustDB())
 	if err != nil {
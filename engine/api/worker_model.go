@@ -44,6 +44,12 @@ func (api *API) postWorkerModelHandler() service.Handler {
 			if !data.Restricted && data.PatternName == "" {
 				return sdk.NewErrorFrom(sdk.ErrWorkerModelNoPattern, "missing model pattern name")
 			}
+			if data.ModelDocker.PodSpec != nil {
+				return sdk.NewErrorFrom(sdk.ErrForbidden, "only an administrator can set the pod spec of a worker model")
+			}
+		}
+		if err := workermodel.ValidatePodSpec(data, isAdmin(ctx)); err != nil {
+			return err
 		}
 
 		tx, err := api.mustDB().Begin()
@@ -110,10 +116,12 @@ func (api *API) putWorkerModelHandler() service.Handler {
 			}
 		}
 
-		if !isAdmin(ctx) {
-			if err := workermodel.CopyModelTypeData(old, &data); err != nil {
+		if isAdmin(ctx) {
+			if err := workermodel.ValidatePodSpec(data, true); err != nil {
 				return err
 			}
+		} else if err := workermodel.CopyModelTypeData(old, &data); err != nil {
+			return err
 		}
 
 		if err := data.IsValidType(); err != nil {
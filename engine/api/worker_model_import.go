@@ -88,6 +88,12 @@ func (api *API) postWorkerModelImportHandler() service.Handler {
 				if !data.Restricted && data.PatternName == "" {
 					return sdk.NewErrorFrom(sdk.ErrWorkerModelNoPattern, "missing model pattern name")
 				}
+				if data.ModelDocker.PodSpec != nil {
+					return sdk.NewErrorFrom(sdk.ErrForbidden, "only an administrator can set the pod spec of a worker model")
+				}
+			}
+			if err := workermodel.ValidatePodSpec(data, isAdmin(ctx)); err != nil {
+				return err
 			}
 
 			// validate worker model type fields
@@ -100,10 +106,12 @@ func (api *API) postWorkerModelImportHandler() service.Handler {
 				return err
 			}
 		} else if force {
-			if !isAdmin(ctx) {
-				if err := workermodel.CopyModelTypeData(old, &data); err != nil {
+			if isAdmin(ctx) {
+				if err := workermodel.ValidatePodSpec(data, true); err != nil {
 					return err
 				}
+			} else if err := workermodel.CopyModelTypeData(old, &data); err != nil {
+				return err
 			}
 
 			// validate worker model type fields
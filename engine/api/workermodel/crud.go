@@ -167,5 +167,11 @@ func CopyModelTypeData(old, data *sdk.Model) error {
 		}
 	}
 
+	// the pod spec can only be changed by an admin, the one of the old model is kept
+	data.ModelDocker.PodSpec = nil
+	if data.Type == sdk.Docker && old.Type == sdk.Docker {
+		data.ModelDocker.PodSpec = old.ModelDocker.PodSpec
+	}
+
 	return nil
 }
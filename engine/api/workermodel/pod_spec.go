@@ -0,0 +1,185 @@
+package workermodel
+
+import (
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/ovh/cds/sdk"
+)
+
+var podSpecResourceNames = []string{"cpu", "memory", "ephemeral-storage"}
+
+// ValidatePodSpec checks the pod spec of a docker worker model, kubernetes would refuse to spawn an invalid pod.
+// The host paths, the service accounts and the privileged workers are reserved to the administrators.
+func ValidatePodSpec(m sdk.Model, isAdmin bool) error {
+	spec := m.ModelDocker.PodSpec
+	if spec == nil {
+		return nil
+	}
+	if m.Type != sdk.Docker {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: only docker worker models can have a pod spec")
+	}
+
+	for k, v := range spec.NodeSelector {
+		if errs := validation.IsQualifiedName(k); len(errs) > 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: node selector %q: %s", k, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: node selector value %q: %s", v, strings.Join(errs, ", "))
+		}
+	}
+
+	for _, t := range spec.Tolerations {
+		if err := validatePodToleration(t); err != nil {
+			return err
+		}
+	}
+
+	if spec.Resources != nil {
+		if err := validatePodResources(*spec.Resources); err != nil {
+			return err
+		}
+	}
+
+	if spec.ServiceAccountName != "" {
+		if errs := validation.IsDNS1123Subdomain(spec.ServiceAccountName); len(errs) > 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: service account %q: %s", spec.ServiceAccountName, strings.Join(errs, ", "))
+		}
+	}
+
+	names := make(map[string]struct{}, len(spec.Volumes))
+	mountPaths := make(map[string]struct{}, len(spec.Volumes))
+	for _, v := range spec.Volumes {
+		if err := validatePodVolume(v); err != nil {
+			return err
+		}
+		if _, ok := names[v.Name]; ok {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: volume %s is defined twice", v.Name)
+		}
+		names[v.Name] = struct{}{}
+		if _, ok := mountPaths[path.Clean(v.MountPath)]; ok {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: mount path %s is used twice", v.MountPath)
+		}
+		mountPaths[path.Clean(v.MountPath)] = struct{}{}
+	}
+
+	if !isAdmin {
+		return validatePodSpecPermissions(*spec)
+	}
+	return nil
+}
+
+func validatePodSpecPermissions(spec sdk.ModelPodSpec) error {
+	if s := spec.SecurityContext; s != nil {
+		if (s.Privileged != nil && *s.Privileged) || (s.AllowPrivilegeEscalation != nil && *s.AllowPrivilegeEscalation) {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "invalid pod spec: only an administrator can run privileged workers")
+		}
+	}
+	if spec.ServiceAccountName != "" {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "invalid pod spec: only an administrator can set the service account")
+	}
+	for _, v := range spec.Volumes {
+		if v.HostPath != "" {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "invalid pod spec: only an administrator can mount the host path %s", v.HostPath)
+		}
+	}
+	return nil
+}
+
+func validatePodToleration(t sdk.ModelPodToleration) error {
+	switch t.Operator {
+	case "", "Equal":
+		if t.Key == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: a toleration without key must have the Exists operator")
+		}
+	case "Exists":
+		if t.Value != "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: toleration %s with the Exists operator can't have a value", t.Key)
+		}
+	default:
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: toleration %s has an unknown operator %s", t.Key, t.Operator)
+	}
+	if t.Key != "" {
+		if errs := validation.IsQualifiedName(t.Key); len(errs) > 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: toleration %q: %s", t.Key, strings.Join(errs, ", "))
+		}
+	}
+
+	switch t.Effect {
+	case "", "NoSchedule", "PreferNoSchedule", "NoExecute":
+	default:
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: toleration %s has an unknown effect %s", t.Key, t.Effect)
+	}
+	if t.TolerationSeconds != nil && t.Effect != "NoExecute" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: toleration %s can have a duration only with the NoExecute effect", t.Key)
+	}
+	return nil
+}
+
+func validatePodResources(r sdk.ModelPodResources) error {
+	requests, err := parsePodResourceList(r.Requests)
+	if err != nil {
+		return err
+	}
+	limits, err := parsePodResourceList(r.Limits)
+	if err != nil {
+		return err
+	}
+	for name, request := range requests {
+		if limit, ok := limits[name]; ok && request.Cmp(limit) > 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: %s request %s is greater than its limit %s", name, request.String(), limit.String())
+		}
+	}
+	return nil
+}
+
+func parsePodResourceList(l map[string]string) (map[string]resource.Quantity, error) {
+	res := make(map[string]resource.Quantity, len(l))
+	for name, value := range l {
+		if !sdk.IsInArray(name, podSpecResourceNames) {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: unknown resource %s, expected one of %s", name, strings.Join(podSpecResourceNames, ", "))
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: %s quantity %q: %v", name, value, err)
+		}
+		res[name] = q
+	}
+	return res, nil
+}
+
+func validatePodVolume(v sdk.ModelPodVolume) error {
+	if errs := validation.IsDNS1123Label(v.Name); len(errs) > 0 {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: volume name %q: %s", v.Name, strings.Join(errs, ", "))
+	}
+	if !path.IsAbs(v.MountPath) {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: mount path of volume %s must be absolute", v.Name)
+	}
+
+	var sources int
+	if v.EmptyDir {
+		sources++
+	}
+	for _, name := range []string{v.ConfigMap, v.Secret, v.PersistentVolumeClaim} {
+		if name == "" {
+			continue
+		}
+		sources++
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: source %q of volume %s: %s", name, v.Name, strings.Join(errs, ", "))
+		}
+	}
+	if v.HostPath != "" {
+		sources++
+		if !path.IsAbs(v.HostPath) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: host path of volume %s must be absolute", v.Name)
+		}
+	}
+	if sources != 1 {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid pod spec: volume %s must have exactly one source", v.Name)
+	}
+	return nil
+}
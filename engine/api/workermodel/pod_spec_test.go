@@ -0,0 +1,110 @@
+package workermodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestValidatePodSpec(t *testing.T) {
+	seconds := int64(30)
+	valid := sdk.ModelPodSpec{
+		NodeSelector: map[string]string{"kubernetes.io/arch": "amd64"},
+		Tolerations: []sdk.ModelPodToleration{
+			{Key: "dedicated", Value: "cds", Effect: "NoSchedule"},
+			{Operator: "Exists"},
+			{Key: "node.kubernetes.io/not-ready", Operator: "Exists", Effect: "NoExecute", TolerationSeconds: &seconds},
+		},
+		Resources: &sdk.ModelPodResources{
+			Requests: map[string]string{"cpu": "250m", "memory": "512Mi"},
+			Limits:   map[string]string{"memory": "1Gi"},
+		},
+		ServiceAccountName: "cds-worker",
+		Volumes: []sdk.ModelPodVolume{
+			{Name: "cache", MountPath: "/cache", EmptyDir: true},
+			{Name: "docker", MountPath: "/var/run/docker.sock", HostPath: "/var/run/docker.sock"},
+		},
+	}
+	require.NoError(t, ValidatePodSpec(sdk.Model{Type: sdk.Docker}, false))
+	require.NoError(t, ValidatePodSpec(sdk.Model{Type: sdk.Docker, ModelDocker: sdk.ModelDocker{PodSpec: &valid}}, true))
+	require.Error(t, ValidatePodSpec(sdk.Model{Type: sdk.Openstack, ModelDocker: sdk.ModelDocker{PodSpec: &valid}}, true))
+
+	tests := []struct {
+		name  string
+		patch func(s *sdk.ModelPodSpec)
+	}{
+		{"node selector", func(s *sdk.ModelPodSpec) { s.NodeSelector = map[string]string{"invalid key!": "a"} }},
+		{"toleration operator", func(s *sdk.ModelPodSpec) { s.Tolerations = []sdk.ModelPodToleration{{Key: "a", Operator: "In"}} }},
+		{"toleration without key", func(s *sdk.ModelPodSpec) { s.Tolerations = []sdk.ModelPodToleration{{Value: "a"}} }},
+		{"toleration effect", func(s *sdk.ModelPodSpec) { s.Tolerations = []sdk.ModelPodToleration{{Key: "a", Effect: "Never"}} }},
+		{"toleration seconds", func(s *sdk.ModelPodSpec) {
+			s.Tolerations = []sdk.ModelPodToleration{{Key: "a", Effect: "NoSchedule", TolerationSeconds: &seconds}}
+		}},
+		{"resource name", func(s *sdk.ModelPodSpec) { s.Resources = &sdk.ModelPodResources{Limits: map[string]string{"gpu": "1"}} }},
+		{"resource quantity", func(s *sdk.ModelPodSpec) {
+			s.Resources = &sdk.ModelPodResources{Limits: map[string]string{"cpu": "a lot"}}
+		}},
+		{"request greater than limit", func(s *sdk.ModelPodSpec) {
+			s.Resources = &sdk.ModelPodResources{Requests: map[string]string{"memory": "2Gi"}, Limits: map[string]string{"memory": "1Gi"}}
+		}},
+		{"service account", func(s *sdk.ModelPodSpec) { s.ServiceAccountName = "Worker_SA" }},
+		{"volume without source", func(s *sdk.ModelPodSpec) { s.Volumes = []sdk.ModelPodVolume{{Name: "a", MountPath: "/a"}} }},
+		{"volume with two sources", func(s *sdk.ModelPodSpec) {
+			s.Volumes = []sdk.ModelPodVolume{{Name: "a", MountPath: "/a", EmptyDir: true, Secret: "s"}}
+		}},
+		{"relative mount path", func(s *sdk.ModelPodSpec) {
+			s.Volumes = []sdk.ModelPodVolume{{Name: "a", MountPath: "a", EmptyDir: true}}
+		}},
+		{"duplicated volume", func(s *sdk.ModelPodSpec) {
+			s.Volumes = []sdk.ModelPodVolume{{Name: "a", MountPath: "/a", EmptyDir: true}, {Name: "a", MountPath: "/b", EmptyDir: true}}
+		}},
+		{"duplicated mount path", func(s *sdk.ModelPodSpec) {
+			s.Volumes = []sdk.ModelPodVolume{{Name: "a", MountPath: "/a", EmptyDir: true}, {Name: "b", MountPath: "/a/", EmptyDir: true}}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := valid
+			tt.patch(&spec)
+			err := ValidatePodSpec(sdk.Model{Type: sdk.Docker, ModelDocker: sdk.ModelDocker{PodSpec: &spec}}, true)
+			require.Error(t, err)
+			require.True(t, sdk.ErrorIs(err, sdk.ErrWrongRequest))
+		})
+	}
+}
+
+func TestValidatePodSpecPermissions(t *testing.T) {
+	privileged := true
+	unprivileged := sdk.ModelPodSpec{
+		NodeSelector: map[string]string{"kubernetes.io/arch": "amd64"},
+		Volumes:      []sdk.ModelPodVolume{{Name: "cache", MountPath: "/cache", EmptyDir: true}},
+	}
+	require.NoError(t, ValidatePodSpec(sdk.Model{Type: sdk.Docker, ModelDocker: sdk.ModelDocker{PodSpec: &unprivileged}}, false))
+
+	tests := []struct {
+		name  string
+		patch func(s *sdk.ModelPodSpec)
+	}{
+		{"privileged", func(s *sdk.ModelPodSpec) { s.SecurityContext = &sdk.ModelPodSecurityContext{Privileged: &privileged} }},
+		{"privilege escalation", func(s *sdk.ModelPodSpec) {
+			s.SecurityContext = &sdk.ModelPodSecurityContext{AllowPrivilegeEscalation: &privileged}
+		}},
+		{"service account", func(s *sdk.ModelPodSpec) { s.ServiceAccountName = "cds-worker" }},
+		{"host path", func(s *sdk.ModelPodSpec) {
+			s.Volumes = []sdk.ModelPodVolume{{Name: "docker", MountPath: "/var/run/docker.sock", HostPath: "/var/run/docker.sock"}}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := unprivileged
+			tt.patch(&spec)
+			m := sdk.Model{Type: sdk.Docker, ModelDocker: sdk.ModelDocker{PodSpec: &spec}}
+			require.NoError(t, ValidatePodSpec(m, true))
+			err := ValidatePodSpec(m, false)
+			require.Error(t, err)
+			require.True(t, sdk.ErrorIs(err, sdk.ErrForbidden))
+		})
+	}
+}
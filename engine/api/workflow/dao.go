@@ -170,12 +170,13 @@ func (w *Workflow) PostInsert(db gorp.SqlExecutor) error {
 // PostGet is a db hook
 func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 	var res = struct {
-		Metadata     sql.NullString `db:"metadata"`
-		PurgeTags    sql.NullString `db:"purge_tags"`
-		WorkflowData sql.NullString `db:"workflow_data"`
+		Metadata        sql.NullString `db:"metadata"`
+		PurgeTags       sql.NullString `db:"purge_tags"`
+		RetentionPolicy sql.NullString `db:"retention_policy"`
+		WorkflowData    sql.NullString `db:"workflow_data"`
 	}{}
 
-	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, workflow_data FROM workflow WHERE id = $1", w.ID); err != nil {
+	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, retention_policy, workflow_data FROM workflow WHERE id = $1", w.ID); err != nil {
 		return sdk.WrapError(err, "PostGet> Unable to load marshalled workflow")
 	}
 
@@ -191,6 +192,14 @@ func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 	}
 	w.PurgeTags = purgeTags
 
+	if res.RetentionPolicy.Valid {
+		retentionPolicy := &sdk.WorkflowRetentionPolicy{}
+		if err := gorpmapping.JSONNullString(res.RetentionPolicy, retentionPolicy); err != nil {
+			return err
+		}
+		w.RetentionPolicy = retentionPolicy
+	}
+
 	data := &sdk.WorkflowData{}
 	if err := gorpmapping.JSONNullString(res.WorkflowData, data); err != nil {
 		return sdk.WrapError(err, "Unable to unmarshall workflow data")
@@ -236,11 +245,20 @@ func (w *Workflow) PostUpdate(db gorp.SqlExecutor) error {
 		return errPt
 	}
 
+	var rp sql.NullString
+	if w.RetentionPolicy != nil {
+		var errRp error
+		rp, errRp = gorpmapping.JSONToNullString(w.RetentionPolicy)
+		if errRp != nil {
+			return sdk.WrapError(errRp, "Workflow.PostUpdate> Unable to marshall retention policy")
+		}
+	}
+
 	data, errD := gorpmapping.JSONToNullString(w.WorkflowData)
 	if errD != nil {
 		return sdk.WrapError(errD, "Workflow.PostUpdate> Unable to marshall workflow data")
 	}
-	if _, err := db.Exec("update workflow set purge_tags = $1, workflow_data = $3 where id = $2", pt, w.ID, data); err != nil {
+	if _, err := db.Exec("update workflow set purge_tags = $1, workflow_data = $3, retention_policy = $4 where id = $2", pt, w.ID, data, rp); err != nil {
 		return err
 	}
 
@@ -610,6 +628,9 @@ func Insert(ctx context.Context, db gorp.SqlExecutor, store cache.Store, w *sdk.
 	if err := IsValid(ctx, store, db, w, p, LoadOptions{}); err != nil {
 		return sdk.WrapError(err, "Unable to validate workflow")
 	}
+	if err := checkConditionsExpressions(w); err != nil {
+		return err
+	}
 
 	if w.WorkflowData.Node.Context != nil && w.WorkflowData.Node.Context.ApplicationID != 0 {
 		var err error
@@ -908,6 +929,9 @@ func Update(ctx context.Context, db gorp.SqlExecutor, store cache.Store, w *sdk.
 	if err := IsValid(ctx, store, db, w, p, LoadOptions{}); err != nil {
 		return err
 	}
+	if err := checkConditionsExpressions(w); err != nil {
+		return err
+	}
 
 	if err := DeleteNotifications(db, w.ID); err != nil {
 		return sdk.WrapError(err, "unable to delete all notifications on workflow(%d - %s)", w.ID, w.Name)
@@ -1056,6 +1080,12 @@ func IsValid(ctx context.Context, store cache.Store, db gorp.SqlExecutor, w *sdk
 		return sdk.NewError(sdk.ErrWorkflowInvalid, fmt.Errorf("Invalid workflow name. It should match %s", sdk.NamePattern))
 	}
 
+	if w.RetentionPolicy != nil {
+		if err := w.RetentionPolicy.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	//Check refs
 	for _, j := range w.WorkflowData.Joins {
 		if len(j.JoinContext) == 0 {
@@ -1,6 +1,9 @@
 package workflow
 
 import (
+	"database/sql"
+	"time"
+
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/sdk"
@@ -23,7 +26,8 @@ func LoadWorkfowArtifactByHash(db gorp.SqlExecutor, hash string) (*sdk.WorkflowN
 				created,
 				workflow_run_id,
 				project_integration_id,
-				coalesce(sha512sum, '') AS sha512sum
+				coalesce(sha512sum, '') AS sha512sum,
+				coalesce(blob_container, '') AS blob_container
 		  FROM workflow_node_run_artifacts
 		  WHERE workflow_node_run_artifacts.download_hash = $1`
 	if err := db.SelectOne(&artGorp, query, hash); err != nil {
@@ -52,7 +56,8 @@ func LoadArtifactByIDs(db gorp.SqlExecutor, workflowID, artifactID int64) (*sdk.
 			workflow_node_run_artifacts.created,
 			workflow_node_run_artifacts.workflow_run_id,
 			workflow_node_run_artifacts.project_integration_id,
-			coalesce(workflow_node_run_artifacts.sha512sum, '') AS sha512sum
+			coalesce(workflow_node_run_artifacts.sha512sum, '') AS sha512sum,
+			coalesce(workflow_node_run_artifacts.blob_container, '') AS blob_container
 		FROM workflow_node_run_artifacts
 		JOIN workflow_run ON workflow_run.id = workflow_node_run_artifacts.workflow_run_id
 		WHERE workflow_run.workflow_id = $1 AND workflow_node_run_artifacts.id = $2
@@ -81,7 +86,8 @@ func loadArtifactByNodeRunID(db gorp.SqlExecutor, nodeRunID int64) ([]sdk.Workfl
 			created,
 			workflow_run_id,
 			project_integration_id,
-			coalesce(sha512sum, '') AS sha512sum
+			coalesce(sha512sum, '') AS sha512sum,
+			coalesce(blob_container, '') AS blob_container
 		FROM workflow_node_run_artifacts WHERE workflow_node_run_id = $1`, nodeRunID); err != nil {
 		return nil, err
 	}
@@ -102,3 +108,98 @@ func InsertArtifact(db gorp.SqlExecutor, a *sdk.WorkflowNodeRunArtifact) error {
 	a.ID = wArtifactDB.ID
 	return nil
 }
+
+// LoadArtifactBlob retrieves the blob with given checksum for a project storage, it returns nil if not found.
+func LoadArtifactBlob(db gorp.SqlExecutor, projectID, projectIntegrationID int64, sha512sum string) (*sdk.WorkflowNodeRunArtifactBlob, error) {
+	var blob NodeRunArtifactBlob
+	query := `SELECT * FROM workflow_node_run_artifact_blob
+		WHERE project_id = $1 AND project_integration_id = $2 AND sha512sum = $3`
+	if err := db.SelectOne(&blob, query, projectID, projectIntegrationID, sha512sum); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "unable to load artifact blob %s", sha512sum)
+	}
+	b := sdk.WorkflowNodeRunArtifactBlob(blob)
+	return &b, nil
+}
+
+// IncrementArtifactBlob inserts the blob or increments its reference counter if it already exists.
+func IncrementArtifactBlob(db gorp.SqlExecutor, b *sdk.WorkflowNodeRunArtifactBlob) error {
+	query := `INSERT INTO workflow_node_run_artifact_blob (project_id, project_integration_id, sha512sum, size, ref_count, created)
+		VALUES ($1, $2, $3, $4, 1, $5)
+		ON CONFLICT (project_id, project_integration_id, sha512sum) DO UPDATE SET ref_count = workflow_node_run_artifact_blob.ref_count + 1
+		RETURNING id, ref_count`
+	if err := db.QueryRow(query, b.ProjectID, b.ProjectIntegrationID, b.SHA512sum, b.Size, time.Now()).Scan(&b.ID, &b.RefCount); err != nil {
+		return sdk.WrapError(err, "unable to increment artifact blob %s", b.SHA512sum)
+	}
+	return nil
+}
+
+// IncrementExistingArtifactBlob increments the reference counter of a blob only if it is still referenced,
+// it returns nil if the blob doesn't exist.
+func IncrementExistingArtifactBlob(db gorp.SqlExecutor, projectID, projectIntegrationID int64, sha512sum string) (*sdk.WorkflowNodeRunArtifactBlob, error) {
+	var blob NodeRunArtifactBlob
+	query := `UPDATE workflow_node_run_artifact_blob SET ref_count = ref_count + 1
+		WHERE project_id = $1 AND project_integration_id = $2 AND sha512sum = $3 AND ref_count > 0
+		RETURNING *`
+	if err := db.SelectOne(&blob, query, projectID, projectIntegrationID, sha512sum); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "unable to increment artifact blob %s", sha512sum)
+	}
+	b := sdk.WorkflowNodeRunArtifactBlob(blob)
+	return &b, nil
+}
+
+// DecrementArtifactBlob decrements the reference counter of a blob and deletes it when it is not referenced anymore.
+// The blob is locked until its content is deleted by the given func so that a concurrent upload of the same content
+// can't reference it in the meantime.
+// If an artifact id is given, the artifact is deleted in the same transaction and the counter is only decremented
+// if the artifact still existed, so that an artifact is dereferenced once.
+func DecrementArtifactBlob(db *gorp.DbMap, artifactID, projectID, projectIntegrationID int64, sha512sum string, deleteContent func() error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	if artifactID != 0 {
+		res, err := tx.Exec("DELETE FROM workflow_node_run_artifacts WHERE id = $1", artifactID)
+		if err != nil {
+			return sdk.WrapError(err, "unable to delete artifact %d", artifactID)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil
+		}
+	}
+
+	query := `SELECT id, ref_count FROM workflow_node_run_artifact_blob
+		WHERE project_id = $1 AND project_integration_id = $2 AND sha512sum = $3
+		FOR UPDATE`
+	var id, refCount int64
+	if err := tx.QueryRow(query, projectID, projectIntegrationID, sha512sum).Scan(&id, &refCount); err != nil {
+		if err == sql.ErrNoRows {
+			return sdk.WithStack(tx.Commit())
+		}
+		return sdk.WrapError(err, "unable to lock artifact blob %s", sha512sum)
+	}
+
+	if refCount > 1 {
+		if _, err := tx.Exec("UPDATE workflow_node_run_artifact_blob SET ref_count = ref_count - 1 WHERE id = $1", id); err != nil {
+			return sdk.WrapError(err, "unable to decrement artifact blob %s", sha512sum)
+		}
+		return sdk.WithStack(tx.Commit())
+	}
+
+	if _, err := tx.Exec("DELETE FROM workflow_node_run_artifact_blob WHERE id = $1", id); err != nil {
+		return sdk.WrapError(err, "unable to delete artifact blob %s", sha512sum)
+	}
+	// The content is deleted while the blob is still locked, an orphan content is overwritten by the next upload
+	deleteErr := deleteContent()
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+	return sdk.WrapError(deleteErr, "unable to delete content of artifact blob %s", sha512sum)
+}
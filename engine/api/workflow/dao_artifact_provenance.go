@@ -0,0 +1,177 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/keys"
+	"github.com/ovh/cds/sdk"
+)
+
+// NewArtifactProvenanceStatement returns the SLSA provenance of an artifact uploaded by a job: the git commit,
+// the workflow and the pipeline, the worker model and the parameters of the node run. Secret parameters are not kept.
+func NewArtifactProvenanceStatement(builderID string, nodeRun sdk.WorkflowNodeRun, jobRun sdk.WorkflowNodeJobRun, art sdk.WorkflowNodeRunArtifact) sdk.ProvenanceStatement {
+	st := sdk.ProvenanceStatement{
+		Type: sdk.InTotoStatementType,
+		Subject: []sdk.ProvenanceSubject{{
+			Name:   art.Name,
+			Digest: map[string]string{"sha512": art.SHA512sum},
+		}},
+		PredicateType: sdk.SLSAProvenancePredicateType,
+	}
+
+	p := &st.Predicate
+	p.Builder.ID = builderID
+	p.BuildType = sdk.ProvenanceBuildType
+
+	workflowName := sdk.ParameterValue(jobRun.Parameters, "cds.workflow")
+	pipelineName := sdk.ParameterValue(jobRun.Parameters, "cds.pipeline")
+	p.Invocation.ConfigSource.EntryPoint = fmt.Sprintf("%s/%s/%s", workflowName, pipelineName, jobRun.Job.Action.Name)
+
+	gitURL := sdk.ParameterValue(jobRun.Parameters, "git.http_url")
+	if gitURL == "" {
+		gitURL = sdk.ParameterValue(jobRun.Parameters, "git.url")
+	}
+	if gitURL != "" && nodeRun.VCSHash != "" {
+		uri := "git+" + gitURL
+		if nodeRun.VCSBranch != "" {
+			uri += "@refs/heads/" + nodeRun.VCSBranch
+		} else if nodeRun.VCSTag != "" {
+			uri += "@refs/tags/" + nodeRun.VCSTag
+		}
+		digest := map[string]string{"sha1": nodeRun.VCSHash}
+		p.Invocation.ConfigSource.URI = uri
+		p.Invocation.ConfigSource.Digest = digest
+		p.Materials = []sdk.ProvenanceMaterial{{URI: uri, Digest: digest}}
+	}
+
+	p.Invocation.Parameters = make(map[string]string, len(nodeRun.BuildParameters))
+	for _, param := range nodeRun.BuildParameters {
+		if sdk.NeedPlaceholder(param.Type) {
+			continue
+		}
+		p.Invocation.Parameters[param.Name] = param.Value
+	}
+	p.Invocation.Environment = map[string]string{
+		"cds.project":     sdk.ParameterValue(jobRun.Parameters, "cds.project"),
+		"cds.workflow":    workflowName,
+		"cds.pipeline":    pipelineName,
+		"cds.application": sdk.ParameterValue(jobRun.Parameters, "cds.application"),
+		"cds.environment": sdk.ParameterValue(jobRun.Parameters, "cds.environment"),
+		"cds.job":         jobRun.Job.Action.Name,
+		"cds.worker":      jobRun.Job.WorkerName,
+		"cds.model":       jobRun.Model,
+		"cds.model.type":  jobRun.ModelType,
+	}
+	for k, v := range p.Invocation.Environment {
+		if v == "" {
+			delete(p.Invocation.Environment, k)
+		}
+	}
+
+	p.Metadata.BuildInvocationID = fmt.Sprintf("%s/%d.%d/%s/%d", workflowName, nodeRun.Number, nodeRun.SubNumber, nodeRun.WorkflowNodeName, jobRun.ID)
+	if !jobRun.Start.IsZero() {
+		start := jobRun.Start
+		p.Metadata.BuildStartedOn = &start
+	}
+	finished := art.Created
+	if finished.IsZero() {
+		finished = time.Now()
+	}
+	p.Metadata.BuildFinishedOn = &finished
+	p.Metadata.Completeness.Parameters = true
+	p.Metadata.Completeness.Materials = p.Materials != nil
+	return st
+}
+
+// SignArtifactProvenance signs a provenance statement with the provenance key of the project
+func SignArtifactProvenance(key sdk.ProjectKey, statement sdk.ProvenanceStatement) (*sdk.ProvenanceEnvelope, error) {
+	_, keyID, err := keys.RSAPublicKeyPEM(key.Private)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.NewProvenanceEnvelope(statement, keyID, func(data []byte) ([]byte, error) {
+		return keys.SignRSA(key.Private, data)
+	})
+}
+
+// InsertArtifactProvenance inserts the provenance of an artifact, or replaces the existing one.
+func InsertArtifactProvenance(db gorp.SqlExecutor, p *sdk.WorkflowNodeRunArtifactProvenance) error {
+	if _, err := db.Exec("DELETE FROM workflow_node_run_artifact_provenance WHERE artifact_id = $1", p.ArtifactID); err != nil {
+		return sdk.WrapError(err, "unable to delete provenance of artifact %d", p.ArtifactID)
+	}
+	dbProvenance := dbArtifactProvenance(*p)
+	if err := gorpmapping.Insert(db, &dbProvenance); err != nil {
+		return sdk.WrapError(err, "unable to insert provenance of artifact %d", p.ArtifactID)
+	}
+	*p = sdk.WorkflowNodeRunArtifactProvenance(dbProvenance)
+	return nil
+}
+
+// LoadArtifactProvenance returns the provenance of an artifact.
+func LoadArtifactProvenance(ctx context.Context, db gorp.SqlExecutor, artifactID int64) (*sdk.WorkflowNodeRunArtifactProvenance, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM workflow_node_run_artifact_provenance
+		WHERE artifact_id = $1
+	`).Args(artifactID)
+	var dbProvenance dbArtifactProvenance
+	found, err := gorpmapping.Get(ctx, db, query, &dbProvenance)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get provenance")
+	}
+	if !found {
+		return nil, sdk.WrapError(sdk.ErrNotFound, "no provenance for artifact %d", artifactID)
+	}
+	p := sdk.WorkflowNodeRunArtifactProvenance(dbProvenance)
+	return &p, nil
+}
+
+// LoadArtifactByNodeRunAndName returns the latest artifact of a node run with the given name and tag.
+func LoadArtifactByNodeRunAndName(db gorp.SqlExecutor, nodeRunID int64, tag, name string) (*sdk.WorkflowNodeRunArtifact, error) {
+	arts, err := loadArtifactByNodeRunID(db, nodeRunID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load artifacts of node run %d", nodeRunID)
+	}
+	var res *sdk.WorkflowNodeRunArtifact
+	for i := range arts {
+		if arts[i].Tag == tag && strings.EqualFold(arts[i].Name, name) && (res == nil || arts[i].ID > res.ID) {
+			res = &arts[i]
+		}
+	}
+	if res == nil {
+		return nil, sdk.WrapError(sdk.ErrNotFound, "no artifact %s with tag %s", name, tag)
+	}
+	return res, nil
+}
+
+// PostGet is a db hook
+func (d *dbArtifactProvenance) PostGet(db gorp.SqlExecutor) error {
+	var envelopeS sql.NullString
+	query := "SELECT envelope FROM workflow_node_run_artifact_provenance WHERE id = $1"
+	if err := db.QueryRow(query, d.ID).Scan(&envelopeS); err != nil {
+		return sdk.WrapError(err, "unable to load envelope")
+	}
+	if err := gorpmapping.JSONNullString(envelopeS, &d.Envelope); err != nil {
+		return sdk.WrapError(err, "unable to unmarshal envelope")
+	}
+	return nil
+}
+
+// PostInsert is a db hook
+func (d *dbArtifactProvenance) PostInsert(db gorp.SqlExecutor) error {
+	envelope, err := gorpmapping.JSONToNullString(d.Envelope)
+	if err != nil {
+		return sdk.WrapError(err, "unable to marshal envelope")
+	}
+	query := "UPDATE workflow_node_run_artifact_provenance SET envelope = $1 WHERE id = $2"
+	if _, err := db.Exec(query, envelope, d.ID); err != nil {
+		return sdk.WrapError(err, "unable to update envelope")
+	}
+	return nil
+}
@@ -3,8 +3,11 @@ package workflow
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
 	"github.com/sguiheux/go-coverage"
 
 	"github.com/ovh/cds/engine/api/cache"
@@ -87,9 +90,9 @@ func UpdateCoverage(db gorp.SqlExecutor, cov sdk.WorkflowNodeRunCoverage) error
 
 // PostGet is a db hook on workflow_node_run_coverage
 func (c *Coverage) PostGet(s gorp.SqlExecutor) error {
-	var report, trend sql.NullString
-	query := "SELECT report, trend FROM workflow_node_run_coverage WHERE workflow_node_run_id=$1"
-	if err := s.QueryRow(query, c.WorkflowNodeRunID).Scan(&report, &trend); err != nil {
+	var report, trend, diff sql.NullString
+	query := "SELECT report, trend, diff FROM workflow_node_run_coverage WHERE workflow_node_run_id=$1"
+	if err := s.QueryRow(query, c.WorkflowNodeRunID).Scan(&report, &trend, &diff); err != nil {
 		return sdk.WrapError(err, "Unable to get report and trend")
 	}
 
@@ -100,6 +103,10 @@ func (c *Coverage) PostGet(s gorp.SqlExecutor) error {
 	if err := gorpmapping.JSONNullString(trend, &c.Trend); err != nil {
 		return sdk.WrapError(err, "Unable to unmarshal trend")
 	}
+
+	if err := gorpmapping.JSONNullString(diff, &c.Diff); err != nil {
+		return sdk.WrapError(err, "Unable to unmarshal diff coverage")
+	}
 	return nil
 }
 
@@ -118,12 +125,16 @@ func (c *Coverage) PostUpdate(s gorp.SqlExecutor) error {
 	if errT != nil {
 		return sdk.WrapError(errT, "workflow.coverage.postupdate> Unable to stringify trend")
 	}
+	diffS, err := gorpmapping.JSONToNullString(c.Diff)
+	if err != nil {
+		return sdk.WrapError(err, "Unable to stringify diff coverage")
+	}
 
 	query := `
     UPDATE workflow_node_run_coverage 
-    SET report=$1, trend=$2
-    WHERE workflow_node_run_id=$3`
-	if _, err := s.Exec(query, reportS, trendS, c.WorkflowNodeRunID); err != nil {
+    SET report=$1, trend=$2, diff=$3
+    WHERE workflow_node_run_id=$4`
+	if _, err := s.Exec(query, reportS, trendS, diffS, c.WorkflowNodeRunID); err != nil {
 		return sdk.WrapError(err, "Unable to update report and trend")
 	}
 
@@ -201,3 +212,134 @@ func ComputeLatestDefaultBranchReport(ctx context.Context, db gorp.SqlExecutor,
 
 	return nil
 }
+
+// InsertCoverageFiles stores the line coverage of the files of a node run, the lines of a file
+// already stored for the node run are replaced.
+func InsertCoverageFiles(db gorp.SqlExecutor, workflowNodeRunID int64, files []sdk.WorkflowNodeRunCoverageFile) error {
+	query := `
+    INSERT INTO workflow_node_run_coverage_file (workflow_node_run_id, path, covered_lines, uncovered_lines)
+    VALUES ($1, $2, $3, $4)
+    ON CONFLICT (workflow_node_run_id, path) DO UPDATE SET covered_lines = $3, uncovered_lines = $4`
+	for _, f := range files {
+		if _, err := db.Exec(query, workflowNodeRunID, f.Path, pq.Int64Array(f.CoveredLines), pq.Int64Array(f.UncoveredLines)); err != nil {
+			return sdk.WrapError(err, "unable to insert coverage of file %s", f.Path)
+		}
+	}
+	return nil
+}
+
+// LoadCoverageFiles loads the line coverage of the files of a node run
+func LoadCoverageFiles(db gorp.SqlExecutor, workflowNodeRunID int64) ([]sdk.WorkflowNodeRunCoverageFile, error) {
+	query := `
+    SELECT path, covered_lines, uncovered_lines
+    FROM workflow_node_run_coverage_file
+    WHERE workflow_node_run_id = $1
+    ORDER BY path`
+	rows, err := db.Query(query, workflowNodeRunID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load coverage files")
+	}
+	defer rows.Close()
+
+	var files []sdk.WorkflowNodeRunCoverageFile
+	for rows.Next() {
+		var f sdk.WorkflowNodeRunCoverageFile
+		var covered, uncovered pq.Int64Array
+		if err := rows.Scan(&f.Path, &covered, &uncovered); err != nil {
+			return nil, sdk.WrapError(err, "unable to scan coverage file")
+		}
+		f.CoveredLines = covered
+		f.UncoveredLines = uncovered
+		files = append(files, f)
+	}
+	return files, sdk.WithStack(rows.Err())
+}
+
+// ComputeDiffCoverage computes the coverage of the lines changed between the default branch and the commit of the node run.
+// Nothing is computed for a run on the default branch or if the diff is not available on the repositories manager.
+func ComputeDiffCoverage(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, wnr *sdk.WorkflowNodeRun, covReport *sdk.WorkflowNodeRunCoverage) error {
+	if wnr.VCSRepository == "" || wnr.VCSHash == "" {
+		return nil
+	}
+
+	projectVCSServer := repositoriesmanager.GetProjectVCSServer(proj, wnr.VCSServer)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, proj.Key, projectVCSServer)
+	if err != nil {
+		return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "cannot get repo client %s: %v", wnr.VCSServer, err)
+	}
+
+	defaultBranch, err := repositoriesmanager.DefaultBranch(ctx, client, wnr.VCSRepository)
+	if err != nil {
+		return err
+	}
+	if defaultBranch.DisplayID == wnr.VCSBranch || defaultBranch.LatestCommit == wnr.VCSHash {
+		return nil
+	}
+
+	diff, err := client.DiffBetweenRefs(ctx, wnr.VCSRepository, defaultBranch.LatestCommit, wnr.VCSHash)
+	if err != nil {
+		if sdk.ErrorIs(err, sdk.ErrNotImplemented) {
+			return nil
+		}
+		return sdk.WrapError(err, "cannot get diff between %s and %s", defaultBranch.DisplayID, wnr.VCSHash)
+	}
+
+	files, err := LoadCoverageFiles(db, wnr.ID)
+	if err != nil {
+		return err
+	}
+
+	d := sdk.ComputeDiffCoverage(files, diff)
+	d.Base = defaultBranch.DisplayID
+	d.Head = wnr.VCSHash
+	covReport.Diff = &d
+	return nil
+}
+
+// diffCoverageCommentMarker identifies the diff coverage comment of a node in a workflow run,
+// it is hidden in the comment body to update the comment on the next coverage upload.
+func diffCoverageCommentMarker(wnr *sdk.WorkflowNodeRun) string {
+	return fmt.Sprintf("<!-- cds:diff-coverage:%d:%s -->", wnr.WorkflowRunID, wnr.WorkflowNodeName)
+}
+
+// SendDiffCoverageComment posts the diff coverage on the opened pull request of the node run branch,
+// or updates the comment previously posted for the same workflow run and node.
+func SendDiffCoverageComment(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, wnr *sdk.WorkflowNodeRun, covReport sdk.WorkflowNodeRunCoverage) error {
+	if covReport.Diff == nil {
+		return nil
+	}
+
+	projectVCSServer := repositoriesmanager.GetProjectVCSServer(proj, wnr.VCSServer)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, proj.Key, projectVCSServer)
+	if err != nil {
+		return sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "cannot get repo client %s: %v", wnr.VCSServer, err)
+	}
+
+	prs, err := client.PullRequests(ctx, wnr.VCSRepository)
+	if err != nil {
+		return sdk.WrapError(err, "unable to get pull requests on repo %s", wnr.VCSRepository)
+	}
+	for _, pr := range prs {
+		if pr.Head.Branch.DisplayID == wnr.VCSBranch && pr.Head.Branch.LatestCommit == wnr.VCSHash && !pr.Merged && !pr.Closed {
+			marker := diffCoverageCommentMarker(wnr)
+			comment := marker + "\n" + covReport.Diff.Markdown(fmt.Sprintf("Diff coverage of %s", wnr.WorkflowNodeName))
+
+			comments, err := client.PullRequestComments(ctx, wnr.VCSRepository, pr.ID)
+			if err != nil && !sdk.ErrorIs(err, sdk.ErrNotImplemented) {
+				return sdk.WrapError(err, "unable to get comments of pull request %d", pr.ID)
+			}
+			for _, c := range comments {
+				if strings.HasPrefix(c.Body, marker) {
+					c.Body = comment
+					return sdk.WrapError(client.PullRequestCommentUpdate(ctx, wnr.VCSRepository, pr.ID, c), "unable to update comment %d of pull request %d", c.ID, pr.ID)
+				}
+			}
+
+			if err := client.PullRequestComment(ctx, wnr.VCSRepository, pr.ID, comment); err != nil {
+				return sdk.WrapError(err, "unable to comment pull request %d", pr.ID)
+			}
+			break
+		}
+	}
+	return nil
+}
@@ -50,7 +50,8 @@ workflow_node_run.uuid,
 workflow_node_run.outgoinghook,
 workflow_node_run.hook_execution_timestamp,
 workflow_node_run.execution_id,
-workflow_node_run.callback
+workflow_node_run.callback,
+workflow_node_run.concurrency_group
 `
 
 const nodeRunTestsField string = ", workflow_node_run.tests"
@@ -113,6 +114,13 @@ func LoadNodeRun(db gorp.SqlExecutor, projectkey, workflowname string, number, i
 		}
 		r.VulnerabilitiesReport = vuln
 	}
+	if loadOpts.WithStaticAnalysis {
+		report, errS := LoadStaticAnalysisReport(db, r.ID)
+		if errS != nil && !sdk.ErrorIs(errS, sdk.ErrNotFound) {
+			return nil, sdk.WrapError(errS, "LoadNodeRun>Error loading static analysis report for run %d", r.ID)
+		}
+		r.StaticAnalysisReport = report
+	}
 	return r, nil
 
 }
@@ -282,6 +290,9 @@ func fromDBNodeRun(rr NodeRun, opts LoadRunOptions) (*sdk.WorkflowNodeRun, error
 	if rr.VCSServer.Valid {
 		r.VCSServer = rr.VCSServer.String
 	}
+	if rr.ConcurrencyGroup.Valid {
+		r.ConcurrencyGroup = rr.ConcurrencyGroup.String
+	}
 
 	if err := gorpmapping.JSONNullString(rr.TriggersRun, &r.TriggersRun); err != nil {
 		return nil, sdk.WrapError(err, "Error loading node run trigger %d", r.ID)
@@ -414,6 +425,10 @@ func makeDBNodeRun(n sdk.WorkflowNodeRun) (*NodeRun, error) {
 	nodeRunDB.HookExecutionTimestamp.Int64 = n.HookExecutionTimeStamp
 	nodeRunDB.UUID.Valid = true
 	nodeRunDB.UUID.String = n.UUID
+	if n.ConcurrencyGroup != "" {
+		nodeRunDB.ConcurrencyGroup.Valid = true
+		nodeRunDB.ConcurrencyGroup.String = n.ConcurrencyGroup
+	}
 
 	if n.TriggersRun != nil {
 		s, err := gorpmapping.JSONToNullString(n.TriggersRun)
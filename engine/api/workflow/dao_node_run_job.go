@@ -383,10 +383,16 @@ func getHatcheryInfo(ctx context.Context, store cache.Store, j *JobRun) {
 	}
 }
 
-// replaceWorkflowJobRunInQueue restart workflow node job
-func replaceWorkflowJobRunInQueue(db gorp.SqlExecutor, wNodeJob sdk.WorkflowNodeJobRun) error {
+// replaceWorkflowJobRunInQueue restart workflow node job, if a delay is given the job
+// will not be returned in the queue before it expires.
+func replaceWorkflowJobRunInQueue(db gorp.SqlExecutor, wNodeJob sdk.WorkflowNodeJobRun, delay time.Duration) error {
 	query := "UPDATE workflow_node_run_job SET status = $1, retry = $2, worker_id = NULL WHERE id = $3"
-	if _, err := db.Exec(query, sdk.StatusWaiting, wNodeJob.Retry+1, wNodeJob.ID); err != nil {
+	args := []interface{}{sdk.StatusWaiting, wNodeJob.Retry + 1, wNodeJob.ID}
+	if delay > 0 {
+		query = "UPDATE workflow_node_run_job SET status = $1, retry = $2, worker_id = NULL, queued = $4 WHERE id = $3"
+		args = append(args, time.Now().Add(delay))
+	}
+	if _, err := db.Exec(query, args...); err != nil {
 		return sdk.WrapError(err, "Unable to set workflow_node_run_job id %d with status %s", wNodeJob.ID, sdk.StatusWaiting)
 	}
 
@@ -0,0 +1,115 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func getSBOMs(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.WorkflowNodeRunSBOM, error) {
+	var dbSBOMs []dbNodeRunSBOM
+	if err := gorpmapping.GetAll(ctx, db, q, &dbSBOMs); err != nil {
+		return nil, sdk.WrapError(err, "cannot get sboms")
+	}
+	sboms := make([]sdk.WorkflowNodeRunSBOM, len(dbSBOMs))
+	for i := range dbSBOMs {
+		sboms[i] = sdk.WorkflowNodeRunSBOM(dbSBOMs[i])
+	}
+	return sboms, nil
+}
+
+// InsertSBOM inserts a SBOM of a node run and indexes its components in one query.
+func InsertSBOM(db gorp.SqlExecutor, sbom *sdk.WorkflowNodeRunSBOM, components []sdk.SBOMComponent) error {
+	sbom.Components = int64(len(components))
+	dbSBOM := dbNodeRunSBOM(*sbom)
+	if err := gorpmapping.Insert(db, &dbSBOM); err != nil {
+		return sdk.WrapError(err, "unable to insert sbom")
+	}
+	*sbom = sdk.WorkflowNodeRunSBOM(dbSBOM)
+
+	if len(components) == 0 {
+		return nil
+	}
+	names := make([]string, len(components))
+	versions := make([]string, len(components))
+	purls := make([]string, len(components))
+	purlBases := make([]string, len(components))
+	for i := range components {
+		names[i] = components[i].Name
+		versions[i] = components[i].Version
+		purls[i] = components[i].PURL
+		purlBases[i] = sdk.SBOMPURLBase(components[i].PURL)
+	}
+	query := `
+		INSERT INTO workflow_node_run_sbom_component (sbom_id, name, version, purl, purl_base)
+		SELECT $1, c.name, c.version, c.purl, c.purl_base
+		FROM unnest($2::text[], $3::text[], $4::text[], $5::text[]) AS c(name, version, purl, purl_base)`
+	if _, err := db.Exec(query, sbom.ID, pq.StringArray(names), pq.StringArray(versions), pq.StringArray(purls), pq.StringArray(purlBases)); err != nil {
+		return sdk.WrapError(err, "unable to insert components of sbom %d", sbom.ID)
+	}
+	return nil
+}
+
+// LoadSBOMsByRunID returns the SBOMs of a workflow run.
+func LoadSBOMsByRunID(ctx context.Context, db gorp.SqlExecutor, workflowRunID int64) ([]sdk.WorkflowNodeRunSBOM, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM workflow_node_run_sbom
+		WHERE workflow_run_id = $1
+		ORDER BY workflow_node_name, name, id
+	`).Args(workflowRunID)
+	return getSBOMs(ctx, db, query)
+}
+
+// LoadSBOMByID returns a SBOM of a workflow run.
+func LoadSBOMByID(ctx context.Context, db gorp.SqlExecutor, workflowRunID, id int64) (*sdk.WorkflowNodeRunSBOM, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM workflow_node_run_sbom
+		WHERE workflow_run_id = $1 AND id = $2
+	`).Args(workflowRunID, id)
+	var dbSBOM dbNodeRunSBOM
+	found, err := gorpmapping.Get(ctx, db, query, &dbSBOM)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get sbom")
+	}
+	if !found {
+		return nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	sbom := sdk.WorkflowNodeRunSBOM(dbSBOM)
+	return &sbom, nil
+}
+
+// SearchSBOMComponents returns the node runs of a project with a SBOM that contains the given component, the latest
+// runs first. The component is searched by name, case insensitive, or by package URL if the name is a purl. Version is optional.
+func SearchSBOMComponents(db gorp.SqlExecutor, projectID int64, name, version string, limit int) ([]sdk.SBOMSearchResult, error) {
+	// Qualifiers and subpath of the package URLs are ignored
+	condition := "lower(workflow_node_run_sbom_component.name) = lower($2)"
+	if strings.HasPrefix(name, "pkg:") {
+		condition = "workflow_node_run_sbom_component.purl_base = $2"
+		name = sdk.SBOMPURLBase(name)
+	}
+	query := `
+		SELECT workflow_node_run_sbom.id AS sbom_id, workflow.name AS workflow_name, COALESCE(application.name, '') AS application_name,
+			workflow_node_run_sbom.workflow_number, workflow_node_run_sbom.workflow_node_name, workflow_node_run_sbom.branch,
+			workflow_node_run_sbom.name AS sbom_name, workflow_node_run_sbom_component.name, workflow_node_run_sbom_component.version,
+			workflow_node_run_sbom_component.purl
+		FROM workflow_node_run_sbom_component
+		JOIN workflow_node_run_sbom ON workflow_node_run_sbom.id = workflow_node_run_sbom_component.sbom_id
+		JOIN workflow ON workflow.id = workflow_node_run_sbom.workflow_id
+		LEFT JOIN application ON application.id = workflow_node_run_sbom.application_id
+		WHERE workflow_node_run_sbom.project_id = $1
+		AND ` + condition + `
+		AND ($3 = '' OR workflow_node_run_sbom_component.version = $3)
+		ORDER BY workflow_node_run_sbom.id DESC, workflow_node_run_sbom_component.name, workflow_node_run_sbom_component.version
+		LIMIT $4`
+
+	var results []sdk.SBOMSearchResult
+	if _, err := db.Select(&results, query, projectID, name, version, limit); err != nil {
+		return nil, sdk.WrapError(err, "unable to search sbom components")
+	}
+	return results, nil
+}
@@ -0,0 +1,159 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/sdk"
+)
+
+// HandleStaticAnalysisReport merges the findings sent by the worker in the report of the node run and computes
+// the new and fixed findings compared to the default branch, or to the previous run if the run is on the default branch.
+func HandleStaticAnalysisReport(ctx context.Context, db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, nr *sdk.WorkflowNodeRun, workerReport sdk.StaticAnalysisWorkerReport) (*sdk.WorkflowNodeRunStaticAnalysisReport, error) {
+	var defaultBranch string
+	if nr.VCSServer != "" {
+		projectVCSServer := repositoriesmanager.GetProjectVCSServer(proj, nr.VCSServer)
+		client, err := repositoriesmanager.AuthorizedClient(ctx, db, cache, proj.Key, projectVCSServer)
+		if err != nil {
+			return nil, sdk.WrapError(sdk.ErrNoReposManagerClientAuth, "cannot get repo client %s: %v", nr.VCSServer, err)
+		}
+		b, err := repositoriesmanager.DefaultBranch(ctx, client, nr.VCSRepository)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to get default branch")
+		}
+		defaultBranch = b.DisplayID
+	}
+
+	report, err := LoadStaticAnalysisReport(db, nr.ID)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+	create := report == nil
+	if create {
+		report = &sdk.WorkflowNodeRunStaticAnalysisReport{
+			ApplicationID:     nr.ApplicationID,
+			WorkflowID:        nr.WorkflowID,
+			WorkflowRunID:     nr.WorkflowRunID,
+			WorkflowNodeRunID: nr.ID,
+			WorkflowNodeName:  nr.WorkflowNodeName,
+			Num:               nr.Number,
+			Branch:            nr.VCSBranch,
+		}
+	}
+
+	// A job can send several reports, ie. one by tool
+	report.Report.Findings = sdk.MergeStaticAnalysisFindings(report.Report.Findings, workerReport.Findings)
+	report.Report.Summary = sdk.StaticAnalysisSummary(report.Report.Findings)
+
+	var base *sdk.WorkflowNodeRunStaticAnalysisReport
+	if defaultBranch != "" && defaultBranch != nr.VCSBranch {
+		base, err = loadLatestRunStaticAnalysisReport(db, nr, defaultBranch)
+	} else {
+		base, err = loadPreviousRunStaticAnalysisReport(db, nr)
+	}
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+	if base != nil {
+		report.Report.ComparedTo = fmt.Sprintf("%s #%d", base.Branch, base.Num)
+		report.Report.NewFindings, report.Report.FixedFindings = sdk.DiffStaticAnalysisFindings(report.Report.Findings, base.Report.Findings)
+	} else {
+		// Without a report to compare to, all the findings are new
+		report.Report.ComparedTo = ""
+		report.Report.NewFindings = report.Report.Findings
+		report.Report.FixedFindings = nil
+	}
+
+	dbReport := dbNodeRunStaticAnalysisReport(*report)
+	if create {
+		if err := gorpmapping.Insert(db, &dbReport); err != nil {
+			return nil, sdk.WrapError(err, "unable to insert static analysis report")
+		}
+	} else {
+		if err := gorpmapping.Update(db, &dbReport); err != nil {
+			return nil, sdk.WrapError(err, "unable to update static analysis report")
+		}
+	}
+	res := sdk.WorkflowNodeRunStaticAnalysisReport(dbReport)
+	return &res, nil
+}
+
+func loadStaticAnalysisReport(db gorp.SqlExecutor, query string, args ...interface{}) (*sdk.WorkflowNodeRunStaticAnalysisReport, error) {
+	var dbReport dbNodeRunStaticAnalysisReport
+	if err := db.SelectOne(&dbReport, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WithStack(sdk.ErrNotFound)
+		}
+		return nil, sdk.WrapError(err, "unable to load static analysis report")
+	}
+	report := sdk.WorkflowNodeRunStaticAnalysisReport(dbReport)
+	return &report, nil
+}
+
+// LoadStaticAnalysisReport loads the static analysis report of a node run
+func LoadStaticAnalysisReport(db gorp.SqlExecutor, nodeRunID int64) (*sdk.WorkflowNodeRunStaticAnalysisReport, error) {
+	query := `
+		SELECT * FROM workflow_node_run_static_analysis
+		WHERE workflow_node_run_id = $1
+		ORDER BY id DESC
+		LIMIT 1
+	`
+	return loadStaticAnalysisReport(db, query, nodeRunID)
+}
+
+func loadPreviousRunStaticAnalysisReport(db gorp.SqlExecutor, nr *sdk.WorkflowNodeRun) (*sdk.WorkflowNodeRunStaticAnalysisReport, error) {
+	query := `
+		SELECT * FROM workflow_node_run_static_analysis
+		WHERE workflow_id = $1 AND workflow_node_name = $2 AND branch = $3 AND workflow_number < $4
+		ORDER BY workflow_number DESC, workflow_node_run_id DESC
+		LIMIT 1
+	`
+	return loadStaticAnalysisReport(db, query, nr.WorkflowID, nr.WorkflowNodeName, nr.VCSBranch, nr.Number)
+}
+
+func loadLatestRunStaticAnalysisReport(db gorp.SqlExecutor, nr *sdk.WorkflowNodeRun, branch string) (*sdk.WorkflowNodeRunStaticAnalysisReport, error) {
+	query := `
+		SELECT * FROM workflow_node_run_static_analysis
+		WHERE workflow_id = $1 AND workflow_node_name = $2 AND branch = $3
+		ORDER BY workflow_number DESC, workflow_node_run_id DESC
+		LIMIT 1
+	`
+	return loadStaticAnalysisReport(db, query, nr.WorkflowID, nr.WorkflowNodeName, branch)
+}
+
+// PostGet is a db hook
+func (d *dbNodeRunStaticAnalysisReport) PostGet(db gorp.SqlExecutor) error {
+	var reportS sql.NullString
+	query := "SELECT report FROM workflow_node_run_static_analysis WHERE id = $1"
+	if err := db.QueryRow(query, d.ID).Scan(&reportS); err != nil {
+		return sdk.WrapError(err, "unable to load report")
+	}
+	if err := gorpmapping.JSONNullString(reportS, &d.Report); err != nil {
+		return sdk.WrapError(err, "unable to unmarshal report")
+	}
+	return nil
+}
+
+// PostInsert is a db hook
+func (d *dbNodeRunStaticAnalysisReport) PostInsert(db gorp.SqlExecutor) error {
+	return d.PostUpdate(db)
+}
+
+// PostUpdate is a db hook
+func (d *dbNodeRunStaticAnalysisReport) PostUpdate(db gorp.SqlExecutor) error {
+	report, err := gorpmapping.JSONToNullString(d.Report)
+	if err != nil {
+		return sdk.WrapError(err, "unable to marshal report")
+	}
+	query := "UPDATE workflow_node_run_static_analysis SET report = $1 WHERE id = $2"
+	if _, err := db.Exec(query, report, d.ID); err != nil {
+		return sdk.WrapError(err, "unable to update report")
+	}
+	return nil
+}
@@ -3,7 +3,7 @@ package workflow
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"time"
 
 	"github.com/go-gorp/gorp"
 
@@ -35,6 +35,10 @@ func HandleVulnerabilityReport(ctx context.Context, db gorp.SqlExecutor, cache c
 		defaultBranch = b.DisplayID
 	}
 
+	// A report can contain the same vulnerability several times, ie. for each path of a dependency
+	workerReport.Vulnerabilities = sdk.DedupVulnerabilities(workerReport.Vulnerabilities)
+	workerReport.Summary = sdk.VulnerabilitiesSummary(workerReport.Vulnerabilities)
+
 	// Get report on the current node run if exist
 	currentNodeRunReport, err := loadVulnerabilityReport(db, nr.ID)
 	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
@@ -47,19 +51,8 @@ func HandleVulnerabilityReport(ctx context.Context, db gorp.SqlExecutor, cache c
 		}
 	}
 
-	currentNodeRunReport.Report.Vulnerabilities = append(currentNodeRunReport.Report.Vulnerabilities, workerReport.Vulnerabilities...)
-	if currentNodeRunReport.Report.Summary == nil {
-		currentNodeRunReport.Report.Summary = workerReport.Summary
-	} else if workerReport.Summary != nil {
-		for k, v := range workerReport.Summary {
-			count, ok := currentNodeRunReport.Report.Summary[k]
-			if !ok {
-				currentNodeRunReport.Report.Summary[k] = v
-			} else {
-				currentNodeRunReport.Report.Summary[k] = count + v
-			}
-		}
-	}
+	currentNodeRunReport.Report.Vulnerabilities = sdk.DedupVulnerabilities(append(currentNodeRunReport.Report.Vulnerabilities, workerReport.Vulnerabilities...))
+	currentNodeRunReport.Report.Summary = sdk.VulnerabilitiesSummary(currentNodeRunReport.Report.Vulnerabilities)
 
 	// Update report
 	dbReport := dbNodeRunVulenrabilitiesReport(currentNodeRunReport)
@@ -148,17 +141,17 @@ func syncVunerabilitiesWithApplication(db gorp.SqlExecutor, nodeRunReport sdk.Wo
 	// create map
 	m := make(map[string]sdk.Vulnerability, len(nodeRunReport.Report.Vulnerabilities))
 	for _, v := range nodeRunReport.Report.Vulnerabilities {
-		m[fmt.Sprintf("%s-%s-%s", v.Component, v.Version, v.CVE)] = v
+		m[v.Key()] = v
 	}
 
 	for _, v := range appVuln {
 		if v.Ignored {
-			mVuln, ok := m[fmt.Sprintf("%s-%s-%s", v.Component, v.Version, v.CVE)]
+			mVuln, ok := m[v.Key()]
 			if !ok {
 				continue
 			}
 			mVuln.Ignored = true
-			m[fmt.Sprintf("%s-%s-%s", v.Component, v.Version, v.CVE)] = mVuln
+			m[v.Key()] = mVuln
 		}
 	}
 
@@ -168,6 +161,14 @@ func syncVunerabilitiesWithApplication(db gorp.SqlExecutor, nodeRunReport sdk.Wo
 		result[i] = v
 		i++
 	}
+
+	// Flag as ignored, vulnerabilities suppressed by a rule of the project
+	rules, err := application.LoadActiveVulnerabilitySuppressionsByApplicationID(db, appID)
+	if err != nil {
+		return nil, err
+	}
+	sdk.ApplyVulnerabilitySuppressions(result, rules, time.Now())
+
 	return result, nil
 }
 
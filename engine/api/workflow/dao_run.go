@@ -41,6 +41,7 @@ type LoadRunOptions struct {
 	WithTests               bool
 	WithLightTests          bool
 	WithVulnerabilities     bool
+	WithStaticAnalysis      bool
 	WithDeleted             bool
 	DisableDetailledNodeRun bool
 	Language                string
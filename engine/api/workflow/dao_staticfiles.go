@@ -17,7 +17,8 @@ func loadStaticFilesByNodeRunID(db gorp.SqlExecutor, nodeRunID int64) ([]sdk.Sta
 			entrypoint,
 			created,
 			public_url,
-			workflow_node_run_id
+			workflow_node_run_id,
+			size
 		FROM workflow_node_run_static_files WHERE workflow_node_run_id = $1`, nodeRunID); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -0,0 +1,121 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+func getTestResults(ctx context.Context, db gorp.SqlExecutor, q gorpmapping.Query) ([]sdk.WorkflowTestResult, error) {
+	var dbResults []dbTestResult
+	if err := gorpmapping.GetAll(ctx, db, q, &dbResults); err != nil {
+		return nil, sdk.WrapError(err, "cannot get test results")
+	}
+	results := make([]sdk.WorkflowTestResult, len(dbResults))
+	for i := range dbResults {
+		results[i] = sdk.WorkflowTestResult(dbResults[i])
+	}
+	return results, nil
+}
+
+// InsertTestResults inserts the results of the test cases of a node run in one query.
+func InsertTestResults(db gorp.SqlExecutor, nodeRun sdk.WorkflowNodeRun, results []sdk.WorkflowTestResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	suites := make([]string, len(results))
+	names := make([]string, len(results))
+	status := make([]string, len(results))
+	durations := make([]float64, len(results))
+	for i := range results {
+		suites[i] = results[i].Suite
+		names[i] = results[i].Name
+		status[i] = results[i].Status
+		durations[i] = results[i].Duration
+	}
+
+	query := `
+		INSERT INTO workflow_test_result (workflow_id, workflow_run_id, workflow_node_run_id, run_number, node_name, vcs_branch, vcs_hash, created, suite, name, status, duration)
+		SELECT $1, $2, $3, $4, $5, $6, $7, current_timestamp, r.suite, r.name, r.status, r.duration
+		FROM unnest($8::text[], $9::text[], $10::text[], $11::float8[]) AS r(suite, name, status, duration)`
+	if _, err := db.Exec(query, nodeRun.WorkflowID, nodeRun.WorkflowRunID, nodeRun.ID, nodeRun.Number, nodeRun.WorkflowNodeName,
+		nodeRun.VCSBranch, nodeRun.VCSHash, pq.StringArray(suites), pq.StringArray(names), pq.StringArray(status), pq.Float64Array(durations)); err != nil {
+		return sdk.WrapError(err, "unable to insert test results for node run %d", nodeRun.ID)
+	}
+	return nil
+}
+
+// LoadTestResultsByRunNumber returns the test results of a workflow run.
+func LoadTestResultsByRunNumber(ctx context.Context, db gorp.SqlExecutor, workflowID, runNumber int64) ([]sdk.WorkflowTestResult, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM workflow_test_result
+		WHERE workflow_id = $1 AND run_number = $2
+		ORDER BY suite, name, workflow_node_run_id
+	`).Args(workflowID, runNumber)
+	return getTestResults(ctx, db, query)
+}
+
+// LoadTestResultsHistory returns the latest results of a test case, the latest first. Branch is optional.
+func LoadTestResultsHistory(ctx context.Context, db gorp.SqlExecutor, workflowID int64, suite, name, branch string, limit int) ([]sdk.WorkflowTestResult, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM workflow_test_result
+		WHERE workflow_id = $1 AND suite = $2 AND name = $3 AND ($4 = '' OR vcs_branch = $4)
+		ORDER BY run_number DESC, workflow_node_run_id DESC
+		LIMIT $5
+	`).Args(workflowID, suite, name, branch, limit)
+	return getTestResults(ctx, db, query)
+}
+
+// LoadTestResultsOfLatestRuns returns the test results of the given number of latest runs with tests. Branch is optional.
+func LoadTestResultsOfLatestRuns(ctx context.Context, db gorp.SqlExecutor, workflowID int64, branch string, runs int) ([]sdk.WorkflowTestResult, error) {
+	query := gorpmapping.NewQuery(`
+		SELECT * FROM workflow_test_result
+		WHERE workflow_id = $1 AND ($2 = '' OR vcs_branch = $2) AND run_number IN (
+			SELECT DISTINCT run_number FROM workflow_test_result
+			WHERE workflow_id = $1 AND ($2 = '' OR vcs_branch = $2)
+			ORDER BY run_number DESC
+			LIMIT $3
+		)
+		ORDER BY run_number, workflow_node_run_id
+	`).Args(workflowID, branch, runs)
+	return getTestResults(ctx, db, query)
+}
+
+// LoadPreviousTestResultsRunNumber returns the number of the latest run with test results before the given run on
+// the same branch, zero if there is none.
+func LoadPreviousTestResultsRunNumber(db gorp.SqlExecutor, workflowID int64, branch string, runNumber int64) (int64, error) {
+	num, err := db.SelectNullInt(`
+		SELECT MAX(run_number) FROM workflow_test_result
+		WHERE workflow_id = $1 AND vcs_branch = $2 AND run_number < $3`, workflowID, branch, runNumber)
+	if err != nil {
+		return 0, sdk.WrapError(err, "unable to load previous run with test results")
+	}
+	return num.Int64, nil
+}
+
+// LoadTestsTrends returns the summary of the test results of the latest runs, the latest first. Branch is optional.
+func LoadTestsTrends(db gorp.SqlExecutor, workflowID int64, branch string, limit int) ([]sdk.WorkflowTestsTrend, error) {
+	var trends []sdk.WorkflowTestsTrend
+	if _, err := db.Select(&trends, `
+		SELECT run_number, MAX(vcs_branch) AS vcs_branch, COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status = $3) AS ok,
+			COUNT(*) FILTER (WHERE status = $4) AS ko,
+			COUNT(*) FILTER (WHERE status = $5) AS skipped,
+			SUM(duration) AS duration
+		FROM workflow_test_result
+		WHERE workflow_id = $1 AND ($2 = '' OR vcs_branch = $2)
+		GROUP BY run_number
+		ORDER BY run_number DESC
+		LIMIT $6`, workflowID, branch, sdk.TestResultStatusSuccess, sdk.TestResultStatusFail, sdk.TestResultStatusSkipped, limit); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "unable to load tests trends")
+	}
+	return trends, nil
+}
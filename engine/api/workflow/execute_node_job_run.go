@@ -257,6 +257,9 @@ func TakeNodeJobRun(ctx context.Context, dbFunc func() *gorp.DbMap, db gorp.SqlE
 	if err := checkStatusWaiting(ctx, store, jobID, job.Status); err != nil {
 		return nil, report, err
 	}
+	if err := CheckNodeJobRunNotDelayed(job); err != nil {
+		return nil, report, err
+	}
 
 	job.Model = workerModel
 	job.Job.WorkerName = workerName
@@ -282,6 +285,15 @@ func TakeNodeJobRun(ctx context.Context, dbFunc func() *gorp.DbMap, db gorp.SqlE
 	return job, report, nil
 }
 
+// CheckNodeJobRunNotDelayed returns an error if the job was replaced in queue with a delay that is not expired,
+// the job is listed in the queue again when the delay expires.
+func CheckNodeJobRunNotDelayed(job *sdk.WorkflowNodeJobRun) error {
+	if job.Queued.After(time.Now()) {
+		return sdk.NewErrorFrom(sdk.ErrJobDelayed, "job %d is delayed until %s", job.ID, job.Queued.Format(time.RFC3339))
+	}
+	return nil
+}
+
 func checkStatusWaiting(ctx context.Context, store cache.Store, jobID int64, status string) error {
 	if status != sdk.StatusWaiting {
 		k := keyBookJob(jobID)
@@ -621,6 +633,51 @@ func RestartWorkflowNodeJob(ctx context.Context, db gorp.SqlExecutor, wNodeJob s
 	ctx, end = observability.Span(ctx, "workflow.RestartWorkflowNodeJob")
 	defer end()
 
+	wNodeJob.Job.Reason = "Killed (Reason: Timeout)\n"
+	return restartWorkflowNodeJob(ctx, db, wNodeJob, "Worker timeout: job replaced in queue", 0)
+}
+
+// RetryWorkflowNodeJob puts a failed job back in queue if its retry policy allows it for given failure cause.
+// It returns false if the job should not be retried.
+func RetryWorkflowNodeJob(ctx context.Context, db gorp.SqlExecutor, wNodeJob sdk.WorkflowNodeJobRun, cause string, exitCode int) (bool, error) {
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.RetryWorkflowNodeJob")
+	defer end()
+
+	policy := wNodeJob.Job.RetryPolicy
+	if policy == nil || !policy.ShouldRetry(wNodeJob.Retry, cause, exitCode) {
+		return false, nil
+	}
+
+	attempt := wNodeJob.Retry + 2
+	delay := policy.Delay(attempt)
+	reason := cause
+	switch cause {
+	case "":
+		reason = "failure"
+	case sdk.JobRetryOnExitCode:
+		reason = fmt.Sprintf("%s %d", cause, exitCode)
+	}
+
+	log.Info(ctx, "RetryWorkflowNodeJob> retrying job %d after %s: attempt %d/%d in %s", wNodeJob.ID, reason, attempt, policy.MaxAttempts, delay)
+
+	infos := []sdk.SpawnInfo{{
+		RemoteTime: time.Now(),
+		Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoJobRetry.ID, Args: []interface{}{reason, attempt, policy.MaxAttempts, delay.String()}},
+	}}
+	if err := AddSpawnInfosNodeJobRun(db, wNodeJob.ID, PrepareSpawnInfos(infos)); err != nil {
+		return false, sdk.WrapError(err, "cannot save spawn info job %d", wNodeJob.ID)
+	}
+
+	wNodeJob.Job.Reason = fmt.Sprintf("Retried (Reason: %s)\n", reason)
+	logMessage := fmt.Sprintf("Job failed (%s): attempt %d/%d replaced in queue", reason, attempt, policy.MaxAttempts)
+	if err := restartWorkflowNodeJob(ctx, db, wNodeJob, logMessage, delay); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func restartWorkflowNodeJob(ctx context.Context, db gorp.SqlExecutor, wNodeJob sdk.WorkflowNodeJobRun, logMessage string, delay time.Duration) error {
 	for iS := range wNodeJob.Job.StepStatus {
 		step := &wNodeJob.Job.StepStatus[iS]
 		if step.Status == sdk.StatusNeverBuilt || step.Status == sdk.StatusSkipped || step.Status == sdk.StatusDisabled {
@@ -630,13 +687,12 @@ func RestartWorkflowNodeJob(ctx context.Context, db gorp.SqlExecutor, wNodeJob s
 		if errL != nil {
 			return sdk.WrapError(errL, "RestartWorkflowNodeJob> error while load step logs")
 		}
-		wNodeJob.Job.Reason = "Killed (Reason: Timeout)\n"
 		step.Status = sdk.StatusWaiting
 		step.Done = time.Time{}
 		if l != nil { // log could be nil here
 			l.Done = nil
 			logbuf := bytes.NewBufferString(l.Val)
-			logbuf.WriteString("\n\n\n-=-=-=-=-=- Worker timeout: job replaced in queue -=-=-=-=-=-\n\n\n")
+			logbuf.WriteString("\n\n\n-=-=-=-=-=- " + logMessage + " -=-=-=-=-=-\n\n\n")
 			l.Val = logbuf.String()
 			if err := updateLog(db, l); err != nil {
 				return sdk.WrapError(errL, "RestartWorkflowNodeJob> error while update step log")
@@ -661,7 +717,7 @@ func RestartWorkflowNodeJob(ctx context.Context, db gorp.SqlExecutor, wNodeJob s
 		return sdk.WrapError(errU, "RestartWorkflowNodeJob> Cannot update node run")
 	}
 
-	if err := replaceWorkflowJobRunInQueue(db, wNodeJob); err != nil {
+	if err := replaceWorkflowJobRunInQueue(db, wNodeJob, delay); err != nil {
 		return sdk.WrapError(err, "Cannot replace workflow job in queue")
 	}
 
@@ -299,6 +299,13 @@ func executeNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 			return nil, sdk.WrapError(err, "Unable to delete node %d job runs ", nr.ID)
 		}
 
+		//Do we release a concurrency group ?
+		r1, err := releaseConcurrencyGroup(ctx, db, store, proj, updatedWorkflowRun.ProjectID, nr)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to release concurrency group %s", nr.ConcurrencyGroup)
+		}
+		report, _ = report.Merge(ctx, r1, nil)
+
 		var hasMutex bool
 		var nodeName string
 
@@ -438,7 +445,8 @@ jobLoop:
 
 		if previousStage != nil {
 			for _, rj := range previousStage.RunJobs {
-				if rj.Job.PipelineActionID == job.PipelineActionID && rj.Status != sdk.StatusFail && sdk.StatusIsTerminated(rj.Status) {
+				if rj.Job.PipelineActionID == job.PipelineActionID && rj.Job.MatrixKey() == job.MatrixKey() &&
+					rj.Status != sdk.StatusFail && sdk.StatusIsTerminated(rj.Status) {
 					stage.RunJobs = append(stage.RunJobs, rj)
 					continue jobLoop
 				}
@@ -811,6 +819,19 @@ func stopWorkflowNodePipeline(ctx context.Context, dbFunc func() *gorp.DbMap, st
 	if errU := UpdateNodeRun(tx, nodeRun); errU != nil {
 		return report, sdk.WrapError(errU, "stopWorkflowNodePipeline> Cannot update node run")
 	}
+
+	if nodeRun.ConcurrencyGroup != "" {
+		wr, err := LoadRunByID(tx, nodeRun.WorkflowRunID, LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return report, sdk.WrapError(err, "stopWorkflowNodePipeline> Cannot load workflow run")
+		}
+		r1, err := releaseConcurrencyGroup(ctx, tx, store, proj, wr.ProjectID, nodeRun)
+		if err != nil {
+			return report, sdk.WrapError(err, "stopWorkflowNodePipeline> Cannot release concurrency group")
+		}
+		_, _ = report.Merge(ctx, r1, nil)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, sdk.WrapError(err, "stopWorkflowNodePipeline> Cannot commit transaction")
 	}
@@ -23,6 +23,14 @@ type Coverage sdk.WorkflowNodeRunCoverage
 
 type dbNodeRunVulenrabilitiesReport sdk.WorkflowNodeRunVulnerabilityReport
 
+type dbNodeRunStaticAnalysisReport sdk.WorkflowNodeRunStaticAnalysisReport
+
+type dbNodeRunSBOM sdk.WorkflowNodeRunSBOM
+
+type dbArtifactProvenance sdk.WorkflowNodeRunArtifactProvenance
+
+type dbTestResult sdk.WorkflowTestResult
+
 // NodeRun is a gorp wrapper around sdk.WorkflowNodeRun
 type NodeRun struct {
 	WorkflowID             sql.NullInt64  `db:"workflow_id"`
@@ -58,6 +66,7 @@ type NodeRun struct {
 	HookExecutionTimestamp sql.NullInt64  `db:"hook_execution_timestamp"`
 	ExecutionID            sql.NullString `db:"execution_id"`
 	Callback               sql.NullString `db:"callback"`
+	ConcurrencyGroup       sql.NullString `db:"concurrency_group"`
 }
 
 // JobRun is a gorp wrapper around sdk.WorkflowNodeJobRun
@@ -175,6 +184,9 @@ func (j JobRun) WorkflowNodeRunJob() (sdk.WorkflowNodeJobRun, error) {
 // NodeRunArtifact is a gorp wrapper around sdk.WorkflowNodeRunArtifact
 type NodeRunArtifact sdk.WorkflowNodeRunArtifact
 
+// NodeRunArtifactBlob is a gorp wrapper around sdk.WorkflowNodeRunArtifactBlob
+type NodeRunArtifactBlob sdk.WorkflowNodeRunArtifactBlob
+
 // dbStaticFiles is a gorp wrapper around sdk.StaticFiles
 type dbStaticFiles sdk.StaticFiles
 
@@ -204,6 +216,7 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(NodeRun{}, "workflow_node_run", true, "id"))
 	gorpmapping.Register(gorpmapping.New(JobRun{}, "workflow_node_run_job", true, "id"))
 	gorpmapping.Register(gorpmapping.New(NodeRunArtifact{}, "workflow_node_run_artifacts", true, "id"))
+	gorpmapping.Register(gorpmapping.New(NodeRunArtifactBlob{}, "workflow_node_run_artifact_blob", true, "id"))
 	gorpmapping.Register(gorpmapping.New(RunTag{}, "workflow_run_tag", false, "workflow_run_id", "tag"))
 	gorpmapping.Register(gorpmapping.New(hookModel{}, "workflow_hook_model", true, "id"))
 	gorpmapping.Register(gorpmapping.New(outgoingHookModel{}, "workflow_outgoing_hook_model", true, "id"))
@@ -212,6 +225,10 @@ func init() {
 	gorpmapping.Register(gorpmapping.New(Coverage{}, "workflow_node_run_coverage", false, "workflow_id", "workflow_run_id", "workflow_node_run_id", "repository", "branch"))
 	gorpmapping.Register(gorpmapping.New(dbStaticFiles{}, "workflow_node_run_static_files", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbNodeRunVulenrabilitiesReport{}, "workflow_node_run_vulnerability", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbNodeRunStaticAnalysisReport{}, "workflow_node_run_static_analysis", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbNodeRunSBOM{}, "workflow_node_run_sbom", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbArtifactProvenance{}, "workflow_node_run_artifact_provenance", true, "id"))
+	gorpmapping.Register(gorpmapping.New(dbTestResult{}, "workflow_test_result", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbNodeData{}, "w_node", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbNodeHookData{}, "w_node_hook", true, "id"))
 	gorpmapping.Register(gorpmapping.New(dbNodeContextData{}, "w_node_context", true, "id"))
@@ -28,7 +28,25 @@ func manageDeadJob(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.S
 		}
 
 		if deadJob.Status == sdk.StatusBuilding {
-			if deadJob.Retry >= maxRetry {
+			stop := deadJob.Retry >= maxRetry
+			// A job with a retry policy on lost workers is only restarted according to this policy
+			if policy := deadJob.Job.RetryPolicy; policy != nil && (len(policy.On) == 0 || sdk.IsInArray(sdk.JobRetryOnWorkerLost, policy.On)) {
+				retried, err := RetryWorkflowNodeJob(ctx, tx, deadJob, sdk.JobRetryOnWorkerLost, 0)
+				if err != nil {
+					log.Warning(ctx, "manageDeadJob> Cannot retry node job run %d: %v", deadJob.ID, err)
+					_ = tx.Rollback()
+					continue
+				}
+				if retried {
+					if err := tx.Commit(); err != nil {
+						log.Error(ctx, "manageDeadJob> Cannot commit transaction : %v", err)
+					}
+					continue
+				}
+				stop = true
+			}
+
+			if stop {
 				if _, err := UpdateNodeJobRunStatus(ctx, tx, store, nil, &deadJob, sdk.StatusStopped); err != nil {
 					log.Error(ctx, "manageDeadJob> Cannot update node run job %d : %v", deadJob.ID, err)
 					_ = tx.Rollback()
@@ -39,7 +39,7 @@ func hookUnregistration(ctx context.Context, db gorp.SqlExecutor, store cache.St
 
 	// Delete from vcs configuration if needed
 	for _, h := range hookToDelete {
-		if h.HookModelName == sdk.RepositoryWebHookModelName {
+		if h.HookModelName == sdk.RepositoryWebHookModelName || h.HookModelName == sdk.MergeQueueHookModelName || h.HookModelName == sdk.PullRequestCommandModelName {
 			// Call VCS to know if repository allows webhook and get the configuration fields
 			projectVCSServer := repositoriesmanager.GetProjectVCSServer(p, h.Config["vcsServer"].Value)
 			if projectVCSServer != nil {
@@ -139,7 +139,13 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 			h.UUID = sdk.UUID()
 		}
 
-		if h.HookModelName == sdk.RepositoryWebHookModelName || h.HookModelName == sdk.GitPollerModelName || h.HookModelName == sdk.GerritHookModelName {
+		if h.HookModelName == sdk.SchedulerModelName {
+			if err := sdk.ValidateSchedulerHookConfig(h.Config); err != nil {
+				return err
+			}
+		}
+
+		if h.HookModelName == sdk.RepositoryWebHookModelName || h.HookModelName == sdk.GitPollerModelName || h.HookModelName == sdk.GerritHookModelName || h.HookModelName == sdk.MergeQueueHookModelName || h.HookModelName == sdk.PullRequestCommandModelName {
 			if wf.WorkflowData.Node.Context.ApplicationID == 0 || wf.Applications[wf.WorkflowData.Node.Context.ApplicationID].RepositoryFullname == "" || wf.Applications[wf.WorkflowData.Node.Context.ApplicationID].VCSServer == "" {
 				return sdk.NewErrorFrom(sdk.ErrForbidden, "cannot create a git poller or repository webhook on an application without a repository")
 			}
@@ -179,7 +185,7 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 				continue
 			}
 			v, ok := h.Config[sdk.HookConfigWebHookID]
-			if h.HookModelName == sdk.RepositoryWebHookModelName && h.Config["vcsServer"].Value != "" {
+			if (h.HookModelName == sdk.RepositoryWebHookModelName || h.HookModelName == sdk.MergeQueueHookModelName || h.HookModelName == sdk.PullRequestCommandModelName) && h.Config["vcsServer"].Value != "" {
 				if !ok || v.Value == "" {
 					if err := createVCSConfiguration(ctx, db, store, p, h); err != nil {
 						return sdk.WrapError(err, "Cannot create vcs configuration")
@@ -191,6 +197,8 @@ func hookRegistration(ctx context.Context, db gorp.SqlExecutor, store cache.Stor
 					}
 				}
 			}
+			// The secret of the webhook is only known by the hooks µService and the repository manager
+			delete(h.Config, sdk.HookConfigWebHookSecret)
 		}
 	}
 
@@ -297,8 +305,21 @@ func createVCSConfiguration(ctx context.Context, db gorp.SqlExecutor, store cach
 		return sdk.WrapError(sdk.ErrInvalidHookConfiguration, "wrong webHookURL value (project: %s, repository: %s)", p.Key, h.Config["repoFullName"].Value)
 	}
 
-	// If empty, take the first event
 	var valueSplitted = strings.Split(h.Config[sdk.HookConfigEventFilter].Value, ";")
+	// If empty, take all the pull request events for a merge queue
+	if valueSplitted[0] == "" && h.HookModelName == sdk.MergeQueueHookModelName {
+		if events := sdk.MergeQueueEvents(webHookInfo.Events); len(events) > 0 {
+			valueSplitted = events
+		}
+	}
+	// If empty, take all the pull request comment events for pull request commands
+	if valueSplitted[0] == "" && h.HookModelName == sdk.PullRequestCommandModelName {
+		if events := sdk.PullRequestCommandEvents(webHookInfo.Events); len(events) > 0 {
+			valueSplitted = events
+		}
+	}
+
+	// If empty, take the first event
 	if valueSplitted[0] == "" && webHookInfo.Events != nil {
 		h.Config[sdk.HookConfigEventFilter] = sdk.WorkflowNodeHookConfigValue{
 			Value:        webHookInfo.Events[0],
@@ -315,6 +336,7 @@ func createVCSConfiguration(ctx context.Context, db gorp.SqlExecutor, store cach
 		URL:      h.Config["webHookURL"].Value,
 		Events:   valueSplitted,
 		Workflow: true,
+		Secret:   h.Config[sdk.HookConfigWebHookSecret].Value,
 	}
 	if err := client.CreateHook(ctx, h.Config["repoFullName"].Value, &vcsHook); err != nil {
 		return sdk.WrapError(err, "Cannot create hook on repository: %+v", vcsHook)
@@ -367,6 +389,7 @@ func updateVCSConfiguration(ctx context.Context, db gorp.SqlExecutor, store cach
 		URL:      h.Config["webHookURL"].Value,
 		Events:   valueSlitted,
 		Workflow: true,
+		Secret:   h.Config[sdk.HookConfigWebHookSecret].Value,
 	}
 	if err := client.UpdateHook(ctx, h.Config["repoFullName"].Value, &vcsHook); err != nil {
 		return sdk.WrapError(err, "Cannot update hook on repository: %+v", vcsHook)
@@ -46,7 +46,7 @@ func checkCondition(ctx context.Context, wr *sdk.WorkflowRun, conditions sdk.Wor
 	var conditionsOK bool
 	var errc error
 	if conditions.LuaScript == "" {
-		conditionsOK, errc = sdk.WorkflowCheckConditions(conditions.PlainConditions, params)
+		conditionsOK, errc = sdk.WorkflowCheckPlainConditionsAndExpression(conditions, params)
 	} else {
 		luacheck, err := luascript.NewCheck()
 		if err != nil {
@@ -112,7 +112,7 @@ func computeRunStatus(status string, counter *statusCounter) {
 	switch status {
 	case sdk.StatusSuccess:
 		counter.success++
-	case sdk.StatusBuilding, sdk.StatusWaiting:
+	case sdk.StatusBuilding, sdk.StatusWaiting, sdk.StatusWaitingConcurrency:
 		counter.building++
 	case sdk.StatusFail:
 		counter.failed++
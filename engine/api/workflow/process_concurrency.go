@@ -0,0 +1,267 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/interpolate"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// computeNodeRunConcurrencyGroup interpolates the concurrency group of the node with the node run build parameters.
+func computeNodeRunConcurrencyGroup(n *sdk.Node, nr *sdk.WorkflowNodeRun) error {
+	if n.Context == nil || n.Context.Concurrency == nil {
+		return nil
+	}
+	group, err := interpolate.Do(n.Context.Concurrency.Group, sdk.ParametersToMap(nr.BuildParameters))
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unable to interpolate concurrency group %q: %v", n.Context.Concurrency.Group, err)
+	}
+	nr.ConcurrencyGroup = strings.TrimSpace(group)
+	return nil
+}
+
+// countNodeRunConcurrencyHolders returns the number of node runs of the project that prevent the given node run
+// to be executed: the running ones of the same concurrency group and the older ones waiting for the group.
+func countNodeRunConcurrencyHolders(db gorp.SqlExecutor, projectID int64, nr *sdk.WorkflowNodeRun) (int64, error) {
+	query := `select count(1)
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	where workflow_run.project_id = $1
+	and workflow_node_run.concurrency_group = $2
+	and workflow_node_run.id <> $3
+	and (
+		workflow_node_run.status = ANY(string_to_array($4, ','))
+		or
+		(workflow_node_run.id < $3 and workflow_node_run.status = $5)
+	)`
+	n, err := db.SelectInt(query, projectID, nr.ConcurrencyGroup, nr.ID,
+		strings.Join([]string{sdk.StatusWaiting, sdk.StatusBuilding}, ","), sdk.StatusWaitingConcurrency)
+	return n, sdk.WrapError(err, "unable to count concurrency holders for group %s", nr.ConcurrencyGroup)
+}
+
+// processNodeRunConcurrency checks if the node run can be executed or if it should wait for its concurrency group.
+// If the node cancels the runs in progress, all the previous node runs of the group on the same branch are stopped.
+func processNodeRunConcurrency(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, wr *sdk.WorkflowRun,
+	n *sdk.Node, nr *sdk.WorkflowNodeRun) (*ProcessorReport, bool, error) {
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.processNodeRunConcurrency")
+	defer end()
+
+	report := new(ProcessorReport)
+
+	if n.Context.Concurrency.CancelInProgress && nr.VCSBranch != "" {
+		query := `select workflow_node_run.id
+		from workflow_node_run
+		join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+		where workflow_run.project_id = $1
+		and workflow_node_run.concurrency_group = $2
+		and workflow_node_run.vcs_branch = $3
+		and workflow_node_run.id < $4
+		and workflow_node_run.status = ANY(string_to_array($5, ','))
+		order by workflow_node_run.id`
+		var ids []int64
+		if _, err := db.Select(&ids, query, wr.ProjectID, nr.ConcurrencyGroup, nr.VCSBranch, nr.ID,
+			strings.Join([]string{sdk.StatusWaiting, sdk.StatusBuilding, sdk.StatusWaitingConcurrency}, ",")); err != nil {
+			return nil, false, sdk.WrapError(err, "unable to load node runs in progress for concurrency group %s", nr.ConcurrencyGroup)
+		}
+
+		for _, id := range ids {
+			r1, err := cancelNodeRunForConcurrency(ctx, db, store, proj, id, nr)
+			if err != nil {
+				return nil, false, err
+			}
+			_, _ = report.Merge(ctx, r1, nil)
+		}
+	}
+
+	nbHolders, err := countNodeRunConcurrencyHolders(db, wr.ProjectID, nr)
+	if err != nil {
+		return nil, false, err
+	}
+	if nbHolders == 0 {
+		return report, true, nil
+	}
+
+	log.Debug("Noderun %s processed but not executed because of concurrency group %s", nr.WorkflowNodeName, nr.ConcurrencyGroup)
+	nr.Status = sdk.StatusWaitingConcurrency
+	if err := updateNodeRunStatusAndStage(db, nr); err != nil {
+		return nil, false, sdk.WrapError(err, "unable to update node run %d status", nr.ID)
+	}
+	report.Add(ctx, *nr)
+
+	nodeRuns := wr.WorkflowNodeRuns[nr.WorkflowNodeID]
+	for i := range nodeRuns {
+		if nodeRuns[i].ID == nr.ID {
+			nodeRuns[i].Status = nr.Status
+		}
+	}
+
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeConcurrency.ID,
+		Args: []interface{}{nr.WorkflowNodeName, nr.ConcurrencyGroup},
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, false, sdk.WrapError(err, "unable to update workflow run")
+	}
+
+	return report, false, nil
+}
+
+// cancelNodeRunForConcurrency stops a node run in progress because a newer one of the same concurrency group started.
+func cancelNodeRunForConcurrency(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, nodeRunID int64, newer *sdk.WorkflowNodeRun) (*ProcessorReport, error) {
+	report := new(ProcessorReport)
+
+	nodeRun, err := LoadAndLockNodeRunByID(ctx, db, nodeRunID)
+	if err != nil {
+		if sdk.Cause(err) == sql.ErrNoRows {
+			return report, nil
+		}
+		return nil, sdk.WrapError(err, "unable to load node run %d", nodeRunID)
+	}
+
+	spawnMsg := sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeConcurrencyCancel.ID,
+		Args: []interface{}{nodeRun.WorkflowNodeName, newer.Number, newer.VCSBranch, newer.ConcurrencyGroup},
+	}
+	stopInfos := sdk.SpawnInfo{
+		APITime:    time.Now(),
+		RemoteTime: time.Now(),
+		Message:    spawnMsg,
+	}
+
+	ids, err := LoadNodeJobRunIDByNodeRunID(db, nodeRun.ID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load node job run ids for node run %d", nodeRun.ID)
+	}
+	for _, id := range ids {
+		njr, err := LoadAndLockNodeJobRunWait(ctx, db, store, id)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to load node job run %d", id)
+		}
+		if err := AddSpawnInfosNodeJobRun(db, njr.ID, []sdk.SpawnInfo{stopInfos}); err != nil {
+			return nil, sdk.WrapError(err, "cannot save spawn info job %d", njr.ID)
+		}
+		r1, err := UpdateNodeJobRunStatus(ctx, db, store, proj, njr, sdk.StatusStopped)
+		if _, err := report.Merge(ctx, r1, err); err != nil {
+			return nil, sdk.WrapError(err, "unable to stop node job run %d", njr.ID)
+		}
+	}
+
+	stopWorkflowNodeRunStages(ctx, db, nodeRun)
+	nodeRun.Status = sdk.StatusStopped
+	nodeRun.Done = time.Now()
+	if err := UpdateNodeRun(db, nodeRun); err != nil {
+		return nil, sdk.WrapError(err, "unable to update node run %d", nodeRun.ID)
+	}
+	report.Add(ctx, *nodeRun)
+
+	wr, err := LoadRunByID(db, nodeRun.WorkflowRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow run %d", nodeRun.WorkflowRunID)
+	}
+	AddWorkflowRunInfo(wr, false, spawnMsg)
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run %d", wr.ID)
+	}
+	r1, err := ResyncWorkflowRunStatus(ctx, db, wr)
+	return report.Merge(ctx, r1, err)
+}
+
+// releaseConcurrencyGroup executes the oldest node run waiting for the concurrency group of the given terminated node run.
+func releaseConcurrencyGroup(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, projectID int64, nr *sdk.WorkflowNodeRun) (*ProcessorReport, error) {
+	if nr.ConcurrencyGroup == "" {
+		return nil, nil
+	}
+
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.releaseConcurrencyGroup")
+	defer end()
+
+	query := `select workflow_node_run.id
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	where workflow_run.project_id = $1
+	and workflow_node_run.concurrency_group = $2
+	and workflow_node_run.status = $3
+	order by workflow_node_run.id asc
+	limit 1`
+	waitingRunID, err := db.SelectInt(query, projectID, nr.ConcurrencyGroup, sdk.StatusWaitingConcurrency)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, sdk.WrapError(err, "unable to load node run waiting for concurrency group %s", nr.ConcurrencyGroup)
+	}
+	if waitingRunID == 0 {
+		return nil, nil
+	}
+
+	waitingRun, err := LoadNodeRunByID(db, waitingRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load node run %d", waitingRunID)
+	}
+
+	// Another node run of the group may still be running
+	nbHolders, err := countNodeRunConcurrencyHolders(db, projectID, waitingRun)
+	if err != nil {
+		return nil, err
+	}
+	if nbHolders > 0 {
+		return nil, nil
+	}
+
+	workflowRun, err := LoadRunByID(db, waitingRun.WorkflowRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow run %d", waitingRun.WorkflowRunID)
+	}
+	AddWorkflowRunInfo(workflowRun, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeConcurrencyRelease.ID,
+		Args: []interface{}{waitingRun.WorkflowNodeName, waitingRun.ConcurrencyGroup},
+	})
+	if err := UpdateWorkflowRun(ctx, db, workflowRun); err != nil {
+		return nil, sdk.WrapError(err, "unable to update workflow run %d after concurrency group release", workflowRun.ID)
+	}
+
+	log.Debug("workflow.execute> process the node run %d because concurrency group %s has been released", waitingRun.ID, waitingRun.ConcurrencyGroup)
+	waitingRun.Status = sdk.StatusWaiting
+	return executeNodeRun(ctx, db, store, proj, waitingRun)
+}
+
+// ConcurrencyGroup identifies a concurrency group of a project.
+type ConcurrencyGroup struct {
+	ProjectID int64  `db:"project_id"`
+	Name      string `db:"concurrency_group"`
+}
+
+// LoadReleasableConcurrencyGroups returns the concurrency groups that have node runs waiting for them
+// while no node run of the group is running anymore.
+func LoadReleasableConcurrencyGroups(db gorp.SqlExecutor) ([]ConcurrencyGroup, error) {
+	query := `select distinct workflow_run.project_id, workflow_node_run.concurrency_group
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	where workflow_node_run.status = $1
+	and not exists (
+		select 1
+		from workflow_node_run holder
+		join workflow_run holder_run on holder_run.id = holder.workflow_run_id
+		where holder_run.project_id = workflow_run.project_id
+		and holder.concurrency_group = workflow_node_run.concurrency_group
+		and holder.status = ANY(string_to_array($2, ','))
+	)`
+	var groups []ConcurrencyGroup
+	if _, err := db.Select(&groups, query, sdk.StatusWaitingConcurrency,
+		strings.Join([]string{sdk.StatusWaiting, sdk.StatusBuilding}, ",")); err != nil {
+		return nil, sdk.WrapError(err, "unable to load releasable concurrency groups")
+	}
+	return groups, nil
+}
+
+// ReleaseConcurrencyGroup executes the oldest node run waiting for the given concurrency group if the group is free.
+func ReleaseConcurrencyGroup(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, group string) (*ProcessorReport, error) {
+	return releaseConcurrencyGroup(ctx, db, store, proj, proj.ID, &sdk.WorkflowNodeRun{ConcurrencyGroup: group})
+}
@@ -75,7 +75,7 @@ func processNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store,
 		n := wr.Workflow.WorkflowData.NodeByID(parentNodeRuns[0].WorkflowNodeID)
 		// If fork or JOIN and No run conditions
 		if (n.Type == sdk.NodeTypeJoin || n.Type == sdk.NodeTypeFork) &&
-			(n.Context == nil || (n.Context.Conditions.LuaScript == "" && len(n.Context.Conditions.PlainConditions) == 0)) {
+			(n.Context == nil || n.Context.Conditions.IsEmpty()) {
 			manual = parentNodeRuns[0].Manual
 		}
 	}
@@ -305,6 +305,15 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		}
 	}
 
+	// Compute the concurrency group before inserting the node run
+	if err := computeNodeRunConcurrencyGroup(n, nr); err != nil {
+		AddWorkflowRunInfo(wr, true, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowError.ID,
+			Args: []interface{}{sdk.Cause(err).Error()},
+		})
+		return nil, false, sdk.WrapError(err, "unable to compute concurrency group for node %s", n.Name)
+	}
+
 	if err := insertWorkflowNodeRun(db, nr); err != nil {
 		return nil, false, sdk.WrapError(err, "unable to insert run (node id : %d, node name : %s, subnumber : %d)", nr.WorkflowNodeID, nr.WorkflowNodeName, nr.SubNumber)
 	}
@@ -380,6 +389,19 @@ func processNode(ctx context.Context, db gorp.SqlExecutor, store cache.Store, pr
 		//Mutex is free, continue
 	}
 
+	//Check the context.concurrency to know if the concurrency group is free
+	if n.Context.Concurrency != nil && nr.ConcurrencyGroup != "" {
+		r1, canRun, err := processNodeRunConcurrency(ctx, db, store, proj, wr, n, nr)
+		if err != nil {
+			return nil, false, sdk.WrapError(err, "unable to check concurrency group")
+		}
+		_, _ = report.Merge(ctx, r1, nil)
+		if !canRun {
+			// The node run will be executed when the concurrency group will be released
+			return report, true, nil
+		}
+	}
+
 	//Execute the node run !
 	r1, err := executeNodeRun(ctx, db, store, proj, nr)
 	if err != nil {
@@ -412,6 +434,14 @@ func createWorkflowNodeRun(wr *sdk.WorkflowRun, n *sdk.Node, parents []*sdk.Work
 		pip = wr.Workflow.Pipelines[n.Context.PipelineID]
 		stages = make([]sdk.Stage, len(pip.Stages))
 		copy(stages, pip.Stages)
+		// Expand jobs with a matrix, one job will be run for each combination
+		for i := range stages {
+			jobs := make([]sdk.Job, 0, len(stages[i].Jobs))
+			for _, j := range stages[i].Jobs {
+				jobs = append(jobs, j.ExpandMatrix()...)
+			}
+			stages[i].Jobs = jobs
+		}
 	}
 
 	// CREATE RUN
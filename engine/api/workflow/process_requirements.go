@@ -22,6 +22,9 @@ func processNodeJobRunRequirements(ctx context.Context, db gorp.SqlExecutor, j s
 	var containsService bool
 	var model string
 	var tmp = sdk.ParametersToMap(run.BuildParameters)
+	for k, v := range j.MatrixValues {
+		tmp["cds.matrix."+k] = v
+	}
 
 	pluginsRequirements := []sdk.Requirement{}
 	for i := range integrationPluginBinaries {
@@ -0,0 +1,281 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"go.opencensus.io/stats"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// retentionRun is a workflow run candidate to the purge.
+type retentionRun struct {
+	ID                  int64     `db:"id"`
+	Number              int64     `db:"num"`
+	Status              string    `db:"status"`
+	LastModified        time.Time `db:"last_modified"`
+	Branch              string    `db:"branch"`
+	Tags                []string  `db:"-"`
+	SuccessEnvironments []string  `db:"-"`
+}
+
+func (r retentionRun) hasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// computeRetention applies the retention policy on the runs of a workflow sorted from the newest to the oldest.
+// The deleted branches rule is ignored if the existing branches of the repository are unknown.
+func computeRetention(policy sdk.WorkflowRetentionPolicy, historyLength int64, runs []retentionRun, branches map[string]struct{}, now time.Time) []sdk.WorkflowRetentionResult {
+	results := make([]sdk.WorkflowRetentionResult, 0, len(runs))
+	runsByBranch := make(map[string]int64)
+	successEnvironments := make(map[string]struct{})
+	var nbRuns int64
+
+	for _, r := range runs {
+		res := sdk.WorkflowRetentionResult{
+			ID:     r.ID,
+			Number: r.Number,
+			Branch: r.Branch,
+			Status: r.Status,
+		}
+
+		// Runs in progress are always kept and don't count in the history
+		if !sdk.StatusIsTerminated(r.Status) || r.Status == sdk.StatusChecking || r.Status == sdk.StatusPending {
+			res.Keep, res.Reason = true, "run in progress"
+			results = append(results, res)
+			continue
+		}
+
+		nbRuns++
+		runsByBranch[r.Branch]++
+		var lastSuccessEnvironments []string
+		for _, env := range r.SuccessEnvironments {
+			if _, has := successEnvironments[env]; !has {
+				successEnvironments[env] = struct{}{}
+				lastSuccessEnvironments = append(lastSuccessEnvironments, env)
+			}
+		}
+
+		res.Keep, res.Reason = func() (bool, string) {
+			for _, t := range policy.KeepTags {
+				if r.hasTag(t) {
+					return true, fmt.Sprintf("tagged with %s", t)
+				}
+			}
+			if policy.KeepLastSuccessPerEnvironment && len(lastSuccessEnvironments) > 0 {
+				return true, fmt.Sprintf("last success on environment %s", strings.Join(lastSuccessEnvironments, ", "))
+			}
+			if branches != nil && r.Branch != "" && policy.DeletedBranchRetentionDays > 0 {
+				if _, has := branches[r.Branch]; !has && now.Sub(r.LastModified) > time.Duration(policy.DeletedBranchRetentionDays)*24*time.Hour {
+					return false, fmt.Sprintf("branch %s deleted and no activity for %d days", r.Branch, policy.DeletedBranchRetentionDays)
+				}
+			}
+			if policy.KeepPerBranch > 0 {
+				if runsByBranch[r.Branch] <= policy.KeepPerBranch {
+					return true, fmt.Sprintf("last %d runs of branch %s", policy.KeepPerBranch, r.Branch)
+				}
+				return false, fmt.Sprintf("more than %d runs on branch %s", policy.KeepPerBranch, r.Branch)
+			}
+			if historyLength <= 0 {
+				return true, "no history limit"
+			}
+			if nbRuns <= historyLength {
+				return true, fmt.Sprintf("last %d runs", historyLength)
+			}
+			return false, fmt.Sprintf("more than %d runs", historyLength)
+		}()
+
+		results = append(results, res)
+	}
+
+	return results
+}
+
+// loadRetentionRuns loads the runs of the workflow not already marked to delete, from the newest to the oldest.
+func loadRetentionRuns(db gorp.SqlExecutor, wf sdk.Workflow) ([]retentionRun, error) {
+	var runs []retentionRun
+	query := `
+		SELECT workflow_run.id, workflow_run.num, workflow_run.status, workflow_run.last_modified,
+			coalesce((
+				SELECT workflow_run_tag.value FROM workflow_run_tag
+				WHERE workflow_run_tag.workflow_run_id = workflow_run.id AND workflow_run_tag.tag = $2
+				LIMIT 1
+			), '') AS branch
+		FROM workflow_run
+		WHERE workflow_run.workflow_id = $1 AND workflow_run.to_delete = false
+		ORDER BY workflow_run.id DESC`
+	if _, err := db.Select(&runs, query, wf.ID, tagGitBranch); err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow runs for workflow %d", wf.ID)
+	}
+
+	runsByID := make(map[int64]*retentionRun, len(runs))
+	for i := range runs {
+		runsByID[runs[i].ID] = &runs[i]
+	}
+
+	policy := wf.RetentionPolicy
+	if len(policy.KeepTags) > 0 {
+		var tags []struct {
+			WorkflowRunID int64  `db:"workflow_run_id"`
+			Tag           string `db:"tag"`
+		}
+		query := `
+			SELECT workflow_run_tag.workflow_run_id, workflow_run_tag.tag
+			FROM workflow_run_tag
+			JOIN workflow_run ON workflow_run.id = workflow_run_tag.workflow_run_id
+			WHERE workflow_run.workflow_id = $1 AND workflow_run.to_delete = false
+			AND workflow_run_tag.tag = ANY(string_to_array($2, ',')::text[])
+			AND workflow_run_tag.value <> ''`
+		if _, err := db.Select(&tags, query, wf.ID, strings.Join(policy.KeepTags, ",")); err != nil {
+			return nil, sdk.WrapError(err, "unable to load workflow run tags for workflow %d", wf.ID)
+		}
+		for _, t := range tags {
+			if r, has := runsByID[t.WorkflowRunID]; has {
+				r.Tags = append(r.Tags, t.Tag)
+			}
+		}
+	}
+
+	if policy.KeepLastSuccessPerEnvironment && wf.WorkflowData != nil {
+		nodeEnvironments := make(map[string]string)
+		for _, n := range wf.WorkflowData.Array() {
+			if n.Context == nil || n.Context.EnvironmentID == 0 {
+				continue
+			}
+			if env, has := wf.Environments[n.Context.EnvironmentID]; has {
+				nodeEnvironments[n.Name] = env.Name
+			}
+		}
+
+		if len(nodeEnvironments) > 0 {
+			var nodeRuns []struct {
+				WorkflowRunID    int64  `db:"workflow_run_id"`
+				WorkflowNodeName string `db:"workflow_node_name"`
+			}
+			query := `
+				SELECT DISTINCT workflow_node_run.workflow_run_id, workflow_node_run.workflow_node_name
+				FROM workflow_node_run
+				JOIN workflow_run ON workflow_run.id = workflow_node_run.workflow_run_id
+				WHERE workflow_run.workflow_id = $1 AND workflow_run.to_delete = false
+				AND workflow_node_run.status = $2`
+			if _, err := db.Select(&nodeRuns, query, wf.ID, sdk.StatusSuccess); err != nil {
+				return nil, sdk.WrapError(err, "unable to load successful node runs for workflow %d", wf.ID)
+			}
+			for _, nr := range nodeRuns {
+				env, has := nodeEnvironments[nr.WorkflowNodeName]
+				if !has {
+					continue
+				}
+				if r, has := runsByID[nr.WorkflowRunID]; has {
+					r.SuccessEnvironments = append(r.SuccessEnvironments, env)
+				}
+			}
+		}
+	}
+
+	return runs, nil
+}
+
+// loadRetentionBranches returns the existing branches of the workflow root application repository.
+func loadRetentionBranches(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wf sdk.Workflow) (map[string]struct{}, error) {
+	if wf.WorkflowData == nil || wf.WorkflowData.Node.Context == nil || wf.WorkflowData.Node.Context.ApplicationID == 0 {
+		return nil, nil
+	}
+	app, has := wf.Applications[wf.WorkflowData.Node.Context.ApplicationID]
+	if !has || app.VCSServer == "" || app.RepositoryFullname == "" {
+		return nil, nil
+	}
+
+	vcsServer := repositoriesmanager.GetProjectVCSServer(&proj, app.VCSServer)
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, vcsServer)
+	if err != nil {
+		return nil, sdk.NewErrorWithStack(err, sdk.ErrNoReposManagerClientAuth)
+	}
+	vcsBranches, err := client.Branches(ctx, app.RepositoryFullname)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to list branches of %s", app.RepositoryFullname)
+	}
+
+	branches := make(map[string]struct{}, len(vcsBranches))
+	for _, b := range vcsBranches {
+		branches[b.DisplayID] = struct{}{}
+	}
+	return branches, nil
+}
+
+// RetentionDryRun returns the decision of the workflow retention policy for each run, without purging anything.
+func RetentionDryRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wf sdk.Workflow) ([]sdk.WorkflowRetentionResult, error) {
+	if wf.RetentionPolicy == nil {
+		wf.RetentionPolicy = &sdk.WorkflowRetentionPolicy{}
+	}
+
+	runs, err := loadRetentionRuns(db, wf)
+	if err != nil {
+		return nil, err
+	}
+
+	var branches map[string]struct{}
+	if wf.RetentionPolicy.DeletedBranchRetentionDays > 0 {
+		branches, err = loadRetentionBranches(ctx, db, store, proj, wf)
+		if err != nil {
+			log.Warning(ctx, "RetentionDryRun> unable to load branches for workflow %s: %v", wf.Name, err)
+		}
+	}
+
+	return computeRetention(*wf.RetentionPolicy, wf.HistoryLength, runs, branches, time.Now()), nil
+}
+
+// PurgeWorkflowRunWithRetention mark the workflow runs to delete according to the workflow retention policy.
+func PurgeWorkflowRunWithRetention(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, wf sdk.Workflow, workflowRunsMarkToDelete *stats.Int64Measure) error {
+	if wf.RetentionPolicy == nil {
+		return PurgeWorkflowRun(ctx, db, wf, workflowRunsMarkToDelete)
+	}
+
+	results, err := RetentionDryRun(ctx, db, store, proj, wf)
+	if err != nil {
+		return err
+	}
+
+	var ids []int64
+	for _, r := range results {
+		if !r.Keep {
+			ids = append(ids, r.ID)
+		}
+	}
+	// Don't mark as to_delete more than 100 workflow_runs
+	if len(ids) > 100 {
+		ids = ids[len(ids)-100:]
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	idsStr := make([]string, len(ids))
+	for i := range ids {
+		idsStr[i] = fmt.Sprintf("%d", ids[i])
+	}
+	res, err := db.Exec("UPDATE workflow_run SET to_delete = true WHERE workflow_run.id = ANY(string_to_array($1, ',')::bigint[])", strings.Join(idsStr, ","))
+	if err != nil {
+		return sdk.WrapError(err, "unable to mark workflow runs to delete for workflow %d", wf.ID)
+	}
+
+	n, _ := res.RowsAffected()
+	log.Debug("PurgeWorkflowRunWithRetention> %d runs marked to delete for workflow %d", n, wf.ID)
+	if workflowRunsMarkToDelete != nil {
+		observability.Record(ctx, workflowRunsMarkToDelete, n)
+	}
+	return nil
+}
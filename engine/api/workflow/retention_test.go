@@ -0,0 +1,64 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_computeRetention(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-10 * 24 * time.Hour)
+
+	runs := []retentionRun{
+		{ID: 9, Number: 9, Status: sdk.StatusBuilding, Branch: "master", LastModified: now},
+		{ID: 8, Number: 8, Status: sdk.StatusFail, Branch: "master", LastModified: now},
+		{ID: 7, Number: 7, Status: sdk.StatusSuccess, Branch: "feat/a", LastModified: now},
+		{ID: 6, Number: 6, Status: sdk.StatusSuccess, Branch: "master", LastModified: now, SuccessEnvironments: []string{"prod"}},
+		{ID: 5, Number: 5, Status: sdk.StatusSuccess, Branch: "feat/deleted", LastModified: old},
+		{ID: 4, Number: 4, Status: sdk.StatusSuccess, Branch: "feat/recent", LastModified: now},
+		{ID: 3, Number: 3, Status: sdk.StatusSuccess, Branch: "master", LastModified: old, Tags: []string{"git.tag"}},
+		{ID: 2, Number: 2, Status: sdk.StatusSuccess, Branch: "master", LastModified: old, SuccessEnvironments: []string{"prod", "preprod"}},
+		{ID: 1, Number: 1, Status: sdk.StatusSuccess, Branch: "master", LastModified: old, SuccessEnvironments: []string{"prod"}},
+	}
+	branches := map[string]struct{}{"master": {}, "feat/a": {}}
+
+	policy := sdk.WorkflowRetentionPolicy{
+		KeepPerBranch:                 1,
+		KeepTags:                      []string{"git.tag"},
+		DeletedBranchRetentionDays:    7,
+		KeepLastSuccessPerEnvironment: true,
+	}
+
+	results := computeRetention(policy, 20, runs, branches, now)
+	keep := make(map[int64]bool, len(results))
+	for _, r := range results {
+		keep[r.Number] = r.Keep
+	}
+	assert.Equal(t, map[int64]bool{
+		9: true,  // in progress
+		8: true,  // last run of master
+		7: true,  // last run of feat/a
+		6: true,  // last success on prod
+		5: false, // deleted branch for more than 7 days
+		4: true,  // deleted branch but recent activity
+		3: true,  // tagged
+		2: true,  // last success on preprod
+		1: false, // more than 1 run on master
+	}, keep)
+
+	// Without policy the history length is used
+	results = computeRetention(sdk.WorkflowRetentionPolicy{}, 3, runs, nil, now)
+	var nbKept int
+	for _, r := range results {
+		if r.Keep {
+			nbKept++
+		}
+	}
+	assert.Equal(t, 4, nbKept)
+	assert.False(t, results[4].Keep)
+	assert.Equal(t, "more than 3 runs", results[4].Reason)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
+	"github.com/ovh/cds/sdk/expression"
 	"github.com/ovh/cds/sdk/log"
 )
 
@@ -53,9 +54,33 @@ func Parse(ctx context.Context, proj *sdk.Project, ew *exportentities.Workflow)
 	w.ProjectID = proj.ID
 	w.ProjectKey = proj.Key
 
+	if err := checkConditionsExpressions(w); err != nil {
+		return nil, err
+	}
+
 	return w, nil
 }
 
+// checkConditionsExpressions parses all conditions expressions on nodes and hooks to report syntax errors when the workflow is imported or saved
+func checkConditionsExpressions(w *sdk.Workflow) error {
+	for _, n := range w.WorkflowData.Array() {
+		if n.Context != nil && n.Context.Conditions.Expression != "" {
+			if _, err := expression.Parse(n.Context.Conditions.Expression); err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid condition expression on node %s: %v", n.Name, err)
+			}
+		}
+		for _, h := range n.Hooks {
+			if h.Conditions.Expression == "" {
+				continue
+			}
+			if _, err := expression.Parse(h.Conditions.Expression); err != nil {
+				return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid condition expression on hook %s of node %s: %v", h.HookModelName, n.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // ParseAndImport parse an exportentities.workflow and insert or update the workflow in database
 func ParseAndImport(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, oldW *sdk.Workflow, ew *exportentities.Workflow, u sdk.Identifiable, opts ImportOptions) (*sdk.Workflow, []sdk.Message, error) {
 	ctx, end := observability.Span(ctx, "workflow.ParseAndImport")
@@ -269,3 +269,33 @@ func TestParseAndImportFromRepository(t *testing.T) {
 
 	assert.Equal(t, w.FromRepository, "foo/myrepo")
 }
+
+func TestParseConditionsExpression(t *testing.T) {
+	proj := &sdk.Project{Key: sdk.RandomString(10)}
+
+	input := &exportentities.Workflow{
+		Name: sdk.RandomString(10),
+		Workflow: map[string]exportentities.NodeEntry{
+			"root": {
+				PipelineName: "build",
+			},
+			"deploy": {
+				PipelineName: "deploy",
+				DependsOn:    []string{"root"},
+				Conditions: &exportentities.ConditionEntry{
+					Expression: `git.branch == "master" && (cds.version > 9 || git.tag =~ "^v")`,
+				},
+			},
+		},
+	}
+	w, err := workflow.Parse(context.TODO(), proj, input)
+	require.NoError(t, err)
+	deploy := w.WorkflowData.NodeByName("deploy")
+	require.NotNil(t, deploy)
+	assert.Equal(t, input.Workflow["deploy"].Conditions.Expression, deploy.Context.Conditions.Expression)
+
+	input.Workflow["deploy"].Conditions.Expression = `git.branch == "master" && (cds.version > 9`
+	_, err = workflow.Parse(context.TODO(), proj, input)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid condition expression on node deploy: syntax error at line 1, column 43")
+}
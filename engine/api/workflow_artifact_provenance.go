@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/keys"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) postWorkflowJobArtifactProvenanceHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return sdk.WrapError(err, "invalid id")
+		}
+		tag, err := base64.RawURLEncoding.DecodeString(mux.Vars(r)["ref"])
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.ErrWrongRequest)
+		}
+
+		var art sdk.WorkflowNodeRunArtifact
+		if err := service.UnmarshalBody(r, &art); err != nil {
+			return err
+		}
+
+		jobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node job run %d", id)
+		}
+		nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), jobRun.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node run")
+		}
+
+		uploaded, err := workflow.LoadArtifactByNodeRunAndName(api.mustDB(), nodeRun.ID, string(tag), art.Name)
+		if err != nil {
+			return err
+		}
+		// The digest of the provenance must be the checksum computed by the API on the stored content
+		if !sdk.IsValidSHA512sum(uploaded.SHA512sum) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "artifact %s has no verified checksum", uploaded.Name)
+		}
+
+		// The key is created outside of any transaction, a concurrent creation would abort it
+		key, err := project.LoadProvenanceKey(api.mustDB(), jobRun.ProjectID)
+		if err != nil {
+			return err
+		}
+
+		statement := workflow.NewArtifactProvenanceStatement(api.Config.URL.API, *nodeRun, *jobRun, *uploaded)
+		envelope, err := workflow.SignArtifactProvenance(key, statement)
+		if err != nil {
+			return err
+		}
+
+		provenance := sdk.WorkflowNodeRunArtifactProvenance{
+			ArtifactID:    uploaded.ID,
+			WorkflowRunID: nodeRun.WorkflowRunID,
+			KeyID:         envelope.Signatures[0].KeyID,
+			Created:       time.Now(),
+			Envelope:      *envelope,
+		}
+		if err := workflow.InsertArtifactProvenance(api.mustDB(), &provenance); err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, provenance, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowArtifactProvenanceHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		id, err := requestVarInt(r, "artifactId")
+		if err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load projet")
+		}
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load workflow")
+		}
+
+		art, err := workflow.LoadArtifactByIDs(api.mustDB(), wf.ID, id)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrNotFound, "cannot load artifact %d: %v", id, err)
+		}
+
+		provenance, err := workflow.LoadArtifactProvenance(ctx, api.mustDB(), art.ID)
+		if err != nil {
+			return err
+		}
+
+		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.intoto.json\"", art.Name))
+		return service.WriteJSON(w, provenance.Envelope, http.StatusOK)
+	}
+}
+
+func (api *API) getProjectProvenanceKeyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		p, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		k, err := project.LoadProvenanceKey(api.mustDB(), p.ID)
+		if err != nil {
+			return err
+		}
+		pub, keyID, err := keys.RSAPublicKeyPEM(k.Private)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, sdk.ProvenancePublicKey{KeyID: keyID, PublicKey: pub}, http.StatusOK)
+	}
+}
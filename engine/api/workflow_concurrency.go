@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var concurrencyGroupsLockKey = cache.Key("api", "concurrency", "lock")
+
+// concurrencyGroupsRoutine periodically releases the concurrency groups that are not held by any running node run
+// but still have node runs waiting for them, for example when the release failed at the end of the previous run.
+func (api *API) concurrencyGroupsRoutine(ctx context.Context) {
+	tick := time.NewTicker(time.Minute)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "concurrencyGroupsRoutine> exiting: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			if err := api.releaseConcurrencyGroups(ctx); err != nil {
+				log.Error(ctx, "concurrencyGroupsRoutine> %v", err)
+			}
+		}
+	}
+}
+
+func (api *API) releaseConcurrencyGroups(ctx context.Context) error {
+	// Only one API instance releases the concurrency groups at a time
+	locked, err := api.Cache.Lock(concurrencyGroupsLockKey, time.Minute, 0, 1)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+	defer api.Cache.Unlock(concurrencyGroupsLockKey) // nolint
+
+	groups, err := workflow.LoadReleasableConcurrencyGroups(api.mustDB())
+	if err != nil {
+		return err
+	}
+
+	for _, g := range groups {
+		if err := api.releaseConcurrencyGroup(ctx, g); err != nil {
+			log.Error(ctx, "concurrencyGroupsRoutine> unable to release concurrency group %s of project %d: %v", g.Name, g.ProjectID, err)
+		}
+	}
+	return nil
+}
+
+func (api *API) releaseConcurrencyGroup(ctx context.Context, g workflow.ConcurrencyGroup) error {
+	proj, err := project.LoadByID(api.mustDB(), api.Cache, g.ProjectID,
+		project.LoadOptions.WithVariables,
+		project.LoadOptions.WithIntegrations,
+	)
+	if err != nil {
+		return sdk.WrapError(err, "cannot load project %d", g.ProjectID)
+	}
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+	defer tx.Rollback() // nolint
+
+	report, err := workflow.ReleaseConcurrencyGroup(ctx, tx, api.Cache, proj, g.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WithStack(err)
+	}
+
+	log.Info(ctx, "concurrencyGroupsRoutine> concurrency group %s of project %s released", g.Name, proj.Key)
+	workflow.ResyncNodeRunsWithCommits(ctx, api.mustDB(), api.Cache, proj, report)
+	go WorkflowSendEvent(context.Background(), api.mustDB(), api.Cache, proj.Key, report)
+	return nil
+}
@@ -137,6 +137,26 @@ func (api *API) getWorkflowHookModelsHandler() service.Handler {
 					}
 					models = append(models, m[i])
 				}
+			case sdk.MergeQueueHookModelName:
+				if events := sdk.MergeQueueEvents(webHookInfo.Events); repoWebHookEnable && len(events) > 0 {
+					m[i].DefaultConfig[sdk.HookConfigEventFilter] = sdk.WorkflowNodeHookConfigValue{
+						Type:               sdk.HookConfigTypeMultiChoice,
+						Value:              strings.Join(events, ";"),
+						Configurable:       true,
+						MultipleChoiceList: events,
+					}
+					models = append(models, m[i])
+				}
+			case sdk.PullRequestCommandModelName:
+				if events := sdk.PullRequestCommandEvents(webHookInfo.Events); repoWebHookEnable && len(events) > 0 {
+					m[i].DefaultConfig[sdk.HookConfigEventFilter] = sdk.WorkflowNodeHookConfigValue{
+						Type:               sdk.HookConfigTypeMultiChoice,
+						Value:              strings.Join(events, ";"),
+						Configurable:       true,
+						MultipleChoiceList: events,
+					}
+					models = append(models, m[i])
+				}
 			case sdk.GitPollerModelName:
 				if repoPollerEnable {
 					models = append(models, m[i])
@@ -334,3 +354,54 @@ func (api *API) getWorkflowJobHookDetailsHandler() service.Handler {
 		return service.WriteJSON(w, hr, http.StatusOK)
 	}
 }
+
+// getWorkflowRepositoryHeadHandler returns the given branch (or the default branch) of the repository
+// of the root application, it is used by the hooks service to know if there are new commits.
+func (api *API) getWorkflowRepositoryHeadHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		branchName := r.FormValue("branch")
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key, project.LoadOptions.WithApplicationWithDeploymentStrategies)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+		if !wf.WorkflowData.Node.IsLinkedToRepo(wf) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow %s is not linked to a repository", name)
+		}
+		app := wf.Applications[wf.WorkflowData.Node.Context.ApplicationID]
+
+		vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
+		if vcsServer == nil {
+			return sdk.NewErrorFrom(sdk.ErrNoReposManagerClientAuth, "no repository manager %s on project %s", app.VCSServer, proj.Key)
+		}
+		client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, vcsServer)
+		if err != nil {
+			return sdk.WrapError(err, "cannot get client for repository manager %s", vcsServer.Name)
+		}
+
+		if branchName == "" {
+			branch, err := repositoriesmanager.DefaultBranch(ctx, client, app.RepositoryFullname)
+			if err != nil {
+				return err
+			}
+			return service.WriteJSON(w, branch, http.StatusOK)
+		}
+
+		branch, err := client.Branch(ctx, app.RepositoryFullname, branchName)
+		if err != nil {
+			return err
+		}
+		if branch == nil {
+			return sdk.NewErrorFrom(sdk.ErrNotFound, "branch %s not found on repository %s", branchName, app.RepositoryFullname)
+		}
+		return service.WriteJSON(w, branch, http.StatusOK)
+	}
+}
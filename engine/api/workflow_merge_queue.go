@@ -0,0 +1,439 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/application"
+	"github.com/ovh/cds/engine/api/authentication"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/mergequeue"
+	"github.com/ovh/cds/engine/api/operation"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var mergeQueueLockKey = cache.Key("api", "mergequeue", "lock")
+
+const mergeQueueOperationTimeout = 10 * time.Minute
+
+func (api *API) getWorkflowMergeQueueHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		entries, err := mergequeue.LoadAllByWorkflowID(ctx, api.mustDB(), wf.ID, 50)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, entries, http.StatusOK)
+	}
+}
+
+// postWorkflowMergeQueueHandler adds a pull request to the merge queue of a workflow, it is called by the hooks service
+func (api *API) postWorkflowMergeQueueHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		// The approval or the label of the pull request is checked by the hooks service
+		if !isHooks(ctx) {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		var entry sdk.MergeQueueEntry
+		if err := service.UnmarshalBody(r, &entry); err != nil {
+			return err
+		}
+		if err := entry.IsValid(); err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		h := wf.WorkflowData.Node.GetHook(entry.HookUUID)
+		if h == nil || h.HookModelName != sdk.MergeQueueHookModelName {
+			return sdk.NewErrorFrom(sdk.ErrHookNotFound, "no merge queue hook %s on workflow %s", entry.HookUUID, name)
+		}
+		if h.Config[sdk.HookConfigRepoFullName].Value != entry.RepositoryFullname {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request repository %s is not the repository of workflow %s", entry.RepositoryFullname, name)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		// A pull request is only once in the queue, a new commit on the pull request replaces the previous entry
+		current, err := mergequeue.LoadActiveByPullRequest(ctx, tx, wf.ID, entry.RepositoryFullname, entry.PullRequestID)
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+			return err
+		}
+		if current != nil {
+			if current.HeadCommit == entry.HeadCommit {
+				return service.WriteJSON(w, current, http.StatusOK)
+			}
+			current.Status = sdk.MergeQueueStatusFailed
+			current.Message = fmt.Sprintf("pull request updated with commit %s", entry.HeadCommit)
+			if err := mergequeue.Update(tx, current); err != nil {
+				return err
+			}
+		}
+
+		entry.ID = 0
+		entry.ProjectID = proj.ID
+		entry.WorkflowID = wf.ID
+		entry.ConsumerID = getAPIConsumer(ctx).ID
+		entry.VCSServer = h.Config[sdk.HookConfigVCSServer].Value
+		entry.MergeBranch, entry.MergeCommit, entry.OperationUUID, entry.Message = "", "", "", ""
+		entry.WorkflowRunID, entry.WorkflowRunNumber = 0, 0
+		entry.Status = sdk.MergeQueueStatusWaiting
+		if err := mergequeue.Insert(tx, &entry); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		if current != nil && current.MergeBranch != "" {
+			mqCtx, err := loadMergeQueueContext(ctx, api.mustDB(), api.Cache, *current)
+			if err != nil {
+				log.Error(ctx, "postWorkflowMergeQueueHandler> %v", err)
+			} else {
+				api.deleteMergeQueueBranch(ctx, mqCtx, current)
+			}
+		}
+
+		return service.WriteJSON(w, entry, http.StatusCreated)
+	}
+}
+
+// mergeQueueRoutine processes the merge queues of all the workflows
+func (api *API) mergeQueueRoutine(ctx context.Context) {
+	tick := time.NewTicker(10 * time.Second)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != nil {
+				log.Error(ctx, "mergeQueueRoutine> exiting: %v", ctx.Err())
+			}
+			return
+		case <-tick.C:
+			if err := api.processMergeQueues(ctx); err != nil {
+				log.Error(ctx, "mergeQueueRoutine> %v", err)
+			}
+		}
+	}
+}
+
+func (api *API) processMergeQueues(ctx context.Context) error {
+	// Only one API instance process the merge queues at a time
+	locked, err := api.Cache.Lock(mergeQueueLockKey, time.Minute, 0, 1)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+	defer api.Cache.Unlock(mergeQueueLockKey) // nolint
+
+	entries, err := mergequeue.LoadAllActive(ctx, api.mustDB())
+	if err != nil {
+		return err
+	}
+
+	for i := range entries {
+		var err error
+		switch entries[i].Status {
+		case sdk.MergeQueueStatusMerging:
+			err = api.checkMergeQueueOperation(ctx, &entries[i])
+		case sdk.MergeQueueStatusBuilding:
+			err = api.checkMergeQueueRun(ctx, &entries[i])
+		}
+		if err != nil {
+			api.failMergeQueueEntry(ctx, &entries[i], err)
+		}
+	}
+
+	for _, e := range sdk.MergeQueueNextEntries(entries) {
+		if err := api.startMergeQueueEntry(ctx, &e); err != nil {
+			api.failMergeQueueEntry(ctx, &e, err)
+		}
+	}
+
+	return nil
+}
+
+type mergeQueueContext struct {
+	proj     *sdk.Project
+	wf       *sdk.Workflow
+	app      *sdk.Application
+	consumer *sdk.AuthConsumer
+	client   sdk.VCSAuthorizedClient
+}
+
+func loadMergeQueueContext(ctx context.Context, db gorp.SqlExecutor, store cache.Store, e sdk.MergeQueueEntry) (*mergeQueueContext, error) {
+	proj, err := project.LoadByID(db, store, e.ProjectID,
+		project.LoadOptions.WithVariables,
+		project.LoadOptions.WithFeatures,
+		project.LoadOptions.WithIntegrations,
+		project.LoadOptions.WithApplicationVariables,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+		project.LoadOptions.WithClearKeys,
+	)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load project %d", e.ProjectID)
+	}
+
+	wf, err := workflow.LoadByID(ctx, db, store, proj, e.WorkflowID, workflow.LoadOptions{
+		DeepPipeline:          true,
+		Base64Keys:            true,
+		WithAsCodeUpdateEvent: true,
+		WithIcon:              true,
+		WithIntegrations:      true,
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load workflow %d", e.WorkflowID)
+	}
+	if wf.WorkflowData.Node.Context == nil || wf.WorkflowData.Node.Context.ApplicationID == 0 {
+		return nil, sdk.NewErrorFrom(sdk.ErrApplicationNotFound, "no application on the root node of workflow %s", wf.Name)
+	}
+
+	app, err := application.LoadByID(db, store, wf.WorkflowData.Node.Context.ApplicationID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load application %d", wf.WorkflowData.Node.Context.ApplicationID)
+	}
+
+	consumer, err := authentication.LoadConsumerByID(ctx, db, e.ConsumerID, authentication.LoadConsumerOptions.WithAuthentifiedUser)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot load consumer %s", e.ConsumerID)
+	}
+
+	vcsServer := repositoriesmanager.GetProjectVCSServer(proj, e.VCSServer)
+	if vcsServer == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrNoReposManagerClientAuth, "no repository manager %s on project %s", e.VCSServer, proj.Key)
+	}
+	client, err := repositoriesmanager.AuthorizedClient(ctx, db, store, proj.Key, vcsServer)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get client for repository manager %s", e.VCSServer)
+	}
+
+	return &mergeQueueContext{proj: proj, wf: wf, app: app, consumer: consumer, client: client}, nil
+}
+
+// startMergeQueueEntry creates the merge commit of the pull request on a temporary branch
+func (api *API) startMergeQueueEntry(ctx context.Context, e *sdk.MergeQueueEntry) error {
+	mqCtx, err := loadMergeQueueContext(ctx, api.mustDB(), api.Cache, *e)
+	if err != nil {
+		return err
+	}
+
+	e.MergeBranch = fmt.Sprintf("cds/merge-queue/%d", e.ID)
+	ope, err := operation.MergeOperation(ctx, api.mustDB(), api.Cache, mqCtx.proj, mqCtx.app, sdk.OperationMerge{
+		BaseBranch:    e.BaseBranch,
+		HeadBranch:    e.HeadBranch,
+		HeadCommit:    e.HeadCommit,
+		PullRequestID: e.PullRequestID,
+		ToBranch:      e.MergeBranch,
+		Message:       fmt.Sprintf("Merge pull request #%d from %s into %s", e.PullRequestID, e.HeadBranch, e.BaseBranch),
+	}, mqCtx.consumer)
+	if err != nil {
+		return err
+	}
+
+	e.OperationUUID = ope.UUID
+	e.Status = sdk.MergeQueueStatusMerging
+	e.Message = ""
+	log.Info(ctx, "startMergeQueueEntry> merging pull request %s#%d on branch %s", e.RepositoryFullname, e.PullRequestID, e.MergeBranch)
+	return mergequeue.Update(api.mustDB(), e)
+}
+
+// checkMergeQueueOperation starts the workflow on the merge commit once created
+func (api *API) checkMergeQueueOperation(ctx context.Context, e *sdk.MergeQueueEntry) error {
+	ope := sdk.Operation{UUID: e.OperationUUID}
+	if err := operation.GetRepositoryOperation(ctx, api.mustDB(), &ope); err != nil {
+		return err
+	}
+
+	switch ope.Status {
+	case sdk.OperationStatusError:
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "cannot merge branch %s on %s: %s", e.HeadBranch, e.BaseBranch, ope.Error)
+	case sdk.OperationStatusDone:
+	default:
+		if time.Since(e.LastModified) > mergeQueueOperationTimeout {
+			return sdk.WithStack(sdk.ErrRepoOperationTimeout)
+		}
+		return nil
+	}
+
+	mqCtx, err := loadMergeQueueContext(ctx, api.mustDB(), api.Cache, *e)
+	if err != nil {
+		return err
+	}
+
+	e.MergeCommit = ope.Setup.Merge.Commit
+	opts := &sdk.WorkflowRunPostHandlerOption{
+		Hook: &sdk.WorkflowNodeRunHookEvent{
+			WorkflowNodeHookUUID: e.HookUUID,
+			Payload: map[string]string{
+				"git.branch":                e.MergeBranch,
+				"git.hash":                  e.MergeCommit,
+				"git.repository":            e.RepositoryFullname,
+				"git.pr.id":                 fmt.Sprintf("%d", e.PullRequestID),
+				"git.pr.base.branch":        e.BaseBranch,
+				"git.pr.head.branch":        e.HeadBranch,
+				"git.pr.head.hash":          e.HeadCommit,
+				"cds.triggered_by.username": "cds.mergequeue",
+				"cds.triggered_by.fullname": "CDS Merge queue",
+			},
+		},
+	}
+
+	run, err := workflow.CreateRun(api.mustDB(), mqCtx.wf, opts, mqCtx.consumer)
+	if err != nil {
+		return err
+	}
+	sdk.GoRoutine(context.Background(), fmt.Sprintf("api.initWorkflowRun-%d", run.ID), func(ctx context.Context) {
+		api.initWorkflowRun(ctx, api.mustDB(), api.Cache, mqCtx.proj, mqCtx.wf, run, opts, mqCtx.consumer)
+	}, api.PanicDump())
+
+	e.WorkflowRunID = run.ID
+	e.WorkflowRunNumber = run.Number
+	e.Status = sdk.MergeQueueStatusBuilding
+	log.Info(ctx, "checkMergeQueueOperation> workflow %s#%d started on merge commit %s of pull request %s#%d", mqCtx.wf.Name, run.Number, e.MergeCommit, e.RepositoryFullname, e.PullRequestID)
+	return mergequeue.Update(api.mustDB(), e)
+}
+
+// checkMergeQueueRun merges the pull request if the workflow run on its merge commit succeeded
+func (api *API) checkMergeQueueRun(ctx context.Context, e *sdk.MergeQueueEntry) error {
+	run, err := workflow.LoadRunByID(api.mustDB(), e.WorkflowRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+	if err != nil {
+		return err
+	}
+	if !sdk.StatusIsTerminated(run.Status) {
+		return nil
+	}
+	if run.Status != sdk.StatusSuccess {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "workflow run #%d on merge commit %s is %s", run.Number, e.MergeCommit, run.Status)
+	}
+
+	mqCtx, err := loadMergeQueueContext(ctx, api.mustDB(), api.Cache, *e)
+	if err != nil {
+		return err
+	}
+	if err := checkMergeQueuePullRequest(ctx, mqCtx, e); err != nil {
+		return err
+	}
+
+	// The head commit is also checked by the repository manager, a pull request updated since will not be merged
+	if err := mqCtx.client.PullRequestMerge(ctx, e.RepositoryFullname, int(e.PullRequestID), e.HeadCommit); err != nil {
+		return sdk.WrapError(err, "cannot merge pull request %s#%d", e.RepositoryFullname, e.PullRequestID)
+	}
+
+	e.Status = sdk.MergeQueueStatusMerged
+	e.Message = fmt.Sprintf("merged after workflow run #%d", run.Number)
+	log.Info(ctx, "checkMergeQueueRun> pull request %s#%d merged", e.RepositoryFullname, e.PullRequestID)
+	if err := mergequeue.Update(api.mustDB(), e); err != nil {
+		return err
+	}
+	api.deleteMergeQueueBranch(ctx, mqCtx, e)
+	return nil
+}
+
+// checkMergeQueuePullRequest checks that the pull request can still be merged: it must be open,
+// still approved or labelled as required by the merge queue hook and its head must be the tested commit.
+func checkMergeQueuePullRequest(ctx context.Context, mqCtx *mergeQueueContext, e *sdk.MergeQueueEntry) error {
+	pr, err := mqCtx.client.PullRequest(ctx, e.RepositoryFullname, int(e.PullRequestID))
+	if err != nil {
+		return sdk.WrapError(err, "cannot get pull request %s#%d", e.RepositoryFullname, e.PullRequestID)
+	}
+	if pr.Merged || pr.Closed {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request %s#%d is not open anymore", e.RepositoryFullname, e.PullRequestID)
+	}
+	if pr.Head.Branch.LatestCommit != e.HeadCommit {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request %s#%d has been updated with commit %s", e.RepositoryFullname, e.PullRequestID, pr.Head.Branch.LatestCommit)
+	}
+
+	h := mqCtx.wf.WorkflowData.Node.GetHook(e.HookUUID)
+	if h == nil {
+		return sdk.NewErrorFrom(sdk.ErrHookNotFound, "no merge queue hook %s on workflow %s", e.HookUUID, mqCtx.wf.Name)
+	}
+	label := h.Config[sdk.MergeQueueHookModelLabel].Value
+	onApproval, _ := strconv.ParseBool(h.Config[sdk.MergeQueueHookModelApproval].Value)
+	labelled := label != "" && sdk.IsInArray(label, pr.Labels)
+	approved := onApproval && pr.Approved
+	if !labelled && !approved {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request %s#%d is not approved or labelled anymore", e.RepositoryFullname, e.PullRequestID)
+	}
+	return nil
+}
+
+// failMergeQueueEntry removes the entry from its merge queue and comments the pull request with the reason
+func (api *API) failMergeQueueEntry(ctx context.Context, e *sdk.MergeQueueEntry, err error) {
+	log.Error(ctx, "failMergeQueueEntry> pull request %s#%d: %v", e.RepositoryFullname, e.PullRequestID, err)
+
+	e.Status = sdk.MergeQueueStatusFailed
+	e.Message = sdk.Cause(err).Error()
+	if err := mergequeue.Update(api.mustDB(), e); err != nil {
+		log.Error(ctx, "failMergeQueueEntry> %v", err)
+		return
+	}
+
+	mqCtx, err := loadMergeQueueContext(ctx, api.mustDB(), api.Cache, *e)
+	if err != nil {
+		log.Error(ctx, "failMergeQueueEntry> %v", err)
+		return
+	}
+	api.deleteMergeQueueBranch(ctx, mqCtx, e)
+
+	comment := fmt.Sprintf("CDS merge queue: pull request not merged, %s", e.Message)
+	if err := mqCtx.client.PullRequestComment(ctx, e.RepositoryFullname, int(e.PullRequestID), comment); err != nil {
+		log.Error(ctx, "failMergeQueueEntry> cannot comment pull request %s#%d: %v", e.RepositoryFullname, e.PullRequestID, err)
+	}
+}
+
+// deleteMergeQueueBranch deletes the temporary branch of an entry that left its merge queue
+func (api *API) deleteMergeQueueBranch(ctx context.Context, mqCtx *mergeQueueContext, e *sdk.MergeQueueEntry) {
+	if e.MergeBranch == "" {
+		return
+	}
+	if _, err := operation.DeleteBranchOperation(ctx, api.mustDB(), api.Cache, mqCtx.proj, mqCtx.app, e.MergeBranch, mqCtx.consumer); err != nil {
+		log.Error(ctx, "deleteMergeQueueBranch> cannot delete branch %s of %s: %v", e.MergeBranch, e.RepositoryFullname, err)
+	}
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/repositoriesmanager"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// postWorkflowPullRequestCommandHandler runs a workflow for a command written in a comment of a pull request,
+// it is called by the hooks service. The author of the comment must be able to write on the repository.
+func (api *API) postWorkflowPullRequestCommandHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		var command sdk.PullRequestCommand
+		if err := service.UnmarshalBody(r, &command); err != nil {
+			return err
+		}
+		if err := command.IsValid(); err != nil {
+			return err
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key,
+			project.LoadOptions.WithVariables,
+			project.LoadOptions.WithFeatures,
+			project.LoadOptions.WithIntegrations,
+			project.LoadOptions.WithApplicationVariables,
+			project.LoadOptions.WithApplicationWithDeploymentStrategies,
+			project.LoadOptions.WithEnvironments,
+			project.LoadOptions.WithPipelines,
+		)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{
+			DeepPipeline:          true,
+			Base64Keys:            true,
+			WithAsCodeUpdateEvent: true,
+			WithIcon:              true,
+			WithIntegrations:      true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		h := wf.WorkflowData.Node.GetHook(command.HookUUID)
+		if h == nil || h.HookModelName != sdk.PullRequestCommandModelName {
+			return sdk.NewErrorFrom(sdk.ErrHookNotFound, "no pull request command hook %s on workflow %s", command.HookUUID, name)
+		}
+		if h.Config[sdk.HookConfigRepoFullName].Value != command.RepositoryFullname {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pull request repository %s is not the repository of workflow %s", command.RepositoryFullname, name)
+		}
+		if !sdk.IsInArray(command.Command, strings.Split(h.Config[sdk.PullRequestCommandModelList].Value, ";")) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "unknown command %s for workflow %s", command.Command, name)
+		}
+
+		vcsServer := repositoriesmanager.GetProjectVCSServer(proj, h.Config[sdk.HookConfigVCSServer].Value)
+		if vcsServer == nil {
+			return sdk.NewErrorFrom(sdk.ErrNoReposManagerClientAuth, "no repository manager %s on project %s", h.Config[sdk.HookConfigVCSServer].Value, proj.Key)
+		}
+		client, err := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, proj.Key, vcsServer)
+		if err != nil {
+			return sdk.WrapError(err, "cannot get client for repository manager %s", vcsServer.Name)
+		}
+		prID := int(command.PullRequestID)
+
+		canWrite, err := client.UserHasWritePermission(ctx, command.RepositoryFullname, command.Author)
+		if err != nil {
+			return err
+		}
+		if !canWrite {
+			comment := fmt.Sprintf("CDS: `%s` ignored, %s is not allowed to write on %s", command.String(), command.Author, command.RepositoryFullname)
+			if err := client.PullRequestComment(ctx, command.RepositoryFullname, prID, comment); err != nil {
+				log.Error(ctx, "postWorkflowPullRequestCommandHandler> cannot comment pull request %s#%d: %v", command.RepositoryFullname, prID, err)
+			}
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "%s is not allowed to write on %s", command.Author, command.RepositoryFullname)
+		}
+
+		// The head of the pull request is not given by all the repository managers with the comment
+		if command.HeadBranch == "" || command.HeadCommit == "" {
+			pr, err := client.PullRequest(ctx, command.RepositoryFullname, prID)
+			if err != nil {
+				return sdk.WrapError(err, "cannot get pull request %s#%d", command.RepositoryFullname, prID)
+			}
+			command.HeadBranch = pr.Head.Branch.DisplayID
+			command.HeadCommit = pr.Head.Branch.LatestCommit
+		}
+
+		payload := map[string]string{
+			"git.branch":                command.HeadBranch,
+			"git.hash":                  command.HeadCommit,
+			"git.repository":            command.RepositoryFullname,
+			"git.pr.id":                 fmt.Sprintf("%d", command.PullRequestID),
+			"git.pr.comment.author":     command.Author,
+			"cds.triggered_by.username": command.Author,
+			"cds.triggered_by.fullname": command.Author,
+			"cds.hook.command":          command.Command,
+			"cds.hook.args":             strings.Join(command.Args, " "),
+		}
+		for i, arg := range command.Args {
+			payload[fmt.Sprintf("cds.hook.arg%d", i+1)] = arg
+		}
+		opts := &sdk.WorkflowRunPostHandlerOption{
+			Hook: &sdk.WorkflowNodeRunHookEvent{
+				WorkflowNodeHookUUID: command.HookUUID,
+				Payload:              payload,
+			},
+		}
+
+		consumer := getAPIConsumer(ctx)
+		run, err := workflow.CreateRun(api.mustDB(), wf, opts, consumer)
+		if err != nil {
+			return err
+		}
+		sdk.GoRoutine(context.Background(), fmt.Sprintf("api.initWorkflowRun-%d", run.ID), func(ctx context.Context) {
+			api.initWorkflowRun(ctx, api.mustDB(), api.Cache, proj, wf, run, opts, consumer)
+		}, api.PanicDump())
+
+		runURL := fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", api.Config.URL.UI, proj.Key, wf.Name, run.Number)
+		comment := fmt.Sprintf("CDS: `%s` started workflow %s [#%d](%s)", command.String(), wf.Name, run.Number, runURL)
+		if err := client.PullRequestComment(ctx, command.RepositoryFullname, prID, comment); err != nil {
+			log.Error(ctx, "postWorkflowPullRequestCommandHandler> cannot comment pull request %s#%d: %v", command.RepositoryFullname, prID, err)
+		}
+
+		return service.WriteJSON(w, run, http.StatusAccepted)
+	}
+}
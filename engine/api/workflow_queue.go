@@ -186,6 +186,15 @@ func (api *API) postBookWorkflowJobHandler() service.Handler {
 			return err
 		}
 
+		// A job retried with a delay can't be booked before the delay expires
+		job, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return err
+		}
+		if err := workflow.CheckNodeJobRunNotDelayed(job); err != nil {
+			return err
+		}
+
 		if _, err := workflow.BookNodeJobRun(ctx, api.Cache, id, s); err != nil {
 			return sdk.WrapError(err, "job already booked")
 		}
@@ -277,6 +286,56 @@ func (api *API) postVulnerabilityReportHandler() service.Handler {
 	}
 }
 
+func (api *API) postStaticAnalysisReportHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return sdk.WrapError(err, "invalid id")
+		}
+
+		nr, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{
+			DisableDetailledNodeRun: true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "unable to save static analysis report")
+		}
+		if nr.ApplicationID == 0 {
+			return sdk.WrapError(sdk.ErrApplicationNotFound, "there is no application linked")
+		}
+
+		var report sdk.StaticAnalysisWorkerReport
+		if err := service.UnmarshalBody(r, &report); err != nil {
+			return sdk.WrapError(err, "unable to read body")
+		}
+
+		p, err := project.LoadProjectByNodeJobRunID(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project by nodeJobRunID: %d", id)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "unable to start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		nodeRunReport, err := workflow.HandleStaticAnalysisReport(ctx, tx, api.Cache, p, nr, report)
+		if err != nil {
+			return sdk.WrapError(err, "unable to handle report")
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, nodeRunReport, http.StatusOK)
+	}
+}
+
 func (api *API) postSpawnInfosWorkflowJobHandler() service.AsynchronousHandler {
 	return func(ctx context.Context, r *http.Request) error {
 		id, err := requestVarInt(r, "permJobID")
@@ -475,6 +534,24 @@ func postJobResult(ctx context.Context, dbFunc func(context.Context) *gorp.DbMap
 		return nil, sdk.WrapError(err, "Cannot update worker %s status", wr.ID)
 	}
 
+	// Put the job back in queue if it failed and its retry policy allows it
+	if res.Status == sdk.StatusFail {
+		retried, err := workflow.RetryWorkflowNodeJob(ctx, tx, *job, sdk.JobFailureCause(*res), res.ExitCode)
+		if err != nil {
+			return nil, sdk.WrapError(err, "cannot retry node job run %d", job.ID)
+		}
+		if retried {
+			if err := tx.Commit(); err != nil {
+				return nil, sdk.WrapError(err, "Cannot commit tx")
+			}
+			job.Status = sdk.StatusWaiting
+			job.Retry++
+			report := new(workflow.ProcessorReport)
+			report.Add(ctx, *job)
+			return report, nil
+		}
+	}
+
 	// Update action status
 	log.Debug("postJobResult> Updating %d to %s in queue", job.ID, res.Status)
 	newDBFunc := func() *gorp.DbMap {
@@ -869,6 +946,75 @@ func (api *API) postWorkflowJobCoverageResultsHandler() service.Handler {
 	}
 }
 
+func (api *API) postWorkflowJobCoverageFilesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return err
+		}
+
+		var files []sdk.WorkflowNodeRunCoverageFile
+		if err := service.UnmarshalBody(r, &files); err != nil {
+			return err
+		}
+
+		wnr, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load node run")
+		}
+
+		covReport, err := workflow.LoadCoverageReport(api.mustDB(), wnr.ID)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				return sdk.NewErrorFrom(sdk.ErrNotFound, "coverage report should be sent before the coverage of the files")
+			}
+			return sdk.WrapError(err, "unable to load coverage report")
+		}
+
+		p, err := project.LoadProjectByNodeJobRunID(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project by nodeJobRunID:%d", id)
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WithStack(err)
+		}
+		defer tx.Rollback() // nolint
+
+		if err := workflow.InsertCoverageFiles(tx, wnr.ID, files); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		// The diff coverage relies on the repositories manager, the files coverage is kept even if it fails
+		if err := workflow.ComputeDiffCoverage(ctx, api.mustDB(), api.Cache, p, wnr, &covReport); err != nil {
+			log.Warning(ctx, "postWorkflowJobCoverageFilesHandler> unable to compute diff coverage of node run %d: %v", wnr.ID, err)
+			return nil
+		}
+		if covReport.Diff == nil {
+			return nil
+		}
+
+		if err := workflow.UpdateCoverage(api.mustDB(), covReport); err != nil {
+			return sdk.WrapError(err, "unable to update code coverage")
+		}
+
+		if err := workflow.SendDiffCoverageComment(ctx, api.mustDB(), api.Cache, p, wnr, covReport); err != nil {
+			log.Warning(ctx, "postWorkflowJobCoverageFilesHandler> unable to send diff coverage of node run %d: %v", wnr.ID, err)
+		}
+
+		return nil
+	}
+}
+
 func (api *API) postWorkflowJobTestsResultsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		if isWorker := isWorker(ctx); !isWorker {
@@ -907,6 +1053,10 @@ func (api *API) postWorkflowJobTestsResultsHandler() service.Handler {
 			nr.Tests = &venom.Tests{}
 		}
 
+		// The tests history is kept with the names of the suites, the unique names given below
+		// contain the id of the job that changes on each run and can't be compared between runs
+		results := sdk.NewWorkflowTestResults(new)
+
 		for k := range new.TestSuites {
 			for i := range nr.Tests.TestSuites {
 				if nr.Tests.TestSuites[i].Name == new.TestSuites[k].Name {
@@ -935,6 +1085,11 @@ func (api *API) postWorkflowJobTestsResultsHandler() service.Handler {
 			return sdk.WrapError(err, "cannot update node run")
 		}
 
+		// Keep the result of each test case to compute the tests history
+		if err := workflow.InsertTestResults(tx, *nr, results); err != nil {
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return sdk.WrapError(err, "cannot update node run")
 		}
@@ -2,9 +2,15 @@ package api
 
 import (
 	"context"
+	"crypto/sha512"
 	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+	"io/ioutil"
 	"mime"
 	"net/http"
+	"path"
 	"strconv"
 	"time"
 
@@ -100,6 +106,11 @@ func (api *API) postWorkflowJobStaticFilesHandler() service.Handler {
 
 		files := m.File[fileName]
 		if len(files) == 1 {
+			staticFile.Size = files[0].Size
+			if err := api.checkStorageQuota(api.mustDB(), nodeJobRun.ProjectID, sdk.StorageKindStaticFiles, staticFile.Size); err != nil {
+				return err
+			}
+
 			file, err := files[0].Open()
 			if err != nil {
 				return sdk.WrapError(err, "cannot open file")
@@ -226,25 +237,41 @@ func (api *API) postWorkflowJobArtifactHandler() service.Handler {
 
 		files := m.File[fileName]
 		if len(files) == 1 {
+			// The quota is checked on the size received, not on the one declared by the worker
+			art.Size = files[0].Size
+
 			file, err := files[0].Open()
 			if err != nil {
-				file.Close()
 				return sdk.WrapError(err, "cannot open file")
 			}
 
-			objectPath, err := storageDriver.Store(&art, file)
-			if err != nil {
-				file.Close()
-				return sdk.WrapError(err, "Cannot store artifact")
+			// Artifacts with a valid checksum are stored once per content in the project storage
+			if sdk.IsValidSHA512sum(art.SHA512sum) {
+				if err := api.storeArtifactBlob(ctx, storageDriver, nodeJobRun.ProjectID, &art, file); err != nil {
+					return err
+				}
+			} else {
+				if err := api.checkStorageQuota(api.mustDB(), nodeJobRun.ProjectID, sdk.StorageKindArtifacts, art.Size); err != nil {
+					file.Close()
+					return err
+				}
+				// The checksum is computed on the received content as the one given by the worker is invalid
+				r := newSHA512ReadCloser(file)
+				objectPath, err := storageDriver.Store(&art, r)
+				if err != nil {
+					r.Close() // nolint
+					return sdk.WrapError(err, "Cannot store artifact")
+				}
+				log.Debug("objectpath=%s\n", objectPath)
+				art.ObjectPath = objectPath
+				art.SHA512sum = r.Sum()
+				r.Close() // nolint
 			}
-			log.Debug("objectpath=%s\n", objectPath)
-			art.ObjectPath = objectPath
-			file.Close()
 		}
 
 		nodeRun.Artifacts = append(nodeRun.Artifacts, art)
 		if err := workflow.InsertArtifact(api.mustDB(), &art); err != nil {
-			_ = storageDriver.Delete(ctx, &art)
+			api.deleteArtifactContent(ctx, storageDriver, nodeJobRun.ProjectID, &art)
 			return sdk.WrapError(err, "Cannot update workflow node run")
 		}
 		return nil
@@ -274,19 +301,52 @@ func (api *API) postWorkflowJobArtifactWithTempURLCallbackHandler() service.Hand
 		}
 
 		cacheKey := cache.Key("workflows:artifacts", art.GetPath(), art.GetName())
-		cachedArt := sdk.WorkflowNodeRunArtifact{}
-		find, err := api.Cache.Get(cacheKey, &cachedArt)
+		var cached artifactTempURLCache
+		find, err := api.Cache.Get(cacheKey, &cached)
 		if err != nil {
 			log.Error(ctx, "cannot get from cache %s: %v", cacheKey, err)
 		}
 		if !find {
 			return sdk.WrapError(sdk.ErrNotFound, "unable to find artifact, key:%s", cacheKey)
 		}
+		cachedArt := cached.Artifact
 
 		if !art.Equal(cachedArt) {
 			return sdk.WrapError(sdk.ErrForbidden, "submitted artifact doesn't match, key:%s art:%v cachedArt:%v", cacheKey, art, cachedArt)
 		}
 
+		if cached.BlobContainer != "" {
+			art.SHA512sum = cachedArt.SHA512sum
+			art.BlobContainer = cached.BlobContainer
+		}
+
+		// The quota was checked on the size declared by the worker, it is checked again on the size of the uploaded content
+		size, sum, err := readUploadedArtifact(ctx, storageDriver, &art)
+		if err != nil {
+			return err
+		}
+		// The checksum of the artifact is always the one of the uploaded content
+		if art.SHA512sum == "" {
+			art.SHA512sum = sum
+		} else if sum != art.SHA512sum {
+			api.deleteUploadedArtifact(ctx, storageDriver, cached.ProjectID, &art)
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "artifact %s checksum %s doesn't match the uploaded content", art.Name, art.SHA512sum)
+		}
+		if size > art.Size {
+			if err := api.checkStorageQuota(api.mustDB(), cached.ProjectID, sdk.StorageKindArtifacts, size-art.Size); err != nil {
+				api.deleteUploadedArtifact(ctx, storageDriver, cached.ProjectID, &art)
+				return err
+			}
+		}
+		art.Size = size
+
+		// The content uploaded by the worker has to be checked before being shared as a blob
+		if cached.BlobContainer != "" {
+			if err := api.referenceUploadedArtifactBlob(ctx, storageDriver, cached.ProjectID, &art, sum); err != nil {
+				return err
+			}
+		}
+
 		nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), art.WorkflowNodeRunID, workflow.LoadRunOptions{WithArtifacts: true, DisableDetailledNodeRun: true})
 		if err != nil {
 			return sdk.WrapError(err, "cannot load node run")
@@ -299,7 +359,7 @@ func (api *API) postWorkflowJobArtifactWithTempURLCallbackHandler() service.Hand
 
 		nodeRun.Artifacts = append(nodeRun.Artifacts, art)
 		if err := workflow.InsertArtifact(api.mustDB(), &art); err != nil {
-			_ = storageDriver.Delete(ctx, &art)
+			api.deleteArtifactContent(ctx, storageDriver, cached.ProjectID, &art)
 			return sdk.WrapError(err, "cannot update workflow node run")
 		}
 
@@ -357,6 +417,10 @@ func (api *API) postWorkflowJobArtifacWithTempURLHandler() service.Handler {
 			return sdk.WrapError(err, "cannot decode ref")
 		}
 
+		if err := api.checkStorageQuota(api.mustDB(), nodeJobRun.ProjectID, sdk.StorageKindArtifacts, art.Size); err != nil {
+			return err
+		}
+
 		art.WorkflowID = nodeRun.WorkflowRunID
 		art.WorkflowNodeRunID = nodeRun.ID
 		art.DownloadHash = hash
@@ -368,6 +432,21 @@ func (api *API) postWorkflowJobArtifacWithTempURLHandler() service.Handler {
 			art.ProjectIntegrationID = &id
 		}
 
+		// The cache key is computed before the artifact is moved to a blob because the worker doesn't know about it
+		cacheKey := cache.Key("workflows:artifacts", art.GetPath(), art.GetName())
+
+		// A blob is only uploaded if it doesn't already exist, to never overwrite a verified content
+		if sdk.IsValidSHA512sum(art.SHA512sum) {
+			blob, err := workflow.LoadArtifactBlob(api.mustDB(), nodeJobRun.ProjectID, id, art.SHA512sum)
+			if err != nil {
+				return err
+			}
+			if blob == nil {
+				blob = &sdk.WorkflowNodeRunArtifactBlob{ProjectID: nodeJobRun.ProjectID}
+				art.BlobContainer = blob.GetPath()
+			}
+		}
+
 		var retryURL = 10
 		var url, key string
 		var errorStoreURL error
@@ -390,12 +469,232 @@ func (api *API) postWorkflowJobArtifacWithTempURLHandler() service.Handler {
 		art.TempURL = url
 		art.TempURLSecretKey = key
 
-		cacheKey := cache.Key("workflows:artifacts", art.GetPath(), art.GetName())
 		//Put this in cache for 1 hour
-		if err := api.Cache.SetWithTTL(cacheKey, art, 60*60); err != nil {
+		cached := artifactTempURLCache{
+			Artifact:      art,
+			BlobContainer: art.BlobContainer,
+			ProjectID:     nodeJobRun.ProjectID,
+		}
+		if err := api.Cache.SetWithTTL(cacheKey, cached, 60*60); err != nil {
 			log.Error(ctx, "cannot SetWithTTL: %s: %v", cacheKey, err)
 		}
 
 		return service.WriteJSON(w, art, http.StatusOK)
 	}
 }
+
+func (api *API) postWorkflowJobArtifactBlobHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		vars := mux.Vars(r)
+		ref := vars["ref"]
+
+		art := sdk.WorkflowNodeRunArtifact{}
+		if err := service.UnmarshalBody(r, &art); err != nil {
+			return err
+		}
+		if !sdk.IsValidSHA512sum(art.SHA512sum) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid sha512sum %q", art.SHA512sum)
+		}
+
+		storageDriver, err := objectstore.GetDriver(ctx, api.mustDB(), api.SharedStorage, vars["permProjectKey"], vars["integrationName"])
+		if err != nil {
+			return err
+		}
+
+		nodeJobRun, err := workflow.LoadNodeJobRun(ctx, api.mustDB(), api.Cache, art.WorkflowNodeJobRunID)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node job run with art.WorkflowNodeJobRunID: %d", art.WorkflowNodeJobRunID)
+		}
+
+		nodeRun, err := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return sdk.WrapError(err, "cannot load node run")
+		}
+
+		tag, err := base64.RawURLEncoding.DecodeString(ref)
+		if err != nil {
+			return sdk.WrapError(err, "cannot decode ref")
+		}
+
+		hash, err := sdk.GenerateHash()
+		if err != nil {
+			return sdk.WrapError(err, "could not generate hash")
+		}
+
+		id := storageDriver.GetProjectIntegration().ID
+		blob, err := workflow.IncrementExistingArtifactBlob(api.mustDB(), nodeJobRun.ProjectID, id, art.SHA512sum)
+		if err != nil {
+			return err
+		}
+		if blob == nil {
+			return sdk.WrapError(sdk.ErrNotFound, "no blob found for artifact %s", art.Name)
+		}
+
+		art.WorkflowID = nodeRun.WorkflowRunID
+		art.WorkflowNodeRunID = nodeRun.ID
+		art.DownloadHash = hash
+		art.Tag = string(tag)
+		art.Ref = ref
+		art.Size = blob.Size
+		art.Created = time.Now()
+		art.BlobContainer = blob.GetPath()
+		art.ObjectPath = path.Join(blob.GetPath(), blob.GetName())
+		if id > 0 {
+			art.ProjectIntegrationID = &id
+		}
+
+		if err := workflow.InsertArtifact(api.mustDB(), &art); err != nil {
+			api.deleteArtifactContent(ctx, storageDriver, nodeJobRun.ProjectID, &art)
+			return sdk.WrapError(err, "cannot update workflow node run")
+		}
+
+		return service.WriteJSON(w, art, http.StatusOK)
+	}
+}
+
+// artifactTempURLCache is the artifact waiting for the upload callback of a worker.
+type artifactTempURLCache struct {
+	Artifact      sdk.WorkflowNodeRunArtifact `json:"artifact"`
+	BlobContainer string                      `json:"blob_container"`
+	ProjectID     int64                       `json:"project_id"`
+}
+
+// sha512ReadCloser computes the checksum of the data read from the underlying reader.
+type sha512ReadCloser struct {
+	io.ReadCloser
+	hash hash.Hash
+}
+
+func newSHA512ReadCloser(r io.ReadCloser) *sha512ReadCloser {
+	return &sha512ReadCloser{ReadCloser: r, hash: sha512.New()}
+}
+
+func (r *sha512ReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n]) // nolint
+	return n, err
+}
+
+func (r *sha512ReadCloser) Sum() string {
+	return hex.EncodeToString(r.hash.Sum(nil))
+}
+
+// storeArtifactBlob stores the artifact content in the project blobs, the upload is skipped if
+// the content is already known. The checksum is verified before the blob is referenced.
+func (api *API) storeArtifactBlob(ctx context.Context, storageDriver objectstore.Driver, projectID int64, art *sdk.WorkflowNodeRunArtifact, data io.ReadCloser) error {
+	integrationID := storageDriver.GetProjectIntegration().ID
+	blob, err := workflow.IncrementExistingArtifactBlob(api.mustDB(), projectID, integrationID, art.SHA512sum)
+	if err != nil {
+		data.Close()
+		return err
+	}
+	if blob != nil {
+		data.Close()
+		log.Debug("storeArtifactBlob> artifact %s already stored in blob %s", art.Name, blob.SHA512sum)
+		art.BlobContainer = blob.GetPath()
+		art.ObjectPath = path.Join(blob.GetPath(), blob.GetName())
+		return nil
+	}
+
+	if err := api.checkStorageQuota(api.mustDB(), projectID, sdk.StorageKindArtifacts, art.Size); err != nil {
+		data.Close()
+		return err
+	}
+
+	blob = &sdk.WorkflowNodeRunArtifactBlob{
+		ProjectID:            projectID,
+		ProjectIntegrationID: integrationID,
+		SHA512sum:            art.SHA512sum,
+		Size:                 art.Size,
+	}
+	art.BlobContainer = blob.GetPath()
+
+	r := newSHA512ReadCloser(data)
+	objectPath, err := storageDriver.Store(art, r)
+	if err != nil {
+		return sdk.WrapError(err, "Cannot store artifact")
+	}
+	if sum := r.Sum(); sum != art.SHA512sum {
+		api.deleteUnreferencedArtifactBlob(ctx, storageDriver, projectID, art)
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "artifact %s checksum %s doesn't match the uploaded content", art.Name, art.SHA512sum)
+	}
+	art.ObjectPath = objectPath
+
+	return workflow.IncrementArtifactBlob(api.mustDB(), blob)
+}
+
+// readUploadedArtifact reads the content uploaded by a worker with a temporary url and returns its size and checksum.
+func readUploadedArtifact(ctx context.Context, storageDriver objectstore.Driver, art *sdk.WorkflowNodeRunArtifact) (int64, string, error) {
+	data, err := storageDriver.Fetch(ctx, art)
+	if err != nil {
+		return 0, "", sdk.WrapError(err, "cannot fetch uploaded artifact %s", art.Name)
+	}
+	r := newSHA512ReadCloser(data)
+	size, err := io.Copy(ioutil.Discard, r)
+	r.Close() // nolint
+	if err != nil {
+		return 0, "", sdk.WrapError(err, "cannot read uploaded artifact %s", art.Name)
+	}
+	return size, r.Sum(), nil
+}
+
+// referenceUploadedArtifactBlob verifies the checksum of the content uploaded by a worker with a temporary url
+// and references the blob for the artifact.
+func (api *API) referenceUploadedArtifactBlob(ctx context.Context, storageDriver objectstore.Driver, projectID int64, art *sdk.WorkflowNodeRunArtifact, sum string) error {
+	if sum != art.SHA512sum {
+		api.deleteUnreferencedArtifactBlob(ctx, storageDriver, projectID, art)
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "artifact %s checksum %s doesn't match the uploaded content", art.Name, art.SHA512sum)
+	}
+
+	return workflow.IncrementArtifactBlob(api.mustDB(), &sdk.WorkflowNodeRunArtifactBlob{
+		ProjectID:            projectID,
+		ProjectIntegrationID: storageDriver.GetProjectIntegration().ID,
+		SHA512sum:            art.SHA512sum,
+		Size:                 art.Size,
+	})
+}
+
+// deleteUploadedArtifact deletes the content uploaded by a worker with a temporary url that can't be referenced.
+func (api *API) deleteUploadedArtifact(ctx context.Context, storageDriver objectstore.Driver, projectID int64, art *sdk.WorkflowNodeRunArtifact) {
+	if art.BlobContainer != "" {
+		api.deleteUnreferencedArtifactBlob(ctx, storageDriver, projectID, art)
+		return
+	}
+	if err := storageDriver.Delete(ctx, art); err != nil {
+		log.Error(ctx, "deleteUploadedArtifact> cannot delete artifact %s: %v", art.Name, err)
+	}
+}
+
+// deleteUnreferencedArtifactBlob deletes an invalid blob content, unless a valid content with the same
+// checksum is already referenced.
+func (api *API) deleteUnreferencedArtifactBlob(ctx context.Context, storageDriver objectstore.Driver, projectID int64, art *sdk.WorkflowNodeRunArtifact) {
+	blob, err := workflow.LoadArtifactBlob(api.mustDB(), projectID, storageDriver.GetProjectIntegration().ID, art.SHA512sum)
+	if err != nil {
+		log.Error(ctx, "deleteUnreferencedArtifactBlob> %v", err)
+		return
+	}
+	if blob != nil {
+		return
+	}
+	if err := storageDriver.Delete(ctx, art); err != nil {
+		log.Error(ctx, "deleteUnreferencedArtifactBlob> cannot delete blob %s: %v", art.SHA512sum, err)
+	}
+}
+
+// deleteArtifactContent removes the content of an artifact that couldn't be saved, blobs are only
+// deleted when they are not referenced anymore.
+func (api *API) deleteArtifactContent(ctx context.Context, storageDriver objectstore.Driver, projectID int64, art *sdk.WorkflowNodeRunArtifact) {
+	if art.BlobContainer != "" {
+		if err := workflow.DecrementArtifactBlob(api.mustDB(), 0, projectID, storageDriver.GetProjectIntegration().ID, art.SHA512sum, func() error {
+			return storageDriver.Delete(ctx, art)
+		}); err != nil {
+			log.Error(ctx, "deleteArtifactContent> %v", err)
+		}
+		return
+	}
+	_ = storageDriver.Delete(ctx, art)
+}
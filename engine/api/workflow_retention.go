@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// getWorkflowRetentionDryRunHandler returns the workflow runs that would be purged by the retention policy of the workflow
+func (api *API) getWorkflowRetentionDryRunHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "unable to load project %s", key)
+		}
+
+		wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "unable to load workflow %s", name)
+		}
+
+		results, err := workflow.RetentionDryRun(ctx, api.mustDB(), api.Cache, *proj, *wf)
+		if err != nil {
+			return err
+		}
+
+		return service.WriteJSON(w, results, http.StatusOK)
+	}
+}
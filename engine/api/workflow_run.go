@@ -765,6 +765,7 @@ func (api *API) getWorkflowNodeRunHandler() service.Handler {
 			WithStaticFiles:     true,
 			WithCoverage:        true,
 			WithVulnerabilities: true,
+			WithStaticAnalysis:  true,
 		})
 		if err != nil {
 			return sdk.WrapError(err, "Unable to load last workflow run")
@@ -837,7 +838,7 @@ func (api *API) postWorkflowRunHandler() service.Handler {
 			var errc error
 			var conditionsOK bool
 			if conditions.LuaScript == "" {
-				conditionsOK, errc = sdk.WorkflowCheckConditions(conditions.PlainConditions, params)
+				conditionsOK, errc = sdk.WorkflowCheckPlainConditionsAndExpression(conditions, params)
 			} else {
 				luacheck, err := luascript.NewCheck()
 				if err != nil {
@@ -1012,7 +1013,10 @@ func (api *API) initWorkflowRun(ctx context.Context, db *gorp.DbMap, cache cache
 
 	workflow.ResyncNodeRunsWithCommits(ctx, db, cache, p, report)
 
-	// Purge workflow run
+	// Purge workflow run, workflows with a retention policy are purged by the purge routine
+	if wf.RetentionPolicy != nil {
+		return
+	}
 	sdk.GoRoutine(ctx, "workflow.PurgeWorkflowRun", func(ctx context.Context) {
 		if err := workflow.PurgeWorkflowRun(ctx, db, *wf, api.Metrics.WorkflowRunsMarkToDelete); err != nil {
 			log.Error(ctx, "workflow.PurgeWorkflowRun> error %v", err)
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	sbomSearchDefaultLimit = 100
+	sbomSearchMaxLimit     = 1000
+)
+
+func (api *API) postWorkflowJobSBOMHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		if isWorker := isWorker(ctx); !isWorker {
+			return sdk.WithStack(sdk.ErrForbidden)
+		}
+
+		id, err := requestVarInt(r, "permJobID")
+		if err != nil {
+			return sdk.WrapError(err, "invalid id")
+		}
+		name := filepath.Base(FormString(r, "name"))
+		if name == "" || name == "." || name == "/" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing sbom name")
+		}
+
+		data, err := ioutil.ReadAll(io.LimitReader(r.Body, sdk.SBOMMaxSize+1))
+		if err != nil {
+			return sdk.NewErrorWithStack(err, sdk.ErrWrongRequest)
+		}
+		if len(data) > sdk.SBOMMaxSize {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "sbom is bigger than %d bytes", sdk.SBOMMaxSize)
+		}
+		doc, err := sdk.ParseSBOM(data)
+		if err != nil {
+			return err
+		}
+
+		nr, err := workflow.LoadNodeRunByNodeJobID(api.mustDB(), id, workflow.LoadRunOptions{
+			DisableDetailledNodeRun: true,
+		})
+		if err != nil {
+			return sdk.WrapError(err, "unable to save sbom")
+		}
+		p, err := project.LoadProjectByNodeJobRunID(ctx, api.mustDB(), api.Cache, id)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project by nodeJobRunID: %d", id)
+		}
+
+		sum := sha256.Sum256(data)
+		sbom := sdk.WorkflowNodeRunSBOM{
+			ProjectID:         p.ID,
+			ApplicationID:     nr.ApplicationID,
+			WorkflowID:        nr.WorkflowID,
+			WorkflowRunID:     nr.WorkflowRunID,
+			WorkflowNodeRunID: nr.ID,
+			WorkflowNodeName:  nr.WorkflowNodeName,
+			Num:               nr.Number,
+			Branch:            nr.VCSBranch,
+			Name:              name,
+			Format:            doc.Format,
+			SpecVersion:       doc.SpecVersion,
+			SHA256:            hex.EncodeToString(sum[:]),
+			Size:              int64(len(data)),
+			Created:           time.Now(),
+		}
+
+		tx, err := api.mustDB().Begin()
+		if err != nil {
+			return sdk.WrapError(err, "unable to start transaction")
+		}
+		defer tx.Rollback() // nolint
+
+		if err := workflow.InsertSBOM(tx, &sbom, doc.Components); err != nil {
+			return err
+		}
+
+		// The sbom is stored once inserted so a failed insert does not leave an orphan object in the store
+		if _, err := api.SharedStorage.Store(&sbom, ioutil.NopCloser(bytes.NewReader(data))); err != nil {
+			return sdk.WrapError(err, "cannot store sbom")
+		}
+
+		if err := tx.Commit(); err != nil {
+			if errD := api.SharedStorage.Delete(ctx, &sbom); errD != nil {
+				log.Error(ctx, "postWorkflowJobSBOMHandler> unable to delete sbom %s: %v", sbom.GetName(), errD)
+			}
+			return sdk.WithStack(err)
+		}
+
+		return service.WriteJSON(w, sbom, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowRunSBOMsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		sboms, err := workflow.LoadSBOMsByRunID(ctx, api.mustDB(), wr.ID)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, sboms, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowRunSBOMDownloadHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		id, err := requestVarInt(r, "sbomID")
+		if err != nil {
+			return err
+		}
+
+		wr, err := workflow.LoadRun(ctx, api.mustDB(), key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if err != nil {
+			return err
+		}
+
+		sbom, err := workflow.LoadSBOMByID(ctx, api.mustDB(), wr.ID, id)
+		if err != nil {
+			return err
+		}
+
+		f, err := api.SharedStorage.Fetch(ctx, sbom)
+		if err != nil {
+			return sdk.WrapError(err, "cannot fetch sbom")
+		}
+		defer f.Close() // nolint
+
+		w.Header().Add("Content-Type", "application/octet-stream")
+		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", sbom.Name))
+		if _, err := io.Copy(w, f); err != nil {
+			return sdk.WrapError(err, "cannot stream sbom")
+		}
+		return nil
+	}
+}
+
+func (api *API) getProjectSBOMSearchHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars[permProjectKey]
+
+		name := FormString(r, "name")
+		if name == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing component name")
+		}
+		limit, err := formLimit(r, "limit", sbomSearchDefaultLimit, sbomSearchMaxLimit)
+		if err != nil {
+			return err
+		}
+
+		p, err := project.Load(api.mustDB(), api.Cache, key)
+		if err != nil {
+			return sdk.WrapError(err, "cannot load project %s", key)
+		}
+
+		results, err := workflow.SearchSBOMComponents(api.mustDB(), p.ID, name, FormString(r, "version"), limit)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, results, http.StatusOK)
+	}
+}
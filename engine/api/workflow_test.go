@@ -640,6 +640,52 @@ func Test_postWorkflowHandlerWithBadPayloadShouldFail(t *testing.T) {
 	assert.Equal(t, 400, w.Code)
 }
 
+func Test_postWorkflowHandlerWithBadConditionExpressionShouldFail(t *testing.T) {
+	api, db, router, end := newTestAPI(t)
+	defer end()
+
+	// Init user
+	u, pass := assets.InsertAdminUser(t, api.mustDB())
+	// Init project
+	key := sdk.RandomString(10)
+	proj := assets.InsertTestProject(t, db, api.Cache, key, key)
+	// Init pipeline
+	pip := sdk.Pipeline{
+		Name:      "pipeline1",
+		ProjectID: proj.ID,
+	}
+	test.NoError(t, pipeline.InsertPipeline(api.mustDB(), api.Cache, proj, &pip))
+
+	//Prepare request
+	vars := map[string]string{
+		"permProjectKey": proj.Key,
+	}
+	uri := router.GetRoute("POST", api.postWorkflowHandler, vars)
+	test.NotEmpty(t, uri)
+
+	var workflow = &sdk.Workflow{
+		Name:        "Name",
+		Description: "Description",
+		WorkflowData: &sdk.WorkflowData{
+			Node: sdk.Node{
+				Type: sdk.NodeTypePipeline,
+				Context: &sdk.NodeContext{
+					PipelineID: pip.ID,
+					Conditions: sdk.WorkflowNodeConditions{
+						Expression: `git.branch == "master" && (cds.version > 9`,
+					},
+				},
+			},
+		},
+	}
+
+	req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, &workflow)
+	//Do the request
+	w := httptest.NewRecorder()
+	router.Mux.ServeHTTP(w, req)
+	assert.Equal(t, 400, w.Code)
+}
+
 func Test_putWorkflowHandler(t *testing.T) {
 
 	api, db, router, end := newTestAPI(t)
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	workflowTestsDefaultLimit = 50
+	workflowTestsDefaultRuns  = 100
+	workflowTestsMaxLimit     = 500
+)
+
+// formLimit returns the integer value of a query param, the default value if unset and at most max.
+func formLimit(r *http.Request, name string, def, max int) (int, error) {
+	limit, err := FormInt(r, name)
+	if err != nil {
+		return 0, err
+	}
+	if limit <= 0 {
+		limit = def
+	}
+	if limit > max {
+		limit = max
+	}
+	return limit, nil
+}
+
+func (api *API) loadWorkflowForTests(ctx context.Context, r *http.Request) (*sdk.Workflow, error) {
+	vars := mux.Vars(r)
+	key := vars["key"]
+	name := vars["permWorkflowName"]
+
+	proj, err := project.Load(api.mustDB(), api.Cache, key)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load project %s", key)
+	}
+
+	wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, workflow.LoadOptions{Minimal: true})
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to load workflow %s", name)
+	}
+	return wf, nil
+}
+
+func (api *API) getWorkflowTestsHistoryHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		suite := FormString(r, "suite")
+		name := FormString(r, "name")
+		if name == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing test name")
+		}
+		limit, err := formLimit(r, "limit", workflowTestsDefaultLimit, workflowTestsMaxLimit)
+		if err != nil {
+			return err
+		}
+
+		wf, err := api.loadWorkflowForTests(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		results, err := workflow.LoadTestResultsHistory(ctx, api.mustDB(), wf.ID, suite, name, FormString(r, "branch"), limit)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, results, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowTestsFlakyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		runs, err := formLimit(r, "runs", workflowTestsDefaultRuns, workflowTestsMaxLimit)
+		if err != nil {
+			return err
+		}
+
+		wf, err := api.loadWorkflowForTests(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		results, err := workflow.LoadTestResultsOfLatestRuns(ctx, api.mustDB(), wf.ID, FormString(r, "branch"), runs)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, sdk.ComputeTestsFlakiness(results), http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowTestsTrendsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		limit, err := formLimit(r, "limit", workflowTestsDefaultLimit, workflowTestsMaxLimit)
+		if err != nil {
+			return err
+		}
+
+		wf, err := api.loadWorkflowForTests(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		trends, err := workflow.LoadTestsTrends(api.mustDB(), wf.ID, FormString(r, "branch"), limit)
+		if err != nil {
+			return err
+		}
+		if trends == nil {
+			trends = []sdk.WorkflowTestsTrend{}
+		}
+		return service.WriteJSON(w, trends, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowRunTestsDiffHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+
+		wf, err := api.loadWorkflowForTests(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		current, err := workflow.LoadTestResultsByRunNumber(ctx, api.mustDB(), wf.ID, number)
+		if err != nil {
+			return err
+		}
+		if len(current) == 0 {
+			diff := sdk.DiffTestResults(nil, nil)
+			diff.RunNumber = number
+			return service.WriteJSON(w, diff, http.StatusOK)
+		}
+
+		// Compare with the latest previous run on the same branch
+		branch := current[0].VCSBranch
+		previousNumber, err := workflow.LoadPreviousTestResultsRunNumber(api.mustDB(), wf.ID, branch, number)
+		if err != nil {
+			return err
+		}
+		var previous []sdk.WorkflowTestResult
+		if previousNumber > 0 {
+			previous, err = workflow.LoadTestResultsByRunNumber(ctx, api.mustDB(), wf.ID, previousNumber)
+			if err != nil {
+				return err
+			}
+		}
+
+		diff := sdk.DiffTestResults(current, previous)
+		diff.RunNumber = number
+		diff.PreviousRunNumber = previousNumber
+		diff.Branch = branch
+		return service.WriteJSON(w, diff, http.StatusOK)
+	}
+}
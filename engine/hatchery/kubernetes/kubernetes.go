@@ -265,8 +265,10 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 	}
 
 	memory := int64(h.Config.DefaultMemory)
+	var memoryRequirement bool
 	for _, r := range spawnArgs.Requirements {
 		if r.Type == sdk.MemoryRequirement {
+			memoryRequirement = true
 			var err error
 			memory, err = strconv.ParseInt(r.Value, 10, 64)
 			if err != nil {
@@ -373,6 +375,16 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 		},
 	}
 
+	if spawnArgs.Model.ModelDocker.PodSpec != nil {
+		if err := h.checkModelPodSpec(*spawnArgs.Model.ModelDocker.PodSpec); err != nil {
+			return sdk.WrapError(err, "cannot apply pod spec of model %s", spawnArgs.Model.Name)
+		}
+		// the memory of a job requirement or of a registration is more specific than the one of the model
+		if err := applyModelPodSpec(&podSchema, *spawnArgs.Model.ModelDocker.PodSpec, memoryRequirement || spawnArgs.RegisterOnly); err != nil {
+			return sdk.WrapError(err, "cannot apply pod spec of model %s", spawnArgs.Model.Name)
+		}
+	}
+
 	var services []sdk.Requirement
 	for _, req := range spawnArgs.Requirements {
 		if req.Type == sdk.ServiceRequirement {
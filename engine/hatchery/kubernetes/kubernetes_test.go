@@ -120,3 +120,109 @@ func TestHatcheryKubernetes_Status(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, gock.IsDone())
 }
+
+func TestHatcheryKubernetes_SpawnWorkerWithPodSpec(t *testing.T) {
+	defer gock.Off()
+	h := NewHatcheryKubernetesTest(t)
+	h.Config.PodSpec.AllowedServiceAccounts = []string{"cds-worker"}
+
+	tolerationSeconds := int64(60)
+	runAsUser := int64(1000)
+	m := &sdk.Model{
+		Name: "model1",
+		Group: &sdk.Group{
+			Name: "group",
+		},
+		ModelDocker: sdk.ModelDocker{
+			PodSpec: &sdk.ModelPodSpec{
+				NodeSelector: map[string]string{"disktype": "ssd"},
+				Tolerations: []sdk.ModelPodToleration{
+					{Key: "dedicated", Operator: "Equal", Value: "cds", Effect: "NoSchedule"},
+					{Key: "node.kubernetes.io/unreachable", Operator: "Exists", Effect: "NoExecute", TolerationSeconds: &tolerationSeconds},
+				},
+				Resources: &sdk.ModelPodResources{
+					Requests: map[string]string{"cpu": "500m", "memory": "1Gi"},
+					Limits:   map[string]string{"cpu": "2", "memory": "8Gi"},
+				},
+				ServiceAccountName: "cds-worker",
+				SecurityContext:    &sdk.ModelPodSecurityContext{RunAsUser: &runAsUser},
+				Volumes: []sdk.ModelPodVolume{
+					{Name: "cache", MountPath: "/cache", EmptyDir: true},
+					{Name: "settings", MountPath: "/etc/settings", ConfigMap: "maven-settings", ReadOnly: true},
+				},
+			},
+		},
+	}
+
+	podResponse := v1.Pod{}
+	gock.New("http://lolcat.kube").Post("/api/v1/namespaces/hachibi/pods").Reply(http.StatusOK).JSON(podResponse)
+
+	var checkRequest gock.ObserverFunc = func(request *http.Request, mock gock.Mock) {
+		if request.Body == nil {
+			return
+		}
+		bodyContent, err := ioutil.ReadAll(request.Body)
+		assert.NoError(t, err)
+		var podRequest v1.Pod
+		require.NoError(t, json.Unmarshal(bodyContent, &podRequest))
+
+		require.Equal(t, map[string]string{"disktype": "ssd"}, podRequest.Spec.NodeSelector)
+		require.Len(t, podRequest.Spec.Tolerations, 2)
+		require.Equal(t, v1.TolerationOpEqual, podRequest.Spec.Tolerations[0].Operator)
+		require.Equal(t, v1.TaintEffectNoExecute, podRequest.Spec.Tolerations[1].Effect)
+		require.Equal(t, int64(60), *podRequest.Spec.Tolerations[1].TolerationSeconds)
+		require.Equal(t, "cds-worker", podRequest.Spec.ServiceAccountName)
+
+		worker := podRequest.Spec.Containers[0]
+		// the memory requirement of the job overrides the memory request of the model
+		require.Equal(t, int64(4096), worker.Resources.Requests.Memory().Value())
+		require.Equal(t, int64(500), worker.Resources.Requests.Cpu().MilliValue())
+		require.Equal(t, int64(8*1024*1024*1024), worker.Resources.Limits.Memory().Value())
+		require.Equal(t, int64(1000), *worker.SecurityContext.RunAsUser)
+
+		require.Len(t, podRequest.Spec.Volumes, 2)
+		require.NotNil(t, podRequest.Spec.Volumes[0].EmptyDir)
+		require.Equal(t, "maven-settings", podRequest.Spec.Volumes[1].ConfigMap.Name)
+		require.Len(t, worker.VolumeMounts, 2)
+		require.Equal(t, "/etc/settings", worker.VolumeMounts[1].MountPath)
+		require.True(t, worker.VolumeMounts[1].ReadOnly)
+	}
+	gock.Observe(checkRequest)
+
+	err := h.SpawnWorker(context.TODO(), hatchery.SpawnArguments{
+		JobID:      666,
+		Model:      m,
+		WorkerName: "k8s-toto",
+		Requirements: []sdk.Requirement{
+			{
+				Name:  "mem",
+				Type:  sdk.MemoryRequirement,
+				Value: "4096",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
+func TestHatcheryKubernetes_checkModelPodSpec(t *testing.T) {
+	h := NewHatcheryKubernetesTest(t)
+	privileged := true
+
+	require.NoError(t, h.checkModelPodSpec(sdk.ModelPodSpec{Volumes: []sdk.ModelPodVolume{{Name: "cache", MountPath: "/cache", EmptyDir: true}}}))
+	require.Error(t, h.checkModelPodSpec(sdk.ModelPodSpec{SecurityContext: &sdk.ModelPodSecurityContext{Privileged: &privileged}}))
+	require.Error(t, h.checkModelPodSpec(sdk.ModelPodSpec{ServiceAccountName: "cds-worker"}))
+	require.Error(t, h.checkModelPodSpec(sdk.ModelPodSpec{Volumes: []sdk.ModelPodVolume{{Name: "docker", MountPath: "/var/run/docker.sock", HostPath: "/var/run/docker.sock"}}}))
+
+	h.Config.PodSpec.AllowPrivileged = true
+	h.Config.PodSpec.AllowedServiceAccounts = []string{"cds-worker"}
+	h.Config.PodSpec.AllowedHostPaths = []string{"/var/run/docker.sock", "/opt/cache/"}
+	require.NoError(t, h.checkModelPodSpec(sdk.ModelPodSpec{SecurityContext: &sdk.ModelPodSecurityContext{Privileged: &privileged}}))
+	require.NoError(t, h.checkModelPodSpec(sdk.ModelPodSpec{ServiceAccountName: "cds-worker"}))
+	require.NoError(t, h.checkModelPodSpec(sdk.ModelPodSpec{Volumes: []sdk.ModelPodVolume{
+		{Name: "docker", MountPath: "/var/run/docker.sock", HostPath: "/var/run/docker.sock"},
+		{Name: "cache", MountPath: "/cache", HostPath: "/opt/cache/maven"},
+	}}))
+	require.Error(t, h.checkModelPodSpec(sdk.ModelPodSpec{Volumes: []sdk.ModelPodVolume{{Name: "etc", MountPath: "/etc", HostPath: "/opt/cache/../../etc"}}}))
+	require.Error(t, h.checkModelPodSpec(sdk.ModelPodSpec{Volumes: []sdk.ModelPodVolume{{Name: "cache", MountPath: "/cache", HostPath: "/opt/cachefoo"}}}))
+}
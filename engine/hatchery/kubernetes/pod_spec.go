@@ -0,0 +1,148 @@
+package kubernetes
+
+import (
+	"path"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// checkModelPodSpec refuses the host paths, the service accounts and the privileged workers that are not allowed
+// by the configuration of the hatchery.
+func (h *HatcheryKubernetes) checkModelPodSpec(spec sdk.ModelPodSpec) error {
+	if s := spec.SecurityContext; s != nil && !h.Config.PodSpec.AllowPrivileged {
+		if (s.Privileged != nil && *s.Privileged) || (s.AllowPrivilegeEscalation != nil && *s.AllowPrivilegeEscalation) {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "privileged workers are not allowed")
+		}
+	}
+
+	if spec.ServiceAccountName != "" && !sdk.IsInArray(spec.ServiceAccountName, h.Config.PodSpec.AllowedServiceAccounts) {
+		return sdk.NewErrorFrom(sdk.ErrForbidden, "service account %s is not allowed", spec.ServiceAccountName)
+	}
+
+	for _, v := range spec.Volumes {
+		if v.HostPath == "" {
+			continue
+		}
+		if !isHostPathAllowed(v.HostPath, h.Config.PodSpec.AllowedHostPaths) {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "host path %s of volume %s is not allowed", v.HostPath, v.Name)
+		}
+	}
+	return nil
+}
+
+func isHostPathAllowed(hostPath string, allowed []string) bool {
+	hostPath = path.Clean(hostPath)
+	for _, a := range allowed {
+		a = path.Clean(a)
+		if hostPath == a || strings.HasPrefix(hostPath, strings.TrimSuffix(a, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// applyModelPodSpec merges the pod spec of a worker model into the pod of the worker, the first container of the pod.
+// The memory request computed by the hatchery from a requirement is kept if keepMemoryRequest is true.
+func applyModelPodSpec(pod *apiv1.Pod, spec sdk.ModelPodSpec, keepMemoryRequest bool) error {
+	worker := &pod.Spec.Containers[0]
+
+	if len(spec.NodeSelector) > 0 {
+		pod.Spec.NodeSelector = make(map[string]string, len(spec.NodeSelector))
+		for k, v := range spec.NodeSelector {
+			pod.Spec.NodeSelector[k] = v
+		}
+	}
+
+	for _, t := range spec.Tolerations {
+		pod.Spec.Tolerations = append(pod.Spec.Tolerations, apiv1.Toleration{
+			Key:               t.Key,
+			Operator:          apiv1.TolerationOperator(t.Operator),
+			Value:             t.Value,
+			Effect:            apiv1.TaintEffect(t.Effect),
+			TolerationSeconds: t.TolerationSeconds,
+		})
+	}
+
+	if spec.Resources != nil {
+		memoryRequest, hasMemoryRequest := worker.Resources.Requests[apiv1.ResourceMemory]
+		requests, err := podResourceList(spec.Resources.Requests)
+		if err != nil {
+			return err
+		}
+		limits, err := podResourceList(spec.Resources.Limits)
+		if err != nil {
+			return err
+		}
+		if len(requests) > 0 {
+			if hasMemoryRequest {
+				if _, ok := requests[apiv1.ResourceMemory]; !ok || keepMemoryRequest {
+					requests[apiv1.ResourceMemory] = memoryRequest
+				}
+			}
+			worker.Resources.Requests = requests
+		}
+		if len(limits) > 0 {
+			worker.Resources.Limits = limits
+		}
+	}
+
+	if spec.ServiceAccountName != "" {
+		pod.Spec.ServiceAccountName = spec.ServiceAccountName
+	}
+
+	if s := spec.SecurityContext; s != nil {
+		worker.SecurityContext = &apiv1.SecurityContext{
+			RunAsUser:                s.RunAsUser,
+			RunAsGroup:               s.RunAsGroup,
+			RunAsNonRoot:             s.RunAsNonRoot,
+			Privileged:               s.Privileged,
+			AllowPrivilegeEscalation: s.AllowPrivilegeEscalation,
+			ReadOnlyRootFilesystem:   s.ReadOnlyRootFilesystem,
+		}
+		if s.FSGroup != nil {
+			pod.Spec.SecurityContext = &apiv1.PodSecurityContext{FSGroup: s.FSGroup}
+		}
+	}
+
+	for _, v := range spec.Volumes {
+		volume := apiv1.Volume{Name: v.Name}
+		switch {
+		case v.EmptyDir:
+			volume.EmptyDir = &apiv1.EmptyDirVolumeSource{}
+		case v.ConfigMap != "":
+			volume.ConfigMap = &apiv1.ConfigMapVolumeSource{LocalObjectReference: apiv1.LocalObjectReference{Name: v.ConfigMap}}
+		case v.Secret != "":
+			volume.Secret = &apiv1.SecretVolumeSource{SecretName: v.Secret}
+		case v.PersistentVolumeClaim != "":
+			volume.PersistentVolumeClaim = &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: v.PersistentVolumeClaim, ReadOnly: v.ReadOnly}
+		case v.HostPath != "":
+			volume.HostPath = &apiv1.HostPathVolumeSource{Path: v.HostPath}
+		default:
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "volume %s has no source", v.Name)
+		}
+		pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+		worker.VolumeMounts = append(worker.VolumeMounts, apiv1.VolumeMount{
+			Name:      v.Name,
+			MountPath: v.MountPath,
+			ReadOnly:  v.ReadOnly,
+		})
+	}
+
+	return nil
+}
+
+func podResourceList(l map[string]string) (apiv1.ResourceList, error) {
+	res := make(apiv1.ResourceList, len(l))
+	for name, value := range l {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid %s quantity %q: %v", name, value, err)
+		}
+		res[apiv1.ResourceName(name)] = q
+	}
+	return res, nil
+}
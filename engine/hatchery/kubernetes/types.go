@@ -49,6 +49,12 @@ type HatcheryConfiguration struct {
 	KubernetesClientCertData string `mapstructure:"clientCertData" toml:"clientCertData" default:"" commented:"true" comment:"Client certificate data (content, not path and not base64 encoded) for tls kubernetes (optional if no tls needed)" json:"-"`
 	// KubernetesKeyData Client certificate data for tls kubernetes (optional if no tls needed)
 	KubernetesClientKeyData string `mapstructure:"clientKeyData" toml:"clientKeyData" default:"" commented:"true" comment:"Client certificate data (content, not path and not base64 encoded) for tls kubernetes (optional if no tls needed)" json:"-"`
+	// PodSpec is the allowlist of the sensitive fields of the pod spec of the worker models
+	PodSpec struct {
+		AllowPrivileged        bool     `mapstructure:"allowPrivileged" toml:"allowPrivileged" default:"false" commented:"true" comment:"Allow the worker models to run privileged workers or workers allowing privilege escalation" json:"allowPrivileged"`
+		AllowedHostPaths       []string `mapstructure:"allowedHostPaths" toml:"allowedHostPaths" commented:"true" comment:"Host paths that the worker models can mount, with their sub paths. Example: [\"/var/run/docker.sock\"]" json:"allowedHostPaths"`
+		AllowedServiceAccounts []string `mapstructure:"allowedServiceAccounts" toml:"allowedServiceAccounts" commented:"true" comment:"Service accounts that the worker models can use. Example: [\"cds-worker\"]" json:"allowedServiceAccounts"`
+	} `mapstructure:"podSpec" toml:"podSpec" comment:"Pod spec of the worker models: host paths, service accounts and privileged workers are refused unless allowed here" json:"podSpec"`
 }
 
 // HatcheryKubernetes implements HatcheryMode interface for local usage
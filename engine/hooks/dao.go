@@ -64,12 +64,21 @@ func (d *dao) DeleteTask(ctx context.Context, r *sdk.Task) error {
 	if err := d.store.SetRemove(rootKey, r.UUID, r); err != nil {
 		return err
 	}
+	if err := d.store.Delete(cache.Key(scheduledRunKey, r.UUID)); err != nil {
+		return err
+	}
 	execs, _ := d.FindAllTaskExecutions(ctx, r)
 	for _, e := range execs {
 		if err := d.DeleteTaskExecution(&e); err != nil {
 			return err
 		}
 	}
+	deadLetters, _ := d.FindAllDeadLetterExecutions(ctx, r)
+	for _, e := range deadLetters {
+		if err := d.DeleteDeadLetterExecution(&e); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -131,3 +140,79 @@ func (d *dao) FindAllTaskExecutionsForTasks(ctx context.Context, ts ...sdk.Task)
 
 	return tes, nil
 }
+
+func (d *dao) SaveDeadLetterExecution(r *sdk.TaskExecution) error {
+	setKey := cache.Key(deadLetterRootKey, r.Type, r.UUID)
+	execKey := fmt.Sprintf("%d", r.Timestamp)
+	if err := d.store.SetAdd(setKey, execKey, r); err != nil {
+		return err
+	}
+	// The executions of all the dead letters are also listed in a single set to count them at once
+	return d.store.SetAdd(deadLetterAllKey, cache.Key(r.Type, r.UUID, execKey), r.Timestamp)
+}
+
+func (d *dao) DeleteDeadLetterExecution(r *sdk.TaskExecution) error {
+	setKey := cache.Key(deadLetterRootKey, r.Type, r.UUID)
+	execKey := fmt.Sprintf("%d", r.Timestamp)
+	if err := d.store.SetRemove(setKey, execKey, r); err != nil {
+		return err
+	}
+	return d.store.SetRemove(deadLetterAllKey, cache.Key(r.Type, r.UUID, execKey), r.Timestamp)
+}
+
+func (d *dao) FindDeadLetterExecution(t *sdk.Task, timestamp int64) (*sdk.TaskExecution, error) {
+	key := cache.Key(deadLetterRootKey, t.Type, t.UUID, fmt.Sprintf("%d", timestamp))
+	var e sdk.TaskExecution
+	find, err := d.store.Get(key, &e)
+	if err != nil {
+		return nil, sdk.WrapError(err, "cannot get from cache %s", key)
+	}
+	if !find {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+func (d *dao) FindAllDeadLetterExecutions(ctx context.Context, t *sdk.Task) ([]sdk.TaskExecution, error) {
+	setKey := cache.Key(deadLetterRootKey, t.Type, t.UUID)
+	nbExecutions, err := d.store.SetCard(setKey)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to setCard %s", setKey)
+	}
+	execs := make([]*sdk.TaskExecution, nbExecutions)
+	for i := 0; i < nbExecutions; i++ {
+		execs[i] = &sdk.TaskExecution{}
+	}
+	if err := d.store.SetScan(ctx, setKey, sdk.InterfaceSlice(execs)...); err != nil {
+		return nil, sdk.WrapError(err, "Unable to scan %s", setKey)
+	}
+
+	allexecs := make([]sdk.TaskExecution, nbExecutions)
+	for i := 0; i < nbExecutions; i++ {
+		allexecs[i] = *execs[i]
+	}
+
+	return allexecs, nil
+}
+
+// FindLastScheduledRuns returns the last workflow run of the scheduled task on each branch
+func (d *dao) FindLastScheduledRuns(t *sdk.Task) (map[string]scheduledRun, error) {
+	key := cache.Key(scheduledRunKey, t.UUID)
+	runs := map[string]scheduledRun{}
+	if _, err := d.store.Get(key, &runs); err != nil {
+		return nil, sdk.WrapError(err, "cannot get from cache %s", key)
+	}
+	return runs, nil
+}
+
+func (d *dao) SaveLastScheduledRuns(t *sdk.Task, runs map[string]scheduledRun) error {
+	return d.store.Set(cache.Key(scheduledRunKey, t.UUID), runs)
+}
+
+func (d *dao) DeadLetterLen(t *sdk.Task) (int, error) {
+	return d.store.SetCard(cache.Key(deadLetterRootKey, t.Type, t.UUID))
+}
+
+func (d *dao) AllDeadLettersLen() (int, error) {
+	return d.store.SetCard(deadLetterAllKey)
+}
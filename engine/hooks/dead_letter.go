@@ -0,0 +1,149 @@
+package hooks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+var (
+	onceDeadLetterMetrics sync.Once
+	deadLetterSize        *stats.Int64Measure
+)
+
+func initDeadLetterMetrics() error {
+	var err error
+	onceDeadLetterMetrics.Do(func() {
+		deadLetterSize = stats.Int64("cds/hooks/dead_letter_executions", "number of task executions in dead letter", stats.UnitDimensionless)
+		tags := []tag.Key{observability.MustNewKey(observability.TagServiceType), observability.MustNewKey(observability.TagServiceName)}
+		err = observability.RegisterView(
+			observability.NewViewLast("cds/hooks/dead_letter_executions", deadLetterSize, tags),
+		)
+	})
+	return err
+}
+
+// isDeadLetter returns true if the execution has exhausted its retries.
+// Executions stopped by an administrator or skipped because their task is stopped are not considered as failed.
+func (s *Service) isDeadLetter(e *sdk.TaskExecution) bool {
+	return e.NbErrors >= s.Cfg.RetryError && e.LastError != "" && e.LastError != TaskExecutionDone && e.LastError != taskExecutionStoppedError
+}
+
+// moveToDeadLetter removes the execution from the executions of its task and keeps it in the dead letter of the task.
+func (s *Service) moveToDeadLetter(ctx context.Context, e *sdk.TaskExecution) error {
+	log.Warning(ctx, "Hooks> moving task execution %s:%d to dead letter after %d errors, lastError:%s", e.UUID, e.Timestamp, e.NbErrors, e.LastError)
+	e.Status = TaskExecutionDone
+	if err := s.Dao.SaveDeadLetterExecution(e); err != nil {
+		return sdk.WrapError(err, "unable to save task execution %s:%d in dead letter", e.UUID, e.Timestamp)
+	}
+	if err := s.Dao.DeleteTaskExecution(e); err != nil {
+		return sdk.WrapError(err, "unable to delete task execution %s:%d", e.UUID, e.Timestamp)
+	}
+	return s.trimDeadLetter(ctx, &sdk.Task{UUID: e.UUID, Type: e.Type})
+}
+
+// trimDeadLetter removes the oldest executions of the dead letter of the task beyond deadLetterMaxSize.
+func (s *Service) trimDeadLetter(ctx context.Context, t *sdk.Task) error {
+	if s.Cfg.DeadLetterMaxSize <= 0 {
+		return nil
+	}
+	size, err := s.Dao.DeadLetterLen(t)
+	if err != nil {
+		return sdk.WrapError(err, "unable to get dead letter len of task %s", t.UUID)
+	}
+	if size <= s.Cfg.DeadLetterMaxSize {
+		return nil
+	}
+
+	execs, err := s.Dao.FindAllDeadLetterExecutions(ctx, t)
+	if err != nil {
+		return err
+	}
+	sort.Slice(execs, func(i, j int) bool { return execs[i].Timestamp < execs[j].Timestamp })
+	for i := 0; i < len(execs)-s.Cfg.DeadLetterMaxSize; i++ {
+		log.Warning(ctx, "Hooks> removing task execution %s:%d from dead letter, max size %d reached", execs[i].UUID, execs[i].Timestamp, s.Cfg.DeadLetterMaxSize)
+		if err := s.Dao.DeleteDeadLetterExecution(&execs[i]); err != nil {
+			return sdk.WrapError(err, "unable to delete task execution %s:%d from dead letter", execs[i].UUID, execs[i].Timestamp)
+		}
+	}
+	return nil
+}
+
+// sameDeadLetterConfig returns true if both configs have the same values, the webhook secret is ignored
+// as it is not always returned by the API.
+func sameDeadLetterConfig(a, b sdk.WorkflowNodeHookConfig) bool {
+	a, b = withoutWebHookSecret(a), withoutWebHookSecret(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, has := b[k]; !has || bv.Value != v.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// updateDeadLetterPayload replaces the payload of the execution by the payload of the body,
+// only the payload of the type of the execution is updated.
+func updateDeadLetterPayload(e, body *sdk.TaskExecution) {
+	if e.WebHook != nil && body.WebHook != nil {
+		e.WebHook = body.WebHook
+	}
+	if e.Kafka != nil && body.Kafka != nil {
+		e.Kafka = body.Kafka
+	}
+	if e.RabbitMQ != nil && body.RabbitMQ != nil {
+		e.RabbitMQ = body.RabbitMQ
+	}
+	if e.GerritEvent != nil && body.GerritEvent != nil {
+		e.GerritEvent = body.GerritEvent
+	}
+	if e.Plugin != nil && body.Plugin != nil {
+		e.Plugin = body.Plugin
+	}
+}
+
+// replayDeadLetter enqueues the execution again with a new timestamp, so that it is not purged with the old executions
+// of its task and can be moved again to the dead letter if it fails. It is removed from the dead letter once enqueued.
+func (s *Service) replayDeadLetter(ctx context.Context, e *sdk.TaskExecution) error {
+	deadLetter := *e
+	e.Timestamp = time.Now().UnixNano()
+	e.Status = TaskExecutionEnqueued
+	e.NbErrors = 0
+	e.LastError = ""
+	e.ProcessingTimestamp = 0
+	if err := s.Dao.SaveTaskExecution(e); err != nil {
+		return sdk.WrapError(err, "unable to save task execution %s:%d", e.UUID, e.Timestamp)
+	}
+	if err := s.Dao.EnqueueTaskExecution(ctx, e); err != nil {
+		return sdk.WrapError(err, "unable to enqueue task execution %s:%d", e.UUID, e.Timestamp)
+	}
+	if err := s.Dao.DeleteDeadLetterExecution(&deadLetter); err != nil {
+		return sdk.WrapError(err, "unable to delete task execution %s:%d from dead letter", deadLetter.UUID, deadLetter.Timestamp)
+	}
+	log.Info(ctx, "Hooks> replaying task execution %s:%d from dead letter as %s:%d", deadLetter.UUID, deadLetter.Timestamp, e.UUID, e.Timestamp)
+	return nil
+}
+
+// recordDeadLetterSize records the number of executions in the dead letters of all the tasks.
+func (s *Service) recordDeadLetterSize(ctx context.Context) {
+	size, err := s.Dao.AllDeadLettersLen()
+	if err != nil {
+		log.Error(ctx, "Hooks> recordDeadLetterSize> unable to get dead letters len: %v", err)
+		return
+	}
+	ctx = observability.ContextWithTag(ctx,
+		observability.TagServiceType, s.Type(),
+		observability.TagServiceName, s.Name(),
+	)
+	observability.Record(ctx, deadLetterSize, int64(size))
+}
@@ -0,0 +1,195 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func Test_isDeadLetter(t *testing.T) {
+	s := Service{}
+	s.Cfg.RetryError = 3
+
+	assert.False(t, s.isDeadLetter(&sdk.TaskExecution{NbErrors: 2, LastError: "boom"}))
+	assert.True(t, s.isDeadLetter(&sdk.TaskExecution{NbErrors: 3, LastError: "boom"}))
+	assert.False(t, s.isDeadLetter(&sdk.TaskExecution{NbErrors: 3}))
+	// Executions stopped by an administrator
+	assert.False(t, s.isDeadLetter(&sdk.TaskExecution{NbErrors: 4, LastError: TaskExecutionDone}))
+	// Executions skipped because their task is stopped
+	assert.False(t, s.isDeadLetter(&sdk.TaskExecution{NbErrors: 3, LastError: taskExecutionStoppedError}))
+}
+
+func Test_moveToDeadLetterAndReplay(t *testing.T) {
+	log.SetLogger(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	ctx := context.TODO()
+
+	task := &sdk.Task{UUID: sdk.UUID(), Type: TypeWebHook}
+	require.NoError(t, s.Dao.SaveTask(task))
+
+	e := &sdk.TaskExecution{
+		UUID:                task.UUID,
+		Type:                task.Type,
+		Timestamp:           time.Now().UnixNano(),
+		ProcessingTimestamp: time.Now().UnixNano(),
+		NbErrors:            s.Cfg.RetryError,
+		LastError:           "boom",
+		Status:              TaskExecutionDone,
+		WebHook:             &sdk.WebHookExecution{RequestBody: []byte(`{"foo": "bar"}`)},
+	}
+	require.NoError(t, s.Dao.SaveTaskExecution(e))
+	require.True(t, s.isDeadLetter(e))
+	require.NoError(t, s.moveToDeadLetter(ctx, e))
+
+	execs, err := s.Dao.FindAllTaskExecutions(ctx, task)
+	require.NoError(t, err)
+	assert.Len(t, execs, 0)
+	size, err := s.Dao.DeadLetterLen(task)
+	require.NoError(t, err)
+	assert.Equal(t, 1, size)
+
+	dl, err := s.Dao.FindDeadLetterExecution(task, e.Timestamp)
+	require.NoError(t, err)
+	require.NotNil(t, dl)
+	assert.Equal(t, "boom", dl.LastError)
+	assert.Equal(t, `{"foo": "bar"}`, string(dl.WebHook.RequestBody))
+
+	require.NoError(t, s.replayDeadLetter(ctx, dl))
+	size, err = s.Dao.DeadLetterLen(task)
+	require.NoError(t, err)
+	assert.Equal(t, 0, size)
+
+	execs, err = s.Dao.FindAllTaskExecutions(ctx, task)
+	require.NoError(t, err)
+	require.Len(t, execs, 1)
+	assert.Equal(t, TaskExecutionEnqueued, execs[0].Status)
+	assert.Equal(t, int64(0), execs[0].NbErrors)
+	assert.Empty(t, execs[0].LastError)
+	assert.Equal(t, int64(0), execs[0].ProcessingTimestamp)
+	// The replayed execution has a new timestamp
+	assert.True(t, execs[0].Timestamp > e.Timestamp)
+	assert.Equal(t, dl.Timestamp, execs[0].Timestamp)
+
+	require.NoError(t, s.Cache.RemoveFromQueue(schedulerQueueKey, cache.Key(executionRootKey, dl.Type, dl.UUID, fmt.Sprintf("%d", dl.Timestamp))))
+	require.NoError(t, s.Dao.DeleteTask(ctx, task))
+}
+
+func Test_replayDeadLetterFailsAgain(t *testing.T) {
+	log.SetLogger(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	ctx := context.TODO()
+
+	task := &sdk.Task{UUID: sdk.UUID(), Type: TypeWebHook}
+	require.NoError(t, s.Dao.SaveTask(task))
+	defer s.Dao.DeleteTask(ctx, task) // nolint
+
+	e := &sdk.TaskExecution{
+		UUID:      task.UUID,
+		Type:      task.Type,
+		Timestamp: time.Now().UnixNano(),
+		NbErrors:  s.Cfg.RetryError,
+		LastError: "boom",
+		WebHook:   &sdk.WebHookExecution{RequestBody: []byte(`{"foo": "bar"}`)},
+	}
+	require.NoError(t, s.Dao.SaveTaskExecution(e))
+	require.NoError(t, s.moveToDeadLetter(ctx, e))
+
+	dl, err := s.Dao.FindDeadLetterExecution(task, e.Timestamp)
+	require.NoError(t, err)
+	require.NotNil(t, dl)
+	require.NoError(t, s.replayDeadLetter(ctx, dl))
+	require.NoError(t, s.Cache.RemoveFromQueue(schedulerQueueKey, cache.Key(executionRootKey, dl.Type, dl.UUID, fmt.Sprintf("%d", dl.Timestamp))))
+
+	// The replayed execution exhausts its retries again
+	replayed := *dl
+	replayed.NbErrors = s.Cfg.RetryError
+	replayed.LastError = "boom again"
+	require.NoError(t, s.Dao.SaveTaskExecution(&replayed))
+	require.True(t, s.isDeadLetter(&replayed))
+	require.NoError(t, s.moveToDeadLetter(ctx, &replayed))
+
+	execs, err := s.Dao.FindAllTaskExecutions(ctx, task)
+	require.NoError(t, err)
+	assert.Len(t, execs, 0)
+
+	// Only the last failure is in the dead letter, the first one was removed by the replay
+	dls, err := s.Dao.FindAllDeadLetterExecutions(ctx, task)
+	require.NoError(t, err)
+	require.Len(t, dls, 1)
+	assert.Equal(t, replayed.Timestamp, dls[0].Timestamp)
+	assert.Equal(t, "boom again", dls[0].LastError)
+	assert.Equal(t, `{"foo": "bar"}`, string(dls[0].WebHook.RequestBody))
+}
+
+func Test_trimDeadLetter(t *testing.T) {
+	log.SetLogger(t)
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	s.Cfg.DeadLetterMaxSize = 2
+	ctx := context.TODO()
+
+	task := &sdk.Task{UUID: sdk.UUID(), Type: TypeWebHook}
+	require.NoError(t, s.Dao.SaveTask(task))
+	defer s.Dao.DeleteTask(ctx, task) // nolint
+
+	allSize, err := s.Dao.AllDeadLettersLen()
+	require.NoError(t, err)
+
+	now := time.Now().UnixNano()
+	for i := 0; i < 3; i++ {
+		e := &sdk.TaskExecution{UUID: task.UUID, Type: task.Type, Timestamp: now + int64(i), NbErrors: 1, LastError: "boom"}
+		require.NoError(t, s.Dao.SaveTaskExecution(e))
+		require.NoError(t, s.moveToDeadLetter(ctx, e))
+	}
+
+	// The oldest execution has been removed
+	execs, err := s.Dao.FindAllDeadLetterExecutions(ctx, task)
+	require.NoError(t, err)
+	require.Len(t, execs, 2)
+	dl, err := s.Dao.FindDeadLetterExecution(task, now)
+	require.NoError(t, err)
+	assert.Nil(t, dl)
+
+	size, err := s.Dao.AllDeadLettersLen()
+	require.NoError(t, err)
+	assert.Equal(t, allSize+2, size)
+}
+
+func Test_updateDeadLetterPayload(t *testing.T) {
+	config := sdk.WorkflowNodeHookConfig{
+		sdk.HookConfigProject:       {Value: "KEY"},
+		sdk.HookConfigWorkflow:      {Value: "my-workflow"},
+		sdk.HookConfigWebHookSecret: {Value: "secret"},
+	}
+	assert.True(t, sameDeadLetterConfig(config, config.Clone()))
+	// The secret is not returned by the API
+	assert.True(t, sameDeadLetterConfig(config, withoutWebHookSecret(config)))
+
+	other := config.Clone()
+	other[sdk.HookConfigWorkflow] = sdk.WorkflowNodeHookConfigValue{Value: "other-workflow"}
+	assert.False(t, sameDeadLetterConfig(config, other))
+	delete(other, sdk.HookConfigWorkflow)
+	assert.False(t, sameDeadLetterConfig(config, other))
+
+	e := &sdk.TaskExecution{Type: TypeWebHook, WebHook: &sdk.WebHookExecution{RequestBody: []byte(`{"foo": "bar"}`)}}
+	updateDeadLetterPayload(e, &sdk.TaskExecution{
+		WebHook: &sdk.WebHookExecution{RequestBody: []byte(`{"foo": "baz"}`)},
+		Kafka:   &sdk.KafkaTaskExecution{Message: []byte("message")},
+	})
+	assert.Equal(t, `{"foo": "baz"}`, string(e.WebHook.RequestBody))
+	assert.Nil(t, e.Kafka)
+
+	// An empty payload does not remove the payload of the execution
+	updateDeadLetterPayload(e, &sdk.TaskExecution{})
+	assert.NotNil(t, e.WebHook)
+}
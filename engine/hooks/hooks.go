@@ -3,6 +3,7 @@ package hooks
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"net/http"
 	"time"
 
@@ -89,6 +90,13 @@ func (s *Service) Serve(c context.Context) error {
 	//Init the DAO
 	s.Dao = dao{s.Cache}
 
+	//Seed the jitter of the schedulers
+	rand.Seed(time.Now().UnixNano())
+
+	if err := initDeadLetterMetrics(); err != nil {
+		log.Error(ctx, "unable to init dead letter metrics: %v", err)
+	}
+
 	// Get current maintenance state
 	var b bool
 	if _, err := s.Dao.store.Get(MaintenanceHookKey, &b); err != nil {
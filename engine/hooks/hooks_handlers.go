@@ -47,19 +47,32 @@ func (s *Service) webhookHandler() service.Handler {
 		}
 
 		//Prepare a web hook execution
+		header := r.Header.Clone()
+		header.Del(GitlabTokenHeader)
 		exec := &sdk.TaskExecution{
 			Timestamp: time.Now().UnixNano(),
 			Type:      webHook.Type,
 			UUID:      webHook.UUID,
-			Config:    webHook.Config,
+			Config:    withoutWebHookSecret(webHook.Config),
 			Status:    TaskExecutionScheduled,
 			WebHook: &sdk.WebHookExecution{
 				RequestBody:   req,
-				RequestHeader: r.Header,
+				RequestHeader: header,
 				RequestURL:    r.URL.RawQuery,
 			},
 		}
 
+		//Check the signature of the repository manager, a rejected call is kept in the executions history
+		if secret := webHook.Config[sdk.HookConfigWebHookSecret].Value; secret != "" {
+			if err := checkRepositoryWebHookSignature(secret, r.Header, req); err != nil {
+				exec.Status = TaskExecutionRejected
+				exec.ProcessingTimestamp = time.Now().UnixNano()
+				exec.LastError = err.Error()
+				s.Dao.SaveTaskExecution(exec)
+				return sdk.WrapError(err, "webhook %s rejected", uuid)
+			}
+		}
+
 		//Save the web hook execution
 		s.Dao.SaveTaskExecution(exec)
 
@@ -165,6 +178,7 @@ func (s *Service) postAndExecuteTaskHandler() service.Handler {
 const (
 	sortKeyNbExecutionsTotal = "nb_executions_total"
 	sortKeyNbExecutionsTodo  = "nb_executions_todo"
+	sortKeyNbDeadLetters     = "nb_dead_letters"
 )
 
 func (s *Service) getTasksHandler() service.Handler {
@@ -174,7 +188,7 @@ func (s *Service) getTasksHandler() service.Handler {
 			return sdk.NewError(sdk.ErrWrongRequest, err)
 		}
 		for k := range sortParams {
-			if k != sortKeyNbExecutionsTotal && k != sortKeyNbExecutionsTodo {
+			if k != sortKeyNbExecutionsTotal && k != sortKeyNbExecutionsTodo && k != sortKeyNbDeadLetters {
 				return sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("invalid given sort key"))
 			}
 		}
@@ -195,6 +209,7 @@ func (s *Service) getTasksHandler() service.Handler {
 		}
 
 		for i, t := range tasks {
+			tasks[i].Config = withoutWebHookSecret(t.Config)
 			var nbTodo int
 			for _, e := range m[t.UUID] {
 				if e.ProcessingTimestamp == 0 {
@@ -203,6 +218,11 @@ func (s *Service) getTasksHandler() service.Handler {
 			}
 			tasks[i].NbExecutionsTotal = len(m[t.UUID])
 			tasks[i].NbExecutionsTodo = nbTodo
+			nbDeadLetters, err := s.Dao.DeadLetterLen(&tasks[i])
+			if err != nil {
+				return err
+			}
+			tasks[i].NbDeadLetters = nbDeadLetters
 		}
 
 		for k, p := range sortParams {
@@ -215,6 +235,10 @@ func (s *Service) getTasksHandler() service.Handler {
 				sort.Slice(tasks, func(i, j int) bool {
 					return api.SortCompareInt(tasks[i].NbExecutionsTodo, tasks[j].NbExecutionsTodo, p)
 				})
+			case sortKeyNbDeadLetters:
+				sort.Slice(tasks, func(i, j int) bool {
+					return api.SortCompareInt(tasks[i].NbDeadLetters, tasks[j].NbDeadLetters, p)
+				})
 			}
 		}
 
@@ -273,7 +297,11 @@ func (s *Service) getTaskHandler() service.Handler {
 			return sdk.WrapError(err, "Unable to load executions")
 		}
 
+		for i := range execs {
+			execs[i].Config = withoutWebHookSecret(execs[i].Config)
+		}
 		t.Executions = execs
+		t.Config = withoutWebHookSecret(t.Config)
 
 		return service.WriteJSON(w, t, http.StatusOK)
 	}
@@ -317,7 +345,12 @@ func (s *Service) getTaskExecutionsHandler() service.Handler {
 		if err != nil {
 			return sdk.WrapError(err, "Unable to find task executions for %s", uuid)
 		}
+		for i := range execs {
+			previewScheduledTaskExecution(&execs[i])
+			execs[i].Config = withoutWebHookSecret(execs[i].Config)
+		}
 		t.Executions = execs
+		t.Config = withoutWebHookSecret(t.Config)
 
 		sort.Slice(t.Executions, func(i, j int) bool {
 			return t.Executions[i].Timestamp > t.Executions[j].Timestamp
@@ -360,6 +393,7 @@ func (s *Service) deleteAllTaskExecutionsHandler() service.Handler {
 			return sdk.WrapError(err, "Unable start task %+v", t)
 		}
 
+		t.Config = withoutWebHookSecret(t.Config)
 		return service.WriteJSON(w, t, http.StatusOK)
 	}
 }
@@ -466,6 +500,11 @@ func (s *Service) updateTask(ctx context.Context, h *sdk.NodeHook) error {
 		return errNoTask
 	}
 
+	// The secret of a repository webhook is not kept by the API, it is sent back with the updated config
+	if secret, has := task.Config[sdk.HookConfigWebHookSecret]; has && t.Config[sdk.HookConfigWebHookSecret].Value == "" {
+		t.Config[sdk.HookConfigWebHookSecret] = secret
+	}
+
 	task.Config = t.Config
 	_ = s.stopTask(ctx, t)
 	execs, _ := s.Dao.FindAllTaskExecutions(ctx, t)
@@ -517,7 +556,7 @@ func (s *Service) Status(ctx context.Context) sdk.MonitoringStatus {
 	}
 	m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Queue", Value: fmt.Sprintf("%d", size), Status: status})
 
-	var nbHooksKafkaTotal int64
+	var nbHooksKafkaTotal, nbHooksPluginTotal int64
 
 	tasks, err := s.Dao.FindAllTasks(ctx)
 	if err != nil {
@@ -528,6 +567,9 @@ func (s *Service) Status(ctx context.Context) sdk.MonitoringStatus {
 		if t.Type == TypeKafka {
 			nbHooksKafkaTotal++
 		}
+		if t.Type == TypePlugin && !t.Stopped {
+			nbHooksPluginTotal++
+		}
 
 		if t.Stopped {
 			m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Task Stopped", Value: t.UUID, Status: sdk.MonitoringStatusWarn})
@@ -576,6 +618,15 @@ func (s *Service) Status(ctx context.Context) sdk.MonitoringStatus {
 	}
 	m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Hook Kafka Consumers", Value: fmt.Sprintf("%d", nbKafkaConsumers), Status: statusConsumer})
 
+	pluginHooksMutex.Lock()
+	nbPluginsRunning := int64(len(pluginHooks))
+	pluginHooksMutex.Unlock()
+	statusPlugins := sdk.MonitoringStatusOK
+	if nbPluginsRunning < nbHooksPluginTotal {
+		statusPlugins = sdk.MonitoringStatusWarn
+	}
+	m.Lines = append(m.Lines, sdk.MonitoringStatusLine{Component: "Hook Plugins Running", Value: fmt.Sprintf("%d/%d", nbPluginsRunning, nbHooksPluginTotal), Status: statusPlugins})
+
 	return m
 }
 
@@ -607,6 +658,7 @@ func (s *Service) getTaskExecutionHandler() service.Handler {
 
 		for _, e := range execs {
 			if strconv.FormatInt(e.Timestamp, 10) == timestamp {
+				e.Config = withoutWebHookSecret(e.Config)
 				return service.WriteJSON(w, e, http.StatusOK)
 			}
 		}
@@ -669,3 +721,132 @@ func (s *Service) postMaintenanceHandler() service.Handler {
 		return nil
 	}
 }
+
+func (s *Service) getTaskDeadLettersHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		uuid := mux.Vars(r)["uuid"]
+
+		t := s.Dao.FindTask(ctx, uuid)
+		if t == nil {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		execs, err := s.Dao.FindAllDeadLetterExecutions(ctx, t)
+		if err != nil {
+			return sdk.WrapError(err, "unable to find dead letter executions for %s", uuid)
+		}
+		sort.Slice(execs, func(i, j int) bool {
+			return execs[i].Timestamp > execs[j].Timestamp
+		})
+
+		return service.WriteJSON(w, execs, http.StatusOK)
+	}
+}
+
+func (s *Service) deleteTaskDeadLettersHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		uuid := mux.Vars(r)["uuid"]
+
+		t := s.Dao.FindTask(ctx, uuid)
+		if t == nil {
+			return sdk.WithStack(sdk.ErrNotFound)
+		}
+
+		execs, err := s.Dao.FindAllDeadLetterExecutions(ctx, t)
+		if err != nil {
+			return sdk.WrapError(err, "unable to find dead letter executions for %s", uuid)
+		}
+		for i := range execs {
+			if err := s.Dao.DeleteDeadLetterExecution(&execs[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// loadDeadLetter returns the task and its dead letter execution from the URL of the request.
+func (s *Service) loadDeadLetter(ctx context.Context, r *http.Request) (*sdk.Task, *sdk.TaskExecution, error) {
+	vars := mux.Vars(r)
+	uuid := vars["uuid"]
+	timestamp, err := strconv.ParseInt(vars["timestamp"], 10, 64)
+	if err != nil {
+		return nil, nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid timestamp %s", vars["timestamp"])
+	}
+
+	t := s.Dao.FindTask(ctx, uuid)
+	if t == nil {
+		return nil, nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+
+	e, err := s.Dao.FindDeadLetterExecution(t, timestamp)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e == nil {
+		return nil, nil, sdk.WithStack(sdk.ErrNotFound)
+	}
+	return t, e, nil
+}
+
+func (s *Service) getTaskDeadLetterHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		_, e, err := s.loadDeadLetter(ctx, r)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, e, http.StatusOK)
+	}
+}
+
+func (s *Service) putTaskDeadLetterHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		_, e, err := s.loadDeadLetter(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		var body sdk.TaskExecution
+		if err := service.UnmarshalBody(r, &body); err != nil {
+			return sdk.WithStack(err)
+		}
+
+		// Only the payload of the execution can be edited, the config gives the workflow to run
+		if body.Config != nil && !sameDeadLetterConfig(body.Config, e.Config) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "only the payload of the execution can be updated")
+		}
+		updateDeadLetterPayload(e, &body)
+		if err := s.Dao.SaveDeadLetterExecution(e); err != nil {
+			return sdk.WrapError(err, "unable to save dead letter execution %s:%d", e.UUID, e.Timestamp)
+		}
+
+		return service.WriteJSON(w, e, http.StatusOK)
+	}
+}
+
+func (s *Service) deleteTaskDeadLetterHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		_, e, err := s.loadDeadLetter(ctx, r)
+		if err != nil {
+			return err
+		}
+		return s.Dao.DeleteDeadLetterExecution(e)
+	}
+}
+
+func (s *Service) postReplayTaskDeadLetterHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		t, e, err := s.loadDeadLetter(ctx, r)
+		if err != nil {
+			return err
+		}
+		if t.Stopped {
+			return sdk.NewErrorFrom(sdk.ErrForbidden, "task %s is stopped", t.UUID)
+		}
+		if err := s.replayDeadLetter(ctx, e); err != nil {
+			return err
+		}
+		return service.WriteJSON(w, e, http.StatusOK)
+	}
+}
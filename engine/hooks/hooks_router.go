@@ -31,4 +31,7 @@ func (s *Service) initRouter(ctx context.Context) {
 	r.Handle("/task/{uuid}/execution", nil, r.GET(s.getTaskExecutionsHandler), r.DELETE(s.deleteAllTaskExecutionsHandler))
 	r.Handle("/task/{uuid}/execution/{timestamp}", nil, r.GET(s.getTaskExecutionHandler))
 	r.Handle("/task/{uuid}/execution/{timestamp}/stop", nil, r.POST(s.postStopTaskExecutionHandler))
+	r.Handle("/task/{uuid}/deadletter", nil, r.GET(s.getTaskDeadLettersHandler), r.DELETE(s.deleteTaskDeadLettersHandler))
+	r.Handle("/task/{uuid}/deadletter/{timestamp}", nil, r.GET(s.getTaskDeadLetterHandler), r.PUT(s.putTaskDeadLetterHandler), r.DELETE(s.deleteTaskDeadLetterHandler))
+	r.Handle("/task/{uuid}/deadletter/{timestamp}/replay", nil, r.POST(s.postReplayTaskDeadLetterHandler))
 }
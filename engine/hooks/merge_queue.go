@@ -0,0 +1,157 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+type githubPullRequestEvent struct {
+	Action string `json:"action"`
+	Label  *struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	Review *struct {
+		State string `json:"state"`
+	} `json:"review"`
+	PullRequest struct {
+		Number int64 `json:"number"`
+		Head   struct {
+			Ref string `json:"ref"`
+			Sha string `json:"sha"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+type gitlabLabel struct {
+	Title string `json:"title"`
+}
+
+type gitlabMergeRequestEvent struct {
+	ObjectKind       string `json:"object_kind"`
+	ObjectAttributes struct {
+		IID          int64  `json:"iid"`
+		Action       string `json:"action"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+		LastCommit   struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	Changes struct {
+		Labels *struct {
+			Previous []gitlabLabel `json:"previous"`
+			Current  []gitlabLabel `json:"current"`
+		} `json:"labels"`
+	} `json:"changes"`
+}
+
+func (s *Service) doMergeQueueExecution(ctx context.Context, t *sdk.TaskExecution) error {
+	entry, err := mergeQueueEntryFromWebHook(t)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		log.Debug("Hooks> %s > event does not add a pull request to the merge queue", t.UUID)
+		return nil
+	}
+
+	confProj := t.Config[sdk.HookConfigProject]
+	confWorkflow := t.Config[sdk.HookConfigWorkflow]
+	res, err := s.Client.WorkflowMergeQueueAdd(confProj.Value, confWorkflow.Value, *entry)
+	if err != nil {
+		return sdk.WrapError(err, "unable to add pull request %d to merge queue of workflow %s/%s", entry.PullRequestID, confProj.Value, confWorkflow.Value)
+	}
+	log.Info(ctx, "Hooks> %s > pull request %s#%d added to the merge queue of workflow %s/%s (entry %d)", t.UUID,
+		entry.RepositoryFullname, entry.PullRequestID, confProj.Value, confWorkflow.Value, res.ID)
+	return nil
+}
+
+// mergeQueueEntryFromWebHook returns the merge queue entry for a pull request labelled or approved,
+// or nil if the event should not add a pull request to the merge queue.
+func mergeQueueEntryFromWebHook(t *sdk.TaskExecution) (*sdk.MergeQueueEntry, error) {
+	label := t.Config[sdk.MergeQueueHookModelLabel].Value
+	onApproval, _ := strconv.ParseBool(t.Config[sdk.MergeQueueHookModelApproval].Value)
+
+	entry := sdk.MergeQueueEntry{HookUUID: t.UUID}
+
+	switch getRepositoryHeader(t.WebHook, sdk.MergeQueueHookEvents) {
+	case GithubHeader:
+		var event githubPullRequestEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &event); err != nil {
+			return nil, sdk.WrapError(err, "unable to read github request: %s", string(t.WebHook.RequestBody))
+		}
+		labelled := event.Action == "labeled" && event.Label != nil && label != "" && event.Label.Name == label
+		approved := onApproval && event.Action == "submitted" && event.Review != nil && event.Review.State == "approved"
+		if !labelled && !approved {
+			return nil, nil
+		}
+		entry.RepositoryFullname = event.Repository.FullName
+		entry.PullRequestID = event.PullRequest.Number
+		entry.BaseBranch = event.PullRequest.Base.Ref
+		entry.HeadBranch = event.PullRequest.Head.Ref
+		entry.HeadCommit = event.PullRequest.Head.Sha
+	case GitlabHeader:
+		var event gitlabMergeRequestEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &event); err != nil {
+			return nil, sdk.WrapError(err, "unable to read gitlab request: %s", string(t.WebHook.RequestBody))
+		}
+		if event.ObjectKind != "merge_request" {
+			return nil, nil
+		}
+		labelled := label != "" && event.Changes.Labels != nil &&
+			hasGitlabLabel(event.Changes.Labels.Current, label) && !hasGitlabLabel(event.Changes.Labels.Previous, label)
+		approved := onApproval && event.ObjectAttributes.Action == "approved"
+		if !labelled && !approved {
+			return nil, nil
+		}
+		entry.RepositoryFullname = event.Project.PathWithNamespace
+		entry.PullRequestID = event.ObjectAttributes.IID
+		entry.BaseBranch = event.ObjectAttributes.TargetBranch
+		entry.HeadBranch = event.ObjectAttributes.SourceBranch
+		entry.HeadCommit = event.ObjectAttributes.LastCommit.ID
+	case BitbucketHeader:
+		var event sdk.BitbucketServerWebhookEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &event); err != nil {
+			return nil, sdk.WrapError(err, "unable to read bitbucket request: %s", string(t.WebHook.RequestBody))
+		}
+		// There is no label on Bitbucket Server pull requests, only approvals can be used
+		if !onApproval || event.EventKey != "pr:reviewer:approved" || event.PullRequest == nil {
+			return nil, nil
+		}
+		pr := event.PullRequest
+		entry.RepositoryFullname = pr.ToRef.Repository.Project.Key + "/" + pr.ToRef.Repository.Slug
+		entry.PullRequestID = int64(pr.ID)
+		entry.BaseBranch = pr.ToRef.DisplayID
+		entry.HeadBranch = pr.FromRef.DisplayID
+		entry.HeadCommit = pr.FromRef.LatestCommit
+	default:
+		return nil, nil
+	}
+
+	if err := entry.IsValid(); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func hasGitlabLabel(labels []gitlabLabel, label string) bool {
+	for _, l := range labels {
+		if l.Title == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_mergeQueueEntryFromWebHook(t *testing.T) {
+	config := sdk.WorkflowNodeHookConfig{
+		sdk.MergeQueueHookModelLabel:    {Value: "cds-merge"},
+		sdk.MergeQueueHookModelApproval: {Value: "true"},
+	}
+
+	tests := []struct {
+		name   string
+		header map[string][]string
+		body   string
+		want   *sdk.MergeQueueEntry
+	}{
+		{
+			name:   "github labeled",
+			header: map[string][]string{GithubHeader: {"pull_request"}},
+			body: `{"action": "labeled", "label": {"name": "cds-merge"}, "repository": {"full_name": "foo/bar"},
+				"pull_request": {"number": 42, "head": {"ref": "feat", "sha": "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c"}, "base": {"ref": "master"}}}`,
+			want: &sdk.MergeQueueEntry{HookUUID: "uuid", RepositoryFullname: "foo/bar", PullRequestID: 42, BaseBranch: "master", HeadBranch: "feat", HeadCommit: "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c"},
+		},
+		{
+			name:   "github other label",
+			header: map[string][]string{GithubHeader: {"pull_request"}},
+			body: `{"action": "labeled", "label": {"name": "bug"}, "repository": {"full_name": "foo/bar"},
+				"pull_request": {"number": 42, "head": {"ref": "feat", "sha": "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c"}, "base": {"ref": "master"}}}`,
+		},
+		{
+			name:   "github approved",
+			header: map[string][]string{GithubHeader: {"pull_request_review"}},
+			body: `{"action": "submitted", "review": {"state": "approved"}, "repository": {"full_name": "foo/bar"},
+				"pull_request": {"number": 42, "head": {"ref": "feat", "sha": "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c"}, "base": {"ref": "master"}}}`,
+			want: &sdk.MergeQueueEntry{HookUUID: "uuid", RepositoryFullname: "foo/bar", PullRequestID: 42, BaseBranch: "master", HeadBranch: "feat", HeadCommit: "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c"},
+		},
+		{
+			name:   "github push",
+			header: map[string][]string{GithubHeader: {"push"}},
+			body:   githubPushEvent,
+		},
+		{
+			name:   "gitlab labeled",
+			header: map[string][]string{GitlabHeader: {"Merge Request Hook"}},
+			body: `{"object_kind": "merge_request", "project": {"path_with_namespace": "foo/bar"},
+				"object_attributes": {"iid": 7, "action": "update", "source_branch": "feat", "target_branch": "master", "last_commit": {"id": "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c"}},
+				"changes": {"labels": {"previous": [], "current": [{"title": "cds-merge"}]}}}`,
+			want: &sdk.MergeQueueEntry{HookUUID: "uuid", RepositoryFullname: "foo/bar", PullRequestID: 7, BaseBranch: "master", HeadBranch: "feat", HeadCommit: "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c"},
+		},
+		{
+			name:   "gitlab label already set",
+			header: map[string][]string{GitlabHeader: {"Merge Request Hook"}},
+			body: `{"object_kind": "merge_request", "project": {"path_with_namespace": "foo/bar"},
+				"object_attributes": {"iid": 7, "action": "update", "source_branch": "feat", "target_branch": "master", "last_commit": {"id": "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c"}},
+				"changes": {"labels": {"previous": [{"title": "cds-merge"}], "current": [{"title": "cds-merge"}, {"title": "bug"}]}}}`,
+		},
+		{
+			name:   "bitbucket approved",
+			header: map[string][]string{BitbucketHeader: {"pr:reviewer:approved"}},
+			body: `{"eventKey": "pr:reviewer:approved", "pullRequest": {"id": 3,
+				"fromRef": {"displayId": "feat", "latestCommit": "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c", "repository": {"slug": "bar", "project": {"key": "FOO"}}},
+				"toRef": {"displayId": "master", "latestCommit": "123456", "repository": {"slug": "bar", "project": {"key": "FOO"}}}}}`,
+			want: &sdk.MergeQueueEntry{HookUUID: "uuid", RepositoryFullname: "FOO/bar", PullRequestID: 3, BaseBranch: "master", HeadBranch: "feat", HeadCommit: "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeQueueEntryFromWebHook(&sdk.TaskExecution{
+				UUID:   "uuid",
+				Type:   TypeMergeQueue,
+				Config: config,
+				WebHook: &sdk.WebHookExecution{
+					RequestHeader: tt.header,
+					RequestBody:   []byte(tt.body),
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package hooks
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/grpcplugin"
+	"github.com/ovh/cds/sdk/grpcplugin/hookplugin"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	pluginHookMinBackoff = 5 * time.Second
+	pluginHookMaxBackoff = 5 * time.Minute
+	// pluginHookEventTTL is the duration during which an event received by several hooks services is saved only once
+	pluginHookEventTTL = 5 * time.Minute
+)
+
+var (
+	pluginHooks        = map[string]context.CancelFunc{}
+	pluginHooksMutex   sync.Mutex
+	pluginBinariesLock sync.Mutex
+)
+
+func (s *Service) savePluginExecution(t *sdk.Task, error string, nbError int64) {
+	exec := &sdk.TaskExecution{
+		Timestamp: time.Now().UnixNano(),
+		Type:      t.Type,
+		UUID:      t.UUID,
+		Config:    t.Config,
+		Status:    TaskExecutionDone,
+		LastError: error,
+		NbErrors:  nbError,
+	}
+	s.Dao.SaveTaskExecution(exec)
+}
+
+// startPluginHook starts the hook plugin of the task and listens to the events it streams.
+// Each event is saved as a scheduled execution of the task. If the stream fails or is closed by the plugin,
+// the plugin is restarted with an exponential backoff until the task is stopped.
+func (s *Service) startPluginHook(ctx context.Context, t *sdk.Task) error {
+	pluginName := t.Config[sdk.HookConfigPlugin].Value
+	if pluginName == "" {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing plugin name in configuration of task %s", t.UUID)
+	}
+
+	// The plugin must outlive the context of the caller, it is stopped with the task
+	s.stopPluginHook(t)
+	pluginCtx, cancel := context.WithCancel(context.Background())
+
+	stream, stopListen, err := s.listenPluginHook(pluginCtx, t, pluginName)
+	if err != nil {
+		cancel()
+		_ = s.stopTask(ctx, t)
+		return err
+	}
+
+	pluginHooksMutex.Lock()
+	pluginHooks[t.UUID] = cancel
+	pluginHooksMutex.Unlock()
+
+	sdk.GoRoutine(pluginCtx, "hooks.plugin."+t.UUID, func(ctx context.Context) {
+		backoff := pluginHookMinBackoff
+		for {
+			listenedAt := time.Now()
+			err := s.receivePluginHookEvents(ctx, t, stream)
+			stopListen()
+			if ctx.Err() != nil {
+				return
+			}
+			// The backoff is reset if the plugin has been listening long enough
+			if time.Since(listenedAt) > pluginHookMaxBackoff {
+				backoff = pluginHookMinBackoff
+			}
+			if err != nil {
+				log.Error(ctx, "Hooks> hook plugin %s of task %s has stopped: %v", pluginName, t.UUID, err)
+				s.savePluginExecution(t, err.Error(), 1)
+			} else {
+				log.Warning(ctx, "Hooks> hook plugin %s of task %s has closed its stream", pluginName, t.UUID)
+			}
+
+			// Restart the plugin until it listens again or the task is stopped
+			for {
+				log.Info(ctx, "Hooks> restarting hook plugin %s of task %s in %v", pluginName, t.UUID, backoff)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+				if backoff *= 2; backoff > pluginHookMaxBackoff {
+					backoff = pluginHookMaxBackoff
+				}
+
+				stream, stopListen, err = s.listenPluginHook(ctx, t, pluginName)
+				if err == nil {
+					break
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				log.Error(ctx, "Hooks> unable to restart hook plugin %s of task %s: %v", pluginName, t.UUID, err)
+				s.savePluginExecution(t, err.Error(), 1)
+			}
+		}
+	})
+
+	return nil
+}
+
+// listenPluginHook starts the hook plugin of the task and returns the stream of its events,
+// the plugin is killed by the returned cancel function.
+func (s *Service) listenPluginHook(ctx context.Context, t *sdk.Task, pluginName string) (hookplugin.HookPlugin_ListenClient, context.CancelFunc, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	c, err := s.startHookPlugin(ctx, pluginName)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	manifest, err := c.Manifest(ctx, new(empty.Empty))
+	if err != nil {
+		cancel()
+		return nil, nil, sdk.WrapError(err, "unable to get manifest of hook plugin %s", pluginName)
+	}
+	log.Info(ctx, "Hooks> hook plugin %s version %s started for task %s", manifest.Name, manifest.Version, t.UUID)
+
+	query := hookplugin.ListenQuery{
+		Uuid:    t.UUID,
+		Options: make(map[string]string, len(t.Config)),
+	}
+	for k, v := range t.Config {
+		query.Options[k] = v.Value
+	}
+	stream, err := c.Listen(ctx, &query)
+	if err != nil {
+		cancel()
+		return nil, nil, sdk.WrapError(err, "unable to listen events of hook plugin %s", pluginName)
+	}
+	return stream, cancel, nil
+}
+
+// receivePluginHookEvents saves the events of the stream until it ends, it returns nil if the plugin has closed the stream.
+func (s *Service) receivePluginHookEvents(ctx context.Context, t *sdk.Task, stream hookplugin.HookPlugin_ListenClient) error {
+	for {
+		event, err := stream.Recv()
+		if ctx.Err() != nil || err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !s.claimPluginHookEvent(ctx, t, event) {
+			continue
+		}
+		exec := sdk.TaskExecution{
+			Status:    TaskExecutionScheduled,
+			Config:    t.Config,
+			Type:      TypePlugin,
+			UUID:      t.UUID,
+			Timestamp: time.Now().UnixNano(),
+			Plugin: &sdk.PluginTaskExecution{
+				Payload: event.Payload,
+				Message: event.Message,
+			},
+		}
+		s.Dao.SaveTaskExecution(&exec)
+	}
+}
+
+// claimPluginHookEvent returns true if the event has to be saved by this hooks service. As each hooks service
+// listens to the plugin, an event received by several services is saved only by the first one that claims it.
+func (s *Service) claimPluginHookEvent(ctx context.Context, t *sdk.Task, event *hookplugin.HookEvent) bool {
+	btes, err := json.Marshal(event)
+	if err != nil {
+		log.Error(ctx, "Hooks> unable to marshal event of hook plugin of task %s: %v", t.UUID, err)
+		return true
+	}
+	hash := sha256.Sum256(btes)
+	k := cache.Key("hooks", "plugin", "event", t.UUID, hex.EncodeToString(hash[:]))
+	claimed, err := s.Cache.Lock(k, pluginHookEventTTL, 0, 1)
+	if err != nil {
+		log.Error(ctx, "Hooks> unable to claim event of hook plugin of task %s: %v", t.UUID, err)
+		return true
+	}
+	return claimed
+}
+
+func (s *Service) stopPluginHook(t *sdk.Task) {
+	pluginHooksMutex.Lock()
+	defer pluginHooksMutex.Unlock()
+	if cancel, has := pluginHooks[t.UUID]; has {
+		cancel()
+		delete(pluginHooks, t.UUID)
+	}
+}
+
+// startHookPlugin downloads the binary of the plugin for the current os and arch if it is not cached yet,
+// then starts it and returns a client connected to it.
+func (s *Service) startHookPlugin(ctx context.Context, pluginName string) (hookplugin.HookPluginClient, error) {
+	currentOS := strings.ToLower(sdk.GOOS)
+	currentARCH := strings.ToLower(sdk.GOARCH)
+
+	binary, err := s.Client.PluginGetBinaryInfos(pluginName, currentOS, currentARCH)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to get binary infos of hook plugin %s", pluginName)
+	}
+	if binary == nil {
+		return nil, sdk.NewErrorFrom(sdk.ErrNotFound, "hook plugin %s has no binary for %s/%s", pluginName, currentOS, currentARCH)
+	}
+
+	pluginsDir := s.Cfg.PluginsDirectory
+	if pluginsDir == "" {
+		pluginsDir = filepath.Join(os.TempDir(), "cds-hooks-plugins")
+	}
+	// Binaries are stored by checksum so that a running plugin is never overwritten by a new version
+	dir := filepath.Join(pluginsDir, pluginName, fmt.Sprintf("%s-%s-%s", currentOS, currentARCH, binary.SHA512sum))
+	if err := s.downloadHookPlugin(pluginName, dir, binary); err != nil {
+		return nil, err
+	}
+
+	fs := afero.NewBasePathFs(afero.NewOsFs(), dir)
+	if _, err := sdk.LookPath(fs, binary.Cmd); err != nil {
+		return nil, sdk.WrapError(err, "unable to find command %s of hook plugin %s", binary.Cmd, pluginName)
+	}
+	cmd := path.Join(dir, binary.Cmd)
+	args := make([]string, 0, len(binary.Entrypoints)+len(binary.Args))
+	for _, e := range binary.Entrypoints {
+		args = append(args, path.Join(dir, e))
+	}
+	args = append(args, binary.Args...)
+
+	envs := make([]string, 0, len(os.Environ()))
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, "CDS_") {
+			continue
+		}
+		envs = append(envs, env)
+	}
+
+	stdPipe, socket, err := grpcplugin.StartPlugin(ctx, pluginName, dir, cmd, args, envs)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to start hook plugin %s", pluginName)
+	}
+
+	// Forward the output of the plugin to the logs of the service
+	go func() {
+		scanner := bufio.NewScanner(stdPipe)
+		for scanner.Scan() {
+			log.Info(ctx, "Hooks> plugin %s> %s", pluginName, scanner.Text())
+		}
+	}()
+
+	c, err := hookplugin.Client(ctx, socket)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to connect to hook plugin %s", pluginName)
+	}
+	return c, nil
+}
+
+func (s *Service) downloadHookPlugin(pluginName, dir string, binary *sdk.GRPCPluginBinary) error {
+	pluginBinariesLock.Lock()
+	defer pluginBinariesLock.Unlock()
+
+	if _, err := os.Stat(filepath.Join(dir, binary.Cmd)); err == nil {
+		log.Debug("Hooks> hook plugin binary %s is in cache %s", pluginName, dir)
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, os.FileMode(0700)); err != nil {
+		return sdk.WrapError(err, "unable to create directory %s", dir)
+	}
+	fs := afero.NewBasePathFs(afero.NewOsFs(), dir)
+
+	buf := new(bytes.Buffer)
+	if err := s.Client.PluginGetBinary(pluginName, binary.OS, binary.Arch, buf); err != nil {
+		return sdk.WrapError(err, "unable to download binary of hook plugin %s", pluginName)
+	}
+	content := buf.Bytes()
+
+	switch {
+	case sdk.IsTar(content):
+		if err := sdk.Untar(fs, "", bytes.NewReader(content)); err != nil {
+			return sdk.WrapError(err, "unable to untar binary of hook plugin %s", pluginName)
+		}
+	case sdk.IsGz(content):
+		if err := sdk.UntarGz(fs, "", bytes.NewReader(content)); err != nil {
+			return sdk.WrapError(err, "unable to untarGz binary of hook plugin %s", pluginName)
+		}
+	default:
+		if err := afero.WriteFile(fs, binary.Name, content, os.FileMode(binary.Perm)); err != nil {
+			return sdk.WrapError(err, "unable to write binary of hook plugin %s", pluginName)
+		}
+	}
+	return nil
+}
+
+func (s *Service) doPluginTaskExecution(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error) {
+	log.Debug("Hooks> Processing plugin %s %s", t.UUID, t.Type)
+
+	// Prepare a struct to send to CDS API
+	h := sdk.WorkflowNodeRunHookEvent{
+		WorkflowNodeHookUUID: t.UUID,
+		Payload:              make(map[string]string, len(t.Plugin.Payload)+1),
+	}
+	for k, v := range t.Plugin.Payload {
+		h.Payload[k] = v
+	}
+	if len(t.Plugin.Message) > 0 {
+		h.Payload["payload"] = string(t.Plugin.Message)
+	}
+
+	return &h, nil
+}
@@ -0,0 +1,67 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/grpcplugin/hookplugin"
+)
+
+func Test_hookToTaskPlugin(t *testing.T) {
+	m := sdk.NewHookPluginModel(sdk.GRPCPlugin{
+		Name:       "my-queue",
+		Type:       sdk.GRPCPluginHook,
+		Parameters: []sdk.Parameter{{Name: "queue", Type: sdk.StringParameter}},
+	})
+	h := sdk.NodeHook{
+		UUID:          "uuid",
+		HookModelName: m.Name,
+		Config:        m.DefaultConfig.Clone(),
+	}
+
+	s := Service{}
+	task, err := s.hookToTask(&h)
+	require.NoError(t, err)
+	assert.Equal(t, TypePlugin, task.Type)
+	assert.Equal(t, "my-queue", task.Config[sdk.HookConfigPlugin].Value)
+
+	delete(h.Config, sdk.HookConfigPlugin)
+	_, err = s.hookToTask(&h)
+	assert.Error(t, err)
+}
+
+func Test_doPluginTaskExecution(t *testing.T) {
+	s := Service{}
+	h, err := s.doPluginTaskExecution(&sdk.TaskExecution{
+		UUID: "uuid",
+		Type: TypePlugin,
+		Plugin: &sdk.PluginTaskExecution{
+			Payload: map[string]string{"git.branch": "master"},
+			Message: []byte(`{"id": 1}`),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "uuid", h.WorkflowNodeHookUUID)
+	assert.Equal(t, map[string]string{"git.branch": "master", "payload": `{"id": 1}`}, h.Payload)
+}
+
+func Test_claimPluginHookEvent(t *testing.T) {
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	other, cancelOther := setupTestHookService(t)
+	defer cancelOther()
+
+	task := &sdk.Task{UUID: sdk.UUID(), Type: TypePlugin}
+	event := &hookplugin.HookEvent{Payload: map[string]string{"git.branch": "master"}, Message: []byte(sdk.UUID())}
+
+	// The event is saved by only one of the hooks services
+	assert.True(t, s.claimPluginHookEvent(context.TODO(), task, event))
+	assert.False(t, other.claimPluginHookEvent(context.TODO(), task, event))
+
+	// Another event is saved
+	assert.True(t, other.claimPluginHookEvent(context.TODO(), task, &hookplugin.HookEvent{Message: []byte(sdk.UUID())}))
+}
@@ -0,0 +1,139 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+type githubIssueCommentEvent struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number      int64     `json:"number"`
+		PullRequest *struct{} `json:"pull_request"`
+	} `json:"issue"`
+	Comment struct {
+		Body string `json:"body"`
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"comment"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+type gitlabNoteEvent struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	ObjectAttributes struct {
+		Note         string `json:"note"`
+		NoteableType string `json:"noteable_type"`
+		System       bool   `json:"system"`
+	} `json:"object_attributes"`
+	MergeRequest *struct {
+		IID          int64  `json:"iid"`
+		SourceBranch string `json:"source_branch"`
+		LastCommit   struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"merge_request"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+}
+
+func (s *Service) doPullRequestCommandExecution(ctx context.Context, t *sdk.TaskExecution) error {
+	command, err := pullRequestCommandFromWebHook(t)
+	if err != nil {
+		return err
+	}
+	if command == nil {
+		log.Debug("Hooks> %s > event is not a command on a pull request", t.UUID)
+		return nil
+	}
+
+	confProj := t.Config[sdk.HookConfigProject]
+	confWorkflow := t.Config[sdk.HookConfigWorkflow]
+	run, err := s.Client.WorkflowPullRequestCommand(confProj.Value, confWorkflow.Value, *command)
+	if err != nil {
+		return sdk.WrapError(err, "unable to run %q from pull request %d on workflow %s/%s", command.String(), command.PullRequestID, confProj.Value, confWorkflow.Value)
+	}
+	t.WorkflowRun = run.Number
+	log.Info(ctx, "Hooks> %s > workflow %s/%s#%d triggered by %q from %s on pull request %s#%d", t.UUID,
+		confProj.Value, confWorkflow.Value, run.Number, command.String(), command.Author, command.RepositoryFullname, command.PullRequestID)
+	return nil
+}
+
+// pullRequestCommandFromWebHook returns the command written in a new comment of a pull request,
+// or nil if the event is not a comment with one of the commands of the hook.
+func pullRequestCommandFromWebHook(t *sdk.TaskExecution) (*sdk.PullRequestCommand, error) {
+	commands := strings.Split(t.Config[sdk.PullRequestCommandModelList].Value, ";")
+
+	command := sdk.PullRequestCommand{HookUUID: t.UUID}
+	var comment string
+
+	switch getRepositoryHeader(t.WebHook, sdk.PullRequestCommandHookEvents) {
+	case GithubHeader:
+		var event githubIssueCommentEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &event); err != nil {
+			return nil, sdk.WrapError(err, "unable to read github request: %s", string(t.WebHook.RequestBody))
+		}
+		// Pull requests are issues, the head of the pull request is not given with the comment
+		if event.Action != "created" || event.Issue.PullRequest == nil {
+			return nil, nil
+		}
+		comment = event.Comment.Body
+		command.RepositoryFullname = event.Repository.FullName
+		command.PullRequestID = event.Issue.Number
+		command.Author = event.Comment.User.Login
+	case GitlabHeader:
+		var event gitlabNoteEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &event); err != nil {
+			return nil, sdk.WrapError(err, "unable to read gitlab request: %s", string(t.WebHook.RequestBody))
+		}
+		if event.ObjectKind != "note" || event.ObjectAttributes.NoteableType != "MergeRequest" ||
+			event.ObjectAttributes.System || event.MergeRequest == nil {
+			return nil, nil
+		}
+		comment = event.ObjectAttributes.Note
+		command.RepositoryFullname = event.Project.PathWithNamespace
+		command.PullRequestID = event.MergeRequest.IID
+		command.HeadBranch = event.MergeRequest.SourceBranch
+		command.HeadCommit = event.MergeRequest.LastCommit.ID
+		command.Author = event.User.Username
+	case BitbucketHeader:
+		var event sdk.BitbucketServerWebhookEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &event); err != nil {
+			return nil, sdk.WrapError(err, "unable to read bitbucket request: %s", string(t.WebHook.RequestBody))
+		}
+		if event.EventKey != "pr:comment:added" || event.PullRequest == nil || event.Comment == nil {
+			return nil, nil
+		}
+		pr := event.PullRequest
+		comment = event.Comment.Text
+		command.RepositoryFullname = pr.ToRef.Repository.Project.Key + "/" + pr.ToRef.Repository.Slug
+		command.PullRequestID = int64(pr.ID)
+		command.HeadBranch = pr.FromRef.DisplayID
+		command.HeadCommit = pr.FromRef.LatestCommit
+		command.Author = event.Comment.Author.Name
+	default:
+		return nil, nil
+	}
+
+	var found bool
+	command.Command, command.Args, found = sdk.ParsePullRequestCommand(comment, commands)
+	if !found {
+		return nil, nil
+	}
+
+	if err := command.IsValid(); err != nil {
+		return nil, err
+	}
+	return &command, nil
+}
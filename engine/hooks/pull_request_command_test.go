@@ -0,0 +1,93 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_pullRequestCommandFromWebHook(t *testing.T) {
+	config := sdk.WorkflowNodeHookConfig{
+		sdk.PullRequestCommandModelList: {Value: "rerun;deploy"},
+	}
+
+	tests := []struct {
+		name   string
+		header map[string][]string
+		body   string
+		want   *sdk.PullRequestCommand
+	}{
+		{
+			name:   "github pull request comment",
+			header: map[string][]string{GithubHeader: {"issue_comment"}},
+			body: `{"action": "created", "repository": {"full_name": "foo/bar"},
+				"issue": {"number": 42, "pull_request": {"url": "https://api.github.com/repos/foo/bar/pulls/42"}},
+				"comment": {"body": "/cds deploy staging", "user": {"login": "alice"}}}`,
+			want: &sdk.PullRequestCommand{HookUUID: "uuid", RepositoryFullname: "foo/bar", PullRequestID: 42, Author: "alice", Command: "deploy", Args: []string{"staging"}},
+		},
+		{
+			name:   "github issue comment",
+			header: map[string][]string{GithubHeader: {"issue_comment"}},
+			body: `{"action": "created", "repository": {"full_name": "foo/bar"},
+				"issue": {"number": 42}, "comment": {"body": "/cds rerun", "user": {"login": "alice"}}}`,
+		},
+		{
+			name:   "github edited comment",
+			header: map[string][]string{GithubHeader: {"issue_comment"}},
+			body: `{"action": "edited", "repository": {"full_name": "foo/bar"},
+				"issue": {"number": 42, "pull_request": {}}, "comment": {"body": "/cds rerun", "user": {"login": "alice"}}}`,
+		},
+		{
+			name:   "github unknown command",
+			header: map[string][]string{GithubHeader: {"issue_comment"}},
+			body: `{"action": "created", "repository": {"full_name": "foo/bar"},
+				"issue": {"number": 42, "pull_request": {}}, "comment": {"body": "/cds destroy", "user": {"login": "alice"}}}`,
+		},
+		{
+			name:   "github push",
+			header: map[string][]string{GithubHeader: {"push"}},
+			body:   githubPushEvent,
+		},
+		{
+			name:   "gitlab merge request note",
+			header: map[string][]string{GitlabHeader: {"Note Hook"}},
+			body: `{"object_kind": "note", "user": {"username": "bob"}, "project": {"path_with_namespace": "foo/bar"},
+				"object_attributes": {"note": "LGTM\n/cds rerun", "noteable_type": "MergeRequest"},
+				"merge_request": {"iid": 7, "source_branch": "feat", "last_commit": {"id": "abcdef"}}}`,
+			want: &sdk.PullRequestCommand{HookUUID: "uuid", RepositoryFullname: "foo/bar", PullRequestID: 7, HeadBranch: "feat", HeadCommit: "abcdef", Author: "bob", Command: "rerun", Args: []string{}},
+		},
+		{
+			name:   "gitlab commit note",
+			header: map[string][]string{GitlabHeader: {"Note Hook"}},
+			body: `{"object_kind": "note", "user": {"username": "bob"}, "project": {"path_with_namespace": "foo/bar"},
+				"object_attributes": {"note": "/cds rerun", "noteable_type": "Commit"}}`,
+		},
+		{
+			name:   "bitbucket pull request comment",
+			header: map[string][]string{BitbucketHeader: {"pr:comment:added"}},
+			body: `{"eventKey": "pr:comment:added", "comment": {"text": "/cds deploy prod eu", "author": {"name": "carol"}}, "pullRequest": {"id": 3,
+				"fromRef": {"displayId": "feat", "latestCommit": "abcdef", "repository": {"slug": "bar", "project": {"key": "FOO"}}},
+				"toRef": {"displayId": "master", "latestCommit": "123456", "repository": {"slug": "bar", "project": {"key": "FOO"}}}}}`,
+			want: &sdk.PullRequestCommand{HookUUID: "uuid", RepositoryFullname: "FOO/bar", PullRequestID: 3, HeadBranch: "feat", HeadCommit: "abcdef", Author: "carol", Command: "deploy", Args: []string{"prod", "eu"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pullRequestCommandFromWebHook(&sdk.TaskExecution{
+				UUID:   "uuid",
+				Type:   TypePullRequestCommand,
+				Config: config,
+				WebHook: &sdk.WebHookExecution{
+					RequestHeader: tt.header,
+					RequestBody:   []byte(tt.body),
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -3,13 +3,26 @@ package hooks
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	dump "github.com/fsamin/go-dump"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
 
-func (s *Service) doScheduledTaskExecution(ctx context.Context, t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error) {
+const blackoutDateFormat = "2006-01-02"
+
+// scheduledRun is the last workflow run of a scheduled task on a branch, it is kept with the task
+// as the executions of the task are purged.
+type scheduledRun struct {
+	WorkflowRun int64  `json:"workflow_run"`
+	GitHash     string `json:"git_hash"`
+}
+
+func (s *Service) doScheduledTaskExecution(ctx context.Context, task *sdk.Task, t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error) {
 	log.Debug("Hooks> Processing scheduled task %s", t.UUID)
 
 	// Prepare a struct to send to CDS API
@@ -44,14 +57,132 @@ func (s *Service) doScheduledTaskExecution(ctx context.Context, t *sdk.TaskExecu
 	}
 	for k, v := range t.Config {
 		switch k {
-		case sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.SchedulerModelCron, sdk.SchedulerModelTimezone, sdk.Payload:
+		case sdk.HookConfigProject, sdk.HookConfigWorkflow, sdk.SchedulerModelCron, sdk.SchedulerModelTimezone, sdk.Payload,
+			sdk.SchedulerModelBlackout, sdk.SchedulerModelJitter, sdk.SchedulerModelSkipIfRunning, sdk.SchedulerModelOnlyIfNewCommits:
 		default:
 			payloadValues[k] = v.Value
 		}
 	}
+
+	//Check the options of the scheduler, the execution is skipped if one of them is not satisfied
+	skipReason, err := s.scheduledTaskSkipReason(ctx, task, t, payloadValues["git.branch"])
+	if err != nil {
+		return nil, err
+	}
+	if skipReason != "" {
+		log.Info(ctx, "Hooks> doScheduledTaskExecution> execution %s:%d skipped: %s", t.UUID, t.Timestamp, skipReason)
+		t.ScheduledTask.SkipReason = skipReason
+		return nil, nil
+	}
+
+	//Run the workflow on the commit that has been checked
+	if t.ScheduledTask.GitHash != "" {
+		payloadValues["git.branch"] = t.ScheduledTask.GitBranch
+		payloadValues["git.hash"] = t.ScheduledTask.GitHash
+	}
+
 	payloadValues["cds.triggered_by.username"] = "cds.scheduler"
 	payloadValues["cds.triggered_by.fullname"] = "CDS Scheduler"
 	h.Payload = payloadValues
 
 	return &h, nil
 }
+
+// scheduledTaskSkipReason returns why the scheduled execution must not run the workflow,
+// or an empty string if it can run.
+func (s *Service) scheduledTaskSkipReason(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution, branch string) (string, error) {
+	if reason, err := blackoutSkipReason(e); err != nil || reason != "" {
+		return reason, err
+	}
+
+	confProj := e.Config[sdk.HookConfigProject]
+	confWorkflow := e.Config[sdk.HookConfigWorkflow]
+
+	if skip, _ := strconv.ParseBool(e.Config[sdk.SchedulerModelSkipIfRunning].Value); skip {
+		runs, err := s.Client.WorkflowRunList(confProj.Value, confWorkflow.Value, 0, 50)
+		if err != nil {
+			return "", sdk.WrapError(err, "unable to list runs of workflow %s/%s", confProj.Value, confWorkflow.Value)
+		}
+		for _, r := range runs {
+			if !sdk.StatusIsTerminated(r.Status) {
+				return fmt.Sprintf("workflow run #%d is still %s", r.Number, r.Status), nil
+			}
+		}
+	}
+
+	if onlyIfNew, _ := strconv.ParseBool(e.Config[sdk.SchedulerModelOnlyIfNewCommits].Value); onlyIfNew {
+		head, err := s.Client.WorkflowRepositoryHead(confProj.Value, confWorkflow.Value, branch)
+		if err != nil {
+			return "", sdk.WrapError(err, "unable to get head of the repository of workflow %s/%s", confProj.Value, confWorkflow.Value)
+		}
+		e.ScheduledTask.GitBranch = head.DisplayID
+		e.ScheduledTask.GitHash = head.LatestCommit
+
+		runs, err := s.Dao.FindLastScheduledRuns(t)
+		if err != nil {
+			return "", err
+		}
+		if last, has := runs[e.ScheduledTask.GitBranch]; has && last.GitHash == e.ScheduledTask.GitHash {
+			return fmt.Sprintf("no new commit on %s since workflow run #%d", e.ScheduledTask.GitBranch, last.WorkflowRun), nil
+		}
+	}
+
+	return "", nil
+}
+
+// saveLastScheduledRun keeps the commit on which the execution has run the workflow, to compare it
+// with the head of the branch at the next execution.
+func (s *Service) saveLastScheduledRun(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution) {
+	if e.ScheduledTask == nil || e.ScheduledTask.GitHash == "" {
+		return
+	}
+	runs, err := s.Dao.FindLastScheduledRuns(t)
+	if err != nil {
+		log.Error(ctx, "Hooks> saveLastScheduledRun> %v", err)
+		return
+	}
+	runs[e.ScheduledTask.GitBranch] = scheduledRun{WorkflowRun: e.WorkflowRun, GitHash: e.ScheduledTask.GitHash}
+	if err := s.Dao.SaveLastScheduledRuns(t, runs); err != nil {
+		log.Error(ctx, "Hooks> saveLastScheduledRun> unable to save last scheduled runs of task %s: %v", t.UUID, err)
+	}
+}
+
+// previewScheduledTaskExecution fills the checks that will be done by a scheduled execution
+// and the reason why it will be skipped if it is already known.
+func previewScheduledTaskExecution(e *sdk.TaskExecution) {
+	if e.Type != TypeScheduler || e.Status != TaskExecutionScheduled || e.ScheduledTask == nil {
+		return
+	}
+	e.ScheduledTask.Checks = nil
+	if e.Config[sdk.SchedulerModelBlackout].Value != "" {
+		e.ScheduledTask.Checks = append(e.ScheduledTask.Checks, sdk.SchedulerModelBlackout)
+	}
+	for _, k := range []string{sdk.SchedulerModelSkipIfRunning, sdk.SchedulerModelOnlyIfNewCommits} {
+		if b, _ := strconv.ParseBool(e.Config[k].Value); b {
+			e.ScheduledTask.Checks = append(e.ScheduledTask.Checks, k)
+		}
+	}
+	reason, err := blackoutSkipReason(e)
+	if err != nil {
+		reason = err.Error()
+	}
+	e.ScheduledTask.SkipReason = reason
+}
+
+// blackoutSkipReason returns the entry of the blackout calendar that contains the date of the execution.
+func blackoutSkipReason(e *sdk.TaskExecution) (string, error) {
+	calendar := e.Config[sdk.SchedulerModelBlackout].Value
+	if strings.TrimSpace(calendar) == "" {
+		return "", nil
+	}
+	loc, err := time.LoadLocation(e.Config[sdk.SchedulerModelTimezone].Value)
+	if err != nil {
+		return "", sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid timezone %q", e.Config[sdk.SchedulerModelTimezone].Value)
+	}
+	date := time.Unix(0, e.Timestamp).In(loc)
+	entry, err := sdk.SchedulerBlackoutEntry(calendar, date)
+	if err != nil || entry == "" {
+		return "", err
+	}
+	return fmt.Sprintf("%s is in blackout period %s", date.Format(blackoutDateFormat), entry), nil
+}
@@ -0,0 +1,124 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient/mock_cdsclient"
+)
+
+func Test_previewScheduledTaskExecution(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	e := sdk.TaskExecution{
+		Type:      TypeScheduler,
+		Status:    TaskExecutionScheduled,
+		Timestamp: time.Date(2020, 12, 25, 0, 30, 0, 0, loc).UnixNano(),
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.SchedulerModelTimezone:         {Value: "Europe/Paris"},
+			sdk.SchedulerModelBlackout:         {Value: "12-25"},
+			sdk.SchedulerModelSkipIfRunning:    {Value: "true"},
+			sdk.SchedulerModelOnlyIfNewCommits: {Value: "false"},
+		},
+		ScheduledTask: &sdk.ScheduledTaskExecution{},
+	}
+	previewScheduledTaskExecution(&e)
+	assert.Equal(t, []string{sdk.SchedulerModelBlackout, sdk.SchedulerModelSkipIfRunning}, e.ScheduledTask.Checks)
+	assert.Equal(t, "2020-12-25 is in blackout period 12-25", e.ScheduledTask.SkipReason)
+
+	// The same instant is still the 24th in UTC
+	e.Config[sdk.SchedulerModelTimezone] = sdk.WorkflowNodeHookConfigValue{Value: "UTC"}
+	previewScheduledTaskExecution(&e)
+	assert.Empty(t, e.ScheduledTask.SkipReason)
+}
+
+func Test_scheduledTaskSkipReasonIfRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock_cdsclient.NewMockInterface(ctrl)
+	s := Service{}
+	s.Client = client
+
+	e := sdk.TaskExecution{
+		UUID:      "uuid",
+		Type:      TypeScheduler,
+		Timestamp: time.Now().UnixNano(),
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigProject:           {Value: "PROJ"},
+			sdk.HookConfigWorkflow:          {Value: "wf"},
+			sdk.SchedulerModelTimezone:      {Value: "UTC"},
+			sdk.SchedulerModelSkipIfRunning: {Value: "true"},
+		},
+		ScheduledTask: &sdk.ScheduledTaskExecution{},
+	}
+	task := sdk.Task{UUID: e.UUID, Type: e.Type, Config: e.Config}
+
+	client.EXPECT().WorkflowRunList("PROJ", "wf", int64(0), int64(50)).Return([]sdk.WorkflowRun{
+		{Number: 3, Status: sdk.StatusBuilding},
+		{Number: 2, Status: sdk.StatusSuccess},
+	}, nil)
+	reason, err := s.scheduledTaskSkipReason(context.TODO(), &task, &e, "")
+	require.NoError(t, err)
+	assert.Equal(t, "workflow run #3 is still Building", reason)
+
+	client.EXPECT().WorkflowRunList("PROJ", "wf", int64(0), int64(50)).Return([]sdk.WorkflowRun{
+		{Number: 2, Status: sdk.StatusSuccess},
+	}, nil)
+	reason, err = s.scheduledTaskSkipReason(context.TODO(), &task, &e, "")
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+}
+
+func Test_scheduledTaskSkipReasonIfNoNewCommit(t *testing.T) {
+	s, cancel := setupTestHookService(t)
+	defer cancel()
+	client := s.Client.(*mock_cdsclient.MockInterface)
+
+	task := sdk.Task{
+		UUID: sdk.UUID(),
+		Type: TypeScheduler,
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigProject:              {Value: "PROJ"},
+			sdk.HookConfigWorkflow:             {Value: "wf"},
+			sdk.SchedulerModelTimezone:         {Value: "UTC"},
+			sdk.SchedulerModelOnlyIfNewCommits: {Value: "true"},
+		},
+	}
+	defer s.Dao.DeleteTask(context.TODO(), &task) // nolint
+	newExecution := func() *sdk.TaskExecution {
+		return &sdk.TaskExecution{
+			UUID:          task.UUID,
+			Type:          task.Type,
+			Timestamp:     time.Now().UnixNano(),
+			Config:        task.Config,
+			ScheduledTask: &sdk.ScheduledTaskExecution{},
+		}
+	}
+
+	// No workflow run yet
+	client.EXPECT().WorkflowRepositoryHead("PROJ", "wf", "").Return(&sdk.VCSBranch{DisplayID: "master", LatestCommit: "aaa"}, nil)
+	e := newExecution()
+	reason, err := s.scheduledTaskSkipReason(context.TODO(), &task, e, "")
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+
+	// The last run is kept with the task, not with its executions that can be purged
+	e.WorkflowRun = 4
+	s.saveLastScheduledRun(context.TODO(), &task, e)
+
+	client.EXPECT().WorkflowRepositoryHead("PROJ", "wf", "").Return(&sdk.VCSBranch{DisplayID: "master", LatestCommit: "aaa"}, nil)
+	reason, err = s.scheduledTaskSkipReason(context.TODO(), &task, newExecution(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "no new commit on master since workflow run #4", reason)
+
+	client.EXPECT().WorkflowRepositoryHead("PROJ", "wf", "").Return(&sdk.VCSBranch{DisplayID: "master", LatestCommit: "bbb"}, nil)
+	reason, err = s.scheduledTaskSkipReason(context.TODO(), &task, newExecution(), "")
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+}
@@ -88,6 +88,14 @@ func (s *Service) retryTaskExecutionsRoutine(ctx context.Context) error {
 						continue
 					}
 
+					// executions that have exhausted their retries are kept in the dead letter of the task
+					if s.isDeadLetter(&e) {
+						if err := s.moveToDeadLetter(ctx, &e); err != nil {
+							log.Error(ctx, "Hooks> retryTaskExecutionsRoutine > %v", err)
+						}
+						continue
+					}
+
 					// old hooks
 					if e.ProcessingTimestamp == 0 && e.Timestamp < time.Now().Add(-2*time.Minute).UnixNano() {
 						if e.UUID == "" {
@@ -127,6 +135,7 @@ func (s *Service) retryTaskExecutionsRoutine(ctx context.Context) error {
 					}
 				}
 			}
+			s.recordDeadLetterSize(ctx)
 		}
 	}
 }
@@ -219,6 +228,12 @@ func (s *Service) deleteTaskExecutionsRoutine(ctx context.Context) error {
 						}
 					default:
 						if i >= s.Cfg.ExecutionHistory && e.ProcessingTimestamp != 0 {
+							if s.isDeadLetter(&e) {
+								if err := s.moveToDeadLetter(ctx, &e); err != nil {
+									log.Error(ctx, "Hooks> deleteTaskExecutionsRoutine > %v", err)
+								}
+								continue
+							}
 							if err := s.Dao.DeleteTaskExecution(&e); err != nil {
 								log.Error(ctx, "Hooks> deleteTaskExecutionsRoutine > error on DeleteTaskExecution: %v", err)
 							}
@@ -276,6 +291,7 @@ func (s *Service) dequeueTaskExecutions(ctx context.Context) error {
 		if !find {
 			continue
 		}
+		lastError := t.LastError
 		t.ProcessingTimestamp = time.Now().UnixNano()
 		t.LastError = ""
 		t.Status = TaskExecutionDoing
@@ -295,6 +311,13 @@ func (s *Service) dequeueTaskExecutions(ctx context.Context) error {
 			continue
 
 		} else if t.NbErrors >= s.Cfg.RetryError {
+			t.LastError = lastError
+			if s.isDeadLetter(&t) {
+				if err := s.moveToDeadLetter(ctx, &t); err != nil {
+					log.Error(ctx, "Hooks> dequeueTaskExecutions > %v", err)
+				}
+				continue
+			}
 			log.Info(ctx, "Hooks> dequeueTaskExecutions> Deleting task execution %s cause: to many errors:%d lastError:%s", t.UUID, t.NbErrors, t.LastError)
 			if err := s.Dao.DeleteTaskExecution(&t); err != nil {
 				log.Error(ctx, "Hooks> dequeueTaskExecutions > error on DeleteTaskExecution: %v", err)
@@ -302,7 +325,7 @@ func (s *Service) dequeueTaskExecutions(ctx context.Context) error {
 			continue
 
 		} else if task.Stopped {
-			t.LastError = "Executions skipped: Task has been stopped"
+			t.LastError = taskExecutionStoppedError
 			t.NbErrors++
 			saveTaskExecution = true
 		} else {
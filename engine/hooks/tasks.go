@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"strconv"
 	"time"
 
@@ -27,6 +28,9 @@ const (
 	TypeWorkflowHook       = "Workflow"
 	TypeOutgoingWebHook    = "OutgoingWebhook"
 	TypeOutgoingWorkflow   = "OutgoingWorkflow"
+	TypeMergeQueue         = "MergeQueue"
+	TypePullRequestCommand = "PullRequestCommand"
+	TypePlugin             = "Plugin"
 
 	GithubHeader         = "X-Github-Event"
 	GitlabHeader         = "X-Gitlab-Event"
@@ -42,6 +46,9 @@ const (
 var (
 	rootKey           = cache.Key("hooks", "tasks")
 	executionRootKey  = cache.Key("hooks", "tasks", "executions")
+	deadLetterRootKey = cache.Key("hooks", "tasks", "deadletter")
+	deadLetterAllKey  = cache.Key("hooks", "deadletter")
+	scheduledRunKey   = cache.Key("hooks", "tasks", "scheduledrun")
 	schedulerQueueKey = cache.Key("hooks", "scheduler", "queue")
 	gerritRepoKey     = cache.Key("hooks", "gerrit", "repo")
 	gerritRepoHooks   = make(map[string]bool)
@@ -183,11 +190,40 @@ func (s *Service) hookToTask(h *sdk.NodeHook) (*sdk.Task, error) {
 			Value:        fmt.Sprintf("%s/webhook/%s", s.Cfg.URLPublic, h.UUID),
 			Configurable: false,
 		}
+		if err := initWebHookSecret(h); err != nil {
+			return nil, err
+		}
 		return &sdk.Task{
 			UUID:   h.UUID,
 			Type:   TypeRepoManagerWebHook,
 			Config: h.Config,
 		}, nil
+	case sdk.MergeQueueHookModelName:
+		h.Config["webHookURL"] = sdk.WorkflowNodeHookConfigValue{
+			Value:        fmt.Sprintf("%s/webhook/%s", s.Cfg.URLPublic, h.UUID),
+			Configurable: false,
+		}
+		if err := initWebHookSecret(h); err != nil {
+			return nil, err
+		}
+		return &sdk.Task{
+			UUID:   h.UUID,
+			Type:   TypeMergeQueue,
+			Config: h.Config,
+		}, nil
+	case sdk.PullRequestCommandModelName:
+		h.Config["webHookURL"] = sdk.WorkflowNodeHookConfigValue{
+			Value:        fmt.Sprintf("%s/webhook/%s", s.Cfg.URLPublic, h.UUID),
+			Configurable: false,
+		}
+		if err := initWebHookSecret(h); err != nil {
+			return nil, err
+		}
+		return &sdk.Task{
+			UUID:   h.UUID,
+			Type:   TypePullRequestCommand,
+			Config: h.Config,
+		}, nil
 	case sdk.SchedulerModelName:
 		return &sdk.Task{
 			UUID:   h.UUID,
@@ -206,6 +242,14 @@ func (s *Service) hookToTask(h *sdk.NodeHook) (*sdk.Task, error) {
 			Type: TypeWorkflowHook,
 		}, nil
 	}
+	// Hooks provided by a plugin have the name of the plugin in their configuration
+	if _, ok := h.Config[sdk.HookConfigPlugin]; ok {
+		return &sdk.Task{
+			UUID:   h.UUID,
+			Type:   TypePlugin,
+			Config: h.Config,
+		}, nil
+	}
 	return nil, fmt.Errorf("Unsupported hook: %s", h.HookModelName)
 }
 
@@ -258,7 +302,7 @@ func (s *Service) startTask(ctx context.Context, t *sdk.Task) (*sdk.TaskExecutio
 	}
 
 	switch t.Type {
-	case TypeWebHook, TypeRepoManagerWebHook, TypeWorkflowHook:
+	case TypeWebHook, TypeRepoManagerWebHook, TypeWorkflowHook, TypeMergeQueue, TypePullRequestCommand:
 		return nil, nil
 	case TypeScheduler, TypeRepoPoller, TypeBranchDeletion:
 		return nil, s.prepareNextScheduledTaskExecution(ctx, t)
@@ -272,6 +316,8 @@ func (s *Service) startTask(ctx context.Context, t *sdk.Task) (*sdk.TaskExecutio
 		return s.startOutgoingWorkflowTask(t)
 	case TypeGerrit:
 		return nil, s.startGerritHookTask(t)
+	case TypePlugin:
+		return nil, s.startPluginHook(ctx, t)
 	default:
 		return nil, fmt.Errorf("Unsupported task type %s", t.Type)
 	}
@@ -303,6 +349,7 @@ func (s *Service) prepareNextScheduledTaskExecution(ctx context.Context, t *sdk.
 
 	var exec *sdk.TaskExecution
 	var nextSchedule time.Time
+	var jitter time.Duration
 	switch t.Type {
 	case TypeScheduler:
 		//Parse the cron expr
@@ -316,6 +363,19 @@ func (s *Service) prepareNextScheduledTaskExecution(ctx context.Context, t *sdk.
 		t0 := time.Now().In(loc)
 		nextSchedule = cronExpr.Next(t0)
 
+		//Delay the execution randomly to spread the executions of the schedulers with the same cron
+		if confJitter := t.Config[sdk.SchedulerModelJitter]; confJitter.Value != "" {
+			// An invalid jitter must not stop the scheduler, the execution is scheduled without delay
+			maxJitter, err := time.ParseDuration(confJitter.Value)
+			if err != nil {
+				log.Error(ctx, "Hooks> Scheduled task %s: unable to parse jitter %q: %v", t.UUID, confJitter.Value, err)
+			}
+			if maxJitter > 0 {
+				jitter = time.Duration(rand.Int63n(int64(maxJitter)))
+				nextSchedule = nextSchedule.Add(jitter)
+			}
+		}
+
 	case TypeRepoPoller:
 		// Default value of next scheduling
 		nextSchedule = time.Now().Add(time.Minute)
@@ -342,6 +402,9 @@ func (s *Service) prepareNextScheduledTaskExecution(ctx context.Context, t *sdk.
 			DateScheduledExecution: fmt.Sprintf("%v", nextSchedule),
 		},
 	}
+	if jitter > 0 {
+		exec.ScheduledTask.Jitter = jitter.Round(time.Second).String()
+	}
 
 	s.Dao.SaveTaskExecution(exec)
 	//We don't push in queue, we will the scheduler to run it
@@ -359,13 +422,17 @@ func (s *Service) stopTask(ctx context.Context, t *sdk.Task) error {
 	}
 
 	switch t.Type {
-	case TypeWebHook, TypeScheduler, TypeRepoManagerWebHook, TypeRepoPoller, TypeKafka, TypeWorkflowHook:
+	case TypeWebHook, TypeScheduler, TypeRepoManagerWebHook, TypeRepoPoller, TypeKafka, TypeWorkflowHook, TypeMergeQueue, TypePullRequestCommand:
 		log.Debug("Hooks> Tasks %s has been stopped", t.UUID)
 		return nil
 	case TypeGerrit:
 		s.stopGerritHookTask(t)
 		log.Debug("Hooks> Gerrit Task %s has been stopped", t.UUID)
 		return nil
+	case TypePlugin:
+		s.stopPluginHook(t)
+		log.Debug("Hooks> Plugin Task %s has been stopped", t.UUID)
+		return nil
 	default:
 		return fmt.Errorf("Unsupported task type %s", t.Type)
 	}
@@ -391,8 +458,12 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 		err = s.doOutgoingWorkflowExecution(ctx, e)
 	case e.WebHook != nil && (e.Type == TypeWebHook || e.Type == TypeRepoManagerWebHook):
 		hs, err = s.doWebHookExecution(ctx, e)
+	case e.WebHook != nil && e.Type == TypeMergeQueue:
+		err = s.doMergeQueueExecution(ctx, e)
+	case e.WebHook != nil && e.Type == TypePullRequestCommand:
+		err = s.doPullRequestCommandExecution(ctx, e)
 	case e.ScheduledTask != nil && e.Type == TypeScheduler:
-		h, err = s.doScheduledTaskExecution(ctx, e)
+		h, err = s.doScheduledTaskExecution(ctx, t, e)
 		doRestart = true
 	case e.ScheduledTask != nil && e.Type == TypeRepoPoller:
 		//Populate next execution
@@ -404,6 +475,8 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 		h, err = s.doKafkaTaskExecution(e)
 	case e.RabbitMQ != nil && e.Type == TypeRabbitMQ:
 		h, err = s.doRabbitMQTaskExecution(e)
+	case e.Plugin != nil && e.Type == TypePlugin:
+		h, err = s.doPluginTaskExecution(e)
 	default:
 		err = fmt.Errorf("Unsupported task type %s", e.Type)
 	}
@@ -430,6 +503,9 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 		} else {
 			//Save the run number
 			e.WorkflowRun = run.Number
+			if e.Type == TypeScheduler {
+				s.saveLastScheduledRun(ctx, t, e)
+			}
 			log.Debug("Hooks> workflow %s/%s#%d has been triggered", confProj.Value, confWorkflow.Value, run.Number)
 		}
 	}
@@ -12,8 +12,12 @@ const (
 	TaskExecutionDoing     = "DOING"
 	TaskExecutionDone      = "DONE"
 	TaskExecutionScheduled = "SCHEDULED"
+	TaskExecutionRejected  = "REJECTED"
 )
 
+// taskExecutionStoppedError is the last error of the executions skipped because their task is stopped
+const taskExecutionStoppedError = "Executions skipped: Task has been stopped"
+
 // Service is the stuct representing a hooks µService
 type Service struct {
 	service.Common
@@ -31,14 +35,16 @@ type Configuration struct {
 		Addr string `toml:"addr" default:"" commented:"true" comment:"Listen address without port, example: 127.0.0.1" json:"addr"`
 		Port int    `toml:"port" default:"8083" json:"port"`
 	} `toml:"http" comment:"######################\n CDS Hooks HTTP Configuration \n######################" json:"http"`
-	URL              string                          `toml:"url" default:"http://localhost:8083" json:"url"`
-	URLPublic        string                          `toml:"urlPublic" comment:"Public url for external call (webhook)" json:"urlPublic"`
-	RetryDelay       int64                           `toml:"retryDelay" default:"120" comment:"Execution retry delay in seconds" json:"retryDelay"`
-	RetryError       int64                           `toml:"retryError" default:"3" comment:"Retry execution while this number of error is not reached" json:"retryError"`
-	ExecutionHistory int                             `toml:"executionHistory" default:"10" comment:"Number of execution to keep" json:"executionHistory"`
-	Disable          bool                            `toml:"disable" default:"false" comment:"Disable all hooks executions" json:"disable"`
-	API              service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
-	Cache            struct {
+	URL               string                          `toml:"url" default:"http://localhost:8083" json:"url"`
+	URLPublic         string                          `toml:"urlPublic" comment:"Public url for external call (webhook)" json:"urlPublic"`
+	RetryDelay        int64                           `toml:"retryDelay" default:"120" comment:"Execution retry delay in seconds" json:"retryDelay"`
+	RetryError        int64                           `toml:"retryError" default:"3" comment:"Retry execution while this number of error is not reached" json:"retryError"`
+	ExecutionHistory  int                             `toml:"executionHistory" default:"10" comment:"Number of execution to keep" json:"executionHistory"`
+	DeadLetterMaxSize int                             `toml:"deadLetterMaxSize" default:"100" comment:"Number of failed executions to keep in the dead letter of each task, the oldest ones are removed. 0 to keep all" json:"deadLetterMaxSize"`
+	Disable           bool                            `toml:"disable" default:"false" comment:"Disable all hooks executions" json:"disable"`
+	PluginsDirectory  string                          `toml:"pluginsDirectory" comment:"Directory where the binaries of the hook plugins are downloaded, default is a temporary directory" json:"pluginsDirectory"`
+	API               service.APIServiceConfiguration `toml:"api" comment:"######################\n CDS API Settings \n######################" json:"api"`
+	Cache             struct {
 		TTL   int `toml:"ttl" default:"60" json:"ttl"`
 		Redis struct {
 			Host     string `toml:"host" default:"localhost:6379" comment:"If your want to use a redis-sentinel based cluster, follow this syntax! <clustername>@sentinel1:26379,sentinel2:26379,sentinel3:26379" json:"host"`
@@ -0,0 +1,93 @@
+package hooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Headers carrying the proof that a repository webhook was sent by the repository manager
+const (
+	GithubSignatureHeader    = "X-Hub-Signature-256"
+	GitlabTokenHeader        = "X-Gitlab-Token"
+	BitbucketSignatureHeader = "X-Hub-Signature"
+)
+
+// initWebHookSecret generates the shared secret of a new repository webhook.
+// Hooks already registered on the repository manager (with a webHookID) are not updated:
+// the repository manager doesn't know the secret, their deliveries are not verified.
+func initWebHookSecret(h *sdk.NodeHook) error {
+	if h.Config[sdk.HookConfigWebHookSecret].Value != "" || h.Config[sdk.HookConfigWebHookID].Value != "" {
+		return nil
+	}
+	secret, err := sdk.GenerateHash()
+	if err != nil {
+		return err
+	}
+	h.Config[sdk.HookConfigWebHookSecret] = sdk.WorkflowNodeHookConfigValue{
+		Value:        secret,
+		Configurable: false,
+		Type:         sdk.HookConfigTypeString,
+	}
+	return nil
+}
+
+// checkRepositoryWebHookSignature checks a delivery against the shared secret of the task,
+// according to the repository manager which sent it.
+func checkRepositoryWebHookSignature(secret string, header http.Header, body []byte) error {
+	switch {
+	case header.Get(GithubHeader) != "":
+		return checkHMACSignature(secret, header.Get(GithubSignatureHeader), body)
+	case header.Get(GitlabHeader) != "":
+		token := header.Get(GitlabTokenHeader)
+		if token == "" {
+			return sdk.NewErrorFrom(sdk.ErrUnauthorized, "missing %s header", GitlabTokenHeader)
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid %s header", GitlabTokenHeader)
+		}
+		return nil
+	case header.Get(BitbucketHeader) != "":
+		return checkHMACSignature(secret, header.Get(BitbucketSignatureHeader), body)
+	}
+	return sdk.NewErrorFrom(sdk.ErrUnauthorized, "unknown repository manager event")
+}
+
+func checkHMACSignature(secret, signature string, body []byte) error {
+	if signature == "" {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "missing signature")
+	}
+	if !strings.HasPrefix(signature, "sha256=") {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "unsupported signature algorithm")
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid signature")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return sdk.NewErrorFrom(sdk.ErrUnauthorized, "invalid signature")
+	}
+	return nil
+}
+
+// withoutWebHookSecret returns a copy of the config of a task without its shared secret,
+// for the executions visible by the users
+func withoutWebHookSecret(config sdk.WorkflowNodeHookConfig) sdk.WorkflowNodeHookConfig {
+	if _, has := config[sdk.HookConfigWebHookSecret]; !has {
+		return config
+	}
+	res := make(sdk.WorkflowNodeHookConfig, len(config))
+	for k, v := range config {
+		if k != sdk.HookConfigWebHookSecret {
+			res[k] = v
+		}
+	}
+	return res
+}
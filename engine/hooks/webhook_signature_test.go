@@ -0,0 +1,71 @@
+package hooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_checkRepositoryWebHookSignature(t *testing.T) {
+	secret := "my-secret"
+	body := []byte(githubPushEvent)
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name   string
+		header http.Header
+		valid  bool
+	}{
+		{"github", http.Header{GithubHeader: {"push"}, GithubSignatureHeader: {signature}}, true},
+		{"github without signature", http.Header{GithubHeader: {"push"}}, false},
+		{"github with an invalid signature", http.Header{GithubHeader: {"push"}, GithubSignatureHeader: {"sha256=0d1a26e6"}}, false},
+		{"github with a sha1 signature", http.Header{GithubHeader: {"push"}, GithubSignatureHeader: {"sha1=0d1a26e6"}}, false},
+		{"gitlab", http.Header{GitlabHeader: {"Push Hook"}, GitlabTokenHeader: {secret}}, true},
+		{"gitlab without token", http.Header{GitlabHeader: {"Push Hook"}}, false},
+		{"gitlab with an invalid token", http.Header{GitlabHeader: {"Push Hook"}, GitlabTokenHeader: {"other"}}, false},
+		{"bitbucket", http.Header{BitbucketHeader: {"repo:refs_changed"}, BitbucketSignatureHeader: {signature}}, true},
+		{"bitbucket with an invalid signature", http.Header{BitbucketHeader: {"repo:refs_changed"}, BitbucketSignatureHeader: {"sha256=zz"}}, false},
+		{"unknown event", http.Header{BitbucketSignatureHeader: {signature}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRepositoryWebHookSignature(secret, tt.header, body)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.True(t, sdk.ErrorIs(err, sdk.ErrUnauthorized))
+			}
+		})
+	}
+}
+
+func Test_initWebHookSecret(t *testing.T) {
+	h := sdk.NodeHook{Config: sdk.WorkflowNodeHookConfig{}}
+	require.NoError(t, initWebHookSecret(&h))
+	secret := h.Config[sdk.HookConfigWebHookSecret].Value
+	assert.NotEmpty(t, secret)
+	assert.False(t, h.Config[sdk.HookConfigWebHookSecret].Configurable)
+
+	// The secret is kept by later updates
+	require.NoError(t, initWebHookSecret(&h))
+	assert.Equal(t, secret, h.Config[sdk.HookConfigWebHookSecret].Value)
+	assert.NotContains(t, withoutWebHookSecret(h.Config), sdk.HookConfigWebHookSecret)
+	assert.Contains(t, h.Config, sdk.HookConfigWebHookSecret)
+
+	// A hook already registered without secret stays unverified
+	legacy := sdk.NodeHook{Config: sdk.WorkflowNodeHookConfig{
+		sdk.HookConfigWebHookID: sdk.WorkflowNodeHookConfigValue{Value: "42"},
+	}}
+	require.NoError(t, initWebHookSecret(&legacy))
+	assert.NotContains(t, legacy.Config, sdk.HookConfigWebHookSecret)
+}
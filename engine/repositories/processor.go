@@ -73,6 +73,24 @@ func (s *Service) do(ctx context.Context, op sdk.Operation) error {
 			op.Error = ""
 			op.Status = sdk.OperationStatusDone
 		}
+	// Merge a pull request on a temporary branch
+	case op.Setup.Merge.ToBranch != "":
+		if err := s.processMerge(ctx, &op); err != nil {
+			op.Error = sdk.Cause(err).Error()
+			op.Status = sdk.OperationStatusError
+		} else {
+			op.Error = ""
+			op.Status = sdk.OperationStatusDone
+		}
+	// Delete a temporary branch
+	case op.Setup.DeleteBranch.Branch != "":
+		if err := s.processDeleteBranch(ctx, &op); err != nil {
+			op.Error = sdk.Cause(err).Error()
+			op.Status = sdk.OperationStatusError
+		} else {
+			op.Error = ""
+			op.Status = sdk.OperationStatusDone
+		}
 	default:
 		op.Error = "unrecognized setup"
 		op.Status = sdk.OperationStatusError
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/fsamin/go-repo"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (s *Service) processMerge(ctx context.Context, op *sdk.Operation) error {
+	gitRepo, path, _, err := s.processGitClone(ctx, op)
+	if err != nil {
+		return sdk.WrapError(err, "unable to process gitclone")
+	}
+
+	if op.Setup.Merge.BaseBranch == "" {
+		op.Setup.Merge.BaseBranch = op.RepositoryInfo.DefaultBranch
+	}
+
+	if err := gitRepo.FetchRemoteBranch("origin", op.Setup.Merge.BaseBranch); err != nil {
+		log.Error(ctx, "Repositories> processMerge> FetchRemoteBranch %s> [%s] error %v", op.Setup.Merge.BaseBranch, op.UUID, err)
+		return sdk.WrapError(err, "cannot fetch branch %s", op.Setup.Merge.BaseBranch)
+	}
+
+	// Merge the head commit if given, else the head of the branch. The head of a pull request is fetched
+	// from the repository of its target as its branch may be on a fork
+	ref := "origin/" + op.Setup.Merge.HeadBranch
+	if op.Setup.Merge.PullRequestID != 0 {
+		prRef, err := fetchPullRequest(gitRepo, op.Setup.Merge.PullRequestID)
+		if err != nil {
+			log.Error(ctx, "Repositories> processMerge> fetchPullRequest %d> [%s] error %v", op.Setup.Merge.PullRequestID, op.UUID, err)
+			return err
+		}
+		ref = prRef
+	} else if err := gitRepo.FetchRemoteBranch("origin", op.Setup.Merge.HeadBranch); err != nil {
+		log.Error(ctx, "Repositories> processMerge> FetchRemoteBranch %s> [%s] error %v", op.Setup.Merge.HeadBranch, op.UUID, err)
+		return sdk.WrapError(err, "cannot fetch branch %s", op.Setup.Merge.HeadBranch)
+	}
+	if op.Setup.Merge.HeadCommit != "" {
+		if !sdk.IsCommitHash(op.Setup.Merge.HeadCommit) {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "invalid head commit %q", op.Setup.Merge.HeadCommit)
+		}
+		ref = op.Setup.Merge.HeadCommit
+	}
+
+	// Create the merge branch from the base branch
+	if err := gitRepo.CheckoutNewBranch(op.Setup.Merge.ToBranch); err != nil {
+		if !strings.Contains(err.Error(), "already exists") {
+			return sdk.WrapError(err, "cannot create branch %s", op.Setup.Merge.ToBranch)
+		}
+		if err := gitRepo.Checkout(op.Setup.Merge.ToBranch); err != nil {
+			return sdk.WrapError(err, "cannot checkout branch %s", op.Setup.Merge.ToBranch)
+		}
+	}
+	if err := gitRepo.ResetHard("origin/" + op.Setup.Merge.BaseBranch); err != nil {
+		log.Error(ctx, "Repositories> processMerge> ResetHard> [%s] Error: %v", op.UUID, err)
+		return err
+	}
+
+	if err := gitMerge(ctx, path, ref, op); err != nil {
+		log.Error(ctx, "Repositories> processMerge> Merge %s> [%s] error %v", ref, op.UUID, err)
+		return err
+	}
+
+	if err := gitRepo.Push("origin", op.Setup.Merge.ToBranch); err != nil {
+		log.Error(ctx, "Repositories> processMerge> push %s> [%s] error %v", op.Setup.Merge.ToBranch, op.UUID, err)
+		return sdk.WrapError(err, "push %s> [%s] error %v", op.Setup.Merge.ToBranch, op.UUID, err)
+	}
+
+	c, err := gitRepo.LatestCommit()
+	if err != nil {
+		return sdk.WrapError(err, "cannot get merge commit")
+	}
+	op.Setup.Merge.Commit = c.LongHash
+
+	log.Debug("Repositories> processMerge> %s merged on %s at %s", ref, op.Setup.Merge.ToBranch, c.LongHash)
+	return nil
+}
+
+// gitMerge creates a merge commit of given ref on the current branch, the merge is aborted on conflict.
+func gitMerge(ctx context.Context, path, ref string, op *sdk.Operation) error {
+	message := op.Setup.Merge.Message
+	if message == "" {
+		message = fmt.Sprintf("Merge %s into %s", op.Setup.Merge.HeadBranch, op.Setup.Merge.BaseBranch)
+	}
+
+	// The ref can't be taken as an option
+	cmd := exec.CommandContext(ctx, "git", "merge", "--no-ff", "--no-edit", "-m", message, "--", ref)
+	cmd.Dir = path
+	cmd.Env = os.Environ()
+	if op.User.Username != "" && op.User.Email != "" {
+		cmd.Env = append(cmd.Env,
+			"GIT_AUTHOR_NAME="+op.User.Username, "GIT_AUTHOR_EMAIL="+op.User.Email,
+			"GIT_COMMITTER_NAME="+op.User.Username, "GIT_COMMITTER_EMAIL="+op.User.Email,
+		)
+	}
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		abort := exec.CommandContext(ctx, "git", "merge", "--abort")
+		abort.Dir = path
+		_ = abort.Run()
+		return sdk.WithStack(fmt.Errorf("cannot merge %s on %s: %s", ref, op.Setup.Merge.BaseBranch, strings.TrimSpace(string(out))))
+	}
+	return nil
+}
+
+// pullRequestRefs are the refs of the head of a pull request on GitHub and Bitbucket Cloud, GitLab and Bitbucket Server.
+var pullRequestRefs = []string{"refs/pull/%d/head", "refs/merge-requests/%d/head", "refs/pull-requests/%d/from"}
+
+// fetchPullRequest fetches the head of the pull request from origin and returns its remote ref.
+// The fetch refspec of origin is replaced during the fetch as only the default refspec can be fetched
+// with the authentication of the repository.
+func fetchPullRequest(gitRepo repo.Repo, id int64) (string, error) {
+	const defaultRefSpec = "+refs/heads/*:refs/remotes/origin/*"
+	defer gitRepo.LocalConfigSet("remote.origin", "fetch", defaultRefSpec) // nolint
+
+	branch := fmt.Sprintf("cds-pull-request/%d", id)
+	var errs []string
+	for _, r := range pullRequestRefs {
+		refSpec := fmt.Sprintf("+"+r+":refs/remotes/origin/%s", id, branch)
+		if err := gitRepo.LocalConfigSet("remote.origin", "fetch", refSpec); err != nil {
+			return "", sdk.WrapError(err, "cannot set fetch refspec")
+		}
+		if err := gitRepo.FetchRemoteBranch("origin", branch); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		return "origin/" + branch, nil
+	}
+	return "", sdk.WithStack(fmt.Errorf("cannot fetch pull request %d: %s", id, strings.Join(errs, ", ")))
+}
+
+func (s *Service) processDeleteBranch(ctx context.Context, op *sdk.Operation) error {
+	gitRepo, _, _, err := s.processGitClone(ctx, op)
+	if err != nil {
+		return sdk.WrapError(err, "unable to process gitclone")
+	}
+
+	if err := gitRepo.Push("origin", ":"+op.Setup.DeleteBranch.Branch); err != nil {
+		// The branch may have already been deleted
+		if strings.Contains(err.Error(), "remote ref does not exist") {
+			return nil
+		}
+		log.Error(ctx, "Repositories> processDeleteBranch> push %s> [%s] error %v", op.Setup.DeleteBranch.Branch, op.UUID, err)
+		return sdk.WrapError(err, "cannot delete branch %s", op.Setup.DeleteBranch.Branch)
+	}
+
+	log.Debug("Repositories> processDeleteBranch> branch %s deleted", op.Setup.DeleteBranch.Branch)
+	return nil
+}
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE workflow_node_run ADD COLUMN concurrency_group TEXT;
+select create_index('workflow_node_run', 'IDX_WORKFLOW_NODE_RUN_CONCURRENCY_GROUP', 'concurrency_group,status');
+
+-- +migrate Down
+DROP INDEX IF EXISTS IDX_WORKFLOW_NODE_RUN_CONCURRENCY_GROUP;
+ALTER TABLE workflow_node_run DROP COLUMN concurrency_group;
@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_node_run_artifact_blob" (
+  id BIGSERIAL PRIMARY KEY,
+  project_id BIGINT NOT NULL,
+  project_integration_id BIGINT NOT NULL DEFAULT 0,
+  sha512sum VARCHAR(128) NOT NULL,
+  size BIGINT NOT NULL DEFAULT 0,
+  ref_count BIGINT NOT NULL DEFAULT 0,
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_W_NODE_RUN_ARTIFACT_BLOB_PROJECT', 'workflow_node_run_artifact_blob', 'project', 'project_id', 'id');
+SELECT create_unique_index('workflow_node_run_artifact_blob', 'IDX_W_NODE_RUN_ARTIFACT_BLOB_SHA512SUM', 'project_id,project_integration_id,sha512sum');
+
+ALTER TABLE workflow_node_run_artifacts ADD COLUMN blob_container TEXT;
+
+-- +migrate Down
+ALTER TABLE workflow_node_run_artifacts DROP COLUMN blob_container;
+DROP TABLE IF EXISTS "workflow_node_run_artifact_blob";
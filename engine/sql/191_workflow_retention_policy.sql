@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE workflow ADD COLUMN retention_policy JSONB;
+
+-- +migrate Down
+ALTER TABLE workflow DROP COLUMN retention_policy;
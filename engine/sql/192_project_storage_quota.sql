@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "project_storage_quota" (
+  project_id BIGINT PRIMARY KEY,
+  artifacts BIGINT NOT NULL DEFAULT 0,
+  static_files BIGINT NOT NULL DEFAULT 0,
+  cache BIGINT NOT NULL DEFAULT 0
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_STORAGE_QUOTA_PROJECT', 'project_storage_quota', 'project', 'project_id', 'id');
+
+CREATE TABLE IF NOT EXISTS "project_storage_cache" (
+  id BIGSERIAL PRIMARY KEY,
+  project_id BIGINT NOT NULL,
+  project_integration_id BIGINT NOT NULL DEFAULT 0,
+  tag VARCHAR(256) NOT NULL,
+  size BIGINT NOT NULL DEFAULT 0,
+  last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_STORAGE_CACHE_PROJECT', 'project_storage_cache', 'project', 'project_id', 'id');
+SELECT create_unique_index('project_storage_cache', 'IDX_PROJECT_STORAGE_CACHE_TAG', 'project_id,project_integration_id,tag');
+
+ALTER TABLE workflow_node_run_static_files ADD COLUMN size BIGINT NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE workflow_node_run_static_files DROP COLUMN size;
+DROP TABLE IF EXISTS "project_storage_cache";
+DROP TABLE IF EXISTS "project_storage_quota";
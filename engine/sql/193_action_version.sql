@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE action ADD COLUMN version VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE action ADD COLUMN published_from_id BIGINT;
+SELECT create_foreign_key_idx_cascade('FK_ACTION_PUBLISHED_FROM', 'action', 'action', 'published_from_id', 'id');
+
+-- +migrate Down
+ALTER TABLE action DROP COLUMN published_from_id;
+ALTER TABLE action DROP COLUMN version;
@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_merge_queue" (
+  id BIGSERIAL PRIMARY KEY,
+  project_id BIGINT NOT NULL,
+  workflow_id BIGINT NOT NULL,
+  hook_uuid VARCHAR(256) NOT NULL,
+  consumer_id VARCHAR(256) NOT NULL DEFAULT '',
+  vcs_server VARCHAR(256) NOT NULL DEFAULT '',
+  repository_fullname VARCHAR(256) NOT NULL,
+  pull_request_id BIGINT NOT NULL,
+  base_branch VARCHAR(256) NOT NULL,
+  head_branch VARCHAR(256) NOT NULL,
+  head_commit VARCHAR(256) NOT NULL,
+  merge_branch VARCHAR(256) NOT NULL DEFAULT '',
+  merge_commit VARCHAR(256) NOT NULL DEFAULT '',
+  operation_uuid VARCHAR(256) NOT NULL DEFAULT '',
+  workflow_run_id BIGINT NOT NULL DEFAULT 0,
+  workflow_run_number BIGINT NOT NULL DEFAULT 0,
+  status VARCHAR(50) NOT NULL,
+  message TEXT NOT NULL DEFAULT '',
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+  last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_MERGE_QUEUE_PROJECT', 'workflow_merge_queue', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_MERGE_QUEUE_WORKFLOW', 'workflow_merge_queue', 'workflow', 'workflow_id', 'id');
+SELECT create_index('workflow_merge_queue', 'IDX_WORKFLOW_MERGE_QUEUE_STATUS', 'status');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_merge_queue";
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "event_log" (
+  id BIGSERIAL PRIMARY KEY,
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+  event_type VARCHAR(256) NOT NULL,
+  project_key VARCHAR(256) NOT NULL DEFAULT '',
+  workflow_name VARCHAR(256) NOT NULL DEFAULT '',
+  data JSONB NOT NULL
+);
+SELECT create_index('event_log', 'IDX_EVENT_LOG_CREATED', 'created');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "event_log";
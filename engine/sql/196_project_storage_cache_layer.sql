@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "project_storage_cache_layer" (
+  id BIGSERIAL PRIMARY KEY,
+  project_id BIGINT NOT NULL,
+  project_integration_id BIGINT NOT NULL DEFAULT 0,
+  digest VARCHAR(64) NOT NULL,
+  size BIGINT NOT NULL DEFAULT 0,
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+  last_used TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_STORAGE_CACHE_LAYER_PROJECT', 'project_storage_cache_layer', 'project', 'project_id', 'id');
+SELECT create_unique_index('project_storage_cache_layer', 'IDX_PROJECT_STORAGE_CACHE_LAYER_DIGEST', 'project_id,project_integration_id,digest');
+SELECT create_index('project_storage_cache_layer', 'IDX_PROJECT_STORAGE_CACHE_LAYER_LAST_USED', 'last_used');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "project_storage_cache_layer";
@@ -0,0 +1,23 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_test_result" (
+  id BIGSERIAL PRIMARY KEY,
+  workflow_id BIGINT NOT NULL,
+  workflow_run_id BIGINT NOT NULL,
+  workflow_node_run_id BIGINT NOT NULL,
+  run_number BIGINT NOT NULL,
+  node_name VARCHAR(256) NOT NULL DEFAULT '',
+  suite TEXT NOT NULL DEFAULT '',
+  name TEXT NOT NULL,
+  status VARCHAR(50) NOT NULL,
+  duration DOUBLE PRECISION NOT NULL DEFAULT 0,
+  vcs_branch VARCHAR(256) NOT NULL DEFAULT '',
+  vcs_hash VARCHAR(256) NOT NULL DEFAULT '',
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_TEST_RESULT_WORKFLOW', 'workflow_test_result', 'workflow', 'workflow_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_TEST_RESULT_WORKFLOW_RUN', 'workflow_test_result', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_index('workflow_test_result', 'IDX_WORKFLOW_TEST_RESULT_RUN_NUMBER', 'workflow_id,run_number');
+SELECT create_index('workflow_test_result', 'IDX_WORKFLOW_TEST_RESULT_NAME', 'workflow_id,suite,name');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_test_result";
@@ -0,0 +1,15 @@
+-- +migrate Up
+ALTER TABLE workflow_node_run_coverage ADD COLUMN diff JSONB;
+
+CREATE TABLE IF NOT EXISTS "workflow_node_run_coverage_file" (
+  workflow_node_run_id BIGINT NOT NULL,
+  path TEXT NOT NULL,
+  covered_lines BIGINT[],
+  uncovered_lines BIGINT[],
+  PRIMARY KEY (workflow_node_run_id, path)
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_COVERAGE_FILE_COVERAGE', 'workflow_node_run_coverage_file', 'workflow_node_run_coverage', 'workflow_node_run_id', 'workflow_node_run_id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_node_run_coverage_file";
+ALTER TABLE workflow_node_run_coverage DROP COLUMN diff;
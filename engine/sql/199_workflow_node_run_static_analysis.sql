@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_node_run_static_analysis" (
+  id BIGSERIAL PRIMARY KEY,
+  application_id BIGINT,
+  workflow_id BIGINT,
+  workflow_run_id BIGINT,
+  workflow_node_run_id BIGINT,
+  workflow_node_name VARCHAR(256),
+  workflow_number BIGINT,
+  branch VARCHAR(256),
+  report JSONB
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_STATIC_ANALYSIS_APPLICATION', 'workflow_node_run_static_analysis', 'application', 'application_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_STATIC_ANALYSIS_RUN', 'workflow_node_run_static_analysis', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_index('workflow_node_run_static_analysis', 'IDX_WORKFLOW_NODE_RUN_STATIC_ANALYSIS_NODE_RUN', 'workflow_node_run_id');
+SELECT create_index('workflow_node_run_static_analysis', 'IDX_WORKFLOW_NODE_RUN_STATIC_ANALYSIS_BRANCH', 'workflow_id,workflow_node_name,branch,workflow_number');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_node_run_static_analysis";
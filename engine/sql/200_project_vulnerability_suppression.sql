@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "project_vulnerability_suppression" (
+  id BIGSERIAL PRIMARY KEY,
+  project_id BIGINT NOT NULL,
+  cve VARCHAR(100) NOT NULL,
+  justification TEXT NOT NULL,
+  expiry TIMESTAMP WITH TIME ZONE NOT NULL,
+  author VARCHAR(256) NOT NULL DEFAULT '',
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_VULNERABILITY_SUPPRESSION_PROJECT', 'project_vulnerability_suppression', 'project', 'project_id', 'id');
+SELECT create_unique_index('project_vulnerability_suppression', 'IDX_PROJECT_VULNERABILITY_SUPPRESSION_CVE_UNIQ', 'project_id,cve');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "project_vulnerability_suppression";
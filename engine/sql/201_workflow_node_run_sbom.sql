@@ -0,0 +1,37 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_node_run_sbom" (
+  id BIGSERIAL PRIMARY KEY,
+  project_id BIGINT,
+  application_id BIGINT,
+  workflow_id BIGINT,
+  workflow_run_id BIGINT,
+  workflow_node_run_id BIGINT,
+  workflow_node_name VARCHAR(256),
+  workflow_number BIGINT,
+  branch VARCHAR(256),
+  name VARCHAR(256),
+  format VARCHAR(32),
+  spec_version VARCHAR(32),
+  sha256 VARCHAR(64),
+  size BIGINT,
+  components BIGINT,
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_SBOM_PROJECT', 'workflow_node_run_sbom', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_SBOM_RUN', 'workflow_node_run_sbom', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_index('workflow_node_run_sbom', 'IDX_WORKFLOW_NODE_RUN_SBOM_NODE_RUN', 'workflow_node_run_id');
+
+CREATE TABLE IF NOT EXISTS "workflow_node_run_sbom_component" (
+  id BIGSERIAL PRIMARY KEY,
+  sbom_id BIGINT,
+  name VARCHAR(512),
+  version VARCHAR(256),
+  purl TEXT
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_SBOM_COMPONENT_SBOM', 'workflow_node_run_sbom_component', 'workflow_node_run_sbom', 'sbom_id', 'id');
+CREATE INDEX idx_workflow_node_run_sbom_component_name ON workflow_node_run_sbom_component (lower(name), version);
+SELECT create_index('workflow_node_run_sbom_component', 'IDX_WORKFLOW_NODE_RUN_SBOM_COMPONENT_PURL', 'purl');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_node_run_sbom_component";
+DROP TABLE IF EXISTS "workflow_node_run_sbom";
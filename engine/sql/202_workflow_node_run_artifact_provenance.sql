@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_node_run_artifact_provenance" (
+  id BIGSERIAL PRIMARY KEY,
+  artifact_id BIGINT,
+  workflow_run_id BIGINT,
+  key_id VARCHAR(64),
+  created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+  envelope JSONB
+);
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_ARTIFACT_PROVENANCE_ARTIFACT', 'workflow_node_run_artifact_provenance', 'workflow_node_run_artifacts', 'artifact_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_NODE_RUN_ARTIFACT_PROVENANCE_RUN', 'workflow_node_run_artifact_provenance', 'workflow_run', 'workflow_run_id', 'id');
+SELECT create_unique_index('workflow_node_run_artifact_provenance', 'IDX_WORKFLOW_NODE_RUN_ARTIFACT_PROVENANCE_ARTIFACT', 'artifact_id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_node_run_artifact_provenance";
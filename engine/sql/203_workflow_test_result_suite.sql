@@ -0,0 +1,10 @@
+-- +migrate Up
+-- The suites reported several times in a node run were stored with the id of their job, ie. 'suite.1234'
+UPDATE workflow_test_result r SET suite = regexp_replace(r.suite, '\.[0-9]+$', '')
+WHERE r.suite ~ '\.[0-9]+$' AND EXISTS (
+  SELECT 1 FROM workflow_test_result o
+  WHERE o.workflow_node_run_id = r.workflow_node_run_id AND o.suite = regexp_replace(r.suite, '\.[0-9]+$', '')
+);
+
+-- +migrate Down
+SELECT 1;
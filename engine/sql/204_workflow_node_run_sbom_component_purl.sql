@@ -0,0 +1,9 @@
+-- +migrate Up
+-- Package URLs without qualifiers and subpath, to search the components with an index
+ALTER TABLE "workflow_node_run_sbom_component" ADD COLUMN IF NOT EXISTS purl_base TEXT;
+UPDATE workflow_node_run_sbom_component SET purl_base = regexp_replace(purl, '[?#].*$', '') WHERE purl IS NOT NULL;
+SELECT create_index('workflow_node_run_sbom_component', 'IDX_WORKFLOW_NODE_RUN_SBOM_COMPONENT_PURL_BASE', 'purl_base');
+
+-- +migrate Down
+DROP INDEX IF EXISTS IDX_WORKFLOW_NODE_RUN_SBOM_COMPONENT_PURL_BASE;
+ALTER TABLE "workflow_node_run_sbom_component" DROP COLUMN IF EXISTS purl_base;
@@ -172,3 +172,17 @@ func (client *bitbucketcloudClient) CommitsBetweenRefs(ctx context.Context, repo
 
 	return commitsResult, nil
 }
+
+// DiffBetweenRefs returns the lines added in head since its merge base with base
+func (client *bitbucketcloudClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	// The diff spec head..base compares head with its merge base with base
+	path := fmt.Sprintf("/repositories/%s/diff/%s..%s", repo, url.PathEscape(head), url.PathEscape(base))
+	status, body, _, err := client.get(path)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to get diff between %s and %s", base, head)
+	}
+	if status >= 400 {
+		return nil, sdk.NewError(sdk.ErrRepoNotFound, errorAPI(body))
+	}
+	return sdk.ParseUnifiedDiff(string(body)), nil
+}
@@ -105,6 +105,60 @@ func (client *bitbucketcloudClient) PullRequestComment(ctx context.Context, repo
 	return nil
 }
 
+// PullRequestComments returns the comments of a pull request
+func (client *bitbucketcloudClient) PullRequestComments(ctx context.Context, repo string, id int) ([]sdk.VCSPullRequestComment, error) {
+	var comments []sdk.VCSPullRequestComment
+	path := fmt.Sprintf("/repositories/%s/pullrequests/%d/comments", repo, id)
+	params := url.Values{}
+	params.Set("pagelen", "50")
+	nextPage := 1
+	for {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if nextPage != 1 {
+			params.Set("page", fmt.Sprintf("%d", nextPage))
+		}
+
+		var response PullRequestComments
+		if err := client.do(ctx, "GET", "core", path, params, nil, &response); err != nil {
+			return nil, sdk.WrapError(err, "unable to get comments of pull request %d", id)
+		}
+		for _, c := range response.Values {
+			comments = append(comments, sdk.VCSPullRequestComment{ID: c.ID, Body: c.Content.Raw})
+		}
+
+		if response.Next == "" {
+			break
+		}
+		nextPage++
+	}
+	return comments, nil
+}
+
+// PullRequestCommentUpdate updates the content of a comment of a pull request
+func (client *bitbucketcloudClient) PullRequestCommentUpdate(ctx context.Context, repo string, id int, comment sdk.VCSPullRequestComment) error {
+	if client.DisableStatus {
+		log.Warning(ctx, "bitbucketcloud.PullRequestCommentUpdate>  ⚠ bitbucketcloud statuses are disabled")
+		return nil
+	}
+
+	path := fmt.Sprintf("/repositories/%s/pullrequests/%d/comments/%d", repo, id, comment.ID)
+	var payload PullRequestComment
+	payload.Content.Raw = comment.Body
+	values, err := json.Marshal(payload)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	var response PullRequestComment
+	if err := client.do(ctx, "PUT", "core", path, nil, values, &response); err != nil {
+		return sdk.WrapError(err, "unable to update comment %d of pull request %d", comment.ID, id)
+	}
+	return nil
+}
+
 func (client *bitbucketcloudClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	path := fmt.Sprintf("/repos/%s/pulls", repo)
 	payload := map[string]string{
@@ -132,6 +186,11 @@ func (client *bitbucketcloudClient) PullRequestCreate(ctx context.Context, repo
 	return prResponse.ToVCSPullRequest(), nil
 }
 
+// PullRequestMerge merges a pull request
+func (client *bitbucketcloudClient) PullRequestMerge(ctx context.Context, repo string, id int, headHash string) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
+
 func (pullr PullRequest) ToVCSPullRequest() sdk.VCSPullRequest {
 	return sdk.VCSPullRequest{
 		ID: pullr.ID,
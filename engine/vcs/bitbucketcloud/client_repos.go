@@ -136,3 +136,7 @@ func (client *bitbucketcloudClient) repoByFullname(ctx context.Context, fullname
 func (client *bitbucketcloudClient) GrantWritePermission(ctx context.Context, fullname string) error {
 	return sdk.WithStack(sdk.ErrNotImplemented)
 }
+
+func (client *bitbucketcloudClient) UserHasWritePermission(ctx context.Context, fullname, username string) (bool, error) {
+	return false, sdk.WithStack(sdk.ErrNotImplemented)
+}
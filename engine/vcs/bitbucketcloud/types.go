@@ -19,6 +19,7 @@ type WebhookCreate struct {
 	URL         string   `json:"url"`
 	Active      bool     `json:"active"`
 	Events      []string `json:"events"`
+	Secret      string   `json:"secret,omitempty"`
 }
 
 type Webhook struct {
@@ -47,6 +48,7 @@ type Webhook struct {
 	Type   string   `json:"type"`
 	Events []string `json:"events"`
 	UUID   string   `json:"uuid"`
+	Secret string   `json:"secret,omitempty"`
 }
 
 type Webhooks struct {
@@ -168,6 +170,24 @@ type PullRequest struct {
 	} `json:"merge_commit"`
 }
 
+// PullRequestComments represents a page of comments of a pull request
+type PullRequestComments struct {
+	Pagelen  int                  `json:"pagelen"`
+	Page     int                  `json:"page"`
+	Size     int64                `json:"size"`
+	Values   []PullRequestComment `json:"values"`
+	Next     string               `json:"next"`
+	Previous string               `json:"previous,omitempty"`
+}
+
+// PullRequestComment represents a comment of a pull request
+type PullRequestComment struct {
+	ID      int64 `json:"id,omitempty"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+}
+
 type PullRequests struct {
 	Pagelen  int           `json:"pagelen"`
 	Page     int           `json:"page"`
@@ -186,3 +186,45 @@ func (b *bitbucketClient) CommitsBetweenRefs(ctx context.Context, repo, base, he
 	}
 	return commits, nil
 }
+
+// DiffBetweenRefs returns the lines added in head since its merge base with base
+func (b *bitbucketClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/compare/diff", project, slug)
+	params := url.Values{}
+	params.Add("from", head)
+	params.Add("to", base)
+	params.Add("contextLines", "0")
+
+	var response DiffResponse
+	if err := b.do(ctx, "GET", "core", path, params, nil, &response, nil); err != nil {
+		return nil, sdk.WrapError(err, "unable to get diff between %s and %s", base, head)
+	}
+
+	diff := make([]sdk.VCSFileDiff, 0, len(response.Diffs))
+	for _, d := range response.Diffs {
+		// Deleted files have no destination
+		if d.Destination == nil {
+			continue
+		}
+		f := sdk.VCSFileDiff{Path: d.Destination.ToString}
+		for _, h := range d.Hunks {
+			for _, s := range h.Segments {
+				if s.Type != "ADDED" {
+					continue
+				}
+				for _, l := range s.Lines {
+					f.AddedLines = append(f.AddedLines, l.Destination)
+				}
+			}
+		}
+		if len(f.AddedLines) > 0 {
+			diff = append(diff, f)
+		}
+	}
+	return diff, nil
+}
@@ -97,6 +97,92 @@ func (b *bitbucketClient) PullRequestComment(ctx context.Context, repo string, p
 	return b.do(ctx, "POST", "core", path, nil, values, nil, &options{asUser: true})
 }
 
+// PullRequestComments returns the comments of a pull request from its activities
+func (b *bitbucketClient) PullRequestComments(ctx context.Context, repo string, prID int) ([]sdk.VCSPullRequestComment, error) {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return nil, sdk.WithStack(err)
+	}
+
+	var comments []sdk.VCSPullRequestComment
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/activities", project, slug, prID)
+	params := url.Values{}
+
+	nextPage := 0
+	for {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if nextPage != 0 {
+			params.Set("start", fmt.Sprintf("%d", nextPage))
+		}
+
+		var response PullRequestActivityResponse
+		if err := b.do(ctx, "GET", "core", path, params, nil, &response, nil); err != nil {
+			return nil, sdk.WrapError(err, "unable to get activities of pull request %d", prID)
+		}
+		for _, a := range response.Values {
+			if a.Action == "COMMENTED" && a.Comment != nil {
+				comments = append(comments, sdk.VCSPullRequestComment{ID: a.Comment.ID, Body: a.Comment.Text})
+			}
+		}
+
+		if response.IsLastPage {
+			break
+		}
+		nextPage = response.NextPageStart
+	}
+	return comments, nil
+}
+
+// PullRequestCommentUpdate updates the text of a comment of a pull request
+func (b *bitbucketClient) PullRequestCommentUpdate(ctx context.Context, repo string, prID int, comment sdk.VCSPullRequestComment) error {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/comments/%d", project, slug, prID, comment.ID)
+
+	// the comment version is required to update it
+	var bbComment PullRequestComment
+	if err := b.do(ctx, "GET", "core", path, nil, nil, &bbComment, nil); err != nil {
+		return sdk.WrapError(err, "unable to get comment %d", comment.ID)
+	}
+	bbComment.Text = comment.Body
+	values, err := json.Marshal(bbComment)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	return b.do(ctx, "PUT", "core", path, nil, values, nil, &options{asUser: true})
+}
+
+// PullRequestMerge merges a pull request only if its head commit is still the given one
+func (b *bitbucketClient) PullRequestMerge(ctx context.Context, repo string, prID int, headHash string) error {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return sdk.WithStack(err)
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d", project, slug, prID)
+
+	var pr sdk.BitbucketServerPullRequest
+	if err := b.do(ctx, "GET", "core", path, nil, nil, &pr, nil); err != nil {
+		return sdk.WrapError(err, "unable to get pullrequest")
+	}
+	if pr.FromRef.LatestCommit != headHash {
+		return sdk.NewErrorFrom(sdk.ErrWrongRequest, "pullrequest %d head changed from %s to %s", prID, headHash, pr.FromRef.LatestCommit)
+	}
+
+	// the pull request version is required to merge it
+	params := url.Values{}
+	params.Set("version", fmt.Sprintf("%d", pr.Version))
+
+	return b.do(ctx, "POST", "core", path+"/merge", params, nil, nil, &options{asUser: true})
+}
+
 func (b *bitbucketClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	project, slug, err := getRepo(repo)
 	if err != nil {
@@ -168,6 +254,9 @@ func (b *bitbucketClient) ToVCSPullRequest(ctx context.Context, repo string, pul
 	if len(pullRequest.Links.Self) > 0 {
 		pr.URL = pullRequest.Links.Self[0].Href
 	}
+	for _, r := range pullRequest.Reviewers {
+		pr.Approved = pr.Approved || r.Approved
+	}
 	if pullRequest.Author != nil {
 		pr.User = sdk.VCSAuthor{
 			Name:        pullRequest.Author.User.Name,
@@ -130,3 +130,29 @@ func (b *bitbucketClient) GrantWritePermission(ctx context.Context, repo string)
 
 	return b.do(ctx, "PUT", "core", path, params, nil, nil, nil)
 }
+
+// UserHasWritePermission returns true if the user can write on the repository, the permission
+// can be given on the repository or on its project, directly or by a group.
+func (b *bitbucketClient) UserHasWritePermission(ctx context.Context, repo, username string) (bool, error) {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	params := url.Values{}
+	params.Add("filter", username)
+	params.Add("permission.1", "REPO_WRITE")
+	params.Add("permission.1.projectKey", project)
+	params.Add("permission.1.repositorySlug", slug)
+
+	var response UsersResponse
+	if err := b.do(ctx, "GET", "core", "/users", params, nil, &response, nil); err != nil {
+		return false, sdk.WrapError(err, "unable to get permission of %s on %s", username, repo)
+	}
+	// The filter matches the beginning of the name, the email or the display name of the users
+	for _, u := range response.Values {
+		if u.Name == username || u.Slug == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
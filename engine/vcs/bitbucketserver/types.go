@@ -71,6 +71,23 @@ type CommitsResponse struct {
 	IsLastPage    bool     `json:"isLastPage"`
 }
 
+// DiffResponse is the response of the compare/diff api
+type DiffResponse struct {
+	Diffs []struct {
+		Destination *struct {
+			ToString string `json:"toString"`
+		} `json:"destination"`
+		Hunks []struct {
+			Segments []struct {
+				Type  string `json:"type"`
+				Lines []struct {
+					Destination int64 `json:"destination"`
+				} `json:"lines"`
+			} `json:"segments"`
+		} `json:"hunks"`
+	} `json:"diffs"`
+}
+
 type Commit struct {
 	Hash      string `json:"id"`
 	Author    Author `json:"author"`
@@ -248,3 +265,22 @@ type PullRequestResponse struct {
 	NextPageStart int                              `json:"nextPageStart"`
 	IsLastPage    bool                             `json:"isLastPage"`
 }
+
+type PullRequestComment struct {
+	ID      int64  `json:"id,omitempty"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+}
+
+type PullRequestActivity struct {
+	ID      int64               `json:"id"`
+	Action  string              `json:"action"`
+	Comment *PullRequestComment `json:"comment,omitempty"`
+}
+
+type PullRequestActivityResponse struct {
+	Values        []PullRequestActivity `json:"values"`
+	Size          int                   `json:"size"`
+	NextPageStart int                   `json:"nextPageStart"`
+	IsLastPage    bool                  `json:"isLastPage"`
+}
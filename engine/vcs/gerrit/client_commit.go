@@ -20,3 +20,8 @@ func (c *gerritClient) Commit(ctx context.Context, repo, hash string) (sdk.VCSCo
 func (c *gerritClient) CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSCommit, error) {
 	return nil, nil
 }
+
+// DiffBetweenRefs returns the lines added in head since its merge base with base
+func (c *gerritClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
@@ -19,7 +19,22 @@ func (c *gerritClient) PullRequestComment(context.Context, string, int, string)
 	return nil
 }
 
+// PullRequestComments returns the comments of a pull request
+func (c *gerritClient) PullRequestComments(context.Context, string, int) ([]sdk.VCSPullRequestComment, error) {
+	return nil, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
+// PullRequestCommentUpdate updates a comment of a pull request
+func (c *gerritClient) PullRequestCommentUpdate(context.Context, string, int, sdk.VCSPullRequestComment) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
+
 // PullRequestCreate create a new pullrequest
 func (c *gerritClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	return sdk.VCSPullRequest{}, nil
 }
+
+// PullRequestMerge merges a pull request
+func (c *gerritClient) PullRequestMerge(ctx context.Context, repo string, id int, headHash string) error {
+	return sdk.WithStack(sdk.ErrNotImplemented)
+}
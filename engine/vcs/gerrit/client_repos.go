@@ -41,6 +41,10 @@ func (c *gerritClient) GrantWritePermission(ctx context.Context, repo string) er
 	return nil
 }
 
+func (c *gerritClient) UserHasWritePermission(ctx context.Context, repo, username string) (bool, error) {
+	return false, sdk.WithStack(sdk.ErrNotImplemented)
+}
+
 func (c *gerritClient) ToVCSRepo(name string, repo gg.ProjectInfo) sdk.VCSRepo {
 	url, _ := url2.Parse(c.url)
 	return sdk.VCSRepo{
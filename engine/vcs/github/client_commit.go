@@ -323,3 +323,16 @@ func (g *githubClient) CommitsBetweenRefs(ctx context.Context, repo, base, head
 
 	return commits, nil
 }
+
+// DiffBetweenRefs returns the lines added in head since its merge base with base
+func (g *githubClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	url := fmt.Sprintf("/repos/%s/compare/%s...%s", repo, base, head)
+	status, body, _, err := g.get(ctx, url, withDiffMediaType)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to get diff between %s and %s", base, head)
+	}
+	if status >= 400 {
+		return nil, sdk.NewError(sdk.ErrRepoNotFound, errorAPI(body))
+	}
+	return sdk.ParseUnifiedDiff(string(body)), nil
+}
@@ -71,7 +71,54 @@ func (g *githubClient) PullRequest(ctx context.Context, fullname string, id int)
 		break
 	}
 
-	return pr.ToVCSPullRequest(), nil
+	res := pr.ToVCSPullRequest()
+	approved, err := g.pullRequestApproved(ctx, fullname, id)
+	if err != nil {
+		return sdk.VCSPullRequest{}, err
+	}
+	res.Approved = approved
+	return res, nil
+}
+
+// pullRequestApproved returns true if the last review of a user approved the pull request and no one requested changes
+func (g *githubClient) pullRequestApproved(ctx context.Context, fullname string, id int) (bool, error) {
+	lastReviews := make(map[string]string)
+	nextPage := fmt.Sprintf("/repos/%s/pulls/%d/reviews?per_page=100", fullname, id)
+	for nextPage != "" {
+		if ctx.Err() != nil {
+			break
+		}
+
+		status, body, headers, err := g.get(ctx, nextPage, withoutETag)
+		if err != nil {
+			return false, sdk.WrapError(err, "unable to get reviews of pull request %d", id)
+		}
+		if status >= 400 {
+			return false, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
+		}
+
+		var reviews []PullRequestReview
+		if err := json.Unmarshal(body, &reviews); err != nil {
+			return false, sdk.WrapError(err, "unable to unmarshal reviews %s", string(body))
+		}
+		// Reviews are sorted from the oldest, comments don't change the state of a previous review
+		for _, r := range reviews {
+			if r.State == "APPROVED" || r.State == "CHANGES_REQUESTED" || r.State == "DISMISSED" {
+				lastReviews[r.User.Login] = r.State
+			}
+		}
+
+		nextPage = getNextPage(headers)
+	}
+
+	var approved bool
+	for _, state := range lastReviews {
+		if state == "CHANGES_REQUESTED" {
+			return false, nil
+		}
+		approved = approved || state == "APPROVED"
+	}
+	return approved, nil
 }
 
 // PullRequests fetch all the pull request for a repository
@@ -170,6 +217,66 @@ func (g *githubClient) PullRequestComment(ctx context.Context, repo string, id i
 	return nil
 }
 
+// PullRequestComments returns the comments of a pull request
+func (g *githubClient) PullRequestComments(ctx context.Context, repo string, id int) ([]sdk.VCSPullRequestComment, error) {
+	var comments []sdk.VCSPullRequestComment
+	nextPage := fmt.Sprintf("/repos/%s/issues/%d/comments?per_page=100", repo, id)
+	for nextPage != "" {
+		if ctx.Err() != nil {
+			break
+		}
+
+		status, body, headers, err := g.get(ctx, nextPage, withoutETag)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to get comments of pull request %d", id)
+		}
+		if status >= 400 {
+			return nil, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
+		}
+
+		var nextComments []IssueComment
+		if err := json.Unmarshal(body, &nextComments); err != nil {
+			return nil, sdk.WrapError(err, "unable to unmarshal comments %s", string(body))
+		}
+		for _, c := range nextComments {
+			comments = append(comments, sdk.VCSPullRequestComment{ID: c.ID, Body: c.Body})
+		}
+
+		nextPage = getNextPage(headers)
+	}
+	return comments, nil
+}
+
+// PullRequestCommentUpdate updates the body of a comment of a pull request
+func (g *githubClient) PullRequestCommentUpdate(ctx context.Context, repo string, id int, comment sdk.VCSPullRequestComment) error {
+	if g.DisableStatus {
+		log.Warning(ctx, "github.PullRequestCommentUpdate>  ⚠ Github statuses are disabled")
+		return nil
+	}
+
+	path := fmt.Sprintf("/repos/%s/issues/comments/%d", repo, comment.ID)
+	payload := map[string]string{
+		"body": comment.Body,
+	}
+	values, _ := json.Marshal(payload)
+	res, err := g.patch(path, "application/json", bytes.NewReader(values), &postOptions{skipDefaultBaseURL: false, asUser: true})
+	if err != nil {
+		return sdk.WrapError(err, "unable to update comment %d", comment.ID)
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WrapError(err, "unable to read body")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return sdk.WithStack(fmt.Errorf("unable to update comment %d of pull request %d on github. Status code : %d - Body: %s", comment.ID, id, res.StatusCode, body))
+	}
+
+	return nil
+}
+
 func (g *githubClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	path := fmt.Sprintf("/repos/%s/pulls", repo)
 	payload := map[string]string{
@@ -197,8 +304,34 @@ func (g *githubClient) PullRequestCreate(ctx context.Context, repo string, pr sd
 	return prResponse.ToVCSPullRequest(), nil
 }
 
+// PullRequestMerge merges a pull request only if its head commit is still the given one
+func (g *githubClient) PullRequestMerge(ctx context.Context, repo string, id int, headHash string) error {
+	path := fmt.Sprintf("/repos/%s/pulls/%d/merge", repo, id)
+	payload := map[string]string{
+		"sha":          headHash,
+		"merge_method": "merge",
+	}
+	values, _ := json.Marshal(payload)
+	res, err := g.put(path, "application/json", bytes.NewReader(values), &postOptions{skipDefaultBaseURL: false, asUser: true})
+	if err != nil {
+		return sdk.WrapError(err, "unable to merge pullrequest %d", id)
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return sdk.WrapError(err, "unable to read body")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return sdk.NewErrorFrom(sdk.ErrUnknownError, "unable to merge pullrequest %d on github (status code %d): %s", id, res.StatusCode, errorAPI(body))
+	}
+
+	return nil
+}
+
 func (pullr PullRequest) ToVCSPullRequest() sdk.VCSPullRequest {
-	return sdk.VCSPullRequest{
+	pr := sdk.VCSPullRequest{
 		ID: pullr.Number,
 		Base: sdk.VCSPushEvent{
 			Repo: pullr.Base.Repo.FullName,
@@ -247,4 +380,8 @@ func (pullr PullRequest) ToVCSPullRequest() sdk.VCSPullRequest {
 		Closed: pullr.State == "closed",
 		Merged: pullr.Merged,
 	}
+	for _, l := range pullr.Labels {
+		pr.Labels = append(pr.Labels, l.Name)
+	}
+	return pr
 }
@@ -210,3 +210,26 @@ func (g *githubClient) GrantWritePermission(ctx context.Context, fullname string
 
 	return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 }
+
+// UserHasWritePermission returns true if the user can push on the repository
+// https://developer.github.com/v3/repos/collaborators/#review-a-users-permission-level
+func (g *githubClient) UserHasWritePermission(ctx context.Context, fullname, username string) (bool, error) {
+	url := "/repos/" + fullname + "/collaborators/" + username + "/permission"
+	status, body, _, err := g.get(ctx, url, withoutETag)
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to get permission of %s on %s", username, fullname)
+	}
+	// Users that are not collaborators of the repository are unknown
+	if status == http.StatusNotFound {
+		return false, nil
+	}
+	if status >= 400 {
+		return false, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
+	}
+
+	var permission RepositoryPermission
+	if err := json.Unmarshal(body, &permission); err != nil {
+		return false, sdk.WrapError(err, "unable to parse permission of %s on %s", username, fullname)
+	}
+	return permission.Permission == "admin" || permission.Permission == "write", nil
+}
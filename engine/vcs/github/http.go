@@ -120,6 +120,11 @@ func withETag(ctx context.Context, c *githubClient, req *http.Request, path stri
 }
 func withoutETag(ctx context.Context, c *githubClient, req *http.Request, path string) {}
 
+// withDiffMediaType asks for the unified diff instead of the json representation of a resource
+func withDiffMediaType(ctx context.Context, c *githubClient, req *http.Request, path string) {
+	req.Header.Set("Accept", "application/vnd.github.v3.diff")
+}
+
 type postOptions struct {
 	skipDefaultBaseURL bool
 	asUser             bool
@@ -63,6 +63,7 @@ type Webhook struct {
 	Config  struct {
 		URL         string `json:"url"`
 		ContentType string `json:"content_type"`
+		Secret      string `json:"secret,omitempty"`
 	} `json:"config"`
 	UpdatedAt time.Time `json:"updated_at"`
 	CreatedAt time.Time `json:"created_at"`
@@ -72,6 +73,7 @@ type Webhook struct {
 type WebHookConfig struct {
 	URL         string `json:"url"`
 	ContentType string `json:"content_type"`
+	Secret      string `json:"secret,omitempty"`
 }
 
 // User represents a GitHub user.
@@ -508,6 +510,19 @@ type PullRequest struct {
 	Additions           int       `json:"additions"`
 	Deletions           int       `json:"deletions"`
 	ChangedFiles        int       `json:"changed_files"`
+	Labels              []Label   `json:"labels"`
+}
+
+// Label represents a label of a github issue or pull request
+type Label struct {
+	Name string `json:"name"`
+}
+
+// PullRequestReview represents a review of a pull request from github api
+type PullRequestReview struct {
+	ID    int64  `json:"id"`
+	User  User   `json:"user"`
+	State string `json:"state"`
 }
 
 // ReleaseRequest Request sent to Github to create a release
@@ -735,3 +750,15 @@ type Ref struct {
 		URL  string `json:"url"`
 	} `json:"object"`
 }
+
+// RepositoryPermission is the permission of a user on a repository: admin, write, read or none
+type RepositoryPermission struct {
+	Permission string `json:"permission"`
+	User       User   `json:"user"`
+}
+
+// IssueComment represents a comment of an issue or a pull request
+type IssueComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
@@ -105,3 +105,29 @@ func (c *gitlabClient) CommitsBetweenRefs(ctx context.Context, repo, base, head
 
 	return vcscommits, nil
 }
+
+// DiffBetweenRefs returns the lines added in head since its merge base with base
+func (c *gitlabClient) DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSFileDiff, error) {
+	opt := &gitlab.CompareOptions{
+		From: &base,
+		To:   &head,
+	}
+
+	compare, _, err := c.client.Repositories.Compare(repo, opt)
+	if err != nil {
+		return nil, sdk.WrapError(err, "unable to compare %s and %s", base, head)
+	}
+
+	var diff []sdk.VCSFileDiff
+	if compare == nil {
+		return diff, nil
+	}
+	for _, d := range compare.Diffs {
+		if d.DeletedFile {
+			continue
+		}
+		// Gitlab only returns the hunks of each file
+		diff = append(diff, sdk.ParseUnifiedDiff("+++ b/"+d.NewPath+"\n"+d.Diff)...)
+	}
+	return diff, nil
+}
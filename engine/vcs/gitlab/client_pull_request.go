@@ -4,11 +4,52 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/xanzy/go-gitlab"
+
 	"github.com/ovh/cds/sdk"
 )
 
+// PullRequest returns a merge request with its labels and approvals
 func (c *gitlabClient) PullRequest(ctx context.Context, repo string, id int) (sdk.VCSPullRequest, error) {
-	return sdk.VCSPullRequest{}, nil
+	mr, _, err := c.client.MergeRequests.GetMergeRequest(repo, id, nil)
+	if err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(err, "unable to get merge request %d", id)
+	}
+	approvals, _, err := c.client.MergeRequests.GetMergeRequestApprovals(repo, id)
+	if err != nil {
+		return sdk.VCSPullRequest{}, sdk.WrapError(err, "unable to get approvals of merge request %d", id)
+	}
+
+	pr := sdk.VCSPullRequest{
+		ID:    mr.IID,
+		URL:   mr.WebURL,
+		Title: mr.Title,
+		Base: sdk.VCSPushEvent{
+			Branch: sdk.VCSBranch{
+				ID:        mr.TargetBranch,
+				DisplayID: mr.TargetBranch,
+			},
+		},
+		Head: sdk.VCSPushEvent{
+			Branch: sdk.VCSBranch{
+				ID:           mr.SourceBranch,
+				DisplayID:    mr.SourceBranch,
+				LatestCommit: mr.SHA,
+			},
+			Commit: sdk.VCSCommit{
+				Hash: mr.SHA,
+			},
+		},
+		Merged:   mr.State == "merged",
+		Closed:   mr.State == "closed" || mr.State == "merged",
+		Labels:   mr.Labels,
+		Approved: len(approvals.ApprovedBy) > 0,
+		User: sdk.VCSAuthor{
+			Name:        mr.Author.Username,
+			DisplayName: mr.Author.Name,
+		},
+	}
+	return pr, nil
 }
 
 // PullRequests fetch all the pull request for a repository
@@ -16,8 +57,46 @@ func (c *gitlabClient) PullRequests(context.Context, string) ([]sdk.VCSPullReque
 	return []sdk.VCSPullRequest{}, nil
 }
 
-// PullRequestComment push a new comment on a pull request
-func (c *gitlabClient) PullRequestComment(context.Context, string, int, string) error {
+// PullRequestComment push a new comment on a merge request
+func (c *gitlabClient) PullRequestComment(ctx context.Context, repo string, id int, text string) error {
+	opts := &gitlab.CreateMergeRequestNoteOptions{
+		Body: gitlab.String(text),
+	}
+	if _, _, err := c.client.Notes.CreateMergeRequestNote(repo, id, opts); err != nil {
+		return sdk.WrapError(err, "unable to comment merge request %d", id)
+	}
+	return nil
+}
+
+// PullRequestComments returns the notes of a merge request
+func (c *gitlabClient) PullRequestComments(ctx context.Context, repo string, id int) ([]sdk.VCSPullRequestComment, error) {
+	var comments []sdk.VCSPullRequestComment
+	opts := &gitlab.ListMergeRequestNotesOptions{}
+	opts.PerPage = 100
+	for {
+		notes, resp, err := c.client.Notes.ListMergeRequestNotes(repo, id, opts)
+		if err != nil {
+			return nil, sdk.WrapError(err, "unable to get notes of merge request %d", id)
+		}
+		for _, n := range notes {
+			comments = append(comments, sdk.VCSPullRequestComment{ID: int64(n.ID), Body: n.Body})
+		}
+		if resp.NextPage == 0 || ctx.Err() != nil {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return comments, nil
+}
+
+// PullRequestCommentUpdate updates the body of a note of a merge request
+func (c *gitlabClient) PullRequestCommentUpdate(ctx context.Context, repo string, id int, comment sdk.VCSPullRequestComment) error {
+	opts := &gitlab.UpdateMergeRequestNoteOptions{
+		Body: gitlab.String(comment.Body),
+	}
+	if _, _, err := c.client.Notes.UpdateMergeRequestNote(repo, id, int(comment.ID), opts); err != nil {
+		return sdk.WrapError(err, "unable to update note %d of merge request %d", comment.ID, id)
+	}
 	return nil
 }
 
@@ -25,3 +104,14 @@ func (c *gitlabClient) PullRequestComment(context.Context, string, int, string)
 func (c *gitlabClient) PullRequestCreate(ctx context.Context, repo string, pr sdk.VCSPullRequest) (sdk.VCSPullRequest, error) {
 	return sdk.VCSPullRequest{}, fmt.Errorf("not yet implemented")
 }
+
+// PullRequestMerge accepts a merge request only if its head commit is still the given one
+func (c *gitlabClient) PullRequestMerge(ctx context.Context, repo string, id int, headHash string) error {
+	opts := &gitlab.AcceptMergeRequestOptions{
+		SHA: gitlab.String(headHash),
+	}
+	if _, _, err := c.client.MergeRequests.AcceptMergeRequest(repo, id, opts); err != nil {
+		return sdk.WrapError(err, "unable to accept merge request %d", id)
+	}
+	return nil
+}
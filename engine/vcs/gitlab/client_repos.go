@@ -3,6 +3,8 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 
 	"github.com/xanzy/go-gitlab"
 
@@ -88,3 +90,31 @@ func (c *gitlabClient) RepoByFullname(ctx context.Context, fullname string) (sdk
 func (c *gitlabClient) GrantWritePermission(ctx context.Context, repo string) error {
 	return nil
 }
+
+// UserHasWritePermission returns true if the user is a developer, a maintainer or the owner of the project,
+// directly or as a member of its groups.
+func (c *gitlabClient) UserHasWritePermission(ctx context.Context, repo, username string) (bool, error) {
+	users, _, err := c.client.Users.ListUsers(&gitlab.ListUsersOptions{Username: gitlab.String(username)})
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to get user %s", username)
+	}
+	if len(users) == 0 {
+		return false, nil
+	}
+
+	// The members/all endpoint also returns the members inherited from the groups of the project,
+	// it is not available in the gitlab client
+	req, err := c.client.NewRequest("GET", fmt.Sprintf("projects/%s/members/all/%d", url.QueryEscape(repo), users[0].ID), nil, nil)
+	if err != nil {
+		return false, sdk.WithStack(err)
+	}
+	member := new(gitlab.ProjectMember)
+	resp, err := c.client.Do(req, member)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, sdk.WrapError(err, "unable to get member %s of project %s", username, repo)
+	}
+	return member.AccessLevel >= gitlab.DeveloperPermissions, nil
+}
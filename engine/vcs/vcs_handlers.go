@@ -179,8 +179,8 @@ func (s *Service) getVCSServersHooksHandler() service.Handler {
 				"project_column",
 				"project",
 				"public",
-				"pull-request_review_comment",
-				"pull-request_review",
+				"pull_request_review_comment",
+				"pull_request_review",
 				"pull_request",
 				"repository",
 				"repository_import",
@@ -556,6 +556,44 @@ func (s *Service) getCommitsBetweenRefsHandler() service.Handler {
 	}
 }
 
+func (s *Service) getDiffBetweenRefsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		base := r.URL.Query().Get("base")
+		head := r.URL.Query().Get("head")
+		if base == "" || head == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing base or head ref")
+		}
+
+		accessToken, accessTokenSecret, created, ok := getAccessTokens(ctx)
+		if !ok {
+			return sdk.WrapError(sdk.ErrUnauthorized, "Unable to get access token headers %s %s/%s", name, owner, repo)
+		}
+
+		consumer, err := s.getConsumer(name)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret, created)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+		// Check if access token has been refreshed
+		if accessToken != client.GetAccessToken(ctx) {
+			w.Header().Set(sdk.HeaderXAccessToken, client.GetAccessToken(ctx))
+		}
+
+		diff, err := client.DiffBetweenRefs(ctx, fmt.Sprintf("%s/%s", owner, repo), base, head)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get diff of %s/%s between %s and %s", owner, repo, base, head)
+		}
+		return service.WriteJSON(w, diff, http.StatusOK)
+	}
+}
+
 func (s *Service) getCommitHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
@@ -777,6 +815,135 @@ func (s *Service) postPullRequestCommentHandler() service.Handler {
 	}
 }
 
+func (s *Service) getPullRequestCommentsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		sid := muxVar(r, "id")
+		id, err := strconv.Atoi(sid)
+		if err != nil {
+			return sdk.WithStack(sdk.ErrWrongRequest)
+		}
+
+		accessToken, accessTokenSecret, created, ok := getAccessTokens(ctx)
+		if !ok {
+			return sdk.WrapError(sdk.ErrUnauthorized, "Unable to get access token headers %s %s/%s", name, owner, repo)
+		}
+
+		consumer, err := s.getConsumer(name)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret, created)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+		// Check if access token has been refreshed
+		if accessToken != client.GetAccessToken(ctx) {
+			w.Header().Set(sdk.HeaderXAccessToken, client.GetAccessToken(ctx))
+		}
+
+		comments, err := client.PullRequestComments(ctx, fmt.Sprintf("%s/%s", owner, repo), id)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get PR comments %s %s/%s", name, owner, repo)
+		}
+
+		return service.WriteJSON(w, comments, http.StatusOK)
+	}
+}
+
+func (s *Service) putPullRequestCommentHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		id, err := strconv.Atoi(muxVar(r, "id"))
+		if err != nil {
+			return sdk.WithStack(sdk.ErrWrongRequest)
+		}
+		commentID, err := strconv.ParseInt(muxVar(r, "commentID"), 10, 64)
+		if err != nil {
+			return sdk.WithStack(sdk.ErrWrongRequest)
+		}
+
+		var comment sdk.VCSPullRequestComment
+		if err := service.UnmarshalBody(r, &comment); err != nil {
+			return sdk.WithStack(err)
+		}
+		comment.ID = commentID
+
+		accessToken, accessTokenSecret, created, ok := getAccessTokens(ctx)
+		if !ok {
+			return sdk.WrapError(sdk.ErrUnauthorized, "Unable to get access token headers %s %s/%s", name, owner, repo)
+		}
+
+		consumer, err := s.getConsumer(name)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret, created)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+		// Check if access token has been refreshed
+		if accessToken != client.GetAccessToken(ctx) {
+			w.Header().Set(sdk.HeaderXAccessToken, client.GetAccessToken(ctx))
+		}
+
+		if err := client.PullRequestCommentUpdate(ctx, fmt.Sprintf("%s/%s", owner, repo), id, comment); err != nil {
+			return sdk.WrapError(err, "Unable to update PR comment %d %s %s/%s", commentID, name, owner, repo)
+		}
+
+		return nil
+	}
+}
+
+func (s *Service) postPullRequestMergeHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		sid := muxVar(r, "id")
+		id, err := strconv.Atoi(sid)
+		if err != nil {
+			return sdk.WithStack(sdk.ErrWrongRequest)
+		}
+
+		headHash := r.URL.Query().Get("sha")
+		if headHash == "" {
+			return sdk.NewErrorFrom(sdk.ErrWrongRequest, "missing pullrequest head commit")
+		}
+
+		accessToken, accessTokenSecret, created, ok := getAccessTokens(ctx)
+		if !ok {
+			return sdk.WrapError(sdk.ErrUnauthorized, "Unable to get access token headers %s %s/%s", name, owner, repo)
+		}
+
+		consumer, err := s.getConsumer(name)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret, created)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+		// Check if access token has been refreshed
+		if accessToken != client.GetAccessToken(ctx) {
+			w.Header().Set(sdk.HeaderXAccessToken, client.GetAccessToken(ctx))
+		}
+
+		if err := client.PullRequestMerge(ctx, fmt.Sprintf("%s/%s", owner, repo), id, headHash); err != nil {
+			return sdk.WrapError(err, "Unable to merge PR %d %s %s/%s", id, name, owner, repo)
+		}
+
+		return nil
+	}
+}
+
 func (s *Service) getEventsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
@@ -1261,3 +1428,38 @@ func (s *Service) postRepoGrantHandler() service.Handler {
 		return nil
 	}
 }
+
+func (s *Service) getRepoUserPermissionHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		username := muxVar(r, "username")
+
+		accessToken, accessTokenSecret, created, ok := getAccessTokens(ctx)
+		if !ok {
+			return sdk.WrapError(sdk.ErrUnauthorized, "Unable to get access token headers %s %s/%s", name, owner, repo)
+		}
+
+		consumer, err := s.getConsumer(name)
+		if err != nil {
+			return sdk.WrapError(err, "VCS server unavailable %s %s/%s", name, owner, repo)
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret, created)
+		if err != nil {
+			return sdk.WrapError(err, "Unable to get authorized client %s %s/%s", name, owner, repo)
+		}
+		// Check if access token has been refreshed
+		if accessToken != client.GetAccessToken(ctx) {
+			w.Header().Set(sdk.HeaderXAccessToken, client.GetAccessToken(ctx))
+		}
+
+		canWrite, err := client.UserHasWritePermission(ctx, owner+"/"+repo, username)
+		if err != nil {
+			return sdk.WrapError(err, "unable to get permission of %s on %s/%s on %s", username, owner, repo, name)
+		}
+
+		return service.WriteJSON(w, canWrite, http.StatusOK)
+	}
+}
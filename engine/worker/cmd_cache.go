@@ -2,7 +2,6 @@ package main
 
 import (
 	"bytes"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -56,14 +55,28 @@ Here, an example of a script inside a CDS Job using the cache feature:
 	# put in cache the updated .m2/ directory
 	worker cache push $tag .m2/
 
+## Cache keys and restore keys
+The tag can contain {{hash <files>}} helpers replaced by the hash of the content of the files, globs are allowed.
+When no cache matches the tag, the latest cache matching one of the restore keys is pulled. Restore keys are prefixes of tags tried in the given order:
+
+	worker cache pull --restore-keys=go-mod- go-mod-{{hash go.sum}}
+	go build ./...
+	worker cache push go-mod-{{hash go.sum}} $(go env GOMODCACHE)
+
+Caches are split in layers identified by their content, only the layers that are not already stored for the project are uploaded.
+
     `,
 	}
-	cmdCacheRoot.AddCommand(cmdCachePush(), cmdCachePull())
+	cmdCacheRoot.AddCommand(cmdCachePush(), cmdCachePull(), cmdCacheHash())
 
 	return cmdCacheRoot
 }
 
-var cmdStorageIntegrationName string
+var (
+	cmdStorageIntegrationName string
+	cmdCacheNoLayers          bool
+	cmdCacheRestoreKeys       []string
+)
 
 func cmdCachePush() *cobra.Command {
 	c := &cobra.Command{
@@ -76,11 +89,17 @@ Inside a project, you can create a cache from your worker with a tag (useful for
 
 You can use you storage integration: 
 	worker cache push --destination=MyStorageIntegration  <tagValue> dir/file
+
+The tag can contain {{hash <files>}} helpers:
+	worker cache push go-mod-{{hash go.sum}} $(go env GOMODCACHE)
+
+The cache is split in layers, only the layers that are not already stored are uploaded. Use --no-layers to upload the cache as a single tar.
 		`,
 		Example: "worker cache push {{.cds.workflow}}-{{.cds.version}} ./pathToUpload",
 		Run:     cachePushCmd(),
 	}
 	c.Flags().StringVar(&cmdStorageIntegrationName, "destination", "", "optional. Your storage integration name")
+	c.Flags().BoolVar(&cmdCacheNoLayers, "no-layers", false, "optional. Upload the cache as a single tar")
 	return c
 }
 
@@ -110,8 +129,13 @@ func cachePushCmd() func(cmd *cobra.Command, args []string) {
 			sdk.Exit("worker cache push > Cannot find working directory : %s", err)
 		}
 
+		tag, err := internal.ExpandCacheKey(cwd, args[0])
+		if err != nil {
+			sdk.Exit("worker cache push > Cannot compute tag %s : %s", args[0], err)
+		}
+
 		c := sdk.Cache{
-			Tag:              args[0],
+			Tag:              tag,
 			Files:            files,
 			WorkingDirectory: cwd,
 			IntegrationName:  cmdStorageIntegrationName,
@@ -122,10 +146,10 @@ func cachePushCmd() func(cmd *cobra.Command, args []string) {
 			sdk.Exit("worker cache push > internal error (%s)", errMarshal)
 		}
 
-		fmt.Printf("Worker cache push in progress... (tag: %s)\n", args[0])
+		fmt.Printf("Worker cache push in progress... (tag: %s)\n", tag)
 		req, errRequest := http.NewRequest(
 			"POST",
-			fmt.Sprintf("http://127.0.0.1:%d/cache/%s/push", port, base64.RawURLEncoding.EncodeToString([]byte(args[0]))),
+			fmt.Sprintf("http://127.0.0.1:%d/cache/%s/push?layers=%t", port, sdk.CacheRef(tag), !cmdCacheNoLayers),
 			bytes.NewReader(data),
 		)
 		if errRequest != nil {
@@ -150,7 +174,7 @@ func cachePushCmd() func(cmd *cobra.Command, args []string) {
 			sdk.Exit("Error: http code %d : %v", resp.StatusCode, cdsError)
 		}
 
-		fmt.Printf("Worker cache push with success (tag: %s)\n", args[0])
+		fmt.Printf("Worker cache push with success (tag: %s)\n", tag)
 	}
 }
 
@@ -178,10 +202,15 @@ If you want to push a cache into a storage integration:
 
 	worker cache push latest --from=MyStorageIntegration {{.cds.workspace}}/pathToUpload
 
+If there is no cache for the tag, the latest cache matching one of the restore keys is pulled. Restore keys are prefixes of tags tried in the given order:
+
+	worker cache pull --restore-keys=go-mod- go-mod-{{hash go.sum}}
+
 		`,
 		Run: cachePullCmd(),
 	}
 	c.Flags().StringVar(&cmdStorageIntegrationName, "from", "", "optional. Your storage integration name")
+	c.Flags().StringSliceVar(&cmdCacheRestoreKeys, "restore-keys", nil, "optional. Prefixes of the tags to pull if there is no cache for the tag")
 	return c
 }
 
@@ -206,14 +235,35 @@ func cachePullCmd() func(cmd *cobra.Command, args []string) {
 			sdk.Exit("worker cache pull > cannot get current path: %s", err)
 		}
 
-		fmt.Printf("Worker cache pull in progress... (tag: %s)\n", args[0])
+		cwd, err := os.Getwd()
+		if err != nil {
+			sdk.Exit("worker cache pull > Cannot find working directory : %s", err)
+		}
+
+		tag, err := internal.ExpandCacheKey(cwd, args[0])
+		if err != nil {
+			sdk.Exit("worker cache pull > Cannot compute tag %s : %s", args[0], err)
+		}
+
+		query := url.Values{}
+		query.Set("path", dir)
+		query.Set("integration", cmdStorageIntegrationName)
+		for _, k := range cmdCacheRestoreKeys {
+			restoreKey, err := internal.ExpandCacheKey(cwd, k)
+			if err != nil {
+				sdk.Exit("worker cache pull > Cannot compute restore key %s : %s", k, err)
+			}
+			query.Add("restore", restoreKey)
+		}
+
+		fmt.Printf("Worker cache pull in progress... (tag: %s)\n", tag)
 		req, errRequest := http.NewRequest(
 			"GET",
-			fmt.Sprintf("http://127.0.0.1:%d/cache/%s/pull?path=%s&integration=%s", port, base64.RawURLEncoding.EncodeToString([]byte(args[0])), url.QueryEscape(dir), url.QueryEscape(cmdStorageIntegrationName)),
+			fmt.Sprintf("http://127.0.0.1:%d/cache/%s/pull?%s", port, sdk.CacheRef(tag), query.Encode()),
 			nil,
 		)
 		if errRequest != nil {
-			sdk.Exit("worker cache pull > cannot post worker cache pull with tag %s (Request): %s", args[0], errRequest)
+			sdk.Exit("worker cache pull > cannot post worker cache pull with tag %s (Request): %s", tag, errRequest)
 		}
 
 		client := http.DefaultClient
@@ -223,16 +273,50 @@ func cachePullCmd() func(cmd *cobra.Command, args []string) {
 		if errDo != nil {
 			sdk.Exit("worker cache pull > cannot post worker cache pull (Do): %s", errDo)
 		}
+		defer resp.Body.Close()
 
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			sdk.Exit("cache pull HTTP error %v", err)
+		}
 		if resp.StatusCode >= 300 {
-			body, err := ioutil.ReadAll(resp.Body)
-			if err != nil {
-				sdk.Exit("cache pull HTTP error %v", err)
-			}
 			cdsError := sdk.DecodeError(body)
 			sdk.Exit("Error: %v", cdsError)
 		}
 
-		fmt.Printf("Worker cache pull with success (tag: %s)\n", args[0])
+		var restored sdk.Cache
+		if err := json.Unmarshal(body, &restored); err == nil && restored.Tag != "" && restored.Tag != tag {
+			fmt.Printf("Worker cache pull with success (tag: %s, restored from: %s)\n", tag, restored.Tag)
+			return
+		}
+		fmt.Printf("Worker cache pull with success (tag: %s)\n", tag)
 	}
 }
+
+func cmdCacheHash() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "hash",
+		Short: "worker cache hash file...",
+		Long: `
+Print the hash of the content of the files, globs are allowed. It is the value of the {{hash <files>}} helper in cache tags.
+
+	worker cache hash go.sum
+	worker cache hash "**/package-lock.json"
+		`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 {
+				sdk.Exit("worker cache hash > Wrong usage: Example : worker cache hash go.sum")
+			}
+			cwd, err := os.Getwd()
+			if err != nil {
+				sdk.Exit("worker cache hash > Cannot find working directory : %s", err)
+			}
+			h, err := internal.HashCacheFiles(cwd, args)
+			if err != nil {
+				sdk.Exit("worker cache hash > %s", err)
+			}
+			fmt.Println(h)
+		},
+	}
+	return c
+}
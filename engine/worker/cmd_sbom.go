@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/sdk"
+)
+
+func cmdSBOM() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "sbom",
+		Short: "worker sbom <file>...",
+		Long: `
+Inside a job, you can attach Software Bills of Materials to the node run:
+
+	# worker sbom bom.json
+	# worker sbom target/*.spdx
+
+SPDX (JSON or tag-value) and CycloneDX (JSON or XML) documents are accepted. They are stored with the run
+and their components are indexed, so you can find the runs that contain a library with cdsctl project sbom search.
+		`,
+		Run: sbomCmd(),
+	}
+	return c
+}
+
+func sbomCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(internal.WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		if len(args) == 0 {
+			sdk.Exit("Wrong usage: Example : worker sbom <file>...")
+		}
+
+		var files []string
+		for _, arg := range args {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				sdk.Exit("invalid pattern %s: %v\n", arg, err)
+			}
+			files = append(files, matches...)
+		}
+		if len(files) == 0 {
+			sdk.Exit("no sbom found for %v\n", args)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 5 * time.Minute
+
+		for _, f := range files {
+			file, err := os.Open(f)
+			if err != nil {
+				sdk.Exit("cannot open sbom %s: %v\n", f, err)
+			}
+
+			req, errRequest := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/sbom?name=%s", port, url.QueryEscape(filepath.Base(f))), file)
+			if errRequest != nil {
+				sdk.Exit("cannot post sbom (Request): %s\n", errRequest)
+			}
+
+			resp, errDo := client.Do(req)
+			if errDo != nil {
+				sdk.Exit("command failed: %v\n", errDo)
+			}
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			file.Close()
+			if err != nil {
+				sdk.Exit("sbom %s failed: unable to read body %v\n", f, err)
+			}
+			if resp.StatusCode >= 300 {
+				cdsError := sdk.DecodeError(body)
+				sdk.Exit("sbom %s failed: %v\n", f, cdsError)
+			}
+
+			var sbom sdk.WorkflowNodeRunSBOM
+			if err := json.Unmarshal(body, &sbom); err != nil {
+				sdk.Exit("sbom %s failed: unable to read response %v\n", f, err)
+			}
+			fmt.Printf("%s: %s %s document with %d component(s)\n", f, sbom.Format, sbom.SpecVersion, sbom.Components)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/engine/worker/internal/action"
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	cmdStaticAnalysisSeverity string
+	cmdStaticAnalysisNewOnly  bool
+)
+
+func cmdStaticAnalysis() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "static-analysis",
+		Short: "worker static-analysis [--severity=<level>] [--new-only] <file>...",
+		Long: `
+Inside a job, you can send the SARIF reports of static analysis tools:
+
+	# worker static-analysis gosec.sarif
+	# worker static-analysis --severity=error --new-only reports/*.sarif
+
+Findings are stored on the node run and compared to the default branch. With the flag --severity, the command
+fails if a finding has this level (note, warning or error) or higher. With the flag --new-only, only the findings
+that don't exist on the default branch are taken into account.
+		`,
+		Run: staticAnalysisCmd(),
+	}
+	c.Flags().StringVar(&cmdStaticAnalysisSeverity, "severity", "", "Fail if a finding has this level or higher: note, warning or error")
+	c.Flags().BoolVar(&cmdStaticAnalysisNewOnly, "new-only", false, "Only new findings compared to the default branch can fail the command")
+	return c
+}
+
+func staticAnalysisCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(internal.WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		if len(args) == 0 {
+			sdk.Exit("Wrong usage: Example : worker static-analysis <file>...")
+		}
+		if cmdStaticAnalysisSeverity != "" && !sdk.IsValidStaticAnalysisLevel(cmdStaticAnalysisSeverity) {
+			sdk.Exit("invalid severity %s: must be note, warning or error\n", cmdStaticAnalysisSeverity)
+		}
+
+		var files []string
+		for _, arg := range args {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				sdk.Exit("invalid pattern %s: %v\n", arg, err)
+			}
+			files = append(files, matches...)
+		}
+
+		report, err := action.ParseStaticAnalysisFiles(afero.NewOsFs(), files)
+		if err != nil {
+			sdk.Exit("%v\n", err)
+		}
+
+		data, err := json.Marshal(report)
+		if err != nil {
+			sdk.Exit("internal error (%s)\n", err)
+		}
+
+		req, errRequest := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/static-analysis", port), bytes.NewReader(data))
+		if errRequest != nil {
+			sdk.Exit("cannot post static analysis report (Request): %s\n", errRequest)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 5 * time.Minute
+
+		resp, errDo := client.Do(req)
+		if errDo != nil {
+			sdk.Exit("command failed: %v\n", errDo)
+		}
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			sdk.Exit("static analysis failed: unable to read body %v\n", err)
+		}
+		if resp.StatusCode >= 300 {
+			cdsError := sdk.DecodeError(body)
+			sdk.Exit("static analysis failed: %v\n", cdsError)
+		}
+
+		var nodeRunReport sdk.WorkflowNodeRunStaticAnalysisReport
+		if err := json.Unmarshal(body, &nodeRunReport); err != nil {
+			sdk.Exit("static analysis failed: unable to read report %v\n", err)
+		}
+
+		summary := sdk.StaticAnalysisSummary(report.Findings)
+		fmt.Printf("%d finding(s): %d error(s), %d warning(s), %d note(s)\n", len(report.Findings),
+			summary[sdk.StaticAnalysisLevelError], summary[sdk.StaticAnalysisLevelWarning], summary[sdk.StaticAnalysisLevelNote])
+		if nodeRunReport.Report.ComparedTo != "" {
+			fmt.Printf("%d new finding(s) and %d fixed finding(s) compared to %s\n",
+				len(nodeRunReport.Report.NewFindings), len(nodeRunReport.Report.FixedFindings), nodeRunReport.Report.ComparedTo)
+		}
+
+		if err := action.CheckStaticAnalysisThreshold(report.Findings, &nodeRunReport, cmdStaticAnalysisSeverity, cmdStaticAnalysisNewOnly); err != nil {
+			sdk.Exit("%v\n", err)
+		}
+	}
+}
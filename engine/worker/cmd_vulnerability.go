@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/ovh/cds/engine/worker/internal"
+	"github.com/ovh/cds/engine/worker/internal/action"
+	"github.com/ovh/cds/sdk"
+)
+
+var cmdVulnerabilityFormat string
+
+func cmdVulnerability() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "vulnerability",
+		Short: "worker vulnerability --format=<format> <file>...",
+		Long: `
+Inside a job, you can send the reports of vulnerability scanners:
+
+	# worker vulnerability --format=dependency-check dependency-check-report.json
+	# worker vulnerability --format=trivy trivy-*.json
+	# worker vulnerability --format=cyclonedx-vex bom.json
+
+Supported formats are the JSON reports of OWASP Dependency-Check (dependency-check), Trivy (trivy) and
+CycloneDX documents with vulnerabilities (cyclonedx-vex). The vulnerabilities marked as not affected by
+a CycloneDX VEX analysis are ignored.
+
+Vulnerabilities are linked to the application of the pipeline context. Vulnerabilities suppressed by
+a rule of the project are ignored until the rule expires.
+		`,
+		Run: vulnerabilityCmd(),
+	}
+	c.Flags().StringVar(&cmdVulnerabilityFormat, "format", "", "Format of the reports: dependency-check, trivy or cyclonedx-vex")
+	return c
+}
+
+func vulnerabilityCmd() func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		portS := os.Getenv(internal.WorkerServerPort)
+		if portS == "" {
+			sdk.Exit("%s not found, are you running inside a CDS worker job?\n", internal.WorkerServerPort)
+		}
+
+		port, errPort := strconv.Atoi(portS)
+		if errPort != nil {
+			sdk.Exit("cannot parse '%s' as a port number", portS)
+		}
+
+		if len(args) == 0 || cmdVulnerabilityFormat == "" {
+			sdk.Exit("Wrong usage: Example : worker vulnerability --format=<format> <file>...")
+		}
+
+		var files []string
+		for _, arg := range args {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				sdk.Exit("invalid pattern %s: %v\n", arg, err)
+			}
+			files = append(files, matches...)
+		}
+
+		report, err := action.ParseVulnerabilityFiles(afero.NewOsFs(), cmdVulnerabilityFormat, files)
+		if err != nil {
+			sdk.Exit("%v\n", err)
+		}
+
+		data, err := json.Marshal(report)
+		if err != nil {
+			sdk.Exit("internal error (%s)\n", err)
+		}
+
+		req, errRequest := http.NewRequest("POST", fmt.Sprintf("http://127.0.0.1:%d/vulnerability", port), bytes.NewReader(data))
+		if errRequest != nil {
+			sdk.Exit("cannot post vulnerability report (Request): %s\n", errRequest)
+		}
+
+		client := http.DefaultClient
+		client.Timeout = 5 * time.Minute
+
+		resp, errDo := client.Do(req)
+		if errDo != nil {
+			sdk.Exit("command failed: %v\n", errDo)
+		}
+
+		if resp.StatusCode >= 300 {
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				sdk.Exit("vulnerability failed: unable to read body %v\n", err)
+			}
+			defer resp.Body.Close()
+			cdsError := sdk.DecodeError(body)
+			sdk.Exit("vulnerability failed: %v\n", cdsError)
+		}
+
+		fmt.Printf("%d vulnerabilities sent: %d critical, %d high, %d medium, %d low\n", len(report.Vulnerabilities),
+			report.Summary[sdk.SeverityCritical], report.Summary[sdk.SeverityHigh], report.Summary[sdk.SeverityMedium], report.Summary[sdk.SeverityLow])
+	}
+}
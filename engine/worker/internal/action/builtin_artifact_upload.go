@@ -81,18 +81,37 @@ func RunArtifactUpload(ctx context.Context, wk workerruntime.Runtime, a sdk.Acti
 		go func(path string) {
 			log.Debug("worker.RunArtifactUpload> Uploading %s projectKey:%v integrationName:%v job:%d", path, projectKey, integrationName, jobID)
 			defer wg.Done()
-			throughTempURL, duration, err := wk.Client().QueueArtifactUpload(ctx, projectKey, integrationName, jobID, tag.Value, path)
+			// The transfer is skipped if the API already stores the same content
+			stored, err := wk.Client().QueueArtifactBlob(ctx, projectKey, integrationName, jobID, tag.Value, path)
 			if err != nil {
-				log.Warning(ctx, "worker.RunArtifactUpload> QueueArtifactUpload(%s, %s, %d, %s, %s) failed: %v", projectKey, integrationName, jobID, tag.Value, path, err)
-				chanError <- sdk.WrapError(err, "Error while uploading artifact %s", path)
-				wgErrors.Add(1)
-				return
+				log.Warning(ctx, "worker.RunArtifactUpload> QueueArtifactBlob(%s, %s, %d, %s, %s) failed: %v", projectKey, integrationName, jobID, tag.Value, path, err)
 			}
-			if throughTempURL {
-				wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("File '%s' uploaded in %.2fs to object store", path, duration.Seconds()))
+			if stored {
+				wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("File '%s' already stored, upload skipped", path))
 			} else {
-				wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("File '%s' uploaded in %.2fs to CDS API", path, duration.Seconds()))
+				throughTempURL, duration, err := wk.Client().QueueArtifactUpload(ctx, projectKey, integrationName, jobID, tag.Value, path)
+				if err != nil {
+					log.Warning(ctx, "worker.RunArtifactUpload> QueueArtifactUpload(%s, %s, %d, %s, %s) failed: %v", projectKey, integrationName, jobID, tag.Value, path, err)
+					chanError <- sdk.WrapError(err, "Error while uploading artifact %s", path)
+					wgErrors.Add(1)
+					return
+				}
+				if throughTempURL {
+					wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("File '%s' uploaded in %.2fs to object store", path, duration.Seconds()))
+				} else {
+					wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("File '%s' uploaded in %.2fs to CDS API", path, duration.Seconds()))
+				}
+			}
+
+			// The provenance is built and signed by the API, from the run of the job
+			provenance, err := wk.Client().QueueArtifactProvenance(ctx, jobID, tag.Value, filepath.Base(path))
+			if err != nil {
+				log.Warning(ctx, "worker.RunArtifactUpload> QueueArtifactProvenance(%d, %s, %s) failed: %v", jobID, tag.Value, path, err)
+				chanError <- sdk.WrapError(err, "Error while signing provenance of artifact %s", path)
+				wgErrors.Add(1)
+				return
 			}
+			wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("Provenance of file '%s' signed with key %s", path, provenance.KeyID))
 		}(p)
 		if len(filesPath) > 1 {
 			//Wait 3 second to get the object storage to set up all the things
@@ -32,12 +32,18 @@ func TestRunArtifactUpload_Absolute(t *testing.T) {
 
 	defer os.Remove("foo")
 
+	gock.New("http://lolcat.host").Post("/project/project/storage/shared.infra/artifact/dGFn/blob").
+		Reply(404)
+
 	gock.New("http://lolcat.host").Get("/project/project/storage/shared.infra").
 		Reply(200)
 
 	gock.New("http://lolcat.host").Post("/project/project/storage/shared.infra/artifact/dGFn").
 		Reply(200)
 
+	gock.New("http://lolcat.host").Post("/queue/workflows/666/artifact/dGFn/provenance").
+		Reply(200).JSON(sdk.WorkflowNodeRunArtifactProvenance{KeyID: "key"})
+
 	var checkRequest gock.ObserverFunc = func(request *http.Request, mock gock.Mock) {
 		bodyContent, err := ioutil.ReadAll(request.Body)
 		assert.NoError(t, err)
@@ -94,12 +100,18 @@ func TestRunArtifactUpload_Relative(t *testing.T) {
 	fname := filepath.Join(wk.workingDirectory.Name(), "foo")
 	assert.NoError(t, afero.WriteFile(wk.workspace, fname, []byte("something"), os.ModePerm))
 
+	gock.New("http://lolcat.host").Post("/project/project/storage/shared.infra/artifact/dGFn/blob").
+		Reply(404)
+
 	gock.New("http://lolcat.host").Get("/project/project/storage/shared.infra").
 		Reply(200)
 
 	gock.New("http://lolcat.host").Post("/project/project/storage/shared.infra/artifact/dGFn").
 		Reply(200)
 
+	gock.New("http://lolcat.host").Post("/queue/workflows/666/artifact/dGFn/provenance").
+		Reply(200).JSON(sdk.WorkflowNodeRunArtifactProvenance{KeyID: "key"})
+
 	var checkRequest gock.ObserverFunc = func(request *http.Request, mock gock.Mock) {
 		bodyContent, err := ioutil.ReadAll(request.Body)
 		assert.NoError(t, err)
@@ -147,3 +159,39 @@ func TestRunArtifactUpload_Relative(t *testing.T) {
 	require.NotNil(t, res)
 	assert.Equal(t, sdk.StatusSuccess, res.Status)
 }
+
+func TestRunArtifactUpload_AlreadyStored(t *testing.T) {
+	wk, ctx := SetupTest(t)
+	wk.Params = []sdk.Parameter{
+		{Name: "cds.project", Value: "project"},
+	}
+	fname := filepath.Join(wk.workingDirectory.Name(), "foo")
+	assert.NoError(t, afero.WriteFile(wk.workspace, fname, []byte("something"), os.ModePerm))
+
+	gock.New("http://lolcat.host").Post("/project/project/storage/shared.infra/artifact/dGFn/blob").
+		Reply(200).JSON(sdk.WorkflowNodeRunArtifact{Name: "foo"})
+
+	gock.New("http://lolcat.host").Post("/queue/workflows/666/artifact/dGFn/provenance").
+		Reply(200).JSON(sdk.WorkflowNodeRunArtifactProvenance{KeyID: "key"})
+
+	gock.InterceptClient(wk.Client().(cdsclient.Raw).HTTPClient())
+
+	res, err := RunArtifactUpload(ctx, wk,
+		sdk.Action{
+			Parameters: []sdk.Parameter{
+				{
+					Name:  "path",
+					Value: "foo",
+				}, {
+					Name:  "tag",
+					Value: "tag",
+				},
+			},
+		},
+		[]sdk.Variable{})
+
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, sdk.StatusSuccess, res.Status)
+	assert.True(t, gock.IsDone())
+}
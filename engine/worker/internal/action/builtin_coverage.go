@@ -3,6 +3,7 @@ package action
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strconv"
 
@@ -38,16 +39,18 @@ func RunParseCoverageResultAction(ctx context.Context, wk workerruntime.Runtime,
 		minReq = f
 	}
 
+	// Formats with a parser in the worker also give the coverage of each line
 	var parserMode coverage.CoverageMode
-	switch mode {
-	case string(coverage.COBERTURA):
-		parserMode = coverage.COBERTURA
-	case string(coverage.LCOV):
-		parserMode = coverage.LCOV
-	case string(coverage.CLOVER):
-		parserMode = coverage.CLOVER
-	default:
-		return res, fmt.Errorf("coverage parser: unknown format %s", mode)
+	parseLines, hasLines := coverageParsers[mode]
+	if !hasLines {
+		switch mode {
+		case string(coverage.COBERTURA):
+			parserMode = coverage.COBERTURA
+		case string(coverage.LCOV):
+			parserMode = coverage.LCOV
+		default:
+			return res, fmt.Errorf("coverage parser: unknown format %s", mode)
+		}
 	}
 
 	workdir, err := workerruntime.WorkingDirectory(ctx)
@@ -69,10 +72,25 @@ func RunParseCoverageResultAction(ctx context.Context, wk workerruntime.Runtime,
 		fpath = p
 	}
 
-	parser := coverage.New(fpath, parserMode)
-	report, errR := parser.Parse()
-	if errR != nil {
-		return res, fmt.Errorf("coverage parser: unable to parse report: %v", errR)
+	var report coverage.Report
+	var files []sdk.WorkflowNodeRunCoverageFile
+	if hasLines {
+		f, err := os.Open(fpath)
+		if err != nil {
+			return res, fmt.Errorf("coverage parser: unable to open report: %v", err)
+		}
+		report, files, err = parseLines(f)
+		f.Close() // nolint
+		if err != nil {
+			return res, fmt.Errorf("coverage parser: unable to parse report: %v", err)
+		}
+	} else {
+		parser := coverage.New(fpath, parserMode)
+		r, errR := parser.Parse()
+		if errR != nil {
+			return res, fmt.Errorf("coverage parser: unable to parse report: %v", errR)
+		}
+		report = r
 	}
 
 	jobID, err := workerruntime.JobID(ctx)
@@ -84,6 +102,13 @@ func RunParseCoverageResultAction(ctx context.Context, wk workerruntime.Runtime,
 		return res, fmt.Errorf("coverage parser: failed to send coverage details: %s", err)
 	}
 
+	// The coverage of the files is used to compute the coverage of the lines changed in a pull request
+	if len(files) > 0 {
+		if err := wk.Client().QueueSendCoverageFiles(ctx, jobID, files); err != nil {
+			return res, fmt.Errorf("coverage parser: failed to send coverage of the files: %s", err)
+		}
+	}
+
 	if minReq > 0 {
 		covPercent := (float64(report.CoveredLines) / float64(report.TotalLines)) * 100
 		if covPercent < minReq {
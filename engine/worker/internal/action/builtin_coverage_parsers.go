@@ -0,0 +1,256 @@
+package action
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	coverage "github.com/sguiheux/go-coverage"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// coverageParser parses a coverage report and returns the line coverage of the files with the global report
+type coverageParser func(r io.Reader) (coverage.Report, []sdk.WorkflowNodeRunCoverageFile, error)
+
+var coverageParsers = map[string]coverageParser{
+	sdk.CoverageFormatCoverprofile: parseCoverprofile,
+	sdk.CoverageFormatJacoco:       parseJacoco,
+	sdk.CoverageFormatClover:       parseClover,
+}
+
+// coverageFile collects the coverage of a file while parsing a report
+type coverageFile struct {
+	path             string
+	lines            map[int64]bool
+	totalFunctions   int
+	coveredFunctions int
+	totalBranches    int
+	coveredBranches  int
+}
+
+// coverageFiles keeps the files in the order of the report
+type coverageFiles struct {
+	files []*coverageFile
+	index map[string]*coverageFile
+}
+
+func (c *coverageFiles) get(path string) *coverageFile {
+	if c.index == nil {
+		c.index = make(map[string]*coverageFile)
+	}
+	f, ok := c.index[path]
+	if !ok {
+		f = &coverageFile{path: path, lines: make(map[int64]bool)}
+		c.index[path] = f
+		c.files = append(c.files, f)
+	}
+	return f
+}
+
+// setLine marks a line as instrumented, a line is covered if one of its statements is covered
+func (f *coverageFile) setLine(line int64, covered bool) {
+	f.lines[line] = f.lines[line] || covered
+}
+
+// report returns the coverage report and the line coverage of all the files
+func (c *coverageFiles) report() (coverage.Report, []sdk.WorkflowNodeRunCoverageFile) {
+	var report coverage.Report
+	files := make([]sdk.WorkflowNodeRunCoverageFile, 0, len(c.files))
+	for _, f := range c.files {
+		fr := coverage.FileReport{
+			Path:             f.path,
+			TotalFunctions:   f.totalFunctions,
+			CoveredFunctions: f.coveredFunctions,
+			TotalBranches:    f.totalBranches,
+			CoveredBranches:  f.coveredBranches,
+		}
+		lines := sdk.WorkflowNodeRunCoverageFile{Path: f.path}
+		for l, covered := range f.lines {
+			fr.TotalLines++
+			if covered {
+				fr.CoveredLines++
+				lines.CoveredLines = append(lines.CoveredLines, l)
+			} else {
+				lines.UncoveredLines = append(lines.UncoveredLines, l)
+			}
+		}
+		sort.Slice(lines.CoveredLines, func(i, j int) bool { return lines.CoveredLines[i] < lines.CoveredLines[j] })
+		sort.Slice(lines.UncoveredLines, func(i, j int) bool { return lines.UncoveredLines[i] < lines.UncoveredLines[j] })
+
+		report.Files = append(report.Files, fr)
+		report.TotalLines += fr.TotalLines
+		report.CoveredLines += fr.CoveredLines
+		report.TotalFunctions += fr.TotalFunctions
+		report.CoveredFunctions += fr.CoveredFunctions
+		report.TotalBranches += fr.TotalBranches
+		report.CoveredBranches += fr.CoveredBranches
+		files = append(files, lines)
+	}
+	return report, files
+}
+
+// parseCoverprofile parses a Go cover profile, as written by go test -coverprofile. Each line
+// is a block: name.go:line.column,line.column numberOfStatements count
+func parseCoverprofile(r io.Reader) (coverage.Report, []sdk.WorkflowNodeRunCoverageFile, error) {
+	var files coverageFiles
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+
+		i := strings.LastIndex(line, ":")
+		fields := strings.Fields(line[i+1:])
+		if i < 0 || len(fields) != 3 {
+			return coverage.Report{}, nil, fmt.Errorf("invalid block at line %d: %s", n, line)
+		}
+		var startLine, startCol, endLine, endCol int64
+		if _, err := fmt.Sscanf(fields[0], "%d.%d,%d.%d", &startLine, &startCol, &endLine, &endCol); err != nil {
+			return coverage.Report{}, nil, fmt.Errorf("invalid block at line %d: %s", n, line)
+		}
+		count, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return coverage.Report{}, nil, fmt.Errorf("invalid count at line %d: %s", n, line)
+		}
+
+		f := files.get(line[:i])
+		for l := startLine; l <= endLine; l++ {
+			f.setLine(l, count > 0)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return coverage.Report{}, nil, err
+	}
+
+	report, lines := files.report()
+	return report, lines, nil
+}
+
+type jacocoReport struct {
+	Packages []struct {
+		Name        string `xml:"name,attr"`
+		Sourcefiles []struct {
+			Name  string `xml:"name,attr"`
+			Lines []struct {
+				Nr int64 `xml:"nr,attr"`
+				Mi int   `xml:"mi,attr"`
+				Ci int   `xml:"ci,attr"`
+				Mb int   `xml:"mb,attr"`
+				Cb int   `xml:"cb,attr"`
+			} `xml:"line"`
+			Counters []struct {
+				Type    string `xml:"type,attr"`
+				Missed  int    `xml:"missed,attr"`
+				Covered int    `xml:"covered,attr"`
+			} `xml:"counter"`
+		} `xml:"sourcefile"`
+	} `xml:"package"`
+}
+
+// parseJacoco parses a JaCoCo XML report, file paths are relative to the source folders
+func parseJacoco(r io.Reader) (coverage.Report, []sdk.WorkflowNodeRunCoverageFile, error) {
+	var jacoco jacocoReport
+	if err := xml.NewDecoder(r).Decode(&jacoco); err != nil {
+		return coverage.Report{}, nil, err
+	}
+
+	var files coverageFiles
+	for _, p := range jacoco.Packages {
+		for _, s := range p.Sourcefiles {
+			path := s.Name
+			if p.Name != "" {
+				path = p.Name + "/" + s.Name
+			}
+			f := files.get(path)
+			for _, l := range s.Lines {
+				f.setLine(l.Nr, l.Ci > 0)
+				f.totalBranches += l.Mb + l.Cb
+				f.coveredBranches += l.Cb
+			}
+			for _, c := range s.Counters {
+				if c.Type == "METHOD" {
+					f.totalFunctions += c.Missed + c.Covered
+					f.coveredFunctions += c.Covered
+				}
+			}
+		}
+	}
+
+	report, lines := files.report()
+	return report, lines, nil
+}
+
+type cloverFile struct {
+	Name  string `xml:"name,attr"`
+	Path  string `xml:"path,attr"`
+	Lines []struct {
+		Num        int64  `xml:"num,attr"`
+		Type       string `xml:"type,attr"`
+		Count      int    `xml:"count,attr"`
+		TrueCount  int    `xml:"truecount,attr"`
+		FalseCount int    `xml:"falsecount,attr"`
+	} `xml:"line"`
+}
+
+type cloverReport struct {
+	Project struct {
+		Files    []cloverFile `xml:"file"`
+		Packages []struct {
+			Files []cloverFile `xml:"file"`
+		} `xml:"package"`
+	} `xml:"project"`
+}
+
+// parseClover parses a Clover XML report
+func parseClover(r io.Reader) (coverage.Report, []sdk.WorkflowNodeRunCoverageFile, error) {
+	var clover cloverReport
+	if err := xml.NewDecoder(r).Decode(&clover); err != nil {
+		return coverage.Report{}, nil, err
+	}
+
+	var cloverFiles []cloverFile
+	for _, p := range clover.Project.Packages {
+		cloverFiles = append(cloverFiles, p.Files...)
+	}
+	// Files can also be declared outside of any package
+	cloverFiles = append(cloverFiles, clover.Project.Files...)
+
+	var files coverageFiles
+	for _, cf := range cloverFiles {
+		path := cf.Path
+		if path == "" {
+			path = cf.Name
+		}
+		f := files.get(path)
+		for _, l := range cf.Lines {
+			switch l.Type {
+			case "method":
+				f.totalFunctions++
+				if l.Count > 0 {
+					f.coveredFunctions++
+				}
+				f.setLine(l.Num, l.Count > 0)
+			case "cond":
+				f.totalBranches += 2
+				if l.TrueCount > 0 {
+					f.coveredBranches++
+				}
+				if l.FalseCount > 0 {
+					f.coveredBranches++
+				}
+				f.setLine(l.Num, l.TrueCount > 0 || l.FalseCount > 0)
+			default:
+				f.setLine(l.Num, l.Count > 0)
+			}
+		}
+	}
+
+	report, lines := files.report()
+	return report, lines, nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ovh/cds/sdk/cdsclient"
@@ -217,6 +218,102 @@ func TestRunCoverageMinimumFail(t *testing.T) {
 	assert.Equal(t, sdk.StatusFail, res.Status)
 }
 
+func TestParseCoverprofile(t *testing.T) {
+	report, files, err := parseCoverprofile(strings.NewReader(coverprofile_result))
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.TotalLines)
+	assert.Equal(t, 5, report.CoveredLines)
+	require.Len(t, files, 2)
+	assert.Equal(t, sdk.WorkflowNodeRunCoverageFile{
+		Path:           "github.com/ovh/cds/sdk/foo.go",
+		CoveredLines:   []int64{3, 4, 5},
+		UncoveredLines: []int64{6, 7},
+	}, files[0])
+	assert.Equal(t, "github.com/ovh/cds/sdk/bar.go", files[1].Path)
+
+	_, _, err = parseCoverprofile(strings.NewReader("mode: set\nfoo.go 1\n"))
+	assert.Error(t, err)
+}
+
+func TestParseJacoco(t *testing.T) {
+	report, files, err := parseJacoco(strings.NewReader(jacoco_result))
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.TotalLines)
+	assert.Equal(t, 3, report.CoveredLines)
+	assert.Equal(t, 2, report.TotalFunctions)
+	assert.Equal(t, 1, report.CoveredFunctions)
+	assert.Equal(t, 2, report.TotalBranches)
+	assert.Equal(t, 1, report.CoveredBranches)
+	require.Len(t, files, 1)
+	assert.Equal(t, sdk.WorkflowNodeRunCoverageFile{
+		Path:           "com/ovh/cds/Foo.java",
+		CoveredLines:   []int64{3, 5, 6},
+		UncoveredLines: []int64{7},
+	}, files[0])
+}
+
+func TestParseClover(t *testing.T) {
+	report, files, err := parseClover(strings.NewReader(clover_result))
+	require.NoError(t, err)
+	assert.Equal(t, 5, report.TotalLines)
+	assert.Equal(t, 3, report.CoveredLines)
+	assert.Equal(t, 2, report.TotalFunctions)
+	assert.Equal(t, 1, report.CoveredFunctions)
+	assert.Equal(t, 2, report.TotalBranches)
+	assert.Equal(t, 1, report.CoveredBranches)
+	require.Len(t, files, 2)
+	assert.Equal(t, sdk.WorkflowNodeRunCoverageFile{
+		Path:           "/src/app/foo.php",
+		CoveredLines:   []int64{3, 4, 5},
+		UncoveredLines: []int64{6},
+	}, files[0])
+	assert.Equal(t, "/src/lib/bar.php", files[1].Path)
+}
+
+const coverprofile_result = `mode: set
+github.com/ovh/cds/sdk/foo.go:3.20,5.2 2 1
+github.com/ovh/cds/sdk/foo.go:5.2,7.3 1 0
+github.com/ovh/cds/sdk/bar.go:10.1,11.2 1 1
+`
+
+const jacoco_result = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd">
+<report name="cds">
+    <package name="com/ovh/cds">
+        <class name="com/ovh/cds/Foo" sourcefilename="Foo.java">
+            <method name="bar" desc="()V" line="3"><counter type="LINE" missed="0" covered="1"/></method>
+        </class>
+        <sourcefile name="Foo.java">
+            <line nr="3" mi="0" ci="2" mb="0" cb="0"/>
+            <line nr="5" mi="0" ci="3" mb="1" cb="1"/>
+            <line nr="6" mi="1" ci="1" mb="0" cb="0"/>
+            <line nr="7" mi="2" ci="0" mb="0" cb="0"/>
+            <counter type="LINE" missed="1" covered="3"/>
+            <counter type="METHOD" missed="1" covered="1"/>
+        </sourcefile>
+        <counter type="LINE" missed="1" covered="3"/>
+    </package>
+</report>
+`
+
+const clover_result = `<?xml version="1.0" encoding="UTF-8"?>
+<coverage generated="1576494434">
+  <project timestamp="1576494434">
+    <package name="app">
+      <file name="foo.php" path="/src/app/foo.php">
+        <line num="3" type="method" name="foo" count="1"/>
+        <line num="4" type="stmt" count="1"/>
+        <line num="5" type="cond" truecount="1" falsecount="0"/>
+        <line num="6" type="stmt" count="0"/>
+      </file>
+    </package>
+    <file name="bar.php" path="/src/lib/bar.php">
+      <line num="1" type="method" name="bar" count="0"/>
+    </file>
+  </project>
+</coverage>
+`
+
 const cobertura_result = `<?xml version="1.0" ?>
 <!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">
 <coverage lines-valid="8"  lines-covered="6"  line-rate="1"  branches-valid="4"  branches-covered="2"  branch-rate="1"  timestamp="1394890504210" complexity="0" version="0.1">
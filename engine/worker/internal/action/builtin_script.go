@@ -263,7 +263,13 @@ func RunScriptAction(ctx context.Context, wk workerruntime.Runtime, a sdk.Action
 		<-outchan
 		<-errchan
 		if err := cmd.Wait(); err != nil {
-			chanErr <- fmt.Errorf("command failure: %v", err)
+			res.Status = sdk.StatusFail
+			res.Reason = fmt.Sprintf("command failure: %v", err)
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				res.ExitCode = exitErr.ExitCode()
+			}
+			chanRes <- res
+			return
 		}
 
 		res.Status = sdk.StatusSuccess
@@ -276,8 +282,13 @@ func RunScriptAction(ctx context.Context, wk workerruntime.Runtime, a sdk.Action
 	select {
 	case <-ctx.Done():
 		log.Error(ctx, "CDS Worker execution canceled: %v", ctx.Err())
+		res.TimedOut = ctx.Err() == context.DeadlineExceeded
 		return res, errors.New("CDS Worker execution canceled")
 	case res = <-chanRes:
+		if res.Status == sdk.StatusFail {
+			res.TimedOut = ctx.Err() == context.DeadlineExceeded
+			globalErr = errors.New(res.Reason)
+		}
 	case globalErr = <-chanErr:
 	}
 
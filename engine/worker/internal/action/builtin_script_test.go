@@ -107,6 +107,23 @@ func TestRunScriptAction(t *testing.T) {
 	assert.Equal(t, sdk.StatusSuccess, res.Status)
 }
 
+func TestRunScriptActionExitCode(t *testing.T) {
+	wk, ctx := SetupTest(t)
+	res, err := RunScriptAction(ctx, wk,
+		sdk.Action{
+			Parameters: []sdk.Parameter{
+				{
+					Name:  "script",
+					Value: "echo this is a failing test\nexit 3",
+				},
+			},
+		}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, sdk.StatusFail, res.Status)
+	assert.Equal(t, 3, res.ExitCode)
+	assert.False(t, res.TimedOut)
+}
+
 func Test_writeScriptContent_windows(t *testing.T) {
 	sdk.GOOS = "windows"
 	defer func() {
@@ -0,0 +1,145 @@
+package action
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func RunStaticAnalysis(ctx context.Context, wk workerruntime.Runtime, a sdk.Action, secrets []sdk.Variable) (sdk.Result, error) {
+	var res sdk.Result
+	res.Status = sdk.StatusFail
+
+	p := sdk.ParameterValue(a.Parameters, "path")
+	if p == "" {
+		return res, fmt.Errorf("static analysis: path not provided")
+	}
+
+	severity := sdk.ParameterValue(a.Parameters, "severity")
+	if severity == "none" {
+		severity = ""
+	}
+	if severity != "" && !sdk.IsValidStaticAnalysisLevel(severity) {
+		return res, fmt.Errorf("static analysis: wrong value for 'severity': %s", severity)
+	}
+
+	var newOnly bool
+	if v := sdk.ParameterValue(a.Parameters, "newOnly"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return res, fmt.Errorf("static analysis: wrong value for 'newOnly': %s", err)
+		}
+		newOnly = b
+	}
+
+	workdir, err := workerruntime.WorkingDirectory(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	var abs string
+	if x, ok := wk.BaseDir().(*afero.BasePathFs); ok {
+		abs, _ = x.RealPath(workdir.Name())
+	} else {
+		abs = workdir.Name()
+	}
+
+	if !sdk.PathIsAbs(p) {
+		p = filepath.Join(abs, p)
+	}
+
+	files, err := afero.Glob(afero.NewOsFs(), p)
+	if err != nil {
+		return res, fmt.Errorf("static analysis: cannot find requested files, invalid pattern")
+	}
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("%d file(s) to analyze", len(files)))
+
+	report, err := ParseStaticAnalysisFiles(afero.NewOsFs(), files)
+	if err != nil {
+		return res, err
+	}
+
+	jobID, err := workerruntime.JobID(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	nodeRunReport, err := wk.Client().QueueSendStaticAnalysis(ctx, jobID, report)
+	if err != nil {
+		return res, fmt.Errorf("static analysis: failed to send report: %s", err)
+	}
+
+	summary := sdk.StaticAnalysisSummary(report.Findings)
+	wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("%d finding(s): %d error(s), %d warning(s), %d note(s)", len(report.Findings),
+		summary[sdk.StaticAnalysisLevelError], summary[sdk.StaticAnalysisLevelWarning], summary[sdk.StaticAnalysisLevelNote]))
+	if nodeRunReport.Report.ComparedTo != "" {
+		wk.SendLog(ctx, workerruntime.LevelInfo, fmt.Sprintf("%d new finding(s) and %d fixed finding(s) compared to %s",
+			len(nodeRunReport.Report.NewFindings), len(nodeRunReport.Report.FixedFindings), nodeRunReport.Report.ComparedTo))
+	}
+
+	if err := CheckStaticAnalysisThreshold(report.Findings, nodeRunReport, severity, newOnly); err != nil {
+		return res, err
+	}
+
+	res.Status = sdk.StatusSuccess
+	return res, nil
+}
+
+// ParseStaticAnalysisFiles reads the SARIF files and returns the findings of all the files
+func ParseStaticAnalysisFiles(fs afero.Fs, files []string) (sdk.StaticAnalysisWorkerReport, error) {
+	report := sdk.StaticAnalysisWorkerReport{Findings: []sdk.StaticAnalysisFinding{}}
+	for _, f := range files {
+		data, err := afero.ReadFile(fs, f)
+		if err != nil {
+			return report, fmt.Errorf("static analysis: cannot read file %s (%s)", f, err)
+		}
+		findings, err := sdk.ParseSARIF(data)
+		if err != nil {
+			return report, fmt.Errorf("static analysis: cannot parse file %s (%s)", f, err)
+		}
+		report.Findings = sdk.MergeStaticAnalysisFindings(report.Findings, findings)
+	}
+	return report, nil
+}
+
+// CheckStaticAnalysisThreshold returns an error if one of the findings sent by the job has a level greater or equal
+// to the severity threshold. If newOnly is true, findings that already exist on the compared report are ignored.
+func CheckStaticAnalysisThreshold(findings []sdk.StaticAnalysisFinding, nodeRunReport *sdk.WorkflowNodeRunStaticAnalysisReport, severity string, newOnly bool) error {
+	if severity == "" {
+		return nil
+	}
+
+	if newOnly && nodeRunReport != nil {
+		newFingerprints := make(map[string]struct{}, len(nodeRunReport.Report.NewFindings))
+		for _, f := range nodeRunReport.Report.NewFindings {
+			newFingerprints[f.Fingerprint] = struct{}{}
+		}
+		var newFindings []sdk.StaticAnalysisFinding
+		for _, f := range findings {
+			if _, ok := newFingerprints[f.Fingerprint]; ok {
+				newFindings = append(newFindings, f)
+			}
+		}
+		findings = newFindings
+	}
+
+	var count int
+	for _, f := range findings {
+		if sdk.StaticAnalysisLevelAtLeast(f.Level, severity) {
+			count++
+		}
+	}
+	if count > 0 {
+		if newOnly {
+			return fmt.Errorf("static analysis: %d new finding(s) with severity %s or higher", count, severity)
+		}
+		return fmt.Errorf("static analysis: %d finding(s) with severity %s or higher", count, severity)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package action
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
+)
+
+const sarifResult = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "gosec"}},
+    "results": [
+      {"ruleId": "G101", "level": "error", "message": {"text": "Potential hardcoded credentials"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}, "region": {"startLine": 12}}}]},
+      {"ruleId": "G104", "level": "note", "message": {"text": "Errors unhandled"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "main.go"}, "region": {"startLine": 20}}}]}
+    ]
+  }]
+}`
+
+func TestRunStaticAnalysis(t *testing.T) {
+	defer gock.Off()
+
+	wk, ctx := SetupTest(t)
+	fname := filepath.Join(wk.workingDirectory.Name(), "gosec.sarif")
+	require.NoError(t, afero.WriteFile(wk.BaseDir(), fname, []byte(sarifResult), os.ModePerm))
+
+	gock.New("http://lolcat.host").Post("/queue/workflows/666/staticanalysis").
+		Times(2).
+		Reply(200).
+		JSON(sdk.WorkflowNodeRunStaticAnalysisReport{})
+
+	gock.InterceptClient(wk.Client().(cdsclient.Raw).HTTPClient())
+
+	params := []sdk.Parameter{
+		{Name: "path", Value: "*.sarif"},
+		{Name: "severity", Value: "warning"},
+	}
+	res, err := RunStaticAnalysis(ctx, wk, sdk.Action{Parameters: params}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 finding(s) with severity warning or higher")
+	assert.Equal(t, sdk.StatusFail, res.Status)
+
+	// The report returned by the API has no new finding
+	params = append(params, sdk.Parameter{Name: "newOnly", Value: "true"})
+	res, err = RunStaticAnalysis(ctx, wk, sdk.Action{Parameters: params}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sdk.StatusSuccess, res.Status)
+	assert.True(t, gock.IsDone())
+}
+
+func TestCheckStaticAnalysisThreshold(t *testing.T) {
+	report, err := ParseStaticAnalysisFiles(afero.NewMemMapFs(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, report.Findings)
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "gosec.sarif", []byte(sarifResult), os.ModePerm))
+	report, err = ParseStaticAnalysisFiles(fs, []string{"gosec.sarif", "gosec.sarif"})
+	require.NoError(t, err)
+	require.Len(t, report.Findings, 2)
+
+	nodeRunReport := &sdk.WorkflowNodeRunStaticAnalysisReport{
+		Report: sdk.WorkflowNodeRunStaticAnalysis{NewFindings: report.Findings[1:]},
+	}
+	assert.NoError(t, CheckStaticAnalysisThreshold(report.Findings, nodeRunReport, "", false))
+	assert.Error(t, CheckStaticAnalysisThreshold(report.Findings, nodeRunReport, sdk.StaticAnalysisLevelError, false))
+	assert.NoError(t, CheckStaticAnalysisThreshold(report.Findings, nodeRunReport, sdk.StaticAnalysisLevelError, true))
+	assert.Error(t, CheckStaticAnalysisThreshold(report.Findings, nodeRunReport, sdk.StaticAnalysisLevelNote, true))
+}
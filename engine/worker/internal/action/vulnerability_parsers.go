@@ -0,0 +1,312 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Vulnerability report formats parsed by the worker
+const (
+	VulnerabilityFormatDependencyCheck = "dependency-check"
+	VulnerabilityFormatTrivy           = "trivy"
+	VulnerabilityFormatCycloneDXVEX    = "cyclonedx-vex"
+)
+
+// vulnerabilityParser returns the vulnerabilities of a report
+type vulnerabilityParser func(data []byte) ([]sdk.Vulnerability, error)
+
+var vulnerabilityParsers = map[string]vulnerabilityParser{
+	VulnerabilityFormatDependencyCheck: parseDependencyCheck,
+	VulnerabilityFormatTrivy:           parseTrivy,
+	VulnerabilityFormatCycloneDXVEX:    parseCycloneDXVEX,
+}
+
+// ParseVulnerabilityFiles parses the reports of a vulnerability scanner and returns the report to send to CDS
+func ParseVulnerabilityFiles(fs afero.Fs, format string, files []string) (sdk.VulnerabilityWorkerReport, error) {
+	report := sdk.VulnerabilityWorkerReport{Type: format}
+	parser, ok := vulnerabilityParsers[format]
+	if !ok {
+		return report, fmt.Errorf("vulnerability parser: unknown format %s", format)
+	}
+	for _, f := range files {
+		data, err := afero.ReadFile(fs, f)
+		if err != nil {
+			return report, fmt.Errorf("vulnerability parser: cannot read file %s (%s)", f, err)
+		}
+		vs, err := parser(data)
+		if err != nil {
+			return report, fmt.Errorf("vulnerability parser: cannot parse file %s (%v)", f, err)
+		}
+		report.Vulnerabilities = append(report.Vulnerabilities, vs...)
+	}
+	report.Vulnerabilities = sdk.DedupVulnerabilities(report.Vulnerabilities)
+	report.Summary = sdk.VulnerabilitiesSummary(report.Vulnerabilities)
+	return report, nil
+}
+
+// parsePackageURL returns the name and the version of a package URL: pkg:type/namespace/name@version?qualifiers#subpath
+func parsePackageURL(purl string) (string, string, bool) {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return "", "", false
+	}
+	p := strings.TrimPrefix(purl, "pkg:")
+	if i := strings.IndexAny(p, "?#"); i >= 0 {
+		p = p[:i]
+	}
+	var version string
+	if i := strings.LastIndex(p, "@"); i >= 0 {
+		version, p = p[i+1:], p[:i]
+	}
+	// Remove the type of the package
+	if i := strings.Index(p, "/"); i >= 0 {
+		p = p[i+1:]
+	}
+	name, err := url.PathUnescape(p)
+	if err != nil {
+		name = p
+	}
+	if v, err := url.PathUnescape(version); err == nil {
+		version = v
+	}
+	return name, version, name != ""
+}
+
+// vulnerabilityLink returns the NVD link of a CVE if the report doesn't give any
+func vulnerabilityLink(id string, links ...string) string {
+	for _, l := range links {
+		if l != "" {
+			return l
+		}
+	}
+	if strings.HasPrefix(strings.ToUpper(id), "CVE-") {
+		return "https://nvd.nist.gov/vuln/detail/" + id
+	}
+	return ""
+}
+
+type dependencyCheckReport struct {
+	Dependencies []struct {
+		FileName string `json:"fileName"`
+		FilePath string `json:"filePath"`
+		Packages []struct {
+			ID string `json:"id"`
+		} `json:"packages"`
+		Vulnerabilities []struct {
+			Name        string `json:"name"`
+			Severity    string `json:"severity"`
+			Description string `json:"description"`
+			References  []struct {
+				URL string `json:"url"`
+			} `json:"references"`
+			VulnerableSoftware []struct {
+				Software struct {
+					VersionEndExcluding string `json:"versionEndExcluding"`
+				} `json:"software"`
+			} `json:"vulnerableSoftware"`
+		} `json:"vulnerabilities"`
+	} `json:"dependencies"`
+}
+
+// parseDependencyCheck parses a JSON report of OWASP Dependency-Check
+func parseDependencyCheck(data []byte) ([]sdk.Vulnerability, error) {
+	var report dependencyCheckReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+
+	var vs []sdk.Vulnerability
+	for _, d := range report.Dependencies {
+		component, version := d.FileName, ""
+		for _, p := range d.Packages {
+			if n, v, ok := parsePackageURL(p.ID); ok {
+				component, version = n, v
+				break
+			}
+		}
+		for _, dv := range d.Vulnerabilities {
+			v := sdk.Vulnerability{
+				Title:       dv.Name,
+				CVE:         dv.Name,
+				Description: dv.Description,
+				Component:   component,
+				Version:     version,
+				Origin:      d.FilePath,
+				Severity:    sdk.ToVulnerabilitySeverity(dv.Severity),
+			}
+			var links []string
+			for _, r := range dv.References {
+				links = append(links, r.URL)
+			}
+			v.Link = vulnerabilityLink(dv.Name, links...)
+			for _, s := range dv.VulnerableSoftware {
+				if s.Software.VersionEndExcluding != "" {
+					v.FixIn = s.Software.VersionEndExcluding
+					break
+				}
+			}
+			vs = append(vs, v)
+		}
+	}
+	return vs, nil
+}
+
+type trivyResult struct {
+	Target          string `json:"Target"`
+	Vulnerabilities []struct {
+		VulnerabilityID  string `json:"VulnerabilityID"`
+		PkgName          string `json:"PkgName"`
+		InstalledVersion string `json:"InstalledVersion"`
+		FixedVersion     string `json:"FixedVersion"`
+		Title            string `json:"Title"`
+		Description      string `json:"Description"`
+		Severity         string `json:"Severity"`
+		PrimaryURL       string `json:"PrimaryURL"`
+	} `json:"Vulnerabilities"`
+}
+
+// parseTrivy parses a JSON report of Trivy, the results are either at the root of the
+// report (before Trivy 0.20) or in the Results field.
+func parseTrivy(data []byte) ([]sdk.Vulnerability, error) {
+	var results []trivyResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		var report struct {
+			Results []trivyResult `json:"Results"`
+		}
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, err
+		}
+		results = report.Results
+	}
+
+	var vs []sdk.Vulnerability
+	for _, r := range results {
+		for _, tv := range r.Vulnerabilities {
+			title := tv.Title
+			if title == "" {
+				title = fmt.Sprintf("%s %s", tv.PkgName, tv.InstalledVersion)
+			}
+			vs = append(vs, sdk.Vulnerability{
+				Title:       title,
+				CVE:         tv.VulnerabilityID,
+				Description: tv.Description,
+				Link:        vulnerabilityLink(tv.VulnerabilityID, tv.PrimaryURL),
+				Component:   tv.PkgName,
+				Version:     tv.InstalledVersion,
+				Origin:      r.Target,
+				Severity:    sdk.ToVulnerabilitySeverity(tv.Severity),
+				FixIn:       tv.FixedVersion,
+			})
+		}
+	}
+	return vs, nil
+}
+
+type cycloneDXComponent struct {
+	BOMRef     string               `json:"bom-ref"`
+	Name       string               `json:"name"`
+	Group      string               `json:"group"`
+	Version    string               `json:"version"`
+	PURL       string               `json:"purl"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXVEX struct {
+	BOMFormat       string               `json:"bomFormat"`
+	Components      []cycloneDXComponent `json:"components"`
+	Vulnerabilities []struct {
+		ID     string `json:"id"`
+		Source struct {
+			URL string `json:"url"`
+		} `json:"source"`
+		Ratings []struct {
+			Severity string `json:"severity"`
+		} `json:"ratings"`
+		Description    string `json:"description"`
+		Recommendation string `json:"recommendation"`
+		Advisories     []struct {
+			URL string `json:"url"`
+		} `json:"advisories"`
+		Affects []struct {
+			Ref string `json:"ref"`
+		} `json:"affects"`
+		Analysis struct {
+			State string `json:"state"`
+		} `json:"analysis"`
+	} `json:"vulnerabilities"`
+}
+
+// parseCycloneDXVEX parses a CycloneDX JSON document with vulnerabilities. Vulnerabilities that the analysis
+// marks as not affecting the component (not_affected, false_positive or resolved) are flagged as ignored.
+func parseCycloneDXVEX(data []byte) ([]sdk.Vulnerability, error) {
+	var vex cycloneDXVEX
+	if err := json.Unmarshal(data, &vex); err != nil {
+		return nil, err
+	}
+	if vex.BOMFormat != "CycloneDX" {
+		return nil, fmt.Errorf("invalid bomFormat %q", vex.BOMFormat)
+	}
+
+	components := make(map[string]cycloneDXComponent)
+	var index func(cs []cycloneDXComponent)
+	index = func(cs []cycloneDXComponent) {
+		for _, c := range cs {
+			if c.BOMRef != "" {
+				components[c.BOMRef] = c
+			}
+			if c.PURL != "" {
+				components[c.PURL] = c
+			}
+			index(c.Components)
+		}
+	}
+	index(vex.Components)
+
+	var vs []sdk.Vulnerability
+	for _, cv := range vex.Vulnerabilities {
+		severity := sdk.SeverityUnknown
+		for _, r := range cv.Ratings {
+			if s := sdk.ToVulnerabilitySeverity(r.Severity); s != sdk.SeverityUnknown {
+				severity = s
+				break
+			}
+		}
+		links := []string{cv.Source.URL}
+		for _, a := range cv.Advisories {
+			links = append(links, a.URL)
+		}
+
+		for _, a := range cv.Affects {
+			v := sdk.Vulnerability{
+				Title:       cv.ID,
+				CVE:         cv.ID,
+				Description: cv.Description,
+				Link:        vulnerabilityLink(cv.ID, links...),
+				Origin:      a.Ref,
+				Severity:    severity,
+				FixIn:       cv.Recommendation,
+			}
+			if c, ok := components[a.Ref]; ok {
+				v.Component, v.Version = c.Name, c.Version
+				if c.Group != "" {
+					v.Component = c.Group + "/" + c.Name
+				}
+			} else if n, version, ok := parsePackageURL(a.Ref); ok {
+				v.Component, v.Version = n, version
+			} else {
+				v.Component = a.Ref
+			}
+			switch cv.Analysis.State {
+			case "not_affected", "false_positive", "resolved":
+				v.Ignored = true
+			}
+			vs = append(vs, v)
+		}
+	}
+	return vs, nil
+}
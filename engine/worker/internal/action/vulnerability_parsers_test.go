@@ -0,0 +1,119 @@
+package action
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ovh/cds/sdk"
+)
+
+const dependencyCheckJSONResult = `{
+  "reportSchema": "1.1",
+  "dependencies": [{
+    "fileName": "jackson-databind-2.9.1.jar",
+    "filePath": "/app/lib/jackson-databind-2.9.1.jar",
+    "packages": [{"id": "pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.9.1"}],
+    "vulnerabilities": [{
+      "source": "NVD",
+      "name": "CVE-2017-17485",
+      "severity": "CRITICAL",
+      "description": "Deserialization of untrusted data",
+      "references": [{"url": "https://github.com/FasterXML/jackson-databind/issues/1855"}],
+      "vulnerableSoftware": [{"software": {"id": "cpe:2.3:a:fasterxml:jackson-databind:*", "versionEndExcluding": "2.9.4"}}]
+    }]
+  }, {
+    "fileName": "commons-io-2.6.jar",
+    "filePath": "/app/lib/commons-io-2.6.jar"
+  }]
+}`
+
+const trivyJSONResult = `{
+  "SchemaVersion": 2,
+  "ArtifactName": "alpine:3.10",
+  "Results": [{
+    "Target": "alpine:3.10 (alpine 3.10.9)",
+    "Vulnerabilities": [{
+      "VulnerabilityID": "CVE-2021-36159",
+      "PkgName": "apk-tools",
+      "InstalledVersion": "2.10.6-r0",
+      "FixedVersion": "2.10.7-r0",
+      "Severity": "CRITICAL",
+      "Description": "libfetch mishandles numeric strings"
+    }, {
+      "VulnerabilityID": "CVE-2021-36159",
+      "PkgName": "apk-tools",
+      "InstalledVersion": "2.10.6-r0",
+      "FixedVersion": "2.10.7-r0",
+      "Severity": "CRITICAL"
+    }]
+  }]
+}`
+
+const cycloneDXVEXResult = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "components": [{"bom-ref": "lodash@4.17.4", "name": "lodash", "version": "4.17.4", "purl": "pkg:npm/lodash@4.17.4"}],
+  "vulnerabilities": [{
+    "id": "CVE-2018-3721",
+    "source": {"name": "NVD", "url": "https://nvd.nist.gov/vuln/detail/CVE-2018-3721"},
+    "ratings": [{"severity": "medium"}],
+    "recommendation": "Upgrade to 4.17.5",
+    "affects": [{"ref": "lodash@4.17.4"}],
+    "analysis": {"state": "not_affected", "justification": "code_not_reachable"}
+  }, {
+    "id": "CVE-2020-8203",
+    "ratings": [{"severity": "high"}],
+    "affects": [{"ref": "pkg:npm/%40babel/core@7.0.0"}]
+  }]
+}`
+
+func TestParseVulnerabilityFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "dependency-check-report.json", []byte(dependencyCheckJSONResult), os.ModePerm))
+	require.NoError(t, afero.WriteFile(fs, "trivy.json", []byte(trivyJSONResult), os.ModePerm))
+	require.NoError(t, afero.WriteFile(fs, "vex.json", []byte(cycloneDXVEXResult), os.ModePerm))
+
+	report, err := ParseVulnerabilityFiles(fs, VulnerabilityFormatDependencyCheck, []string{"dependency-check-report.json"})
+	require.NoError(t, err)
+	assert.Equal(t, VulnerabilityFormatDependencyCheck, report.Type)
+	require.Len(t, report.Vulnerabilities, 1)
+	assert.Equal(t, sdk.Vulnerability{
+		Title:       "CVE-2017-17485",
+		CVE:         "CVE-2017-17485",
+		Description: "Deserialization of untrusted data",
+		Link:        "https://github.com/FasterXML/jackson-databind/issues/1855",
+		Component:   "com.fasterxml.jackson.core/jackson-databind",
+		Version:     "2.9.1",
+		Origin:      "/app/lib/jackson-databind-2.9.1.jar",
+		Severity:    sdk.SeverityCritical,
+		FixIn:       "2.9.4",
+	}, report.Vulnerabilities[0])
+
+	// The same vulnerability is reported twice
+	report, err = ParseVulnerabilityFiles(fs, VulnerabilityFormatTrivy, []string{"trivy.json"})
+	require.NoError(t, err)
+	require.Len(t, report.Vulnerabilities, 1)
+	assert.Equal(t, "apk-tools", report.Vulnerabilities[0].Component)
+	assert.Equal(t, "2.10.7-r0", report.Vulnerabilities[0].FixIn)
+	assert.Equal(t, "https://nvd.nist.gov/vuln/detail/CVE-2021-36159", report.Vulnerabilities[0].Link)
+	assert.Equal(t, map[string]int64{sdk.SeverityCritical: 1}, report.Summary)
+
+	report, err = ParseVulnerabilityFiles(fs, VulnerabilityFormatCycloneDXVEX, []string{"vex.json"})
+	require.NoError(t, err)
+	require.Len(t, report.Vulnerabilities, 2)
+	assert.Equal(t, "lodash", report.Vulnerabilities[0].Component)
+	assert.Equal(t, sdk.SeverityMedium, report.Vulnerabilities[0].Severity)
+	assert.True(t, report.Vulnerabilities[0].Ignored)
+	assert.Equal(t, "@babel/core", report.Vulnerabilities[1].Component)
+	assert.Equal(t, "7.0.0", report.Vulnerabilities[1].Version)
+	assert.False(t, report.Vulnerabilities[1].Ignored)
+
+	_, err = ParseVulnerabilityFiles(fs, "foo", []string{"vex.json"})
+	assert.Error(t, err)
+	_, err = ParseVulnerabilityFiles(fs, VulnerabilityFormatCycloneDXVEX, []string{"trivy.json"})
+	assert.Error(t, err)
+}
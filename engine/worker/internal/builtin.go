@@ -25,6 +25,7 @@ func init() {
 	mapBuiltinActions[sdk.CoverageAction] = action.RunParseCoverageResultAction
 	mapBuiltinActions[sdk.ServeStaticFiles] = action.RunServeStaticFiles
 	mapBuiltinActions[sdk.InstallKeyAction] = action.RunInstallKey
+	mapBuiltinActions[sdk.StaticAnalysisAction] = action.RunStaticAnalysis
 }
 
 func (w *CurrentWorker) runBuiltin(ctx context.Context, a sdk.Action, secrets []sdk.Variable) sdk.Result {
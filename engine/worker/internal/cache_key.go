@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	zglob "github.com/mattn/go-zglob"
+)
+
+// cacheKeyHashRegex matches the {{hash <files>}} helpers of a cache key
+var cacheKeyHashRegex = regexp.MustCompile(`{{\s*hash\s+([^}]*?)\s*}}`)
+
+// ExpandCacheKey replaces the {{hash <files>}} helpers of a cache key by the hash of the files, ie. go-mod-{{hash go.sum}}.
+func ExpandCacheKey(cwd, key string) (string, error) {
+	var err error
+	res := cacheKeyHashRegex.ReplaceAllStringFunc(key, func(helper string) string {
+		if err != nil {
+			return helper
+		}
+		var h string
+		h, err = HashCacheFiles(cwd, strings.Fields(cacheKeyHashRegex.FindStringSubmatch(helper)[1]))
+		return h
+	})
+	if err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+// HashCacheFiles returns the sha256 of the files matching the given patterns, the patterns can contain globs
+// (ie. **/package-lock.json). The hash depends on the paths and on the content of the files, not on their dates.
+func HashCacheFiles(cwd string, patterns []string) (string, error) {
+	if len(patterns) == 0 {
+		return "", fmt.Errorf("missing files to hash")
+	}
+
+	files := map[string]struct{}{}
+	for _, p := range patterns {
+		p = strings.Trim(p, `"'`)
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(cwd, p)
+		}
+		matches, err := zglob.Glob(p)
+		if err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("invalid pattern %s: %v", p, err)
+		}
+		for _, m := range matches {
+			fi, err := os.Stat(m)
+			if err != nil {
+				return "", err
+			}
+			if !fi.IsDir() {
+				files[m] = struct{}{}
+			}
+		}
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("no file matches %s", strings.Join(patterns, " "))
+	}
+
+	paths := make([]string, 0, len(files))
+	for f := range files {
+		paths = append(paths, f)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, p := range paths {
+		rel, err := filepath.Rel(cwd, p)
+		if err != nil {
+			rel = p
+		}
+		fh := sha256.New()
+		f, err := os.Open(p)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(fh, f)
+		_ = f.Close()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%x\n", filepath.ToSlash(rel), fh.Sum(nil))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandCacheKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache-key")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "go.sum"), []byte("sum"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a", "package-lock.json"), []byte("lock a"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a", "b", "package-lock.json"), []byte("lock b"), 0644))
+
+	k, err := ExpandCacheKey(dir, "my-key")
+	require.NoError(t, err)
+	assert.Equal(t, "my-key", k)
+
+	goSum, err := HashCacheFiles(dir, []string{"go.sum"})
+	require.NoError(t, err)
+	assert.Len(t, goSum, 64)
+
+	k, err = ExpandCacheKey(dir, "go-mod-{{hash go.sum}}")
+	require.NoError(t, err)
+	assert.Equal(t, "go-mod-"+goSum, k)
+
+	k, err = ExpandCacheKey(dir, `go-mod-{{ hash "go.sum" }}`)
+	require.NoError(t, err)
+	assert.Equal(t, "go-mod-"+goSum, k)
+
+	// All the files matching the patterns are hashed
+	npm, err := ExpandCacheKey(dir, "npm-{{hash **/package-lock.json}}")
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a", "b", "package-lock.json"), []byte("new lock b"), 0644))
+	npm2, err := ExpandCacheKey(dir, "npm-{{hash **/package-lock.json}}")
+	require.NoError(t, err)
+	assert.NotEqual(t, npm, npm2)
+
+	_, err = ExpandCacheKey(dir, "{{hash unknown.txt}}")
+	assert.Error(t, err)
+}
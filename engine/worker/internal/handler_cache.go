@@ -3,8 +3,12 @@ package internal
 import (
 	"archive/tar"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -16,12 +20,16 @@ import (
 	"github.com/spf13/afero"
 
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/cdsclient"
 	"github.com/ovh/cds/sdk/log"
 )
 
 func cachePushHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
+		// Caches are split in layers unless the old single tar upload is asked
+		layers := r.FormValue("layers") != "false"
+
 		// Get body
 		data, errRead := ioutil.ReadAll(r.Body)
 		if errRead != nil {
@@ -45,8 +53,21 @@ func cachePushHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 			return
 		}
 
-		res, size, errTar := sdk.CreateTarFromPaths(afero.NewOsFs(), c.WorkingDirectory, c.Files, nil)
-		if errTar != nil {
+		// The tar is written in a temporary file to be read again on retries
+		content, errTmp := ioutil.TempFile("", "cds-cache-")
+		if errTmp != nil {
+			errTmp = sdk.Error{
+				Message: "worker cache push > Cannot create temporary file : " + errTmp.Error(),
+				Status:  http.StatusInternalServerError,
+			}
+			log.Error(ctx, "%v", errTmp)
+			writeError(w, r, errTmp)
+			return
+		}
+		defer os.Remove(content.Name()) // nolint
+		defer content.Close()           // nolint
+
+		if errTar := sdk.WriteTarFromPaths(afero.NewOsFs(), c.WorkingDirectory, c.Files, &sdk.TarOptions{NormalizeHeaders: layers}, content); errTar != nil {
 			errTar = sdk.Error{
 				Message: fmt.Sprintf("worker cache push > Cannot tar (%+v) : %v", c.Files, errTar.Error()),
 				Status:  http.StatusBadRequest,
@@ -55,6 +76,17 @@ func cachePushHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 			writeError(w, r, errTar)
 			return
 		}
+		size, errTar := content.Seek(0, io.SeekCurrent)
+		if errTar != nil {
+			errTar = sdk.Error{
+				Message: fmt.Sprintf("worker cache push > Cannot tar (%+v) : %v", c.Files, errTar.Error()),
+				Status:  http.StatusInternalServerError,
+			}
+			log.Error(ctx, "%v", errTar)
+			writeError(w, r, errTar)
+			return
+		}
+
 		params := wk.currentJob.wJob.Parameters
 		projectKey := sdk.ParameterValue(params, "cds.project")
 		if projectKey == "" {
@@ -66,10 +98,31 @@ func cachePushHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 			writeError(w, r, errP)
 			return
 		}
+		integrationName := sdk.DefaultIfEmptyStorage(c.IntegrationName)
+
+		var manifest sdk.CacheManifest
+		if layers {
+			var errManifest error
+			manifest, errManifest = sdk.NewCacheManifestFromReader(vars["ref"], io.NewSectionReader(content, 0, size))
+			if errManifest != nil {
+				errManifest = sdk.Error{
+					Message: "worker cache push > Cannot split cache in layers : " + errManifest.Error(),
+					Status:  http.StatusInternalServerError,
+				}
+				log.Error(ctx, "%v", errManifest)
+				writeError(w, r, errManifest)
+				return
+			}
+		}
 
 		var errPush error
 		for i := 0; i < 10; i++ {
-			if errPush = wk.client.WorkflowCachePush(projectKey, sdk.DefaultIfEmptyStorage(c.IntegrationName), vars["ref"], res, size); errPush == nil {
+			if layers {
+				errPush = pushCacheLayers(ctx, wk, projectKey, integrationName, manifest, content)
+			} else {
+				errPush = wk.client.WorkflowCachePush(projectKey, integrationName, vars["ref"], io.NewSectionReader(content, 0, size), int(size))
+			}
+			if errPush == nil {
 				return
 			}
 			time.Sleep(3 * time.Second)
@@ -85,14 +138,62 @@ func cachePushHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 	}
 }
 
+// pushCacheLayers only uploads the layers of the cache that are not already stored for the project,
+// the layers are read from the content of the cache one after the other.
+func pushCacheLayers(ctx context.Context, wk *CurrentWorker, projectKey, integrationName string, manifest sdk.CacheManifest, content io.ReaderAt) error {
+	missing, err := wk.client.WorkflowCacheLayersMissing(projectKey, integrationName, manifest.Layers)
+	if err != nil {
+		return err
+	}
+	missingDigests := make(map[string]struct{}, len(missing))
+	for _, l := range missing {
+		missingDigests[l.Digest] = struct{}{}
+	}
+
+	var offset, uploaded int64
+	for _, l := range manifest.Layers {
+		layerOffset := offset
+		offset += l.Size
+		if _, ok := missingDigests[l.Digest]; !ok {
+			continue
+		}
+		if err := wk.client.WorkflowCacheLayerPush(projectKey, integrationName, l.Digest, io.NewSectionReader(content, layerOffset, l.Size)); err != nil {
+			return err
+		}
+		delete(missingDigests, l.Digest)
+		uploaded += l.Size
+	}
+
+	if err := wk.client.WorkflowCacheManifestPush(projectKey, integrationName, manifest.Tag, manifest); err != nil {
+		return err
+	}
+	log.Info(ctx, "worker cache push > cache %s pushed with %s, %d bytes uploaded", manifest.Tag, manifest, uploaded)
+	return nil
+}
+
 func cachePullHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		path := r.FormValue("path")
 		integrationName := sdk.DefaultIfEmptyStorage(r.FormValue("integration"))
+		restoreKeys := r.Form["restore"]
 		params := wk.currentJob.wJob.Parameters
 		projectKey := sdk.ParameterValue(params, "cds.project")
-		bts, err := wk.client.WorkflowCachePull(projectKey, integrationName, vars["ref"])
+
+		key, _ := base64.RawURLEncoding.DecodeString(vars["ref"])
+		restored := sdk.Cache{Project: projectKey, Tag: string(key)}
+
+		content, err := pullCache(wk, projectKey, integrationName, vars["ref"])
+		if err != nil && len(restoreKeys) > 0 {
+			// Fallback on the latest cache matching one of the restore keys
+			log.Info(ctx, "worker cache pull > cache %s not found, looking for restore keys %v: %v", key, restoreKeys, err)
+			var c *sdk.Cache
+			c, err = wk.client.WorkflowCacheLookup(projectKey, integrationName, restoreKeys)
+			if err == nil {
+				restored.Tag = c.Name
+				content, err = pullCache(wk, projectKey, integrationName, c.Tag)
+			}
+		}
 		if err != nil {
 			err = sdk.Error{
 				Message: "worker cache pull > Cannot pull cache: " + err.Error(),
@@ -101,91 +202,161 @@ func cachePullHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
 			writeError(w, r, err)
 			return
 		}
+		defer content.Close() // nolint
 
-		tr := tar.NewReader(bts)
-		for {
-			header, errH := tr.Next()
-			if errH == io.EOF {
-				break
-			}
+		if err := extractCache(content, path); err != nil {
+			writeError(w, r, err)
+			return
+		}
 
-			if errH != nil {
-				errH = sdk.Error{
-					Message: "worker cache pull > Unable to read tar file: " + errH.Error(),
-					Status:  http.StatusBadRequest,
-				}
-				writeJSON(w, errH, http.StatusBadRequest)
-				return
+		writeJSON(w, restored, http.StatusOK)
+	}
+}
+
+// pullCache returns the content of a cache, from its layers if the cache has a manifest or from its tar.
+func pullCache(wk *CurrentWorker, projectKey, integrationName, ref string) (io.ReadCloser, error) {
+	manifest, err := wk.client.WorkflowCacheManifestPull(projectKey, integrationName, ref)
+	if err == nil {
+		return &cacheLayersReader{
+			client:          wk.client,
+			projectKey:      projectKey,
+			integrationName: integrationName,
+			layers:          manifest.Layers,
+		}, nil
+	}
+	if !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return nil, err
+	}
+
+	res, err := wk.client.WorkflowCachePull(projectKey, integrationName, ref)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(res), nil
+}
+
+// cacheLayersReader downloads the layers of a cache one after the other, the checksum of each layer is verified.
+type cacheLayersReader struct {
+	client          cdsclient.WorkerInterface
+	projectKey      string
+	integrationName string
+	layers          []sdk.CacheLayer
+	current         io.ReadCloser
+	hash            hash.Hash
+}
+
+func (r *cacheLayersReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.layers) == 0 {
+				return 0, io.EOF
+			}
+			current, err := r.client.WorkflowCacheLayerPull(r.projectKey, r.integrationName, r.layers[0].Digest)
+			if err != nil {
+				return 0, err
 			}
+			r.current = current
+			r.hash = sha256.New()
+		}
 
-			if header == nil {
+		n, err := r.current.Read(p)
+		r.hash.Write(p[:n]) // nolint
+		if err == io.EOF {
+			_ = r.current.Close()
+			r.current = nil
+			if sum := hex.EncodeToString(r.hash.Sum(nil)); sum != r.layers[0].Digest {
+				return n, fmt.Errorf("invalid checksum %s for cache layer %s", sum, r.layers[0].Digest)
+			}
+			r.layers = r.layers[1:]
+			if n == 0 {
 				continue
 			}
+			return n, nil
+		}
+		return n, err
+	}
+}
 
-			// the target location where the dir/file should be created
-			target := filepath.Join(path, header.Name)
-
-			// check the file type
-			switch header.Typeflag {
-			// if its a dir and it doesn't exist create it
-			case tar.TypeDir:
-				if _, err := os.Stat(target); err != nil {
-					if err := os.MkdirAll(target, 0755); err != nil {
-						err = sdk.Error{
-							Message: "worker cache pull > Unable to mkdir all files : " + err.Error(),
-							Status:  http.StatusInternalServerError,
-						}
-						writeJSON(w, err, http.StatusInternalServerError)
-						return
-					}
-				}
-			case tar.TypeSymlink:
-				if err := os.Symlink(header.Linkname, target); err != nil {
-					err = sdk.Error{
-						Message: "worker cache pull > Unable to create symlink: " + err.Error(),
+func (r *cacheLayersReader) Close() error {
+	if r.current != nil {
+		return r.current.Close()
+	}
+	return nil
+}
+
+// extractCache writes the files of the cache tar in the given path.
+func extractCache(content io.Reader, path string) error {
+	tr := tar.NewReader(content)
+	for {
+		header, errH := tr.Next()
+		if errH == io.EOF {
+			return nil
+		}
+
+		if errH != nil {
+			return sdk.Error{
+				Message: "worker cache pull > Unable to read tar file: " + errH.Error(),
+				Status:  http.StatusBadRequest,
+			}
+		}
+
+		if header == nil {
+			continue
+		}
+
+		// the target location where the dir/file should be created
+		target := filepath.Join(path, header.Name)
+
+		// check the file type
+		switch header.Typeflag {
+		// if its a dir and it doesn't exist create it
+		case tar.TypeDir:
+			if _, err := os.Stat(target); err != nil {
+				if err := os.MkdirAll(target, 0755); err != nil {
+					return sdk.Error{
+						Message: "worker cache pull > Unable to mkdir all files : " + err.Error(),
 						Status:  http.StatusInternalServerError,
 					}
-					writeJSON(w, err, http.StatusInternalServerError)
-					return
 				}
-
-				// if it's a file create it
-			case tar.TypeReg, tar.TypeLink:
-				// if directory of file does not exist, create it before
-				if _, err := os.Stat(filepath.Dir(target)); err != nil {
-					if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
-						err = sdk.Error{
-							Message: "worker cache pull > Unable to mkdir all files : " + err.Error(),
-							Status:  http.StatusInternalServerError,
-						}
-						writeJSON(w, err, http.StatusInternalServerError)
-						return
-					}
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return sdk.Error{
+					Message: "worker cache pull > Unable to create symlink: " + err.Error(),
+					Status:  http.StatusInternalServerError,
 				}
+			}
 
-				f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, os.FileMode(header.Mode))
-				if err != nil {
-					sdkErr := sdk.Error{
-						Message: "worker cache pull > Unable to open file: " + err.Error(),
+			// if it's a file create it
+		case tar.TypeReg, tar.TypeLink:
+			// if directory of file does not exist, create it before
+			if _, err := os.Stat(filepath.Dir(target)); err != nil {
+				if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+					return sdk.Error{
+						Message: "worker cache pull > Unable to mkdir all files : " + err.Error(),
 						Status:  http.StatusInternalServerError,
 					}
-					writeJSON(w, sdkErr, sdkErr.Status)
-					return
 				}
+			}
 
-				// copy over contents
-				if _, err := io.Copy(f, tr); err != nil {
-					_ = f.Close()
-					sdkErr := sdk.Error{
-						Message: "worker cache pull > Cannot copy content file: " + err.Error(),
-						Status:  http.StatusInternalServerError,
-					}
-					writeJSON(w, sdkErr, sdkErr.Status)
-					return
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, os.FileMode(header.Mode))
+			if err != nil {
+				return sdk.Error{
+					Message: "worker cache pull > Unable to open file: " + err.Error(),
+					Status:  http.StatusInternalServerError,
 				}
+			}
 
+			// copy over contents
+			if _, err := io.Copy(f, tr); err != nil {
 				_ = f.Close()
+				return sdk.Error{
+					Message: "worker cache pull > Cannot copy content file: " + err.Error(),
+					Status:  http.StatusInternalServerError,
+				}
 			}
+
+			_ = f.Close()
 		}
 	}
 }
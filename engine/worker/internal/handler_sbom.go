@@ -0,0 +1,32 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+)
+
+func sbomHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		jobID, err := workerruntime.JobID(wk.currentJob.context)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		sbom, err := wk.Client().QueueSendSBOM(wk.currentJob.context, jobID, r.FormValue("name"), r.Body)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, sbom, http.StatusOK)
+	}
+}
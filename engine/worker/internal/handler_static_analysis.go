@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/ovh/cds/engine/worker/pkg/workerruntime"
+	"github.com/ovh/cds/sdk"
+)
+
+func staticAnalysisHandler(ctx context.Context, wk *CurrentWorker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		var report sdk.StaticAnalysisWorkerReport
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		if err := json.Unmarshal(data, &report); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		jobID, err := workerruntime.JobID(wk.currentJob.context)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		nodeRunReport, err := wk.Client().QueueSendStaticAnalysis(wk.currentJob.context, jobID, report)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		writeJSON(w, nodeRunReport, http.StatusOK)
+	}
+}
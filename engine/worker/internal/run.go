@@ -146,6 +146,11 @@ func (w *CurrentWorker) runJob(ctx context.Context, a *sdk.Action, jobID int64,
 				nDisabled++
 			case sdk.StatusFail:
 				if !step.Optional {
+					// Keep the cause of the first critical failure for the job retry policy
+					if nCriticalFailed == 0 {
+						jobResult.ExitCode = stepResult.ExitCode
+						jobResult.TimedOut = stepResult.TimedOut
+					}
 					nCriticalFailed++
 				}
 			}
@@ -243,8 +248,8 @@ func (w *CurrentWorker) runSteps(ctx context.Context, steps []sdk.Action, a sdk.
 	defer func() {
 		log.Info(ctx, "runSteps> end action steps %s %d len(steps):%d context=%p (%s)", stepName, jobID, len(steps), ctx, ctx.Err())
 	}()
-	var criticalStepFailed bool
-	var nbDisabledChildren int
+	var criticalStepFailed, timedOut bool
+	var nbDisabledChildren, exitCode int
 
 	r := sdk.Result{
 		Status:  sdk.StatusFail,
@@ -264,6 +269,9 @@ func (w *CurrentWorker) runSteps(ctx context.Context, steps []sdk.Action, a sdk.
 		if !criticalStepFailed || child.AlwaysExecuted {
 			r = w.runAction(ctx, child, jobID, secrets, childName)
 			if r.Status != sdk.StatusSuccess && !child.Optional {
+				if !criticalStepFailed {
+					exitCode, timedOut = r.ExitCode, r.TimedOut
+				}
 				criticalStepFailed = true
 			}
 		} else if criticalStepFailed && !child.AlwaysExecuted {
@@ -289,6 +297,7 @@ func (w *CurrentWorker) runSteps(ctx context.Context, steps []sdk.Action, a sdk.
 
 	if criticalStepFailed {
 		r.Status = sdk.StatusFail
+		r.ExitCode, r.TimedOut = exitCode, timedOut
 	} else {
 		r.Status = sdk.StatusSuccess
 	}
@@ -537,6 +546,9 @@ func (w *CurrentWorker) ProcessJob(jobInfo sdk.WorkflowNodeJobRunData) (sdk.Resu
 	w.currentJob.params = jobParameters
 
 	res, err := w.runJob(ctx, &jobInfo.NodeJobRun.Job.Action, jobInfo.NodeJobRun.ID, jobInfo.Secrets)
+	if res.Status == sdk.StatusFail && ctx.Err() == context.DeadlineExceeded {
+		res.TimedOut = true
+	}
 
 	if len(res.NewVariables) > 0 {
 		log.Debug("processJob> new variables: %v", res.NewVariables)
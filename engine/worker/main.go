@@ -16,6 +16,9 @@ func main() {
 	cmd.AddCommand(cmdCache())
 	cmd.AddCommand(cmdKey())
 	cmd.AddCommand(cmdJunitParser())
+	cmd.AddCommand(cmdStaticAnalysis())
+	cmd.AddCommand(cmdVulnerability())
+	cmd.AddCommand(cmdSBOM())
 
 	// last command: doc, this command is hidden
 	cmd.AddCommand(cmdDoc(cmd))
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	json "encoding/json"
 	"fmt"
+	"regexp"
 )
 
 // Action type
@@ -12,6 +13,8 @@ const (
 	BuiltinAction = "Builtin"
 	PluginAction  = "Plugin"
 	JoinedAction  = "Joined"
+	// PublishedAction is an immutable copy of a default action for a given version
+	PublishedAction = "Published"
 )
 
 // Builtin Action
@@ -25,10 +28,17 @@ const (
 	CheckoutApplicationAction = "CheckoutApplication"
 	DeployApplicationAction   = "DeployApplication"
 	InstallKeyAction          = "InstallKey"
+	StaticAnalysisAction      = "StaticAnalysis"
 
 	DefaultGitCloneParameterTagValue = "{{.git.tag}}"
 )
 
+// ActionVersionPattern is the pattern of a published action version
+const ActionVersionPattern = "^[a-zA-Z0-9._-]{1,100}$"
+
+// ActionVersionRegex is the regexp of a published action version
+var ActionVersionRegex = regexp.MustCompile(ActionVersionPattern)
+
 // NewAction instantiate a new Action
 func NewAction(name string) *Action {
 	return &Action{
@@ -46,6 +56,9 @@ type Action struct {
 	Description string `json:"description" yaml:"desc,omitempty" db:"description"`
 	Enabled     bool   `json:"enabled" yaml:"-" db:"enabled"`
 	Deprecated  bool   `json:"deprecated" yaml:"-" db:"deprecated"`
+	// published action version, and id of the default action it was published from
+	Version         string `json:"version,omitempty" yaml:"-" db:"version"`
+	PublishedFromID *int64 `json:"published_from_id,omitempty" yaml:"-" db:"published_from_id"`
 	// aggregates from action_edge
 	StepName       string `json:"step_name,omitempty" yaml:"step_name,omitempty" db:"-"`
 	Optional       bool   `json:"optional" yaml:"-" db:"-"`
@@ -117,6 +130,26 @@ func (a Action) IsValid() error {
 	return nil
 }
 
+// IsValidActionVersion returns an error if given action version is not valid.
+func IsValidActionVersion(version string) error {
+	if !ActionVersionRegex.MatchString(version) {
+		return NewErrorFrom(ErrWrongRequest, "invalid action version %q, it should match %s", version, ActionVersionPattern)
+	}
+	return nil
+}
+
+// Path returns the reference of the action used in steps: name, group/name, name@version or group/name@version.
+func (a Action) Path() string {
+	path := a.Name
+	if a.Group != nil && a.Group.Name != "" && a.Group.Name != SharedInfraGroupName {
+		path = a.Group.Name + "/" + path
+	}
+	if a.Version != "" {
+		path += "@" + a.Version
+	}
+	return path
+}
+
 // FlattenRequirements returns all requirements for an action and its children.
 func (a *Action) FlattenRequirements() RequirementList {
 	if !a.Enabled {
@@ -24,6 +24,7 @@ var List = []Manifest{
 	Release,
 	Script,
 	ServeStaticFiles,
+	StaticAnalysis,
 }
 
 // Manifest for a action.
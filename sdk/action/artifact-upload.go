@@ -9,7 +9,7 @@ import (
 var ArtifactUpload = Manifest{
 	Action: sdk.Action{
 		Name:        sdk.ArtifactUpload,
-		Description: "This action can be used to upload artifacts in CDS. This is the recommended way to share files between pipelines or stages.",
+		Description: "This action can be used to upload artifacts in CDS. This is the recommended way to share files between pipelines or stages. A file is not uploaded again if the project storage already contains the same content.",
 		Parameters: []sdk.Parameter{
 			{
 				Name:        "path",
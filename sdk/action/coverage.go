@@ -13,13 +13,17 @@ var Coverage = Manifest{
 Parse given file to extract coverage results.
 
 Coverage report will be linked to the application from the pipeline context.
-You will be able to see the coverage history in the application home page.`,
+You will be able to see the coverage history in the application home page.
+
+With the coverprofile (Go), jacoco and clover formats, the coverage of each line is also sent.
+It is used to compute the coverage of the lines changed since the default branch, that is posted
+as a comment on the pull request of the branch.`,
 		Parameters: []sdk.Parameter{
 			{
 				Name:        "format",
 				Description: `Coverage report format.`,
 				Type:        sdk.ListParameter,
-				Value:       "lcov;cobertura;clover",
+				Value:       "lcov;cobertura;clover;coverprofile;jacoco",
 			},
 			{
 				Name:        "path",
@@ -0,0 +1,59 @@
+package action
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// StaticAnalysis action definition.
+var StaticAnalysis = Manifest{
+	Action: sdk.Action{
+		Name: sdk.StaticAnalysisAction,
+		Description: `CDS Builtin Action.
+Parse given SARIF files to extract the findings of static analysis tools.
+
+Findings are stored on the node run and compared to the latest report of the default branch
+(or to the previous run on the default branch) to display the new and the fixed findings.
+
+The job fails if a finding has a level greater or equal to the given severity.`,
+		Parameters: []sdk.Parameter{
+			{
+				Name:        "path",
+				Description: `Path of the SARIF files, glob patterns are allowed.`,
+				Type:        sdk.StringParameter,
+			},
+			{
+				Name:        "severity",
+				Description: `Fail the job if a finding has this level or higher (none means never fail).`,
+				Type:        sdk.ListParameter,
+				Value:       "none;note;warning;error",
+				Advanced:    true,
+			},
+			{
+				Name:        "newOnly",
+				Description: `Only new findings compared to the default branch can fail the job.`,
+				Type:        sdk.BooleanParameter,
+				Value:       "false",
+				Advanced:    true,
+			},
+		},
+	},
+	Example: exportentities.PipelineV1{
+		Version: exportentities.PipelineVersion1,
+		Name:    "Pipeline1",
+		Stages:  []string{"Stage1"},
+		Jobs: []exportentities.Job{{
+			Name:  "Job1",
+			Stage: "Stage1",
+			Steps: []exportentities.Step{
+				{
+					StaticAnalysis: &exportentities.StepStaticAnalysis{
+						Path:     "./reports/*.sarif",
+						Severity: "error",
+						NewOnly:  "true",
+					},
+				},
+			},
+		}},
+	},
+}
@@ -0,0 +1,36 @@
+package sdk
+
+// ActionUsage for action.
+type ActionUsage struct {
+	Pipelines []ActionUsagePipeline `json:"pipelines" cli:"-"`
+	Actions   []ActionUsageAction   `json:"actions" cli:"-"`
+}
+
+// ActionUsagePipeline represent a pipeline using an action.
+type ActionUsagePipeline struct {
+	ProjectID     string `json:"project_id" cli:"-"`
+	ProjectKey    string `json:"project_key" cli:"project"`
+	ProjectName   string `json:"project_name" cli:"-"`
+	PipelineID    int64  `json:"pipeline_id" cli:"-"`
+	PipelineName  string `json:"pipeline_name" cli:"pipeline"`
+	StageID       int64  `json:"stage_id" cli:"-"`
+	StageName     string `json:"stage_name" cli:"stage"`
+	JobID         int64  `json:"job_id" cli:"-"`
+	JobName       string `json:"job_name" cli:"job"`
+	ActionID      int64  `json:"action_id" cli:"-"`
+	ActionName    string `json:"action_name" cli:"action"`
+	ActionVersion string `json:"action_version,omitempty" cli:"version"`
+	Warning       bool   `json:"warning" cli:"-"`
+}
+
+// ActionUsageAction represent a action using an action.
+type ActionUsageAction struct {
+	GroupID          int64  `json:"group_id" cli:"-"`
+	GroupName        string `json:"group_name" cli:"group"`
+	ParentActionID   int64  `json:"parent_action_id" cli:"-"`
+	ParentActionName string `json:"parent_action_name" cli:"parent_action"`
+	ActionID         int64  `json:"action_id" cli:"-"`
+	ActionName       string `json:"action_name" cli:"action"`
+	ActionVersion    string `json:"action_version,omitempty" cli:"version"`
+	Warning          bool   `json:"warning" cli:"-"`
+}
@@ -1,6 +1,10 @@
 package sdk
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // VulnerabilityWorkerReport represent a vulnerability report
 type VulnerabilityWorkerReport struct {
@@ -24,6 +28,85 @@ type Vulnerability struct {
 	FixIn         string `json:"fix_in" db:"fix_in"`
 	Ignored       bool   `json:"ignored" db:"ignored"`
 	Type          string `json:"type" db:"type"`
+
+	// Suppression is the project rule that ignores the vulnerability, if any
+	Suppression *VulnerabilitySuppression `json:"suppression,omitempty" db:"-"`
+}
+
+// Key returns the identifier of a vulnerability, used to find the same vulnerability across reports and runs
+func (v Vulnerability) Key() string {
+	return fmt.Sprintf("%s-%s-%s", v.Component, v.Version, v.CVE)
+}
+
+// VulnerabilitySuppression is a project rule to ignore a CVE until its expiry date
+type VulnerabilitySuppression struct {
+	ID            int64     `json:"id" db:"id"`
+	ProjectID     int64     `json:"project_id" db:"project_id"`
+	CVE           string    `json:"cve" db:"cve" cli:"cve,key"`
+	Justification string    `json:"justification" db:"justification" cli:"justification"`
+	Expiry        time.Time `json:"expiry" db:"expiry" cli:"expiry"`
+	Author        string    `json:"author" db:"author" cli:"author"`
+	Created       time.Time `json:"created" db:"created" cli:"created"`
+}
+
+// IsValid returns an error if the suppression rule is not complete
+func (s VulnerabilitySuppression) IsValid() error {
+	if strings.TrimSpace(s.CVE) == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid given CVE id")
+	}
+	if strings.TrimSpace(s.Justification) == "" {
+		return NewErrorFrom(ErrWrongRequest, "a justification is required to suppress %s", s.CVE)
+	}
+	if s.Expiry.IsZero() {
+		return NewErrorFrom(ErrWrongRequest, "an expiry date is required to suppress %s", s.CVE)
+	}
+	return nil
+}
+
+// IsActive returns true if the suppression rule is not expired
+func (s VulnerabilitySuppression) IsActive(now time.Time) bool {
+	return now.Before(s.Expiry)
+}
+
+// ApplyVulnerabilitySuppressions flags as ignored the vulnerabilities that match an active suppression rule
+func ApplyVulnerabilitySuppressions(vs []Vulnerability, rules []VulnerabilitySuppression, now time.Time) {
+	if len(rules) == 0 {
+		return
+	}
+	for i := range vs {
+		for j := range rules {
+			if rules[j].IsActive(now) && strings.EqualFold(rules[j].CVE, vs[i].CVE) {
+				vs[i].Ignored = true
+				vs[i].Suppression = &rules[j]
+				break
+			}
+		}
+	}
+}
+
+// DedupVulnerabilities returns the vulnerabilities without duplicates, the first one is kept.
+// A vulnerability that is ignored stays ignored.
+func DedupVulnerabilities(vs []Vulnerability) []Vulnerability {
+	res := make([]Vulnerability, 0, len(vs))
+	index := make(map[string]int, len(vs))
+	for _, v := range vs {
+		if i, ok := index[v.Key()]; ok {
+			res[i].Ignored = res[i].Ignored || v.Ignored
+			continue
+		}
+		index[v.Key()] = len(res)
+		res = append(res, v)
+	}
+	return res
+}
+
+// VulnerabilitiesSummary returns the count of vulnerabilities by severity
+func VulnerabilitiesSummary(vs []Vulnerability) map[string]int64 {
+	summary := make(map[string]int64)
+	for _, v := range vs {
+		summary[v.Severity]++
+	}
+	return summary
 }
 
 const (
@@ -42,8 +125,10 @@ func ToVulnerabilitySeverity(s string) string {
 	switch s {
 	case SeverityNegligible, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical, SeverityDefcon1:
 		return s
-	case "Moderate":
+	case "moderate":
 		return SeverityMedium
+	case "info", "none":
+		return SeverityNegligible
 	default:
 		return SeverityUnknown
 	}
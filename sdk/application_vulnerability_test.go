@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyVulnerabilitySuppressions(t *testing.T) {
+	now := time.Now()
+	vs := []Vulnerability{
+		{Component: "lodash", Version: "4.17.4", CVE: "CVE-2018-3721"},
+		{Component: "openssl", Version: "1.0.2", CVE: "CVE-2016-2107"},
+		{Component: "jackson", Version: "2.9.1", CVE: "CVE-2017-17485"},
+	}
+	rules := []VulnerabilitySuppression{
+		{CVE: "cve-2018-3721", Justification: "not exploitable", Expiry: now.Add(time.Hour)},
+		{CVE: "CVE-2016-2107", Justification: "waiting for the upgrade", Expiry: now.Add(-time.Hour)},
+	}
+
+	ApplyVulnerabilitySuppressions(vs, rules, now)
+	assert.True(t, vs[0].Ignored)
+	require.NotNil(t, vs[0].Suppression)
+	assert.Equal(t, "not exploitable", vs[0].Suppression.Justification)
+	// The second rule is expired
+	assert.False(t, vs[1].Ignored)
+	assert.Nil(t, vs[1].Suppression)
+	assert.False(t, vs[2].Ignored)
+
+	assert.Error(t, VulnerabilitySuppression{CVE: "CVE-2016-2107"}.IsValid())
+	assert.Error(t, VulnerabilitySuppression{CVE: "CVE-2016-2107", Justification: "test"}.IsValid())
+	assert.NoError(t, rules[0].IsValid())
+}
+
+func TestDedupVulnerabilities(t *testing.T) {
+	vs := DedupVulnerabilities([]Vulnerability{
+		{Component: "lodash", Version: "4.17.4", CVE: "CVE-2018-3721", Severity: SeverityHigh},
+		{Component: "openssl", Version: "1.0.2", CVE: "CVE-2016-2107", Severity: SeverityHigh},
+		{Component: "lodash", Version: "4.17.4", CVE: "CVE-2018-3721", Severity: SeverityHigh, Ignored: true},
+		{Component: "lodash", Version: "4.17.5", CVE: "CVE-2018-3721", Severity: SeverityLow},
+	})
+	require.Len(t, vs, 3)
+	assert.True(t, vs[0].Ignored)
+	assert.Equal(t, map[string]int64{SeverityHigh: 2, SeverityLow: 1}, VulnerabilitiesSummary(vs))
+
+	assert.Equal(t, SeverityMedium, ToVulnerabilitySeverity("MODERATE"))
+	assert.Equal(t, SeverityCritical, ToVulnerabilitySeverity("CRITICAL"))
+	assert.Equal(t, SeverityUnknown, ToVulnerabilitySeverity("foo"))
+}
@@ -111,12 +111,14 @@ const (
 	StatusStopped           = "Stopped"
 	StatusWorkerPending     = "Pending"
 	StatusWorkerRegistering = "Registering"
+	// StatusWaitingConcurrency is the status of a node run waiting for its concurrency group to be free
+	StatusWaitingConcurrency = "Waiting for concurrency slot"
 )
 
 // StatusIsTerminated returns if status is terminated (nothing related to building or waiting, ...)
 func StatusIsTerminated(status string) bool {
 	switch status {
-	case StatusBuilding, StatusWaiting, "": // A stage does not have status when he's waiting a previous stage
+	case StatusBuilding, StatusWaiting, StatusWaitingConcurrency, "": // A stage does not have status when he's waiting a previous stage
 		return false
 	default:
 		return true
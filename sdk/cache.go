@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/afero"
 )
@@ -22,6 +23,7 @@ type Cache struct {
 	TmpURL          string `json:"tmp_url"`
 	SecretKey       string `json:"secret_key"`
 	IntegrationName string `json:"integration_name"`
+	Size            int64  `json:"size,omitempty"`
 
 	Files            []string `json:"files"`
 	WorkingDirectory string   `json:"working_directory"`
@@ -43,15 +45,30 @@ func (c *Cache) GetPath() string {
 // TarOptions useful to indicate some options when we want to tar directory or files
 type TarOptions struct {
 	TrimDirName string
+	// NormalizeHeaders removes the times and owners from the headers, the same files then always give the same tar
+	NormalizeHeaders bool
 }
 
 // CreateTarFromPaths returns a tar formatted reader of a tar made of several path
 func CreateTarFromPaths(fs afero.Fs, cwd string, paths []string, opts *TarOptions) (io.Reader, int, error) {
 	// Create a buffer to write our archive to.
 	buf := new(bytes.Buffer)
+	if err := WriteTarFromPaths(fs, cwd, paths, opts, buf); err != nil {
+		return nil, 0, err
+	}
+
+	// Open the tar archive for reading.
+	btes := buf.Bytes()
+	size := buf.Len()
+	res := bytes.NewBuffer(btes)
+
+	return res, size, nil
+}
 
+// WriteTarFromPaths writes in w a tar made of several path
+func WriteTarFromPaths(fs afero.Fs, cwd string, paths []string, opts *TarOptions, w io.Writer) error {
 	// Create a new tar archive.
-	tw := tar.NewWriter(buf)
+	tw := tar.NewWriter(w)
 
 	for _, p := range paths {
 		// ensure the src actually exists before trying to tar it
@@ -62,7 +79,7 @@ func CreateTarFromPaths(fs afero.Fs, cwd string, paths []string, opts *TarOption
 		}
 
 		if _, err := fs.Stat(completePath); err != nil {
-			return nil, 0, fmt.Errorf("unable to tar files - %v", err.Error())
+			return fmt.Errorf("unable to tar files - %v", err.Error())
 		}
 
 		// walk path
@@ -83,6 +100,13 @@ func CreateTarFromPaths(fs afero.Fs, cwd string, paths []string, opts *TarOption
 				opts.TrimDirName = strings.TrimPrefix(opts.TrimDirName, string(filepath.Separator))
 				header.Name = strings.TrimPrefix(strings.TrimPrefix(header.Name, opts.TrimDirName), string(filepath.Separator))
 			}
+			if opts != nil && opts.NormalizeHeaders {
+				header.ModTime = time.Unix(0, 0)
+				header.AccessTime = time.Time{}
+				header.ChangeTime = time.Time{}
+				header.Uid, header.Gid = 0, 0
+				header.Uname, header.Gname = "", ""
+			}
 			if fi.Mode()&os.ModeSymlink != 0 {
 
 				symlink, errEval := filepath.EvalSymlinks(file)
@@ -126,18 +150,9 @@ func CreateTarFromPaths(fs afero.Fs, cwd string, paths []string, opts *TarOption
 
 		if errWalk != nil {
 			_ = tw.Close()
-			return nil, 0, WrapError(errWalk, "CreateTarFromPaths> Cannot walk file")
+			return WrapError(errWalk, "WriteTarFromPaths> Cannot walk file")
 		}
 	}
 
-	if err := tw.Close(); err != nil {
-		return nil, 0, err
-	}
-
-	// Open the tar archive for reading.
-	btes := buf.Bytes()
-	size := buf.Len()
-	res := bytes.NewBuffer(btes)
-
-	return res, size, nil
+	return tw.Close()
 }
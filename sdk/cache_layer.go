@@ -0,0 +1,187 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// Bounds of the size of the layers of a cache. The limits between layers depend on the content
+// so a change in a file only changes the layers around it, the others are not uploaded again.
+const (
+	CacheLayerMinSize = 512 * 1024
+	CacheLayerMaxSize = 8 * 1024 * 1024
+	// cacheLayerMask gives an average layer size of 2MB after the min size
+	cacheLayerMask = 1<<21 - 1
+)
+
+// CacheLayer is a part of a cache stored once by project and storage integration, identified by its sha256 checksum.
+type CacheLayer struct {
+	Project string `json:"project,omitempty"`
+	Digest  string `json:"digest"`
+	Size    int64  `json:"size"`
+}
+
+// GetName returns the name of the layer
+func (l *CacheLayer) GetName() string {
+	return l.Digest
+}
+
+// GetPath returns the path of the layer
+func (l *CacheLayer) GetPath() string {
+	return strings.Replace(url.QueryEscape("cache-layers-"+l.Project), "/", "-", -1)
+}
+
+// CacheManifest lists the layers of a cache, the cache is the concatenation of its layers.
+type CacheManifest struct {
+	Tag    string       `json:"tag"`
+	Layers []CacheLayer `json:"layers"`
+	Size   int64        `json:"size"`
+}
+
+// IsValid returns an error if the manifest is invalid.
+func (m CacheManifest) IsValid() error {
+	var size int64
+	for _, l := range m.Layers {
+		if !IsValidSHA256sum(l.Digest) {
+			return NewErrorFrom(ErrWrongRequest, "invalid cache layer digest %q", l.Digest)
+		}
+		size += l.Size
+	}
+	if size != m.Size {
+		return NewErrorFrom(ErrWrongRequest, "invalid cache manifest size %d, layers size is %d", m.Size, size)
+	}
+	return nil
+}
+
+// IsValidSHA256sum returns true if given string is a lowercase sha256 hex checksum.
+func IsValidSHA256sum(s string) bool {
+	if len(s) != 64 || strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// NewCacheManifest splits a cache content in layers, the returned layers data are sub slices of the given content.
+func NewCacheManifest(tag string, content []byte) (CacheManifest, [][]byte) {
+	m := CacheManifest{Tag: tag, Layers: []CacheLayer{}}
+	datas := SplitCacheLayers(content)
+	for _, d := range datas {
+		sum := sha256.Sum256(d)
+		m.Layers = append(m.Layers, CacheLayer{Digest: hex.EncodeToString(sum[:]), Size: int64(len(d))})
+		m.Size += int64(len(d))
+	}
+	return m, datas
+}
+
+// NewCacheManifestFromReader splits a cache content read from r in layers, the same way as NewCacheManifest.
+// Only the data of the layer being computed is kept in memory.
+func NewCacheManifestFromReader(tag string, r io.Reader) (CacheManifest, error) {
+	m := CacheManifest{Tag: tag, Layers: []CacheLayer{}}
+	buf := make([]byte, 0, CacheLayerMaxSize)
+	var eof bool
+	for {
+		// A layer is never bigger than the max size, so its limit can be found in a full buffer
+		for !eof && len(buf) < cap(buf) {
+			n, err := r.Read(buf[len(buf):cap(buf)])
+			buf = buf[:len(buf)+n]
+			if err == io.EOF {
+				eof = true
+			} else if err != nil {
+				return m, WithStack(err)
+			}
+		}
+		if len(buf) == 0 {
+			return m, nil
+		}
+
+		n := cacheLayerCut(buf)
+		sum := sha256.Sum256(buf[:n])
+		m.Layers = append(m.Layers, CacheLayer{Digest: hex.EncodeToString(sum[:]), Size: int64(n)})
+		m.Size += int64(n)
+		buf = buf[:copy(buf, buf[n:])]
+	}
+}
+
+// cacheLayerGear is the table of the rolling hash used to find the limits between layers.
+var cacheLayerGear = func() [256]uint64 {
+	var t [256]uint64
+	// splitmix64 with a fixed seed, the table must never change to keep the same layers
+	x := uint64(0x43445343414348)
+	for i := range t {
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		t[i] = z ^ (z >> 31)
+	}
+	return t
+}()
+
+// SplitCacheLayers splits the content with a content defined chunking, the returned layers are sub slices of the content.
+func SplitCacheLayers(content []byte) [][]byte {
+	var res [][]byte
+	for len(content) > 0 {
+		n := cacheLayerCut(content)
+		res = append(res, content[:n])
+		content = content[n:]
+	}
+	return res
+}
+
+func cacheLayerCut(content []byte) int {
+	if len(content) <= CacheLayerMinSize {
+		return len(content)
+	}
+	max := len(content)
+	if max > CacheLayerMaxSize {
+		max = CacheLayerMaxSize
+	}
+	var h uint64
+	for i := CacheLayerMinSize; i < max; i++ {
+		h = (h << 1) + cacheLayerGear[content[i]]
+		if h&cacheLayerMask == 0 {
+			return i + 1
+		}
+	}
+	return max
+}
+
+// CacheRestoreKey returns the first tag matching the restore keys. Restore keys are prefixes tried in the given
+// order, tags should be sorted from the latest to the oldest. Tags are the base64 encoded keys used in the API routes.
+func CacheRestoreKey(tags []string, restoreKeys []string) (string, bool) {
+	keys := make([]string, len(tags))
+	for i, t := range tags {
+		k, err := base64.RawURLEncoding.DecodeString(t)
+		if err != nil {
+			continue
+		}
+		keys[i] = string(k)
+	}
+	for _, prefix := range restoreKeys {
+		if prefix == "" {
+			continue
+		}
+		for _, k := range keys {
+			if k != "" && strings.HasPrefix(k, prefix) {
+				return k, true
+			}
+		}
+	}
+	return "", false
+}
+
+// CacheRef returns the value used in the API routes for a cache key.
+func CacheRef(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// String returns a short description of the manifest.
+func (m CacheManifest) String() string {
+	return fmt.Sprintf("%d layers, %d bytes", len(m.Layers), m.Size)
+}
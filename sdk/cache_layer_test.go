@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCacheLayers(t *testing.T) {
+	content := make([]byte, 20*1024*1024)
+	rand.New(rand.NewSource(42)).Read(content)
+
+	layers := SplitCacheLayers(content)
+	require.True(t, len(layers) > 1)
+	assert.Equal(t, content, bytes.Join(layers, nil))
+	for i, l := range layers {
+		assert.True(t, len(l) <= CacheLayerMaxSize)
+		if i < len(layers)-1 {
+			assert.True(t, len(l) >= CacheLayerMinSize)
+		}
+	}
+
+	// Inserting data at the beginning only changes the first layers
+	modified := append([]byte("some new data"), content...)
+	m1, _ := NewCacheManifest("a", content)
+	m2, _ := NewCacheManifest("b", modified)
+	require.NoError(t, m1.IsValid())
+	require.NoError(t, m2.IsValid())
+	assert.Equal(t, m1.Size+13, m2.Size)
+
+	digests := map[string]struct{}{}
+	for _, l := range m1.Layers {
+		digests[l.Digest] = struct{}{}
+	}
+	var shared int
+	for _, l := range m2.Layers {
+		if _, ok := digests[l.Digest]; ok {
+			shared++
+		}
+	}
+	assert.True(t, shared >= len(m1.Layers)-2, "only %d/%d layers are shared", shared, len(m1.Layers))
+
+	assert.Empty(t, SplitCacheLayers(nil))
+	assert.Len(t, SplitCacheLayers([]byte("small")), 1)
+}
+
+func TestNewCacheManifestFromReader(t *testing.T) {
+	content := make([]byte, 20*1024*1024)
+	rand.New(rand.NewSource(42)).Read(content)
+
+	expected, _ := NewCacheManifest("a", content)
+	m, err := NewCacheManifestFromReader("a", iotest.HalfReader(bytes.NewReader(content)))
+	require.NoError(t, err)
+	assert.Equal(t, expected, m)
+
+	m, err = NewCacheManifestFromReader("a", bytes.NewReader(nil))
+	require.NoError(t, err)
+	assert.Empty(t, m.Layers)
+
+	_, err = NewCacheManifestFromReader("a", iotest.TimeoutReader(bytes.NewReader(content)))
+	assert.Error(t, err)
+}
+
+func TestCacheManifestIsValid(t *testing.T) {
+	m, _ := NewCacheManifest("a", []byte("my cache"))
+	require.NoError(t, m.IsValid())
+
+	m.Size++
+	assert.Error(t, m.IsValid())
+
+	m.Size--
+	m.Layers[0].Digest = "../wrong"
+	assert.Error(t, m.IsValid())
+}
+
+func TestCacheRestoreKey(t *testing.T) {
+	tags := []string{CacheRef("go-mod-bbb"), CacheRef("node-aaa"), "not base64!", CacheRef("go-mod-aaa")}
+
+	k, ok := CacheRestoreKey(tags, []string{"go-mod-ccc", "go-mod-"})
+	assert.True(t, ok)
+	assert.Equal(t, "go-mod-bbb", k)
+
+	k, ok = CacheRestoreKey(tags, []string{"node-", "go-mod-"})
+	assert.True(t, ok)
+	assert.Equal(t, "node-aaa", k)
+
+	_, ok = CacheRestoreKey(tags, []string{"", "python-"})
+	assert.False(t, ok)
+}
@@ -75,15 +75,48 @@ func (c *client) ActionImport(content io.Reader, format string) error {
 	return nil
 }
 
-func (c *client) ActionExport(groupName, name string, format string) ([]byte, error) {
+func (c *client) ActionExport(groupName, name string, format string, mods ...RequestModifier) ([]byte, error) {
 	path := fmt.Sprintf("/action/%s/%s/export?format=%s", groupName, name, format)
-	body, _, _, err := c.Request(context.Background(), "GET", path, nil)
+	body, _, _, err := c.Request(context.Background(), "GET", path, nil, mods...)
 	if err != nil {
 		return nil, err
 	}
 	return body, nil
 }
 
+func (c *client) ActionUsage(groupName, name string) (*sdk.ActionUsage, error) {
+	var u sdk.ActionUsage
+
+	path := fmt.Sprintf("/action/%s/%s/usage", groupName, name)
+	if _, err := c.GetJSON(context.Background(), path, &u); err != nil {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+func (c *client) ActionVersionList(groupName, name string) ([]sdk.Action, error) {
+	versions := []sdk.Action{}
+
+	path := fmt.Sprintf("/action/%s/%s/version", groupName, name)
+	if _, err := c.GetJSON(context.Background(), path, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (c *client) ActionPublish(groupName, name, version string) (*sdk.Action, error) {
+	var a sdk.Action
+
+	path := fmt.Sprintf("/action/%s/%s/version/%s", groupName, name, version)
+	if _, err := c.PostJSON(context.Background(), path, nil, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
 func (c *client) ActionBuiltinList() ([]sdk.Action, error) {
 	actions := []sdk.Action{}
 	if _, err := c.GetJSON(context.Background(), "/actionBuiltin", &actions); err != nil {
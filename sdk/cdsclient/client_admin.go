@@ -46,6 +46,22 @@ func (c *client) AdminCDSMigrationList() ([]sdk.Migration, error) {
 	return migrations, nil
 }
 
+func (c *client) AdminStorageUsages() ([]sdk.ProjectStorageUsage, error) {
+	var usages []sdk.ProjectStorageUsage
+	if _, err := c.GetJSON(context.Background(), "/admin/storage/usage", &usages); err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
+
+func (c *client) AdminStorageQuotaUpdate(projectKey string, quota sdk.ProjectStorageQuota) (*sdk.ProjectStorageUsage, error) {
+	var usage sdk.ProjectStorageUsage
+	if _, err := c.PutJSON(context.Background(), "/admin/storage/quota/"+projectKey, quota, &usage); err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
 func (c *client) Services() ([]sdk.Service, error) {
 	srvs := []sdk.Service{}
 	if _, err := c.GetJSON(context.Background(), "/admin/services", &srvs); err != nil {
@@ -2,15 +2,50 @@ package cdsclient
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strconv"
+	"sync/atomic"
 	"time"
+
+	"github.com/ovh/cds/sdk"
 )
 
 func (c *client) EventsListen(ctx context.Context, chanSSEvt chan<- SSEvent) {
+	c.EventsListenFrom(ctx, 0, chanSSEvt)
+}
+
+// EventsListenFrom listens the events published after the given event id. When the
+// stream is interrupted, the missed events are sent by the API on reconnection.
+func (c *client) EventsListenFrom(ctx context.Context, lastEventID int64, chanSSEvt chan<- SSEvent) {
+	// Intermediate chan used to keep the id of the last received event
+	ch := make(chan SSEvent)
+	go func() {
+		for e := range ch {
+			if id, err := strconv.ParseInt(e.ID, 10, 64); err == nil && id > atomic.LoadInt64(&lastEventID) {
+				atomic.StoreInt64(&lastEventID, id)
+			}
+			chanSSEvt <- e
+		}
+	}()
+	defer close(ch)
+
 	for ctx.Err() == nil {
-		if err := c.RequestSSEGet(ctx, "/events", chanSSEvt); err != nil {
+		var mods []RequestModifier
+		if id := atomic.LoadInt64(&lastEventID); id > 0 {
+			mods = append(mods, SetHeader("Last-Event-ID", strconv.FormatInt(id, 10)))
+		}
+		if err := c.RequestSSEGet(ctx, "/events", ch, mods...); err != nil {
 			log.Println("EventsListen", err)
 		}
 		time.Sleep(1 * time.Second)
 	}
 }
+
+func (c *client) EventsHistory(since int64, limit int) (*sdk.EventHistory, error) {
+	var res sdk.EventHistory
+	if _, err := c.GetJSON(context.Background(), fmt.Sprintf("/events?since=%d&limit=%d", since, limit), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
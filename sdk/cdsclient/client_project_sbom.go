@@ -0,0 +1,32 @@
+package cdsclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c *client) ProjectSBOMSearch(projectKey, name, version string, limit int) ([]sdk.SBOMSearchResult, error) {
+	q := url.Values{}
+	q.Set("name", name)
+	q.Set("version", version)
+	q.Set("limit", strconv.Itoa(limit))
+	path := fmt.Sprintf("/project/%s/sbom/search?%s", projectKey, q.Encode())
+	var results []sdk.SBOMSearchResult
+	if _, err := c.GetJSON(context.Background(), path, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *client) ProjectProvenanceKey(projectKey string) (*sdk.ProvenancePublicKey, error) {
+	path := fmt.Sprintf("/project/%s/provenance/key", projectKey)
+	var key sdk.ProvenancePublicKey
+	if _, err := c.GetJSON(context.Background(), path, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
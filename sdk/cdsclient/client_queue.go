@@ -101,8 +101,8 @@ func (c *client) QueuePolling(ctx context.Context, jobs chan<- sdk.WorkflowNodeJ
 						continue
 					}
 
-					// push the job in the channel
-					if job.Status == sdk.StatusWaiting && job.BookedBy.Name == "" {
+					// push the job in the channel, a job retried with a delay is polled when the delay expires
+					if job.Status == sdk.StatusWaiting && job.BookedBy.Name == "" && !job.Queued.After(time.Now()) {
 						job.Header["SSE"] = "true"
 						jobs <- *job
 					}
@@ -255,6 +255,12 @@ func (c *client) QueueSendCoverage(ctx context.Context, id int64, report coverag
 	return err
 }
 
+func (c *client) QueueSendCoverageFiles(ctx context.Context, id int64, files []sdk.WorkflowNodeRunCoverageFile) error {
+	path := fmt.Sprintf("/queue/workflows/%d/coverage/files", id)
+	_, err := c.PostJSON(ctx, path, files, nil)
+	return err
+}
+
 func (c *client) QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error {
 	path := fmt.Sprintf("/queue/workflows/%d/test", id)
 	_, err := c.PostJSON(ctx, path, report, nil)
@@ -273,6 +279,28 @@ func (c *client) QueueSendVulnerability(ctx context.Context, id int64, report sd
 	return err
 }
 
+func (c *client) QueueSendStaticAnalysis(ctx context.Context, id int64, report sdk.StaticAnalysisWorkerReport) (*sdk.WorkflowNodeRunStaticAnalysisReport, error) {
+	path := fmt.Sprintf("/queue/workflows/%d/staticanalysis", id)
+	var res sdk.WorkflowNodeRunStaticAnalysisReport
+	if _, err := c.PostJSON(ctx, path, report, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *client) QueueSendSBOM(ctx context.Context, id int64, name string, data io.Reader) (*sdk.WorkflowNodeRunSBOM, error) {
+	path := fmt.Sprintf("/queue/workflows/%d/sbom?name=%s", id, url.QueryEscape(name))
+	btes, _, _, err := c.Request(ctx, "POST", path, data, SetHeader("Content-Type", "application/octet-stream"))
+	if err != nil {
+		return nil, err
+	}
+	var res sdk.WorkflowNodeRunSBOM
+	if err := json.Unmarshal(btes, &res); err != nil {
+		return nil, sdk.WithStack(err)
+	}
+	return &res, nil
+}
+
 func (c *client) QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error {
 	path := fmt.Sprintf("/queue/workflows/%d/step", id)
 	_, err := c.PostJSON(ctx, path, res, nil)
@@ -292,6 +320,63 @@ func (c *client) QueueArtifactUpload(ctx context.Context, projectKey, integratio
 	return false, time.Since(t0), err
 }
 
+func (c *client) QueueArtifactBlob(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	sha512sum, err := sdk.FileSHA512sum(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	md5sum, err := sdk.FileMd5sum(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	_, name := filepath.Split(filePath)
+	ref := base64.RawURLEncoding.EncodeToString([]byte(tag))
+	art := sdk.WorkflowNodeRunArtifact{
+		Name:                 name,
+		Tag:                  tag,
+		Ref:                  ref,
+		Size:                 stat.Size(),
+		Perm:                 uint32(stat.Mode().Perm()),
+		MD5sum:               md5sum,
+		SHA512sum:            sha512sum,
+		Created:              time.Now(),
+		WorkflowNodeJobRunID: nodeJobRunID,
+	}
+
+	uri := fmt.Sprintf("/project/%s/storage/%s/artifact/%s/blob", projectKey, integrationName, ref)
+	code, err := c.PostJSON(ctx, uri, &art, nil)
+	if code == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *client) QueueArtifactProvenance(ctx context.Context, nodeJobRunID int64, tag, name string) (*sdk.WorkflowNodeRunArtifactProvenance, error) {
+	ref := base64.RawURLEncoding.EncodeToString([]byte(tag))
+	uri := fmt.Sprintf("/queue/workflows/%d/artifact/%s/provenance", nodeJobRunID, ref)
+	var res sdk.WorkflowNodeRunArtifactProvenance
+	if _, err := c.PostJSON(ctx, uri, sdk.WorkflowNodeRunArtifact{Name: name, Tag: tag, Ref: ref}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 func (c *client) queueIndirectArtifactTempURL(ctx context.Context, projectKey, integrationName string, art *sdk.WorkflowNodeRunArtifact) error {
 	var retryURL = 10
 	var globalURLErr error
@@ -322,6 +407,8 @@ func (c *client) queueIndirectArtifactTempURLPost(url string, content []byte) er
 		if errRequest != nil {
 			return errRequest
 		}
+		// Needed by Azure Blob storage, other storages ignore it
+		req.Header.Set("x-ms-blob-type", "BlockBlob")
 
 		var resp *http.Response
 		resp, globalErr = http.DefaultClient.Do(req)
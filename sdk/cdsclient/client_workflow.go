@@ -10,6 +10,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/ovh/cds/sdk"
@@ -136,6 +137,127 @@ func (c *client) WorkflowRunArtifacts(projectKey string, workflowName string, nu
 	return arts, nil
 }
 
+func (c *client) WorkflowRetentionDryRun(projectKey, workflowName string) ([]sdk.WorkflowRetentionResult, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/retention/dryrun", projectKey, workflowName)
+	var results []sdk.WorkflowRetentionResult
+	if _, err := c.GetJSON(context.Background(), url, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *client) WorkflowTestsHistory(projectKey, workflowName, suite, name, branch string, limit int) ([]sdk.WorkflowTestResult, error) {
+	q := url.Values{}
+	q.Set("suite", suite)
+	q.Set("name", name)
+	q.Set("branch", branch)
+	q.Set("limit", strconv.Itoa(limit))
+	path := fmt.Sprintf("/project/%s/workflows/%s/tests/history?%s", projectKey, workflowName, q.Encode())
+	var results []sdk.WorkflowTestResult
+	if _, err := c.GetJSON(context.Background(), path, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *client) WorkflowTestsFlaky(projectKey, workflowName, branch string, runs int) ([]sdk.WorkflowTestFlakiness, error) {
+	q := url.Values{}
+	q.Set("branch", branch)
+	q.Set("runs", strconv.Itoa(runs))
+	path := fmt.Sprintf("/project/%s/workflows/%s/tests/flaky?%s", projectKey, workflowName, q.Encode())
+	var results []sdk.WorkflowTestFlakiness
+	if _, err := c.GetJSON(context.Background(), path, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *client) WorkflowTestsTrends(projectKey, workflowName, branch string, limit int) ([]sdk.WorkflowTestsTrend, error) {
+	q := url.Values{}
+	q.Set("branch", branch)
+	q.Set("limit", strconv.Itoa(limit))
+	path := fmt.Sprintf("/project/%s/workflows/%s/tests/trends?%s", projectKey, workflowName, q.Encode())
+	var trends []sdk.WorkflowTestsTrend
+	if _, err := c.GetJSON(context.Background(), path, &trends); err != nil {
+		return nil, err
+	}
+	return trends, nil
+}
+
+func (c *client) WorkflowRunTestsDiff(projectKey, workflowName string, number int64) (*sdk.WorkflowTestsDiff, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/tests/diff", projectKey, workflowName, number)
+	var diff sdk.WorkflowTestsDiff
+	if _, err := c.GetJSON(context.Background(), path, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+func (c *client) WorkflowRunSBOMList(projectKey, workflowName string, number int64) ([]sdk.WorkflowNodeRunSBOM, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/sbom", projectKey, workflowName, number)
+	var sboms []sdk.WorkflowNodeRunSBOM
+	if _, err := c.GetJSON(context.Background(), path, &sboms); err != nil {
+		return nil, err
+	}
+	return sboms, nil
+}
+
+func (c *client) WorkflowRunSBOMDownload(projectKey, workflowName string, number, id int64, w io.Writer) error {
+	path := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/sbom/%d/download", projectKey, workflowName, number, id)
+	reader, _, code, err := c.Stream(context.Background(), "GET", path, nil, true)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if code >= 400 {
+		body, _ := ioutil.ReadAll(reader)
+		if err := sdk.DecodeError(body); err != nil {
+			return err
+		}
+		return fmt.Errorf("HTTP %d", code)
+	}
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+func (c *client) WorkflowMergeQueueAdd(projectKey, workflowName string, entry sdk.MergeQueueEntry) (*sdk.MergeQueueEntry, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/mergequeue", projectKey, workflowName)
+	if _, err := c.PostJSON(context.Background(), url, &entry, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (c *client) WorkflowMergeQueueList(projectKey, workflowName string) ([]sdk.MergeQueueEntry, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/mergequeue", projectKey, workflowName)
+	var entries []sdk.MergeQueueEntry
+	if _, err := c.GetJSON(context.Background(), url, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (c *client) WorkflowPullRequestCommand(projectKey, workflowName string, command sdk.PullRequestCommand) (*sdk.WorkflowRun, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/pullrequest/command", projectKey, workflowName)
+	var run sdk.WorkflowRun
+	if _, err := c.PostJSON(context.Background(), url, &command, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (c *client) WorkflowRepositoryHead(projectKey, workflowName, branch string) (*sdk.VCSBranch, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/repository/head", projectKey, workflowName)
+	if branch != "" {
+		path += "?branch=" + url.QueryEscape(branch)
+	}
+	var head sdk.VCSBranch
+	if _, err := c.GetJSON(context.Background(), path, &head); err != nil {
+		return nil, err
+	}
+	return &head, nil
+}
+
 func (c *client) WorkflowNodeRun(projectKey string, workflowName string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRun, error) {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d", projectKey, workflowName, number, nodeRunID)
 	run := sdk.WorkflowNodeRun{}
@@ -176,6 +298,15 @@ func (c *client) WorkflowNodeRunJobStep(projectKey string, workflowName string,
 	return &buildState, nil
 }
 
+func (c *client) WorkflowNodeRunArtifactProvenance(projectKey, workflowName string, artifactID int64) (*sdk.ProvenanceEnvelope, error) {
+	path := fmt.Sprintf("/project/%s/workflows/%s/artifact/%d/provenance", projectKey, workflowName, artifactID)
+	var envelope sdk.ProvenanceEnvelope
+	if _, err := c.GetJSON(context.Background(), path, &envelope); err != nil {
+		return nil, err
+	}
+	return &envelope, nil
+}
+
 func (c *client) WorkflowNodeRunArtifactDownload(projectKey string, workflowName string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error {
 	var url = fmt.Sprintf("/project/%s/workflows/%s/artifact/%d", projectKey, workflowName, a.ID)
 	var reader io.ReadCloser
@@ -327,7 +458,7 @@ func (c *client) workflowCachePushDirectUpload(projectKey, integrationName, ref
 
 func (c *client) workflowCachePushIndirectUpload(projectKey, integrationName, ref string, tarContent io.Reader, size int) error {
 	uri := fmt.Sprintf("/project/%s/storage/%s/cache/%s/url", projectKey, integrationName, ref)
-	cacheObj := sdk.Cache{}
+	cacheObj := sdk.Cache{Size: int64(size)}
 	code, err := c.PostJSON(context.Background(), uri, cacheObj, &cacheObj)
 	if err != nil {
 		return err
@@ -427,6 +558,88 @@ func (c *client) WorkflowCachePull(projectKey, integrationName, ref string) (io.
 	return bytes.NewBuffer(body), nil
 }
 
+func (c *client) WorkflowCacheLayersMissing(projectKey, integrationName string, layers []sdk.CacheLayer) ([]sdk.CacheLayer, error) {
+	uri := fmt.Sprintf("/project/%s/storage/%s/cachelayers", projectKey, integrationName)
+	var missing []sdk.CacheLayer
+	if _, err := c.PostJSON(context.Background(), uri, layers, &missing); err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+func (c *client) WorkflowCacheLayerPush(projectKey, integrationName, digest string, content io.Reader) error {
+	mods := []RequestModifier{
+		(func(r *http.Request) {
+			r.Header.Set("Content-Type", "application/octet-stream")
+		}),
+	}
+
+	uri := fmt.Sprintf("/project/%s/storage/%s/cachelayers/%s", projectKey, integrationName, digest)
+	res, _, code, err := c.Stream(context.Background(), "POST", uri, content, true, mods...)
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	if code >= 400 {
+		return cacheStreamError(res, code)
+	}
+	return nil
+}
+
+func (c *client) WorkflowCacheLayerPull(projectKey, integrationName, digest string) (io.ReadCloser, error) {
+	uri := fmt.Sprintf("/project/%s/storage/%s/cachelayers/%s", projectKey, integrationName, digest)
+	res, _, code, err := c.Stream(context.Background(), "GET", uri, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if code >= 400 {
+		defer res.Close()
+		return nil, cacheStreamError(res, code)
+	}
+	return res, nil
+}
+
+func (c *client) WorkflowCacheManifestPush(projectKey, integrationName, ref string, manifest sdk.CacheManifest) error {
+	uri := fmt.Sprintf("/project/%s/storage/%s/cache/%s/manifest", projectKey, integrationName, ref)
+	_, err := c.PostJSON(context.Background(), uri, manifest, nil)
+	return err
+}
+
+func (c *client) WorkflowCacheManifestPull(projectKey, integrationName, ref string) (*sdk.CacheManifest, error) {
+	uri := fmt.Sprintf("/project/%s/storage/%s/cache/%s/manifest", projectKey, integrationName, ref)
+	var manifest sdk.CacheManifest
+	if _, err := c.GetJSON(context.Background(), uri, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// WorkflowCacheLookup returns the latest cache matching one of the restore keys, the name of
+// the returned cache is its key and its tag the ref to use to pull it.
+func (c *client) WorkflowCacheLookup(projectKey, integrationName string, restoreKeys []string) (*sdk.Cache, error) {
+	q := url.Values{}
+	for _, k := range restoreKeys {
+		q.Add("restore", k)
+	}
+	uri := fmt.Sprintf("/project/%s/storage/%s/cache?%s", projectKey, integrationName, q.Encode())
+	var cache sdk.Cache
+	if _, err := c.GetJSON(context.Background(), uri, &cache); err != nil {
+		return nil, err
+	}
+	return &cache, nil
+}
+
+// cacheStreamError returns the CDS error sent in the body of a streamed response.
+func cacheStreamError(body io.Reader, code int) error {
+	buf, _ := ioutil.ReadAll(body)
+	if err := sdk.DecodeError(buf); err != nil {
+		return err
+	}
+	return fmt.Errorf("HTTP Code %d", code)
+}
+
 func (c *client) WorkflowTemplateInstanceGet(projectKey, workflowName string) (*sdk.WorkflowTemplateInstance, error) {
 	url := fmt.Sprintf("/project/%s/workflow/%s/templateInstance", projectKey, workflowName)
 
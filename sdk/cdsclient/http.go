@@ -190,9 +190,17 @@ func (c *client) Stream(ctx context.Context, method string, path string, body io
 	pprof.SetGoroutineLabels(ctx)
 	var savederror error
 
+	// A seekable body is read again from its beginning on retries, other bodies are kept in memory
 	var bodyContent []byte
+	var bodySize int64
 	var err error
-	if body != nil {
+	bodySeeker, seekable := body.(io.ReadSeeker)
+	if seekable {
+		bodySize, err = bodySeeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, nil, 0, sdk.WithStack(err)
+		}
+	} else if body != nil {
 		bodyContent, err = ioutil.ReadAll(body)
 		if err != nil {
 			return nil, nil, 0, sdk.WithStack(err)
@@ -207,11 +215,24 @@ func (c *client) Stream(ctx context.Context, method string, path string, body io
 	}
 
 	for i := 0; i <= c.config.Retry; i++ {
-		req, requestError := http.NewRequest(method, url, bytes.NewBuffer(bodyContent))
+		var reqBody io.Reader = bytes.NewBuffer(bodyContent)
+		if seekable {
+			if _, err := bodySeeker.Seek(0, io.SeekStart); err != nil {
+				return nil, nil, 0, sdk.WithStack(err)
+			}
+			reqBody = bodySeeker
+		}
+		req, requestError := http.NewRequest(method, url, reqBody)
 		if requestError != nil {
 			savederror = requestError
 			continue
 		}
+		if seekable {
+			req.ContentLength = bodySize
+			if bodySize == 0 {
+				req.Body = http.NoBody
+			}
+		}
 
 		req = req.WithContext(ctx)
 		date := sdk.FormatDateRFC5322(time.Now())
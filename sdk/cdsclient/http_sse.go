@@ -15,12 +15,15 @@ import (
 const (
 	sseEvent = "event"
 	sseData  = "data"
+	sseID    = "id"
 )
 
 //SSEvent is a go representation of an http server-sent event
 type SSEvent struct {
 	URI  string
 	Type string
+	// ID is the id of the event, it can be given in the Last-Event-ID header to resume the stream
+	ID   string
 	Data io.Reader
 }
 
@@ -80,6 +83,7 @@ func (c *client) RequestSSEGet(ctx context.Context, path string, evCh chan<- SSE
 	delim := []byte{':', ' '}
 
 	var currEvent *SSEvent
+	var currType, currID string
 	var EOF bool
 
 	for !EOF {
@@ -111,9 +115,14 @@ func (c *client) RequestSSEGet(ctx context.Context, path string, evCh chan<- SSE
 		currEvent = &SSEvent{URI: uri}
 		switch string(spl[0]) {
 		case sseEvent:
-			currEvent.Type = string(bytes.TrimSpace(spl[1]))
+			currType = string(bytes.TrimSpace(spl[1]))
+		case sseID:
+			currID = string(bytes.TrimSpace(spl[1]))
 		case sseData:
+			currEvent.Type = currType
+			currEvent.ID = currID
 			currEvent.Data = bytes.NewBuffer(bytes.TrimSpace(spl[1]))
+			currType, currID = "", ""
 			evCh <- *currEvent
 		}
 
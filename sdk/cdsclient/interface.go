@@ -45,6 +45,8 @@ type Admin interface {
 	AdminCDSMigrationList() ([]sdk.Migration, error)
 	AdminCDSMigrationCancel(id int64) error
 	AdminCDSMigrationReset(id int64) error
+	AdminStorageUsages() ([]sdk.ProjectStorageUsage, error)
+	AdminStorageQuotaUpdate(projectKey string, quota sdk.ProjectStorageQuota) (*sdk.ProjectStorageUsage, error)
 	Services() ([]sdk.Service, error)
 	ServicesByName(name string) (*sdk.Service, error)
 	ServiceDelete(name string) error
@@ -143,6 +145,9 @@ type EnvironmentVariableClient interface {
 type EventsClient interface {
 	// Must be  run in a go routine
 	EventsListen(ctx context.Context, chanSSEvt chan<- SSEvent)
+	// Must be  run in a go routine
+	EventsListenFrom(ctx context.Context, lastEventID int64, chanSSEvt chan<- SSEvent)
+	EventsHistory(since int64, limit int) (*sdk.EventHistory, error)
 }
 
 // DownloadClient exposes download related functions
@@ -158,7 +163,10 @@ type ActionClient interface {
 	ActionGet(groupName, name string, mods ...RequestModifier) (*sdk.Action, error)
 	ActionList() ([]sdk.Action, error)
 	ActionImport(content io.Reader, format string) error
-	ActionExport(groupName, name string, format string) ([]byte, error)
+	ActionExport(groupName, name string, format string, mods ...RequestModifier) ([]byte, error)
+	ActionUsage(groupName, name string) (*sdk.ActionUsage, error)
+	ActionVersionList(groupName, name string) ([]sdk.Action, error)
+	ActionPublish(groupName, name, version string) (*sdk.Action, error)
 	ActionBuiltinList() ([]sdk.Action, error)
 	ActionBuiltinGet(name string, mods ...RequestModifier) (*sdk.Action, error)
 }
@@ -213,6 +221,8 @@ type ProjectClient interface {
 	ProjectIntegrationDelete(projectKey string, integrationName string) error
 	ProjectRepositoryManagerList(projectKey string) ([]sdk.ProjectVCSServer, error)
 	ProjectRepositoryManagerDelete(projectKey string, repoManagerName string, force bool) error
+	ProjectSBOMSearch(projectKey, name, version string, limit int) ([]sdk.SBOMSearchResult, error)
+	ProjectProvenanceKey(projectKey string) (*sdk.ProvenancePublicKey, error)
 }
 
 // ProjectKeysClient exposes project keys related functions
@@ -243,12 +253,17 @@ type QueueClient interface {
 	QueueJobInfo(ctx context.Context, id int64) (*sdk.WorkflowNodeJobRun, error)
 	QueueJobSendSpawnInfo(ctx context.Context, id int64, in []sdk.SpawnInfo) error
 	QueueSendCoverage(ctx context.Context, id int64, report coverage.Report) error
+	QueueSendCoverageFiles(ctx context.Context, id int64, files []sdk.WorkflowNodeRunCoverageFile) error
 	QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error
 	QueueSendLogs(ctx context.Context, id int64, log sdk.Log) error
 	QueueSendVulnerability(ctx context.Context, id int64, report sdk.VulnerabilityWorkerReport) error
+	QueueSendStaticAnalysis(ctx context.Context, id int64, report sdk.StaticAnalysisWorkerReport) (*sdk.WorkflowNodeRunStaticAnalysisReport, error)
+	QueueSendSBOM(ctx context.Context, id int64, name string, data io.Reader) (*sdk.WorkflowNodeRunSBOM, error)
 	QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error
 	QueueSendResult(ctx context.Context, id int64, res sdk.Result) error
 	QueueArtifactUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, time.Duration, error)
+	QueueArtifactBlob(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, error)
+	QueueArtifactProvenance(ctx context.Context, nodeJobRunID int64, tag, name string) (*sdk.WorkflowNodeRunArtifactProvenance, error)
 	QueueStaticFilesUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, name, entrypoint, staticKey string, tarContent io.Reader) (string, bool, time.Duration, error)
 	QueueJobTag(ctx context.Context, jobID int64, tags []sdk.WorkflowRunTag) error
 	QueueServiceLogs(ctx context.Context, logs []sdk.ServiceLog) error
@@ -314,9 +329,27 @@ type WorkflowClient interface {
 	WorkflowAllHooksList() ([]sdk.NodeHook, error)
 	WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error
 	WorkflowCachePull(projectKey, integrationName, ref string) (io.Reader, error)
+	WorkflowCacheLayersMissing(projectKey, integrationName string, layers []sdk.CacheLayer) ([]sdk.CacheLayer, error)
+	WorkflowCacheLayerPush(projectKey, integrationName, digest string, content io.Reader) error
+	WorkflowCacheLayerPull(projectKey, integrationName, digest string) (io.ReadCloser, error)
+	WorkflowCacheManifestPush(projectKey, integrationName, ref string, manifest sdk.CacheManifest) error
+	WorkflowCacheManifestPull(projectKey, integrationName, ref string) (*sdk.CacheManifest, error)
+	WorkflowCacheLookup(projectKey, integrationName string, restoreKeys []string) (*sdk.Cache, error)
 	WorkflowTemplateInstanceGet(projectKey, workflowName string) (*sdk.WorkflowTemplateInstance, error)
 	WorkflowTransformAsCode(projectKey, workflowName string) (*sdk.Operation, error)
 	WorkflowTransformAsCodeFollow(projectKey, workflowName string, ope *sdk.Operation) error
+	WorkflowRetentionDryRun(projectKey, workflowName string) ([]sdk.WorkflowRetentionResult, error)
+	WorkflowTestsHistory(projectKey, workflowName, suite, name, branch string, limit int) ([]sdk.WorkflowTestResult, error)
+	WorkflowTestsFlaky(projectKey, workflowName, branch string, runs int) ([]sdk.WorkflowTestFlakiness, error)
+	WorkflowTestsTrends(projectKey, workflowName, branch string, limit int) ([]sdk.WorkflowTestsTrend, error)
+	WorkflowRunTestsDiff(projectKey, workflowName string, number int64) (*sdk.WorkflowTestsDiff, error)
+	WorkflowRunSBOMList(projectKey, workflowName string, number int64) ([]sdk.WorkflowNodeRunSBOM, error)
+	WorkflowRunSBOMDownload(projectKey, workflowName string, number, id int64, w io.Writer) error
+	WorkflowNodeRunArtifactProvenance(projectKey, workflowName string, artifactID int64) (*sdk.ProvenanceEnvelope, error)
+	WorkflowMergeQueueAdd(projectKey, workflowName string, entry sdk.MergeQueueEntry) (*sdk.MergeQueueEntry, error)
+	WorkflowMergeQueueList(projectKey, workflowName string) ([]sdk.MergeQueueEntry, error)
+	WorkflowPullRequestCommand(projectKey, workflowName string, command sdk.PullRequestCommand) (*sdk.WorkflowRun, error)
+	WorkflowRepositoryHead(projectKey, workflowName, branch string) (*sdk.VCSBranch, error)
 }
 
 // MonitoringClient exposes monitoring functions
@@ -380,6 +413,12 @@ type WorkerInterface interface {
 	WorkflowRunArtifacts(projectKey string, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error)
 	WorkflowCachePush(projectKey, integrationName, ref string, tarContent io.Reader, size int) error
 	WorkflowCachePull(projectKey, integrationName, ref string) (io.Reader, error)
+	WorkflowCacheLayersMissing(projectKey, integrationName string, layers []sdk.CacheLayer) ([]sdk.CacheLayer, error)
+	WorkflowCacheLayerPush(projectKey, integrationName, digest string, content io.Reader) error
+	WorkflowCacheLayerPull(projectKey, integrationName, digest string) (io.ReadCloser, error)
+	WorkflowCacheManifestPush(projectKey, integrationName, ref string, manifest sdk.CacheManifest) error
+	WorkflowCacheManifestPull(projectKey, integrationName, ref string) (*sdk.CacheManifest, error)
+	WorkflowCacheLookup(projectKey, integrationName string, restoreKeys []string) (*sdk.Cache, error)
 	WorkflowRunSearch(projectKey string, offset, limit int64, filter ...Filter) ([]sdk.WorkflowRun, error)
 	WorkflowNodeRunArtifactDownload(projectKey string, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error
 	WorkflowNodeRunRelease(projectKey string, workflowName string, runNumber int64, nodeRunID int64, release sdk.WorkflowNodeRunRelease) error
@@ -457,6 +496,15 @@ func WithUsage() RequestModifier {
 	}
 }
 
+// WithVersion allow to retrieve a published version of an action
+func WithVersion(version string) RequestModifier {
+	return func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("version", version)
+		r.URL.RawQuery = q.Encode()
+	}
+}
+
 // AuthClient is the interface for authentication management.
 type AuthClient interface {
 	AuthDriverList() (sdk.AuthDriverResponse, error)
@@ -385,6 +385,36 @@ func (mr *MockAdminMockRecorder) AdminCDSMigrationReset(id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationReset", reflect.TypeOf((*MockAdmin)(nil).AdminCDSMigrationReset), id)
 }
 
+// AdminStorageUsages mocks base method
+func (m *MockAdmin) AdminStorageUsages() ([]sdk.ProjectStorageUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminStorageUsages")
+	ret0, _ := ret[0].([]sdk.ProjectStorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminStorageUsages indicates an expected call of AdminStorageUsages
+func (mr *MockAdminMockRecorder) AdminStorageUsages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminStorageUsages", reflect.TypeOf((*MockAdmin)(nil).AdminStorageUsages))
+}
+
+// AdminStorageQuotaUpdate mocks base method
+func (m *MockAdmin) AdminStorageQuotaUpdate(projectKey string, quota sdk.ProjectStorageQuota) (*sdk.ProjectStorageUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminStorageQuotaUpdate", projectKey, quota)
+	ret0, _ := ret[0].(*sdk.ProjectStorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminStorageQuotaUpdate indicates an expected call of AdminStorageQuotaUpdate
+func (mr *MockAdminMockRecorder) AdminStorageQuotaUpdate(projectKey, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminStorageQuotaUpdate", reflect.TypeOf((*MockAdmin)(nil).AdminStorageQuotaUpdate), projectKey, quota)
+}
+
 // Services mocks base method
 func (m *MockAdmin) Services() ([]sdk.Service, error) {
 	m.ctrl.T.Helper()
@@ -1675,6 +1705,33 @@ func (mr *MockEventsClientMockRecorder) EventsListen(ctx, chanSSEvt interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsListen", reflect.TypeOf((*MockEventsClient)(nil).EventsListen), ctx, chanSSEvt)
 }
 
+// EventsListenFrom mocks base method
+func (m *MockEventsClient) EventsListenFrom(ctx context.Context, lastEventID int64, chanSSEvt chan<- cdsclient.SSEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EventsListenFrom", ctx, lastEventID, chanSSEvt)
+}
+
+// EventsListenFrom indicates an expected call of EventsListenFrom
+func (mr *MockEventsClientMockRecorder) EventsListenFrom(ctx, lastEventID, chanSSEvt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsListenFrom", reflect.TypeOf((*MockEventsClient)(nil).EventsListenFrom), ctx, lastEventID, chanSSEvt)
+}
+
+// EventsHistory mocks base method
+func (m *MockEventsClient) EventsHistory(since int64, limit int) (*sdk.EventHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventsHistory", since, limit)
+	ret0, _ := ret[0].(*sdk.EventHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EventsHistory indicates an expected call of EventsHistory
+func (mr *MockEventsClientMockRecorder) EventsHistory(since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsHistory", reflect.TypeOf((*MockEventsClient)(nil).EventsHistory), since, limit)
+}
+
 // MockDownloadClient is a mock of DownloadClient interface
 type MockDownloadClient struct {
 	ctrl     *gomock.Controller
@@ -1829,18 +1886,68 @@ func (mr *MockActionClientMockRecorder) ActionImport(content, format interface{}
 }
 
 // ActionExport mocks base method
-func (m *MockActionClient) ActionExport(groupName, name, format string) ([]byte, error) {
+func (m *MockActionClient) ActionExport(groupName, name, format string, mods ...cdsclient.RequestModifier) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActionExport", groupName, name, format)
+	varargs := []interface{}{groupName, name, format}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ActionExport", varargs...)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ActionExport indicates an expected call of ActionExport
-func (mr *MockActionClientMockRecorder) ActionExport(groupName, name, format interface{}) *gomock.Call {
+func (mr *MockActionClientMockRecorder) ActionExport(groupName, name, format interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{groupName, name, format}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionExport", reflect.TypeOf((*MockActionClient)(nil).ActionExport), varargs...)
+}
+
+// ActionUsage mocks base method
+func (m *MockActionClient) ActionUsage(groupName, name string) (*sdk.ActionUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionUsage", groupName, name)
+	ret0, _ := ret[0].(*sdk.ActionUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionUsage indicates an expected call of ActionUsage
+func (mr *MockActionClientMockRecorder) ActionUsage(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionUsage", reflect.TypeOf((*MockActionClient)(nil).ActionUsage), groupName, name)
+}
+
+// ActionVersionList mocks base method
+func (m *MockActionClient) ActionVersionList(groupName, name string) ([]sdk.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionList", groupName, name)
+	ret0, _ := ret[0].([]sdk.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionVersionList indicates an expected call of ActionVersionList
+func (mr *MockActionClientMockRecorder) ActionVersionList(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionList", reflect.TypeOf((*MockActionClient)(nil).ActionVersionList), groupName, name)
+}
+
+// ActionPublish mocks base method
+func (m *MockActionClient) ActionPublish(groupName, name, version string) (*sdk.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionPublish", groupName, name, version)
+	ret0, _ := ret[0].(*sdk.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionPublish indicates an expected call of ActionPublish
+func (mr *MockActionClientMockRecorder) ActionPublish(groupName, name, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionExport", reflect.TypeOf((*MockActionClient)(nil).ActionExport), groupName, name, format)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionPublish", reflect.TypeOf((*MockActionClient)(nil).ActionPublish), groupName, name, version)
 }
 
 // ActionBuiltinList mocks base method
@@ -2572,6 +2679,36 @@ func (mr *MockProjectClientMockRecorder) ProjectRepositoryManagerDelete(projectK
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerDelete", reflect.TypeOf((*MockProjectClient)(nil).ProjectRepositoryManagerDelete), projectKey, repoManagerName, force)
 }
 
+// ProjectSBOMSearch mocks base method
+func (m *MockProjectClient) ProjectSBOMSearch(projectKey, name, version string, limit int) ([]sdk.SBOMSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectSBOMSearch", projectKey, name, version, limit)
+	ret0, _ := ret[0].([]sdk.SBOMSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectSBOMSearch indicates an expected call of ProjectSBOMSearch
+func (mr *MockProjectClientMockRecorder) ProjectSBOMSearch(projectKey, name, version, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSBOMSearch", reflect.TypeOf((*MockProjectClient)(nil).ProjectSBOMSearch), projectKey, name, version, limit)
+}
+
+// ProjectProvenanceKey mocks base method
+func (m *MockProjectClient) ProjectProvenanceKey(projectKey string) (*sdk.ProvenancePublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectProvenanceKey", projectKey)
+	ret0, _ := ret[0].(*sdk.ProvenancePublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectProvenanceKey indicates an expected call of ProjectProvenanceKey
+func (mr *MockProjectClientMockRecorder) ProjectProvenanceKey(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectProvenanceKey", reflect.TypeOf((*MockProjectClient)(nil).ProjectProvenanceKey), projectKey)
+}
+
 // MockProjectKeysClient is a mock of ProjectKeysClient interface
 type MockProjectKeysClient struct {
 	ctrl     *gomock.Controller
@@ -2905,6 +3042,20 @@ func (mr *MockQueueClientMockRecorder) QueueSendCoverage(ctx, id, report interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverage", reflect.TypeOf((*MockQueueClient)(nil).QueueSendCoverage), ctx, id, report)
 }
 
+// QueueSendCoverageFiles mocks base method
+func (m *MockQueueClient) QueueSendCoverageFiles(ctx context.Context, id int64, files []sdk.WorkflowNodeRunCoverageFile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendCoverageFiles", ctx, id, files)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueSendCoverageFiles indicates an expected call of QueueSendCoverageFiles
+func (mr *MockQueueClientMockRecorder) QueueSendCoverageFiles(ctx, id, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverageFiles", reflect.TypeOf((*MockQueueClient)(nil).QueueSendCoverageFiles), ctx, id, files)
+}
+
 // QueueSendUnitTests mocks base method
 func (m *MockQueueClient) QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error {
 	m.ctrl.T.Helper()
@@ -2947,6 +3098,51 @@ func (mr *MockQueueClientMockRecorder) QueueSendVulnerability(ctx, id, report in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendVulnerability", reflect.TypeOf((*MockQueueClient)(nil).QueueSendVulnerability), ctx, id, report)
 }
 
+// QueueSendStaticAnalysis mocks base method
+func (m *MockQueueClient) QueueSendStaticAnalysis(ctx context.Context, id int64, report sdk.StaticAnalysisWorkerReport) (*sdk.WorkflowNodeRunStaticAnalysisReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendStaticAnalysis", ctx, id, report)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunStaticAnalysisReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueSendStaticAnalysis indicates an expected call of QueueSendStaticAnalysis
+func (mr *MockQueueClientMockRecorder) QueueSendStaticAnalysis(ctx, id, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendStaticAnalysis", reflect.TypeOf((*MockQueueClient)(nil).QueueSendStaticAnalysis), ctx, id, report)
+}
+
+// QueueSendSBOM mocks base method
+func (m *MockQueueClient) QueueSendSBOM(ctx context.Context, id int64, name string, data io.Reader) (*sdk.WorkflowNodeRunSBOM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendSBOM", ctx, id, name, data)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunSBOM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueSendSBOM indicates an expected call of QueueSendSBOM
+func (mr *MockQueueClientMockRecorder) QueueSendSBOM(ctx, id, name, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendSBOM", reflect.TypeOf((*MockQueueClient)(nil).QueueSendSBOM), ctx, id, name, data)
+}
+
+// QueueArtifactProvenance mocks base method
+func (m *MockQueueClient) QueueArtifactProvenance(ctx context.Context, nodeJobRunID int64, tag, name string) (*sdk.WorkflowNodeRunArtifactProvenance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueArtifactProvenance", ctx, nodeJobRunID, tag, name)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunArtifactProvenance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueArtifactProvenance indicates an expected call of QueueArtifactProvenance
+func (mr *MockQueueClientMockRecorder) QueueArtifactProvenance(ctx, nodeJobRunID, tag, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactProvenance", reflect.TypeOf((*MockQueueClient)(nil).QueueArtifactProvenance), ctx, nodeJobRunID, tag, name)
+}
+
 // QueueSendStepResult mocks base method
 func (m *MockQueueClient) QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error {
 	m.ctrl.T.Helper()
@@ -2991,6 +3187,21 @@ func (mr *MockQueueClientMockRecorder) QueueArtifactUpload(ctx, projectKey, inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactUpload", reflect.TypeOf((*MockQueueClient)(nil).QueueArtifactUpload), ctx, projectKey, integrationName, nodeJobRunID, tag, filePath)
 }
 
+// QueueArtifactBlob mocks base method
+func (m *MockQueueClient) QueueArtifactBlob(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueArtifactBlob", ctx, projectKey, integrationName, nodeJobRunID, tag, filePath)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueArtifactBlob indicates an expected call of QueueArtifactBlob
+func (mr *MockQueueClientMockRecorder) QueueArtifactBlob(ctx, projectKey, integrationName, nodeJobRunID, tag, filePath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactBlob", reflect.TypeOf((*MockQueueClient)(nil).QueueArtifactBlob), ctx, projectKey, integrationName, nodeJobRunID, tag, filePath)
+}
+
 // QueueStaticFilesUpload mocks base method
 func (m *MockQueueClient) QueueStaticFilesUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, name, entrypoint, staticKey string, tarContent io.Reader) (string, bool, time.Duration, error) {
 	m.ctrl.T.Helper()
@@ -3814,6 +4025,94 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowCachePull(projectKey, integrat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCachePull", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCachePull), projectKey, integrationName, ref)
 }
 
+// WorkflowCacheLayersMissing mocks base method
+func (m *MockWorkflowClient) WorkflowCacheLayersMissing(projectKey, integrationName string, layers []sdk.CacheLayer) ([]sdk.CacheLayer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLayersMissing", projectKey, integrationName, layers)
+	ret0, _ := ret[0].([]sdk.CacheLayer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheLayersMissing indicates an expected call of WorkflowCacheLayersMissing
+func (mr *MockWorkflowClientMockRecorder) WorkflowCacheLayersMissing(projectKey, integrationName, layers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLayersMissing", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCacheLayersMissing), projectKey, integrationName, layers)
+}
+
+// WorkflowCacheLayerPush mocks base method
+func (m *MockWorkflowClient) WorkflowCacheLayerPush(projectKey, integrationName, digest string, content io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLayerPush", projectKey, integrationName, digest, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowCacheLayerPush indicates an expected call of WorkflowCacheLayerPush
+func (mr *MockWorkflowClientMockRecorder) WorkflowCacheLayerPush(projectKey, integrationName, digest, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLayerPush", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCacheLayerPush), projectKey, integrationName, digest, content)
+}
+
+// WorkflowCacheLayerPull mocks base method
+func (m *MockWorkflowClient) WorkflowCacheLayerPull(projectKey, integrationName, digest string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLayerPull", projectKey, integrationName, digest)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheLayerPull indicates an expected call of WorkflowCacheLayerPull
+func (mr *MockWorkflowClientMockRecorder) WorkflowCacheLayerPull(projectKey, integrationName, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLayerPull", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCacheLayerPull), projectKey, integrationName, digest)
+}
+
+// WorkflowCacheManifestPush mocks base method
+func (m *MockWorkflowClient) WorkflowCacheManifestPush(projectKey, integrationName, ref string, manifest sdk.CacheManifest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheManifestPush", projectKey, integrationName, ref, manifest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowCacheManifestPush indicates an expected call of WorkflowCacheManifestPush
+func (mr *MockWorkflowClientMockRecorder) WorkflowCacheManifestPush(projectKey, integrationName, ref, manifest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheManifestPush", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCacheManifestPush), projectKey, integrationName, ref, manifest)
+}
+
+// WorkflowCacheManifestPull mocks base method
+func (m *MockWorkflowClient) WorkflowCacheManifestPull(projectKey, integrationName, ref string) (*sdk.CacheManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheManifestPull", projectKey, integrationName, ref)
+	ret0, _ := ret[0].(*sdk.CacheManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheManifestPull indicates an expected call of WorkflowCacheManifestPull
+func (mr *MockWorkflowClientMockRecorder) WorkflowCacheManifestPull(projectKey, integrationName, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheManifestPull", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCacheManifestPull), projectKey, integrationName, ref)
+}
+
+// WorkflowCacheLookup mocks base method
+func (m *MockWorkflowClient) WorkflowCacheLookup(projectKey, integrationName string, restoreKeys []string) (*sdk.Cache, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLookup", projectKey, integrationName, restoreKeys)
+	ret0, _ := ret[0].(*sdk.Cache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheLookup indicates an expected call of WorkflowCacheLookup
+func (mr *MockWorkflowClientMockRecorder) WorkflowCacheLookup(projectKey, integrationName, restoreKeys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLookup", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowCacheLookup), projectKey, integrationName, restoreKeys)
+}
+
 // WorkflowTemplateInstanceGet mocks base method
 func (m *MockWorkflowClient) WorkflowTemplateInstanceGet(projectKey, workflowName string) (*sdk.WorkflowTemplateInstance, error) {
 	m.ctrl.T.Helper()
@@ -3858,146 +4157,325 @@ func (mr *MockWorkflowClientMockRecorder) WorkflowTransformAsCodeFollow(projectK
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTransformAsCodeFollow", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTransformAsCodeFollow), projectKey, workflowName, ope)
 }
 
-// MockMonitoringClient is a mock of MonitoringClient interface
-type MockMonitoringClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockMonitoringClientMockRecorder
+// WorkflowRetentionDryRun mocks base method
+func (m *MockWorkflowClient) WorkflowRetentionDryRun(projectKey, workflowName string) ([]sdk.WorkflowRetentionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRetentionDryRun", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.WorkflowRetentionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockMonitoringClientMockRecorder is the mock recorder for MockMonitoringClient
-type MockMonitoringClientMockRecorder struct {
-	mock *MockMonitoringClient
+// WorkflowRetentionDryRun indicates an expected call of WorkflowRetentionDryRun
+func (mr *MockWorkflowClientMockRecorder) WorkflowRetentionDryRun(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRetentionDryRun", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRetentionDryRun), projectKey, workflowName)
 }
 
-// NewMockMonitoringClient creates a new mock instance
-func NewMockMonitoringClient(ctrl *gomock.Controller) *MockMonitoringClient {
-	mock := &MockMonitoringClient{ctrl: ctrl}
-	mock.recorder = &MockMonitoringClientMockRecorder{mock}
-	return mock
+// WorkflowTestsHistory mocks base method
+func (m *MockWorkflowClient) WorkflowTestsHistory(projectKey, workflowName, suite, name, branch string, limit int) ([]sdk.WorkflowTestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTestsHistory", projectKey, workflowName, suite, name, branch, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowTestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockMonitoringClient) EXPECT() *MockMonitoringClientMockRecorder {
-	return m.recorder
+// WorkflowTestsHistory indicates an expected call of WorkflowTestsHistory
+func (mr *MockWorkflowClientMockRecorder) WorkflowTestsHistory(projectKey, workflowName, suite, name, branch, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTestsHistory", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTestsHistory), projectKey, workflowName, suite, name, branch, limit)
 }
 
-// MonStatus mocks base method
-func (m *MockMonitoringClient) MonStatus() (*sdk.MonitoringStatus, error) {
+// WorkflowTestsFlaky mocks base method
+func (m *MockWorkflowClient) WorkflowTestsFlaky(projectKey, workflowName, branch string, runs int) ([]sdk.WorkflowTestFlakiness, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MonStatus")
-	ret0, _ := ret[0].(*sdk.MonitoringStatus)
+	ret := m.ctrl.Call(m, "WorkflowTestsFlaky", projectKey, workflowName, branch, runs)
+	ret0, _ := ret[0].([]sdk.WorkflowTestFlakiness)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MonStatus indicates an expected call of MonStatus
-func (mr *MockMonitoringClientMockRecorder) MonStatus() *gomock.Call {
+// WorkflowTestsFlaky indicates an expected call of WorkflowTestsFlaky
+func (mr *MockWorkflowClientMockRecorder) WorkflowTestsFlaky(projectKey, workflowName, branch, runs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonStatus", reflect.TypeOf((*MockMonitoringClient)(nil).MonStatus))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTestsFlaky", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTestsFlaky), projectKey, workflowName, branch, runs)
 }
 
-// MonVersion mocks base method
-func (m *MockMonitoringClient) MonVersion() (*sdk.Version, error) {
+// WorkflowTestsTrends mocks base method
+func (m *MockWorkflowClient) WorkflowTestsTrends(projectKey, workflowName, branch string, limit int) ([]sdk.WorkflowTestsTrend, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MonVersion")
-	ret0, _ := ret[0].(*sdk.Version)
+	ret := m.ctrl.Call(m, "WorkflowTestsTrends", projectKey, workflowName, branch, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowTestsTrend)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MonVersion indicates an expected call of MonVersion
-func (mr *MockMonitoringClientMockRecorder) MonVersion() *gomock.Call {
+// WorkflowTestsTrends indicates an expected call of WorkflowTestsTrends
+func (mr *MockWorkflowClientMockRecorder) WorkflowTestsTrends(projectKey, workflowName, branch, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonVersion", reflect.TypeOf((*MockMonitoringClient)(nil).MonVersion))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTestsTrends", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowTestsTrends), projectKey, workflowName, branch, limit)
 }
 
-// MonDBMigrate mocks base method
-func (m *MockMonitoringClient) MonDBMigrate() ([]sdk.MonDBMigrate, error) {
+// WorkflowRunTestsDiff mocks base method
+func (m *MockWorkflowClient) WorkflowRunTestsDiff(projectKey, workflowName string, number int64) (*sdk.WorkflowTestsDiff, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MonDBMigrate")
-	ret0, _ := ret[0].([]sdk.MonDBMigrate)
+	ret := m.ctrl.Call(m, "WorkflowRunTestsDiff", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowTestsDiff)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MonDBMigrate indicates an expected call of MonDBMigrate
-func (mr *MockMonitoringClientMockRecorder) MonDBMigrate() *gomock.Call {
+// WorkflowRunTestsDiff indicates an expected call of WorkflowRunTestsDiff
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunTestsDiff(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonDBMigrate", reflect.TypeOf((*MockMonitoringClient)(nil).MonDBMigrate))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunTestsDiff", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunTestsDiff), projectKey, workflowName, number)
 }
 
-// MonErrorsGet mocks base method
-func (m *MockMonitoringClient) MonErrorsGet(uuid string) (*sdk.Error, error) {
+// WorkflowRunSBOMList mocks base method
+func (m *MockWorkflowClient) WorkflowRunSBOMList(projectKey, workflowName string, number int64) ([]sdk.WorkflowNodeRunSBOM, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MonErrorsGet", uuid)
-	ret0, _ := ret[0].(*sdk.Error)
+	ret := m.ctrl.Call(m, "WorkflowRunSBOMList", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.WorkflowNodeRunSBOM)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MonErrorsGet indicates an expected call of MonErrorsGet
-func (mr *MockMonitoringClientMockRecorder) MonErrorsGet(uuid interface{}) *gomock.Call {
+// WorkflowRunSBOMList indicates an expected call of WorkflowRunSBOMList
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunSBOMList(projectKey, workflowName, number interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonErrorsGet", reflect.TypeOf((*MockMonitoringClient)(nil).MonErrorsGet), uuid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSBOMList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunSBOMList), projectKey, workflowName, number)
 }
 
-// MockIntegrationClient is a mock of IntegrationClient interface
-type MockIntegrationClient struct {
-	ctrl     *gomock.Controller
-	recorder *MockIntegrationClientMockRecorder
+// WorkflowRunSBOMDownload mocks base method
+func (m *MockWorkflowClient) WorkflowRunSBOMDownload(projectKey, workflowName string, number, id int64, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunSBOMDownload", projectKey, workflowName, number, id, w)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockIntegrationClientMockRecorder is the mock recorder for MockIntegrationClient
-type MockIntegrationClientMockRecorder struct {
-	mock *MockIntegrationClient
+// WorkflowRunSBOMDownload indicates an expected call of WorkflowRunSBOMDownload
+func (mr *MockWorkflowClientMockRecorder) WorkflowRunSBOMDownload(projectKey, workflowName, number, id, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSBOMDownload", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRunSBOMDownload), projectKey, workflowName, number, id, w)
 }
 
-// NewMockIntegrationClient creates a new mock instance
-func NewMockIntegrationClient(ctrl *gomock.Controller) *MockIntegrationClient {
-	mock := &MockIntegrationClient{ctrl: ctrl}
-	mock.recorder = &MockIntegrationClientMockRecorder{mock}
-	return mock
+// WorkflowNodeRunArtifactProvenance mocks base method
+func (m *MockWorkflowClient) WorkflowNodeRunArtifactProvenance(projectKey, workflowName string, artifactID int64) (*sdk.ProvenanceEnvelope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowNodeRunArtifactProvenance", projectKey, workflowName, artifactID)
+	ret0, _ := ret[0].(*sdk.ProvenanceEnvelope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockIntegrationClient) EXPECT() *MockIntegrationClientMockRecorder {
-	return m.recorder
+// WorkflowNodeRunArtifactProvenance indicates an expected call of WorkflowNodeRunArtifactProvenance
+func (mr *MockWorkflowClientMockRecorder) WorkflowNodeRunArtifactProvenance(projectKey, workflowName, artifactID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunArtifactProvenance", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowNodeRunArtifactProvenance), projectKey, workflowName, artifactID)
 }
 
-// IntegrationModelList mocks base method
-func (m *MockIntegrationClient) IntegrationModelList() ([]sdk.IntegrationModel, error) {
+// WorkflowMergeQueueAdd mocks base method
+func (m *MockWorkflowClient) WorkflowMergeQueueAdd(projectKey, workflowName string, entry sdk.MergeQueueEntry) (*sdk.MergeQueueEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IntegrationModelList")
-	ret0, _ := ret[0].([]sdk.IntegrationModel)
+	ret := m.ctrl.Call(m, "WorkflowMergeQueueAdd", projectKey, workflowName, entry)
+	ret0, _ := ret[0].(*sdk.MergeQueueEntry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IntegrationModelList indicates an expected call of IntegrationModelList
-func (mr *MockIntegrationClientMockRecorder) IntegrationModelList() *gomock.Call {
+// WorkflowMergeQueueAdd indicates an expected call of WorkflowMergeQueueAdd
+func (mr *MockWorkflowClientMockRecorder) WorkflowMergeQueueAdd(projectKey, workflowName, entry interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelList", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowMergeQueueAdd", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowMergeQueueAdd), projectKey, workflowName, entry)
 }
 
-// IntegrationModelGet mocks base method
-func (m *MockIntegrationClient) IntegrationModelGet(name string) (sdk.IntegrationModel, error) {
+// WorkflowMergeQueueList mocks base method
+func (m *MockWorkflowClient) WorkflowMergeQueueList(projectKey, workflowName string) ([]sdk.MergeQueueEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IntegrationModelGet", name)
-	ret0, _ := ret[0].(sdk.IntegrationModel)
+	ret := m.ctrl.Call(m, "WorkflowMergeQueueList", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.MergeQueueEntry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IntegrationModelGet indicates an expected call of IntegrationModelGet
-func (mr *MockIntegrationClientMockRecorder) IntegrationModelGet(name interface{}) *gomock.Call {
+// WorkflowMergeQueueList indicates an expected call of WorkflowMergeQueueList
+func (mr *MockWorkflowClientMockRecorder) WorkflowMergeQueueList(projectKey, workflowName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelGet", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelGet), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowMergeQueueList", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowMergeQueueList), projectKey, workflowName)
 }
 
-// IntegrationModelAdd mocks base method
-func (m_2 *MockIntegrationClient) IntegrationModelAdd(m *sdk.IntegrationModel) error {
-	m_2.ctrl.T.Helper()
-	ret := m_2.ctrl.Call(m_2, "IntegrationModelAdd", m)
+// WorkflowPullRequestCommand mocks base method
+func (m *MockWorkflowClient) WorkflowPullRequestCommand(projectKey, workflowName string, command sdk.PullRequestCommand) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowPullRequestCommand", projectKey, workflowName, command)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowPullRequestCommand indicates an expected call of WorkflowPullRequestCommand
+func (mr *MockWorkflowClientMockRecorder) WorkflowPullRequestCommand(projectKey, workflowName, command interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowPullRequestCommand", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowPullRequestCommand), projectKey, workflowName, command)
+}
+
+// WorkflowRepositoryHead mocks base method
+func (m *MockWorkflowClient) WorkflowRepositoryHead(projectKey, workflowName, branch string) (*sdk.VCSBranch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRepositoryHead", projectKey, workflowName, branch)
+	ret0, _ := ret[0].(*sdk.VCSBranch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRepositoryHead indicates an expected call of WorkflowRepositoryHead
+func (mr *MockWorkflowClientMockRecorder) WorkflowRepositoryHead(projectKey, workflowName, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRepositoryHead", reflect.TypeOf((*MockWorkflowClient)(nil).WorkflowRepositoryHead), projectKey, workflowName, branch)
+}
+
+// MockMonitoringClient is a mock of MonitoringClient interface
+type MockMonitoringClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMonitoringClientMockRecorder
+}
+
+// MockMonitoringClientMockRecorder is the mock recorder for MockMonitoringClient
+type MockMonitoringClientMockRecorder struct {
+	mock *MockMonitoringClient
+}
+
+// NewMockMonitoringClient creates a new mock instance
+func NewMockMonitoringClient(ctrl *gomock.Controller) *MockMonitoringClient {
+	mock := &MockMonitoringClient{ctrl: ctrl}
+	mock.recorder = &MockMonitoringClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMonitoringClient) EXPECT() *MockMonitoringClientMockRecorder {
+	return m.recorder
+}
+
+// MonStatus mocks base method
+func (m *MockMonitoringClient) MonStatus() (*sdk.MonitoringStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MonStatus")
+	ret0, _ := ret[0].(*sdk.MonitoringStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MonStatus indicates an expected call of MonStatus
+func (mr *MockMonitoringClientMockRecorder) MonStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonStatus", reflect.TypeOf((*MockMonitoringClient)(nil).MonStatus))
+}
+
+// MonVersion mocks base method
+func (m *MockMonitoringClient) MonVersion() (*sdk.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MonVersion")
+	ret0, _ := ret[0].(*sdk.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MonVersion indicates an expected call of MonVersion
+func (mr *MockMonitoringClientMockRecorder) MonVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonVersion", reflect.TypeOf((*MockMonitoringClient)(nil).MonVersion))
+}
+
+// MonDBMigrate mocks base method
+func (m *MockMonitoringClient) MonDBMigrate() ([]sdk.MonDBMigrate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MonDBMigrate")
+	ret0, _ := ret[0].([]sdk.MonDBMigrate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MonDBMigrate indicates an expected call of MonDBMigrate
+func (mr *MockMonitoringClientMockRecorder) MonDBMigrate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonDBMigrate", reflect.TypeOf((*MockMonitoringClient)(nil).MonDBMigrate))
+}
+
+// MonErrorsGet mocks base method
+func (m *MockMonitoringClient) MonErrorsGet(uuid string) (*sdk.Error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MonErrorsGet", uuid)
+	ret0, _ := ret[0].(*sdk.Error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MonErrorsGet indicates an expected call of MonErrorsGet
+func (mr *MockMonitoringClientMockRecorder) MonErrorsGet(uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonErrorsGet", reflect.TypeOf((*MockMonitoringClient)(nil).MonErrorsGet), uuid)
+}
+
+// MockIntegrationClient is a mock of IntegrationClient interface
+type MockIntegrationClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIntegrationClientMockRecorder
+}
+
+// MockIntegrationClientMockRecorder is the mock recorder for MockIntegrationClient
+type MockIntegrationClientMockRecorder struct {
+	mock *MockIntegrationClient
+}
+
+// NewMockIntegrationClient creates a new mock instance
+func NewMockIntegrationClient(ctrl *gomock.Controller) *MockIntegrationClient {
+	mock := &MockIntegrationClient{ctrl: ctrl}
+	mock.recorder = &MockIntegrationClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIntegrationClient) EXPECT() *MockIntegrationClientMockRecorder {
+	return m.recorder
+}
+
+// IntegrationModelList mocks base method
+func (m *MockIntegrationClient) IntegrationModelList() ([]sdk.IntegrationModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntegrationModelList")
+	ret0, _ := ret[0].([]sdk.IntegrationModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntegrationModelList indicates an expected call of IntegrationModelList
+func (mr *MockIntegrationClientMockRecorder) IntegrationModelList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelList", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelList))
+}
+
+// IntegrationModelGet mocks base method
+func (m *MockIntegrationClient) IntegrationModelGet(name string) (sdk.IntegrationModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntegrationModelGet", name)
+	ret0, _ := ret[0].(sdk.IntegrationModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntegrationModelGet indicates an expected call of IntegrationModelGet
+func (mr *MockIntegrationClientMockRecorder) IntegrationModelGet(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntegrationModelGet", reflect.TypeOf((*MockIntegrationClient)(nil).IntegrationModelGet), name)
+}
+
+// IntegrationModelAdd mocks base method
+func (m_2 *MockIntegrationClient) IntegrationModelAdd(m *sdk.IntegrationModel) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "IntegrationModelAdd", m)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
@@ -4480,18 +4958,68 @@ func (mr *MockInterfaceMockRecorder) ActionImport(content, format interface{}) *
 }
 
 // ActionExport mocks base method
-func (m *MockInterface) ActionExport(groupName, name, format string) ([]byte, error) {
+func (m *MockInterface) ActionExport(groupName, name, format string, mods ...cdsclient.RequestModifier) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActionExport", groupName, name, format)
+	varargs := []interface{}{groupName, name, format}
+	for _, a := range mods {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ActionExport", varargs...)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ActionExport indicates an expected call of ActionExport
-func (mr *MockInterfaceMockRecorder) ActionExport(groupName, name, format interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) ActionExport(groupName, name, format interface{}, mods ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{groupName, name, format}, mods...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionExport", reflect.TypeOf((*MockInterface)(nil).ActionExport), varargs...)
+}
+
+// ActionUsage mocks base method
+func (m *MockInterface) ActionUsage(groupName, name string) (*sdk.ActionUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionUsage", groupName, name)
+	ret0, _ := ret[0].(*sdk.ActionUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionUsage indicates an expected call of ActionUsage
+func (mr *MockInterfaceMockRecorder) ActionUsage(groupName, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionExport", reflect.TypeOf((*MockInterface)(nil).ActionExport), groupName, name, format)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionUsage", reflect.TypeOf((*MockInterface)(nil).ActionUsage), groupName, name)
+}
+
+// ActionVersionList mocks base method
+func (m *MockInterface) ActionVersionList(groupName, name string) ([]sdk.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionVersionList", groupName, name)
+	ret0, _ := ret[0].([]sdk.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionVersionList indicates an expected call of ActionVersionList
+func (mr *MockInterfaceMockRecorder) ActionVersionList(groupName, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionVersionList", reflect.TypeOf((*MockInterface)(nil).ActionVersionList), groupName, name)
+}
+
+// ActionPublish mocks base method
+func (m *MockInterface) ActionPublish(groupName, name, version string) (*sdk.Action, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActionPublish", groupName, name, version)
+	ret0, _ := ret[0].(*sdk.Action)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActionPublish indicates an expected call of ActionPublish
+func (mr *MockInterfaceMockRecorder) ActionPublish(groupName, name, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionPublish", reflect.TypeOf((*MockInterface)(nil).ActionPublish), groupName, name, version)
 }
 
 // ActionBuiltinList mocks base method
@@ -4701,6 +5229,36 @@ func (mr *MockInterfaceMockRecorder) AdminCDSMigrationReset(id interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminCDSMigrationReset", reflect.TypeOf((*MockInterface)(nil).AdminCDSMigrationReset), id)
 }
 
+// AdminStorageUsages mocks base method
+func (m *MockInterface) AdminStorageUsages() ([]sdk.ProjectStorageUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminStorageUsages")
+	ret0, _ := ret[0].([]sdk.ProjectStorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminStorageUsages indicates an expected call of AdminStorageUsages
+func (mr *MockInterfaceMockRecorder) AdminStorageUsages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminStorageUsages", reflect.TypeOf((*MockInterface)(nil).AdminStorageUsages))
+}
+
+// AdminStorageQuotaUpdate mocks base method
+func (m *MockInterface) AdminStorageQuotaUpdate(projectKey string, quota sdk.ProjectStorageQuota) (*sdk.ProjectStorageUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminStorageQuotaUpdate", projectKey, quota)
+	ret0, _ := ret[0].(*sdk.ProjectStorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminStorageQuotaUpdate indicates an expected call of AdminStorageQuotaUpdate
+func (mr *MockInterfaceMockRecorder) AdminStorageQuotaUpdate(projectKey, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminStorageQuotaUpdate", reflect.TypeOf((*MockInterface)(nil).AdminStorageQuotaUpdate), projectKey, quota)
+}
+
 // Services mocks base method
 func (m *MockInterface) Services() ([]sdk.Service, error) {
 	m.ctrl.T.Helper()
@@ -5333,6 +5891,33 @@ func (mr *MockInterfaceMockRecorder) EventsListen(ctx, chanSSEvt interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsListen", reflect.TypeOf((*MockInterface)(nil).EventsListen), ctx, chanSSEvt)
 }
 
+// EventsListenFrom mocks base method
+func (m *MockInterface) EventsListenFrom(ctx context.Context, lastEventID int64, chanSSEvt chan<- cdsclient.SSEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EventsListenFrom", ctx, lastEventID, chanSSEvt)
+}
+
+// EventsListenFrom indicates an expected call of EventsListenFrom
+func (mr *MockInterfaceMockRecorder) EventsListenFrom(ctx, lastEventID, chanSSEvt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsListenFrom", reflect.TypeOf((*MockInterface)(nil).EventsListenFrom), ctx, lastEventID, chanSSEvt)
+}
+
+// EventsHistory mocks base method
+func (m *MockInterface) EventsHistory(since int64, limit int) (*sdk.EventHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventsHistory", since, limit)
+	ret0, _ := ret[0].(*sdk.EventHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EventsHistory indicates an expected call of EventsHistory
+func (mr *MockInterfaceMockRecorder) EventsHistory(since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsHistory", reflect.TypeOf((*MockInterface)(nil).EventsHistory), since, limit)
+}
+
 // PipelineExport mocks base method
 func (m *MockInterface) PipelineExport(projectKey, name, exportFormat string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -6324,6 +6909,36 @@ func (mr *MockInterfaceMockRecorder) ProjectRepositoryManagerDelete(projectKey,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectRepositoryManagerDelete", reflect.TypeOf((*MockInterface)(nil).ProjectRepositoryManagerDelete), projectKey, repoManagerName, force)
 }
 
+// ProjectSBOMSearch mocks base method
+func (m *MockInterface) ProjectSBOMSearch(projectKey, name, version string, limit int) ([]sdk.SBOMSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectSBOMSearch", projectKey, name, version, limit)
+	ret0, _ := ret[0].([]sdk.SBOMSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectSBOMSearch indicates an expected call of ProjectSBOMSearch
+func (mr *MockInterfaceMockRecorder) ProjectSBOMSearch(projectKey, name, version, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectSBOMSearch", reflect.TypeOf((*MockInterface)(nil).ProjectSBOMSearch), projectKey, name, version, limit)
+}
+
+// ProjectProvenanceKey mocks base method
+func (m *MockInterface) ProjectProvenanceKey(projectKey string) (*sdk.ProvenancePublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectProvenanceKey", projectKey)
+	ret0, _ := ret[0].(*sdk.ProvenancePublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectProvenanceKey indicates an expected call of ProjectProvenanceKey
+func (mr *MockInterfaceMockRecorder) ProjectProvenanceKey(projectKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectProvenanceKey", reflect.TypeOf((*MockInterface)(nil).ProjectProvenanceKey), projectKey)
+}
+
 // QueueWorkflowNodeJobRun mocks base method
 func (m *MockInterface) QueueWorkflowNodeJobRun(status ...string) ([]sdk.WorkflowNodeJobRun, error) {
 	m.ctrl.T.Helper()
@@ -6458,6 +7073,20 @@ func (mr *MockInterfaceMockRecorder) QueueSendCoverage(ctx, id, report interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverage", reflect.TypeOf((*MockInterface)(nil).QueueSendCoverage), ctx, id, report)
 }
 
+// QueueSendCoverageFiles mocks base method
+func (m *MockInterface) QueueSendCoverageFiles(ctx context.Context, id int64, files []sdk.WorkflowNodeRunCoverageFile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendCoverageFiles", ctx, id, files)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueSendCoverageFiles indicates an expected call of QueueSendCoverageFiles
+func (mr *MockInterfaceMockRecorder) QueueSendCoverageFiles(ctx, id, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverageFiles", reflect.TypeOf((*MockInterface)(nil).QueueSendCoverageFiles), ctx, id, files)
+}
+
 // QueueSendUnitTests mocks base method
 func (m *MockInterface) QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error {
 	m.ctrl.T.Helper()
@@ -6500,6 +7129,51 @@ func (mr *MockInterfaceMockRecorder) QueueSendVulnerability(ctx, id, report inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendVulnerability", reflect.TypeOf((*MockInterface)(nil).QueueSendVulnerability), ctx, id, report)
 }
 
+// QueueSendStaticAnalysis mocks base method
+func (m *MockInterface) QueueSendStaticAnalysis(ctx context.Context, id int64, report sdk.StaticAnalysisWorkerReport) (*sdk.WorkflowNodeRunStaticAnalysisReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendStaticAnalysis", ctx, id, report)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunStaticAnalysisReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueSendStaticAnalysis indicates an expected call of QueueSendStaticAnalysis
+func (mr *MockInterfaceMockRecorder) QueueSendStaticAnalysis(ctx, id, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendStaticAnalysis", reflect.TypeOf((*MockInterface)(nil).QueueSendStaticAnalysis), ctx, id, report)
+}
+
+// QueueSendSBOM mocks base method
+func (m *MockInterface) QueueSendSBOM(ctx context.Context, id int64, name string, data io.Reader) (*sdk.WorkflowNodeRunSBOM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendSBOM", ctx, id, name, data)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunSBOM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueSendSBOM indicates an expected call of QueueSendSBOM
+func (mr *MockInterfaceMockRecorder) QueueSendSBOM(ctx, id, name, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendSBOM", reflect.TypeOf((*MockInterface)(nil).QueueSendSBOM), ctx, id, name, data)
+}
+
+// QueueArtifactProvenance mocks base method
+func (m *MockInterface) QueueArtifactProvenance(ctx context.Context, nodeJobRunID int64, tag, name string) (*sdk.WorkflowNodeRunArtifactProvenance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueArtifactProvenance", ctx, nodeJobRunID, tag, name)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunArtifactProvenance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueArtifactProvenance indicates an expected call of QueueArtifactProvenance
+func (mr *MockInterfaceMockRecorder) QueueArtifactProvenance(ctx, nodeJobRunID, tag, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactProvenance", reflect.TypeOf((*MockInterface)(nil).QueueArtifactProvenance), ctx, nodeJobRunID, tag, name)
+}
+
 // QueueSendStepResult mocks base method
 func (m *MockInterface) QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error {
 	m.ctrl.T.Helper()
@@ -6544,6 +7218,21 @@ func (mr *MockInterfaceMockRecorder) QueueArtifactUpload(ctx, projectKey, integr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactUpload", reflect.TypeOf((*MockInterface)(nil).QueueArtifactUpload), ctx, projectKey, integrationName, nodeJobRunID, tag, filePath)
 }
 
+// QueueArtifactBlob mocks base method
+func (m *MockInterface) QueueArtifactBlob(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, tag, filePath string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueArtifactBlob", ctx, projectKey, integrationName, nodeJobRunID, tag, filePath)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueArtifactBlob indicates an expected call of QueueArtifactBlob
+func (mr *MockInterfaceMockRecorder) QueueArtifactBlob(ctx, projectKey, integrationName, nodeJobRunID, tag, filePath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactBlob", reflect.TypeOf((*MockInterface)(nil).QueueArtifactBlob), ctx, projectKey, integrationName, nodeJobRunID, tag, filePath)
+}
+
 // QueueStaticFilesUpload mocks base method
 func (m *MockInterface) QueueStaticFilesUpload(ctx context.Context, projectKey, integrationName string, nodeJobRunID int64, name, entrypoint, staticKey string, tarContent io.Reader) (string, bool, time.Duration, error) {
 	m.ctrl.T.Helper()
@@ -7318,6 +8007,94 @@ func (mr *MockInterfaceMockRecorder) WorkflowCachePull(projectKey, integrationNa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCachePull", reflect.TypeOf((*MockInterface)(nil).WorkflowCachePull), projectKey, integrationName, ref)
 }
 
+// WorkflowCacheLayersMissing mocks base method
+func (m *MockInterface) WorkflowCacheLayersMissing(projectKey, integrationName string, layers []sdk.CacheLayer) ([]sdk.CacheLayer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLayersMissing", projectKey, integrationName, layers)
+	ret0, _ := ret[0].([]sdk.CacheLayer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheLayersMissing indicates an expected call of WorkflowCacheLayersMissing
+func (mr *MockInterfaceMockRecorder) WorkflowCacheLayersMissing(projectKey, integrationName, layers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLayersMissing", reflect.TypeOf((*MockInterface)(nil).WorkflowCacheLayersMissing), projectKey, integrationName, layers)
+}
+
+// WorkflowCacheLayerPush mocks base method
+func (m *MockInterface) WorkflowCacheLayerPush(projectKey, integrationName, digest string, content io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLayerPush", projectKey, integrationName, digest, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowCacheLayerPush indicates an expected call of WorkflowCacheLayerPush
+func (mr *MockInterfaceMockRecorder) WorkflowCacheLayerPush(projectKey, integrationName, digest, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLayerPush", reflect.TypeOf((*MockInterface)(nil).WorkflowCacheLayerPush), projectKey, integrationName, digest, content)
+}
+
+// WorkflowCacheLayerPull mocks base method
+func (m *MockInterface) WorkflowCacheLayerPull(projectKey, integrationName, digest string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLayerPull", projectKey, integrationName, digest)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheLayerPull indicates an expected call of WorkflowCacheLayerPull
+func (mr *MockInterfaceMockRecorder) WorkflowCacheLayerPull(projectKey, integrationName, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLayerPull", reflect.TypeOf((*MockInterface)(nil).WorkflowCacheLayerPull), projectKey, integrationName, digest)
+}
+
+// WorkflowCacheManifestPush mocks base method
+func (m *MockInterface) WorkflowCacheManifestPush(projectKey, integrationName, ref string, manifest sdk.CacheManifest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheManifestPush", projectKey, integrationName, ref, manifest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowCacheManifestPush indicates an expected call of WorkflowCacheManifestPush
+func (mr *MockInterfaceMockRecorder) WorkflowCacheManifestPush(projectKey, integrationName, ref, manifest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheManifestPush", reflect.TypeOf((*MockInterface)(nil).WorkflowCacheManifestPush), projectKey, integrationName, ref, manifest)
+}
+
+// WorkflowCacheManifestPull mocks base method
+func (m *MockInterface) WorkflowCacheManifestPull(projectKey, integrationName, ref string) (*sdk.CacheManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheManifestPull", projectKey, integrationName, ref)
+	ret0, _ := ret[0].(*sdk.CacheManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheManifestPull indicates an expected call of WorkflowCacheManifestPull
+func (mr *MockInterfaceMockRecorder) WorkflowCacheManifestPull(projectKey, integrationName, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheManifestPull", reflect.TypeOf((*MockInterface)(nil).WorkflowCacheManifestPull), projectKey, integrationName, ref)
+}
+
+// WorkflowCacheLookup mocks base method
+func (m *MockInterface) WorkflowCacheLookup(projectKey, integrationName string, restoreKeys []string) (*sdk.Cache, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLookup", projectKey, integrationName, restoreKeys)
+	ret0, _ := ret[0].(*sdk.Cache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheLookup indicates an expected call of WorkflowCacheLookup
+func (mr *MockInterfaceMockRecorder) WorkflowCacheLookup(projectKey, integrationName, restoreKeys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLookup", reflect.TypeOf((*MockInterface)(nil).WorkflowCacheLookup), projectKey, integrationName, restoreKeys)
+}
+
 // WorkflowTemplateInstanceGet mocks base method
 func (m *MockInterface) WorkflowTemplateInstanceGet(projectKey, workflowName string) (*sdk.WorkflowTemplateInstance, error) {
 	m.ctrl.T.Helper()
@@ -7362,6 +8139,185 @@ func (mr *MockInterfaceMockRecorder) WorkflowTransformAsCodeFollow(projectKey, w
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTransformAsCodeFollow", reflect.TypeOf((*MockInterface)(nil).WorkflowTransformAsCodeFollow), projectKey, workflowName, ope)
 }
 
+// WorkflowRetentionDryRun mocks base method
+func (m *MockInterface) WorkflowRetentionDryRun(projectKey, workflowName string) ([]sdk.WorkflowRetentionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRetentionDryRun", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.WorkflowRetentionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRetentionDryRun indicates an expected call of WorkflowRetentionDryRun
+func (mr *MockInterfaceMockRecorder) WorkflowRetentionDryRun(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRetentionDryRun", reflect.TypeOf((*MockInterface)(nil).WorkflowRetentionDryRun), projectKey, workflowName)
+}
+
+// WorkflowTestsHistory mocks base method
+func (m *MockInterface) WorkflowTestsHistory(projectKey, workflowName, suite, name, branch string, limit int) ([]sdk.WorkflowTestResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTestsHistory", projectKey, workflowName, suite, name, branch, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowTestResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowTestsHistory indicates an expected call of WorkflowTestsHistory
+func (mr *MockInterfaceMockRecorder) WorkflowTestsHistory(projectKey, workflowName, suite, name, branch, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTestsHistory", reflect.TypeOf((*MockInterface)(nil).WorkflowTestsHistory), projectKey, workflowName, suite, name, branch, limit)
+}
+
+// WorkflowTestsFlaky mocks base method
+func (m *MockInterface) WorkflowTestsFlaky(projectKey, workflowName, branch string, runs int) ([]sdk.WorkflowTestFlakiness, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTestsFlaky", projectKey, workflowName, branch, runs)
+	ret0, _ := ret[0].([]sdk.WorkflowTestFlakiness)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowTestsFlaky indicates an expected call of WorkflowTestsFlaky
+func (mr *MockInterfaceMockRecorder) WorkflowTestsFlaky(projectKey, workflowName, branch, runs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTestsFlaky", reflect.TypeOf((*MockInterface)(nil).WorkflowTestsFlaky), projectKey, workflowName, branch, runs)
+}
+
+// WorkflowTestsTrends mocks base method
+func (m *MockInterface) WorkflowTestsTrends(projectKey, workflowName, branch string, limit int) ([]sdk.WorkflowTestsTrend, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowTestsTrends", projectKey, workflowName, branch, limit)
+	ret0, _ := ret[0].([]sdk.WorkflowTestsTrend)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowTestsTrends indicates an expected call of WorkflowTestsTrends
+func (mr *MockInterfaceMockRecorder) WorkflowTestsTrends(projectKey, workflowName, branch, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowTestsTrends", reflect.TypeOf((*MockInterface)(nil).WorkflowTestsTrends), projectKey, workflowName, branch, limit)
+}
+
+// WorkflowRunTestsDiff mocks base method
+func (m *MockInterface) WorkflowRunTestsDiff(projectKey, workflowName string, number int64) (*sdk.WorkflowTestsDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunTestsDiff", projectKey, workflowName, number)
+	ret0, _ := ret[0].(*sdk.WorkflowTestsDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunTestsDiff indicates an expected call of WorkflowRunTestsDiff
+func (mr *MockInterfaceMockRecorder) WorkflowRunTestsDiff(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunTestsDiff", reflect.TypeOf((*MockInterface)(nil).WorkflowRunTestsDiff), projectKey, workflowName, number)
+}
+
+// WorkflowRunSBOMList mocks base method
+func (m *MockInterface) WorkflowRunSBOMList(projectKey, workflowName string, number int64) ([]sdk.WorkflowNodeRunSBOM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunSBOMList", projectKey, workflowName, number)
+	ret0, _ := ret[0].([]sdk.WorkflowNodeRunSBOM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRunSBOMList indicates an expected call of WorkflowRunSBOMList
+func (mr *MockInterfaceMockRecorder) WorkflowRunSBOMList(projectKey, workflowName, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSBOMList", reflect.TypeOf((*MockInterface)(nil).WorkflowRunSBOMList), projectKey, workflowName, number)
+}
+
+// WorkflowRunSBOMDownload mocks base method
+func (m *MockInterface) WorkflowRunSBOMDownload(projectKey, workflowName string, number, id int64, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRunSBOMDownload", projectKey, workflowName, number, id, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowRunSBOMDownload indicates an expected call of WorkflowRunSBOMDownload
+func (mr *MockInterfaceMockRecorder) WorkflowRunSBOMDownload(projectKey, workflowName, number, id, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRunSBOMDownload", reflect.TypeOf((*MockInterface)(nil).WorkflowRunSBOMDownload), projectKey, workflowName, number, id, w)
+}
+
+// WorkflowNodeRunArtifactProvenance mocks base method
+func (m *MockInterface) WorkflowNodeRunArtifactProvenance(projectKey, workflowName string, artifactID int64) (*sdk.ProvenanceEnvelope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowNodeRunArtifactProvenance", projectKey, workflowName, artifactID)
+	ret0, _ := ret[0].(*sdk.ProvenanceEnvelope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowNodeRunArtifactProvenance indicates an expected call of WorkflowNodeRunArtifactProvenance
+func (mr *MockInterfaceMockRecorder) WorkflowNodeRunArtifactProvenance(projectKey, workflowName, artifactID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowNodeRunArtifactProvenance", reflect.TypeOf((*MockInterface)(nil).WorkflowNodeRunArtifactProvenance), projectKey, workflowName, artifactID)
+}
+
+// WorkflowMergeQueueAdd mocks base method
+func (m *MockInterface) WorkflowMergeQueueAdd(projectKey, workflowName string, entry sdk.MergeQueueEntry) (*sdk.MergeQueueEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowMergeQueueAdd", projectKey, workflowName, entry)
+	ret0, _ := ret[0].(*sdk.MergeQueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowMergeQueueAdd indicates an expected call of WorkflowMergeQueueAdd
+func (mr *MockInterfaceMockRecorder) WorkflowMergeQueueAdd(projectKey, workflowName, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowMergeQueueAdd", reflect.TypeOf((*MockInterface)(nil).WorkflowMergeQueueAdd), projectKey, workflowName, entry)
+}
+
+// WorkflowMergeQueueList mocks base method
+func (m *MockInterface) WorkflowMergeQueueList(projectKey, workflowName string) ([]sdk.MergeQueueEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowMergeQueueList", projectKey, workflowName)
+	ret0, _ := ret[0].([]sdk.MergeQueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowMergeQueueList indicates an expected call of WorkflowMergeQueueList
+func (mr *MockInterfaceMockRecorder) WorkflowMergeQueueList(projectKey, workflowName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowMergeQueueList", reflect.TypeOf((*MockInterface)(nil).WorkflowMergeQueueList), projectKey, workflowName)
+}
+
+// WorkflowPullRequestCommand mocks base method
+func (m *MockInterface) WorkflowPullRequestCommand(projectKey, workflowName string, command sdk.PullRequestCommand) (*sdk.WorkflowRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowPullRequestCommand", projectKey, workflowName, command)
+	ret0, _ := ret[0].(*sdk.WorkflowRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowPullRequestCommand indicates an expected call of WorkflowPullRequestCommand
+func (mr *MockInterfaceMockRecorder) WorkflowPullRequestCommand(projectKey, workflowName, command interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowPullRequestCommand", reflect.TypeOf((*MockInterface)(nil).WorkflowPullRequestCommand), projectKey, workflowName, command)
+}
+
+// WorkflowRepositoryHead mocks base method
+func (m *MockInterface) WorkflowRepositoryHead(projectKey, workflowName, branch string) (*sdk.VCSBranch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowRepositoryHead", projectKey, workflowName, branch)
+	ret0, _ := ret[0].(*sdk.VCSBranch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowRepositoryHead indicates an expected call of WorkflowRepositoryHead
+func (mr *MockInterfaceMockRecorder) WorkflowRepositoryHead(projectKey, workflowName, branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowRepositoryHead", reflect.TypeOf((*MockInterface)(nil).WorkflowRepositoryHead), projectKey, workflowName, branch)
+}
+
 // MonStatus mocks base method
 func (m *MockInterface) MonStatus() (*sdk.MonitoringStatus, error) {
 	m.ctrl.T.Helper()
@@ -7918,6 +8874,20 @@ func (mr *MockWorkerInterfaceMockRecorder) QueueSendCoverage(ctx, id, report int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverage", reflect.TypeOf((*MockWorkerInterface)(nil).QueueSendCoverage), ctx, id, report)
 }
 
+// QueueSendCoverageFiles mocks base method
+func (m *MockWorkerInterface) QueueSendCoverageFiles(ctx context.Context, id int64, files []sdk.WorkflowNodeRunCoverageFile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendCoverageFiles", ctx, id, files)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueSendCoverageFiles indicates an expected call of QueueSendCoverageFiles
+func (mr *MockWorkerInterfaceMockRecorder) QueueSendCoverageFiles(ctx, id, files interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendCoverageFiles", reflect.TypeOf((*MockWorkerInterface)(nil).QueueSendCoverageFiles), ctx, id, files)
+}
+
 // QueueSendUnitTests mocks base method
 func (m *MockWorkerInterface) QueueSendUnitTests(ctx context.Context, id int64, report venom.Tests) error {
 	m.ctrl.T.Helper()
@@ -7960,6 +8930,51 @@ func (mr *MockWorkerInterfaceMockRecorder) QueueSendVulnerability(ctx, id, repor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendVulnerability", reflect.TypeOf((*MockWorkerInterface)(nil).QueueSendVulnerability), ctx, id, report)
 }
 
+// QueueSendStaticAnalysis mocks base method
+func (m *MockWorkerInterface) QueueSendStaticAnalysis(ctx context.Context, id int64, report sdk.StaticAnalysisWorkerReport) (*sdk.WorkflowNodeRunStaticAnalysisReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendStaticAnalysis", ctx, id, report)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunStaticAnalysisReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueSendStaticAnalysis indicates an expected call of QueueSendStaticAnalysis
+func (mr *MockWorkerInterfaceMockRecorder) QueueSendStaticAnalysis(ctx, id, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendStaticAnalysis", reflect.TypeOf((*MockWorkerInterface)(nil).QueueSendStaticAnalysis), ctx, id, report)
+}
+
+// QueueSendSBOM mocks base method
+func (m *MockWorkerInterface) QueueSendSBOM(ctx context.Context, id int64, name string, data io.Reader) (*sdk.WorkflowNodeRunSBOM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueSendSBOM", ctx, id, name, data)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunSBOM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueSendSBOM indicates an expected call of QueueSendSBOM
+func (mr *MockWorkerInterfaceMockRecorder) QueueSendSBOM(ctx, id, name, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueSendSBOM", reflect.TypeOf((*MockWorkerInterface)(nil).QueueSendSBOM), ctx, id, name, data)
+}
+
+// QueueArtifactProvenance mocks base method
+func (m *MockWorkerInterface) QueueArtifactProvenance(ctx context.Context, nodeJobRunID int64, tag, name string) (*sdk.WorkflowNodeRunArtifactProvenance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueArtifactProvenance", ctx, nodeJobRunID, tag, name)
+	ret0, _ := ret[0].(*sdk.WorkflowNodeRunArtifactProvenance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueArtifactProvenance indicates an expected call of QueueArtifactProvenance
+func (mr *MockWorkerInterfaceMockRecorder) QueueArtifactProvenance(ctx, nodeJobRunID, tag, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueArtifactProvenance", reflect.TypeOf((*MockWorkerInterface)(nil).QueueArtifactProvenance), ctx, nodeJobRunID, tag, name)
+}
+
 // QueueSendStepResult mocks base method
 func (m *MockWorkerInterface) QueueSendStepResult(ctx context.Context, id int64, res sdk.StepStatus) error {
 	m.ctrl.T.Helper()
@@ -8297,6 +9312,94 @@ func (mr *MockWorkerInterfaceMockRecorder) WorkflowCachePull(projectKey, integra
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCachePull", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowCachePull), projectKey, integrationName, ref)
 }
 
+// WorkflowCacheLayersMissing mocks base method
+func (m *MockWorkerInterface) WorkflowCacheLayersMissing(projectKey, integrationName string, layers []sdk.CacheLayer) ([]sdk.CacheLayer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLayersMissing", projectKey, integrationName, layers)
+	ret0, _ := ret[0].([]sdk.CacheLayer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheLayersMissing indicates an expected call of WorkflowCacheLayersMissing
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowCacheLayersMissing(projectKey, integrationName, layers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLayersMissing", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowCacheLayersMissing), projectKey, integrationName, layers)
+}
+
+// WorkflowCacheLayerPush mocks base method
+func (m *MockWorkerInterface) WorkflowCacheLayerPush(projectKey, integrationName, digest string, content io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLayerPush", projectKey, integrationName, digest, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowCacheLayerPush indicates an expected call of WorkflowCacheLayerPush
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowCacheLayerPush(projectKey, integrationName, digest, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLayerPush", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowCacheLayerPush), projectKey, integrationName, digest, content)
+}
+
+// WorkflowCacheLayerPull mocks base method
+func (m *MockWorkerInterface) WorkflowCacheLayerPull(projectKey, integrationName, digest string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLayerPull", projectKey, integrationName, digest)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheLayerPull indicates an expected call of WorkflowCacheLayerPull
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowCacheLayerPull(projectKey, integrationName, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLayerPull", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowCacheLayerPull), projectKey, integrationName, digest)
+}
+
+// WorkflowCacheManifestPush mocks base method
+func (m *MockWorkerInterface) WorkflowCacheManifestPush(projectKey, integrationName, ref string, manifest sdk.CacheManifest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheManifestPush", projectKey, integrationName, ref, manifest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowCacheManifestPush indicates an expected call of WorkflowCacheManifestPush
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowCacheManifestPush(projectKey, integrationName, ref, manifest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheManifestPush", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowCacheManifestPush), projectKey, integrationName, ref, manifest)
+}
+
+// WorkflowCacheManifestPull mocks base method
+func (m *MockWorkerInterface) WorkflowCacheManifestPull(projectKey, integrationName, ref string) (*sdk.CacheManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheManifestPull", projectKey, integrationName, ref)
+	ret0, _ := ret[0].(*sdk.CacheManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheManifestPull indicates an expected call of WorkflowCacheManifestPull
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowCacheManifestPull(projectKey, integrationName, ref interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheManifestPull", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowCacheManifestPull), projectKey, integrationName, ref)
+}
+
+// WorkflowCacheLookup mocks base method
+func (m *MockWorkerInterface) WorkflowCacheLookup(projectKey, integrationName string, restoreKeys []string) (*sdk.Cache, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowCacheLookup", projectKey, integrationName, restoreKeys)
+	ret0, _ := ret[0].(*sdk.Cache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowCacheLookup indicates an expected call of WorkflowCacheLookup
+func (mr *MockWorkerInterfaceMockRecorder) WorkflowCacheLookup(projectKey, integrationName, restoreKeys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowCacheLookup", reflect.TypeOf((*MockWorkerInterface)(nil).WorkflowCacheLookup), projectKey, integrationName, restoreKeys)
+}
+
 // WorkflowRunSearch mocks base method
 func (m *MockWorkerInterface) WorkflowRunSearch(projectKey string, offset, limit int64, filter ...cdsclient.Filter) ([]sdk.WorkflowRun, error) {
 	m.ctrl.T.Helper()
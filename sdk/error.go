@@ -201,6 +201,8 @@ var (
 	ErrInvalidWorkerModelNamePattern                 = Error{ID: 185, Status: http.StatusBadRequest}
 	ErrWorkflowAsCodeResync                          = Error{ID: 186, Status: http.StatusForbidden}
 	ErrWorkflowNodeNameDuplicate                     = Error{ID: 187, Status: http.StatusBadRequest}
+	ErrStorageQuotaExceeded                          = Error{ID: 188, Status: http.StatusRequestEntityTooLarge}
+	ErrJobDelayed                                    = Error{ID: 189, Status: http.StatusConflict}
 )
 
 var errorsAmericanEnglish = map[int]string{
@@ -384,6 +386,8 @@ var errorsAmericanEnglish = map[int]string{
 	ErrInvalidJobRequirementNetworkAccess.ID:            "Invalid job requirement: network requirement must contains ':'. Example: golang.org:http, golang.org:443",
 	ErrWorkflowAsCodeResync.ID:                          "You cannot resynchronize an as-code workflow",
 	ErrWorkflowNodeNameDuplicate.ID:                     "You cannot have same name for different pipelines in your workflow",
+	ErrStorageQuotaExceeded.ID:                          "Storage quota exceeded for this project",
+	ErrJobDelayed.ID:                                    "Job is waiting for its retry delay",
 }
 
 var errorsFrench = map[int]string{
@@ -566,6 +570,8 @@ var errorsFrench = map[int]string{
 	ErrInvalidJobRequirementNetworkAccess.ID:            "Pré-requis de job invalide: Le pré-requis network doit contenir un ':'. Exemple: golang.org:http, golang.org:443",
 	ErrWorkflowAsCodeResync.ID:                          "Impossible de resynchroniser un workflow en mode as-code",
 	ErrWorkflowNodeNameDuplicate.ID:                     "Vous ne pouvez pas avoir plusieurs fois le même nom de pipeline dans votre workflow",
+	ErrStorageQuotaExceeded.ID:                          "Quota de stockage dépassé pour ce projet",
+	ErrJobDelayed.ID:                                    "Le job attend son délai avant d'être relancé",
 }
 
 var errorsLanguages = []map[int]string{
@@ -10,11 +10,16 @@ const (
 	EventSubWorkflowRun   = "event:workflow:run"
 )
 
+// EventReplayTruncated is the type of the SSE message sent when the missed events are not all replayed
+// on reconnection, its data is the id of the last replayed event from which the events history can be listed.
+const EventReplayTruncated = "replay-truncated"
+
 // Event represents a event from API
 // Event is "create", "update", "delete"
 // Status is  "Waiting" "Building" "Success" "Fail" "Unknown", optional
 // DateEvent is a date (timestamp format)
 type Event struct {
+	ID                  int64                  `json:"id,omitempty"` // position in the events log, 0 if the event was not persisted
 	Timestamp           time.Time              `json:"timestamp"`
 	Hostname            string                 `json:"hostname"`
 	CDSName             string                 `json:"cdsname"`
@@ -35,6 +40,13 @@ type Event struct {
 	EventIntegrationsID []int64                `json:"event_integrations_id"`
 }
 
+// EventHistory is a page of the events log
+type EventHistory struct {
+	Events []Event `json:"events"`
+	// Next is the cursor to use to get the next page, 0 if there is no more events
+	Next int64 `json:"next,omitempty"`
+}
+
 // EventFilter represents filters when getting events
 type EventFilter struct {
 	CurrentItem int            `json:"current_item"`
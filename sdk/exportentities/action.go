@@ -14,6 +14,8 @@ type Action struct {
 	Parameters   map[string]ParameterValue `json:"parameters,omitempty" yaml:"parameters,omitempty"`
 	Requirements []Requirement             `json:"requirements,omitempty" yaml:"requirements,omitempty"`
 	Steps        []Step                    `json:"steps,omitempty" yaml:"steps,omitempty"`
+	// PublishedVersion if set, the imported action is published with this version
+	PublishedVersion string `json:"published_version,omitempty" yaml:"published_version,omitempty"`
 }
 
 // ActionVersion is a version
@@ -35,6 +37,9 @@ func NewAction(a sdk.Action) Action {
 	}
 
 	ea.Version = ActionVersion1
+	if a.Type == sdk.PublishedAction {
+		ea.PublishedVersion = a.Version
+	}
 	ea.Description = a.Description
 	ea.Parameters = make(map[string]ParameterValue, len(a.Parameters))
 	for k, v := range a.Parameters {
@@ -51,6 +51,29 @@ type Job struct {
 	Requirements   []Requirement `json:"requirements,omitempty" yaml:"requirements,omitempty" jsonschema_description:"The list of requirements for the jobs."`
 	Optional       *bool         `json:"optional,omitempty" yaml:"optional,omitempty" jsonschema_description:"Set this option to ignore job's errors."`
 	AlwaysExecuted *bool         `json:"always_executed,omitempty" yaml:"always_executed,omitempty" jsonschema_description:"Set this option to execute the job even if a previous step failed."`
+	Matrix         *JobMatrix    `json:"matrix,omitempty" yaml:"matrix,omitempty" jsonschema_description:"Run the job once for each combination of the matrix values, available as cds.matrix.* variables."`
+	Retry          *JobRetry     `json:"retry,omitempty" yaml:"retry,omitempty" jsonschema_description:"Put the job back in queue when it fails."`
+}
+
+// JobRetry represents an exported sdk.JobRetryPolicy
+type JobRetry struct {
+	MaxAttempts int      `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty" jsonschema_description:"The maximum number of runs of the job, including the first one."`
+	Backoff     int64    `json:"backoff,omitempty" yaml:"backoff,omitempty" jsonschema_description:"The delay in seconds before the first retry, doubled for each next one."`
+	On          []string `json:"on,omitempty" yaml:"on,omitempty" jsonschema_description:"The failure causes to retry on: worker-lost, timeout or exit-code. All causes if not set."`
+	ExitCodes   []int    `json:"exit_codes,omitempty" yaml:"exit_codes,omitempty" jsonschema_description:"The exit codes to retry on, any non-zero exit code if not set."`
+}
+
+// JobMatrix represents an exported sdk.JobMatrix
+type JobMatrix struct {
+	Axes    map[string][]string `json:"axes,omitempty" yaml:"axes,omitempty" jsonschema_description:"The values for each axis of the matrix."`
+	Include []JobMatrixInclude  `json:"include,omitempty" yaml:"include,omitempty" jsonschema_description:"Combinations to add or to extend."`
+	Exclude []map[string]string `json:"exclude,omitempty" yaml:"exclude,omitempty" jsonschema_description:"Combinations to remove."`
+}
+
+// JobMatrixInclude represents an exported sdk.JobMatrixInclude
+type JobMatrixInclude struct {
+	Values       map[string]string `json:"values,omitempty" yaml:"values,omitempty"`
+	Requirements []Requirement     `json:"requirements,omitempty" yaml:"requirements,omitempty" jsonschema_description:"The requirements overriding the job ones for the matching combinations."`
 }
 
 // Requirement represents an exported sdk.Requirement
@@ -121,7 +144,7 @@ func newStagesForPipelineV1(stages []sdk.Stage) ([]string, map[string]Stage) {
 			st.Enabled = &s.Enabled
 			hasOptions = true
 		}
-		if len(s.Conditions.PlainConditions) > 0 || s.Conditions.LuaScript != "" {
+		if !s.Conditions.IsEmpty() {
 			st.Conditions = &s.Conditions
 			hasOptions = true
 		}
@@ -169,9 +192,35 @@ func newJob(j sdk.Job) Job {
 	jo.Steps = newSteps(j.Action)
 	jo.Description = j.Action.Description
 	jo.Requirements = newRequirements(j.Action.Requirements)
+	jo.Matrix = newJobMatrix(j.Matrix)
+	if j.RetryPolicy != nil {
+		jo.Retry = &JobRetry{
+			MaxAttempts: j.RetryPolicy.MaxAttempts,
+			Backoff:     j.RetryPolicy.Backoff,
+			On:          j.RetryPolicy.On,
+			ExitCodes:   j.RetryPolicy.ExitCodes,
+		}
+	}
 	return jo
 }
 
+func newJobMatrix(m *sdk.JobMatrix) *JobMatrix {
+	if m == nil {
+		return nil
+	}
+	res := &JobMatrix{
+		Axes:    m.Axes,
+		Exclude: m.Exclude,
+	}
+	for _, i := range m.Include {
+		res.Include = append(res.Include, JobMatrixInclude{
+			Values:       i.Values,
+			Requirements: newRequirements(i.Requirements),
+		})
+	}
+	return res
+}
+
 func newJobs(jobs []sdk.Job) map[string]Job {
 	res := map[string]Job{}
 	for i := range jobs {
@@ -259,6 +308,34 @@ func computeJob(name string, j Job) (*sdk.Job, error) {
 	job.Action.Enabled = job.Enabled
 	job.Action.Requirements = computeJobRequirements(j.Requirements)
 
+	if j.Matrix != nil {
+		job.Matrix = &sdk.JobMatrix{
+			Axes:    j.Matrix.Axes,
+			Exclude: j.Matrix.Exclude,
+		}
+		for _, i := range j.Matrix.Include {
+			job.Matrix.Include = append(job.Matrix.Include, sdk.JobMatrixInclude{
+				Values:       i.Values,
+				Requirements: computeJobRequirements(i.Requirements),
+			})
+		}
+		if err := job.Matrix.IsValid(); err != nil {
+			return nil, sdk.WrapError(err, "invalid matrix for job %s", name)
+		}
+	}
+
+	if j.Retry != nil {
+		job.RetryPolicy = &sdk.JobRetryPolicy{
+			MaxAttempts: j.Retry.MaxAttempts,
+			Backoff:     j.Retry.Backoff,
+			On:          j.Retry.On,
+			ExitCodes:   j.Retry.ExitCodes,
+		}
+		if err := job.RetryPolicy.IsValid(); err != nil {
+			return nil, sdk.WrapError(err, "invalid retry for job %s", name)
+		}
+	}
+
 	//Compute steps for the jobs
 	children, err := computeSteps(j.Steps)
 	if err != nil {
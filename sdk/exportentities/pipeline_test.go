@@ -7,6 +7,7 @@ import (
 	"github.com/ovh/cds/sdk/exportentities"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 
 	"github.com/ovh/cds/engine/api/test"
@@ -535,6 +536,49 @@ jobs:
 	assert.Len(t, p.Stages[0].Jobs[0].Action.Requirements, 2)
 }
 
+func Test_ImportPipelineWithMatrix(t *testing.T) {
+	in := `name: build
+jobs:
+- job: test
+  requirements:
+  - model: golang
+  matrix:
+    axes:
+      go: ["1.13", "1.14"]
+      os: [linux, darwin]
+    exclude:
+    - go: "1.13"
+      os: darwin
+    include:
+    - values:
+        go: "1.14"
+      requirements:
+      - model: golang-1.14
+  steps:
+  - script: go test ./...
+`
+
+	payload := &exportentities.PipelineV1{}
+	test.NoError(t, yaml.Unmarshal([]byte(in), payload))
+
+	p, err := payload.Pipeline()
+	test.NoError(t, err)
+
+	job := p.Stages[0].Jobs[0]
+	require.NotNil(t, job.Matrix)
+	assert.Len(t, job.Matrix.Axes, 2)
+	assert.Len(t, job.ExpandMatrix(), 3)
+
+	exported := exportentities.NewPipelineV1(*p)
+	require.NotNil(t, exported.Jobs[0].Matrix)
+	assert.Equal(t, payload.Jobs[0].Matrix.Axes, exported.Jobs[0].Matrix.Axes)
+	assert.Equal(t, "golang-1.14", exported.Jobs[0].Matrix.Include[0].Requirements[0].Model)
+
+	payload.Jobs[0].Matrix.Exclude = []map[string]string{{"arch": "amd64"}}
+	_, err = payload.Pipeline()
+	assert.Error(t, err)
+}
+
 func Test_ImportPipelineWithGitClone(t *testing.T) {
 	in := `name: build-all-images
 jobs:
@@ -3,7 +3,6 @@ package exportentities
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"reflect"
 	"strings"
 
@@ -49,6 +48,20 @@ func newStep(act sdk.Action) Step {
 			if minimum != nil {
 				s.Coverage.Minimum = minimum.Value
 			}
+		case sdk.StaticAnalysisAction:
+			s.StaticAnalysis = &StepStaticAnalysis{}
+			path := sdk.ParameterFind(act.Parameters, "path")
+			if path != nil {
+				s.StaticAnalysis.Path = path.Value
+			}
+			severity := sdk.ParameterFind(act.Parameters, "severity")
+			if severity != nil && severity.Value != "none" {
+				s.StaticAnalysis.Severity = severity.Value
+			}
+			newOnly := sdk.ParameterFind(act.Parameters, "newOnly")
+			if newOnly != nil && newOnly.Value != "false" {
+				s.StaticAnalysis.NewOnly = newOnly.Value
+			}
 		case sdk.ArtifactDownload:
 			s.ArtifactDownload = &StepArtifactDownload{}
 			path := sdk.ParameterFind(act.Parameters, "path")
@@ -226,14 +239,9 @@ func newStep(act sdk.Action) Step {
 			}
 		}
 
-		name := act.Name
-		// Do not export "shared.infra" group name
-		if act.Group != nil && act.Group.Name != sdk.SharedInfraGroupName {
-			name = fmt.Sprintf("%s/%s", act.Group.Name, act.Name)
-		}
-
+		// Do not export "shared.infra" group name, add the version for published action
 		s.StepCustom = StepCustom{
-			name: args,
+			act.Path(): args,
 		}
 	}
 	return s
@@ -252,6 +260,13 @@ type StepCoverage struct {
 	Path    string `json:"path,omitempty" yaml:"path,omitempty"`
 }
 
+// StepStaticAnalysis represents exported static analysis step.
+type StepStaticAnalysis struct {
+	NewOnly  string `json:"newOnly,omitempty" yaml:"newOnly,omitempty"`
+	Path     string `json:"path,omitempty" yaml:"path,omitempty" jsonschema:"required"`
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+}
+
 // StepArtifactDownload represents exported artifact download step.
 type StepArtifactDownload struct {
 	Path    string `json:"path,omitempty" yaml:"path,omitempty" jsonschema:"required"`
@@ -340,6 +355,7 @@ type Step struct {
 	Checkout         *StepCheckout         `json:"checkout,omitempty" yaml:"checkout,omitempty" jsonschema:"oneof_required=actionCheckout" jsonschema_description:"Checkout repository for an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-checkoutapplication"`
 	InstallKey       *StepInstallKey       `json:"installKey,omitempty" yaml:"installKey,omitempty" jsonschema:"oneof_required=actionInstallKey" jsonschema_description:"Install a key (GPG, SSH) in your current workspace.\nhttps://ovh.github.io/cds/docs/actions/builtin-installkey"`
 	Deploy           *StepDeploy           `json:"deploy,omitempty" yaml:"deploy,omitempty" jsonschema:"oneof_required=actionDeploy" jsonschema_description:"Deploy an application.\nhttps://ovh.github.io/cds/docs/actions/builtin-deployapplication"`
+	StaticAnalysis   *StepStaticAnalysis   `json:"staticAnalysis,omitempty" yaml:"staticAnalysis,omitempty" jsonschema:"oneof_required=actionStaticAnalysis" jsonschema_description:"Parse static analysis reports (SARIF).\nhttps://ovh.github.io/cds/docs/actions/builtin-staticanalysis"`
 }
 
 // MarshalJSON custom marshal json impl to inline custom step.
@@ -456,6 +472,9 @@ func (s Step) IsValid() bool {
 	if s.isCoverage() {
 		count++
 	}
+	if s.isStaticAnalysis() {
+		count++
+	}
 	if s.isScript() {
 		count++
 	}
@@ -493,6 +512,8 @@ func (s Step) toAction() (*sdk.Action, error) {
 		a = s.asDeployApplication()
 	} else if s.isCoverage() {
 		a, err = s.asCoverage()
+	} else if s.isStaticAnalysis() {
+		a, err = s.asStaticAnalysis()
 	} else if s.isScript() {
 		a, err = s.asScript()
 	} else {
@@ -638,6 +659,22 @@ func (s Step) asCoverage() (sdk.Action, error) {
 	return a, nil
 }
 
+func (s Step) isStaticAnalysis() bool { return s.StaticAnalysis != nil }
+
+func (s Step) asStaticAnalysis() (sdk.Action, error) {
+	var a sdk.Action
+	m, err := stepToMap(s.StaticAnalysis)
+	if err != nil {
+		return a, err
+	}
+	a = sdk.Action{
+		Name:       sdk.StaticAnalysisAction,
+		Type:       sdk.BuiltinAction,
+		Parameters: sdk.ParametersFromMap(m),
+	}
+	return a, nil
+}
+
 func (s Step) isDeploy() bool { return s.Deploy != nil }
 
 func (s Step) asDeployApplication() sdk.Action {
@@ -755,7 +792,13 @@ func (s Step) asAction() sdk.Action {
 		Parameters: []sdk.Parameter{},
 	}
 
-	splitted := strings.Split(name, "/")
+	// name could be suffixed by a published action version like group/name@v1
+	if i := strings.LastIndex(a.Name, "@"); i > 0 {
+		a.Version = a.Name[i+1:]
+		a.Name = a.Name[:i]
+	}
+
+	splitted := strings.Split(a.Name, "/")
 	if len(splitted) == 2 {
 		a.Name = splitted[1]
 		a.Group = &sdk.Group{Name: splitted[0]}
@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v2"
 
+	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/exportentities"
 )
 
@@ -31,6 +33,18 @@ var tests = []struct {
 		Json: `{"group/action":{"param1":"value1","param2":"value2"}}`,
 		Yaml: "group/action:\n  param1: value1\n  param2: value2\n",
 	},
+	{
+		Name: "Step with published custom action",
+		Step: exportentities.Step{
+			StepCustom: exportentities.StepCustom{
+				"group/action@v2": map[string]string{
+					"param1": "value1",
+				},
+			},
+		},
+		Json: `{"group/action@v2":{"param1":"value1"}}`,
+		Yaml: "group/action@v2:\n  param1: value1\n",
+	},
 	{
 		Name: "Step with typed action",
 		Step: exportentities.Step{
@@ -97,3 +111,37 @@ func TestUnMarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishedActionStep(t *testing.T) {
+	ea := exportentities.Action{
+		Name: "my-action",
+		Steps: []exportentities.Step{
+			{StepCustom: exportentities.StepCustom{"group/action@v2": map[string]string{"param1": "value1"}}},
+			{StepCustom: exportentities.StepCustom{"action@1.0.0": nil}},
+			{StepCustom: exportentities.StepCustom{"group/action": nil}},
+		},
+	}
+
+	a, err := ea.GetAction()
+	require.NoError(t, err)
+	require.Len(t, a.Actions, 3)
+	assert.Equal(t, "action", a.Actions[0].Name)
+	assert.Equal(t, "group", a.Actions[0].Group.Name)
+	assert.Equal(t, "v2", a.Actions[0].Version)
+	assert.Equal(t, "action", a.Actions[1].Name)
+	assert.Nil(t, a.Actions[1].Group)
+	assert.Equal(t, "1.0.0", a.Actions[1].Version)
+	assert.Equal(t, "", a.Actions[2].Version)
+
+	a.Actions[0].Type = sdk.PublishedAction
+	exported := exportentities.NewAction(sdk.Action{
+		Name:    "my-action",
+		Type:    sdk.PublishedAction,
+		Version: "v1",
+		Enabled: true,
+		Actions: a.Actions[:1],
+	})
+	assert.Equal(t, "v1", exported.PublishedVersion)
+	require.Len(t, exported.Steps, 1)
+	assert.Contains(t, exported.Steps[0].StepCustom, "group/action@v2")
+}
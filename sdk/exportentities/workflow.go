@@ -32,6 +32,7 @@ type Workflow struct {
 	Hooks    map[string][]HookEntry `json:"hooks,omitempty" yaml:"hooks,omitempty" jsonschema_description:"Workflow hooks list."`
 	// this will be filled for simple workflows
 	OneAtATime             *bool                  `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty" jsonschema_description:"Set to true if you want to limit the execution of this node to one at a time."`
+	Concurrency            *ConcurrencyEntry      `json:"concurrency,omitempty" yaml:"concurrency,omitempty" jsonschema_description:"Concurrency group of the node, only one node of a project runs at a time for a group.\nhttps://ovh.github.io/cds/docs/concepts/workflow/concurrency"`
 	Conditions             *ConditionEntry        `json:"conditions,omitempty" yaml:"conditions,omitempty" jsonschema_description:"Conditions to run this node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/run-conditions."`
 	When                   []string               `json:"when,omitempty" yaml:"when,omitempty" jsonschema_description:"Set manual and status condition (ex: 'success')."` //This is used only for manual and success condition
 	PipelineName           string                 `json:"pipeline,omitempty" yaml:"pipeline,omitempty" jsonschema_description:"The name of a pipeline used for pipeline node."`
@@ -47,6 +48,7 @@ type Workflow struct {
 	PurgeTags        []string                       `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty"`
 	Notifications    []NotificationEntry            `json:"notify,omitempty" yaml:"notify,omitempty"` // This is used when the workflow have only one pipeline
 	HistoryLength    *int64                         `json:"history_length,omitempty" yaml:"history_length,omitempty"`
+	RetentionPolicy  *sdk.WorkflowRetentionPolicy   `json:"retention_policy,omitempty" yaml:"retention_policy,omitempty" jsonschema_description:"The retention rules of the workflow runs, replaces the history length.\nhttps://ovh.github.io/cds/docs/concepts/workflow/retention"`
 	MapNotifications map[string][]NotificationEntry `json:"notifications,omitempty" yaml:"notifications,omitempty"` // This is used when the workflow have more than one pipeline
 }
 
@@ -75,6 +77,7 @@ type NodeEntry struct {
 	EnvironmentName        string                 `json:"environment,omitempty" yaml:"environment,omitempty" jsonschema_description:"The environment to use in the context of the node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/pipeline-context"`
 	ProjectIntegrationName string                 `json:"integration,omitempty" yaml:"integration,omitempty" jsonschema_description:"The integration to use in the context of the node.\nhttps://ovh.github.io/cds/docs/concepts/workflow/pipeline-context"`
 	OneAtATime             *bool                  `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty" jsonschema_description:"Set to true if you want to limit the execution of this node to one at a time."`
+	Concurrency            *ConcurrencyEntry      `json:"concurrency,omitempty" yaml:"concurrency,omitempty" jsonschema_description:"Concurrency group of the node, only one node of a project runs at a time for a group.\nhttps://ovh.github.io/cds/docs/concepts/workflow/concurrency"`
 	Payload                map[string]interface{} `json:"payload,omitempty" yaml:"payload,omitempty"`
 	Parameters             map[string]string      `json:"parameters,omitempty" yaml:"parameters,omitempty" jsonschema_description:"List of parameters for the workflow."`
 	OutgoingHookModelName  string                 `json:"trigger,omitempty" yaml:"trigger,omitempty"`
@@ -84,9 +87,16 @@ type NodeEntry struct {
 
 type ConditionEntry struct {
 	PlainConditions []PlainConditionEntry `json:"plain,omitempty" yaml:"check,omitempty"`
+	Expression      string                `json:"expression,omitempty" yaml:"expression,omitempty" jsonschema_description:"Condition expression (ex: git.branch == 'master' && cds.version > 10)."`
 	LuaScript       string                `json:"script,omitempty" yaml:"script,omitempty"`
 }
 
+// ConcurrencyEntry represents the concurrency group of a node as code
+type ConcurrencyEntry struct {
+	Group            string `json:"group" yaml:"group" jsonschema_description:"Name of the concurrency group, it can contain variables (ex: deploy-{{.cds.env.name}})."`
+	CancelInProgress bool   `json:"cancel_in_progress,omitempty" yaml:"cancel_in_progress,omitempty" jsonschema_description:"Set to true to stop the node runs of the group in progress on the same branch when a new one starts."`
+}
+
 //WorkflowNodeCondition represents a condition to trigger ot not a pipeline in a workflow. Operator can be =, !=, regex
 type PlainConditionEntry struct {
 	Variable string `json:"variable" yaml:"variable"`
@@ -165,9 +175,10 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 			}
 		}
 
-		if len(conditions) > 0 || n.Context.Conditions.LuaScript != "" {
+		if len(conditions) > 0 || n.Context.Conditions.Expression != "" || n.Context.Conditions.LuaScript != "" {
 			entry.Conditions = &ConditionEntry{
 				PlainConditions: make([]PlainConditionEntry, 0, len(conditions)),
+				Expression:      n.Context.Conditions.Expression,
 				LuaScript:       n.Context.Conditions.LuaScript,
 			}
 			for _, c := range conditions {
@@ -193,6 +204,13 @@ func craftNodeEntry(w sdk.Workflow, n sdk.Node) (NodeEntry, error) {
 			entry.OneAtATime = &n.Context.Mutex
 		}
 
+		if n.Context.Concurrency != nil {
+			entry.Concurrency = &ConcurrencyEntry{
+				Group:            n.Context.Concurrency.Group,
+				CancelInProgress: n.Context.Concurrency.CancelInProgress,
+			}
+		}
+
 		if n.Context.HasDefaultPayload() {
 			enc := dump.NewDefaultEncoder()
 			enc.ExtraFields.DetailedMap = false
@@ -296,8 +314,20 @@ func WorkflowSkipIfOnlyOneRepoWebhook(w sdk.Workflow, exportedWorkflow *Workflow
 	return nil
 }
 
+// exportedHookModel returns the model of the hook, hook models that are not builtin are provided by plugins
+// and have to be loaded in the workflow.
+func exportedHookModel(w sdk.Workflow, h sdk.NodeHook) *sdk.WorkflowHookModel {
+	if m := sdk.GetBuiltinHookModelByName(h.HookModelName); m != nil {
+		return m
+	}
+	if m, ok := w.HookModels[h.HookModelID]; ok && m.Name == h.HookModelName {
+		return &m
+	}
+	return nil
+}
+
 func joinAsNode(n *sdk.Node) bool {
-	return n.Context != nil && (n.Context.Conditions.LuaScript != "" || len(n.Context.Conditions.PlainConditions) > 0)
+	return n.Context != nil && !n.Context.Conditions.IsEmpty()
 }
 
 //NewWorkflow creates a new exportable workflow
@@ -323,6 +353,7 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, opts ...WorkflowOptions) (
 	}
 
 	exportedWorkflow.PurgeTags = w.PurgeTags
+	exportedWorkflow.RetentionPolicy = w.RetentionPolicy
 
 	nodes := w.WorkflowData.Array()
 
@@ -337,7 +368,8 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, opts ...WorkflowOptions) (
 		exportedWorkflow.EnvironmentName = entry.EnvironmentName
 		exportedWorkflow.ProjectIntegrationName = entry.ProjectIntegrationName
 		exportedWorkflow.OneAtATime = entry.OneAtATime
-		if entry.Conditions != nil && (len(entry.Conditions.PlainConditions) > 0 || entry.Conditions.LuaScript != "") {
+		exportedWorkflow.Concurrency = entry.Concurrency
+		if entry.Conditions != nil && (len(entry.Conditions.PlainConditions) > 0 || entry.Conditions.Expression != "" || entry.Conditions.LuaScript != "") {
 			exportedWorkflow.When = entry.When
 			exportedWorkflow.Conditions = entry.Conditions
 		}
@@ -346,7 +378,7 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, opts ...WorkflowOptions) (
 				exportedWorkflow.Hooks = make(map[string][]HookEntry)
 			}
 
-			m := sdk.GetBuiltinHookModelByName(h.HookModelName)
+			m := exportedHookModel(w, h)
 			if m == nil {
 				return exportedWorkflow, sdk.WrapError(sdk.ErrNotFound, "unable to find hook model %s", h.HookModelName)
 			}
@@ -357,7 +389,7 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, opts ...WorkflowOptions) (
 				Conditions: &h.Conditions,
 			}
 
-			if h.Conditions.LuaScript == "" && len(h.Conditions.PlainConditions) == 0 {
+			if h.Conditions.IsEmpty() {
 				pipHook.Conditions = nil
 			}
 
@@ -382,7 +414,7 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, opts ...WorkflowOptions) (
 					exportedWorkflow.Hooks = make(map[string][]HookEntry)
 				}
 
-				m := sdk.GetBuiltinHookModelByName(h.HookModelName)
+				m := exportedHookModel(w, h)
 				if m == nil {
 					return exportedWorkflow, sdk.WrapError(sdk.ErrNotFound, "unable to find hook model %s", h.HookModelName)
 				}
@@ -392,7 +424,7 @@ func NewWorkflow(ctx context.Context, w sdk.Workflow, opts ...WorkflowOptions) (
 					Conditions: &h.Conditions,
 				}
 
-				if h.Conditions.LuaScript == "" && len(h.Conditions.PlainConditions) == 0 {
+				if h.Conditions.IsEmpty() {
 					pipHook.Conditions = nil
 				}
 
@@ -436,6 +468,7 @@ func (w Workflow) Entries() map[string]NodeEntry {
 		Payload:                w.Payload,
 		Parameters:             w.Parameters,
 		OneAtATime:             w.OneAtATime,
+		Concurrency:            w.Concurrency,
 	}
 	return map[string]NodeEntry{
 		w.PipelineName: singleEntry,
@@ -549,6 +582,12 @@ func (w Workflow) GetWorkflow() (*sdk.Workflow, error) {
 	} else {
 		wf.HistoryLength = sdk.DefaultHistoryLength
 	}
+	if w.RetentionPolicy != nil {
+		if err := w.RetentionPolicy.IsValid(); err != nil {
+			return nil, err
+		}
+		wf.RetentionPolicy = w.RetentionPolicy
+	}
 
 	rand.Seed(time.Now().Unix())
 	entries := w.Entries()
@@ -647,6 +686,7 @@ func (e *NodeEntry) getNode(name string, w *sdk.Workflow) (*sdk.Node, error) {
 	if e.Conditions != nil {
 		node.Context.Conditions = sdk.WorkflowNodeConditions{
 			PlainConditions: make([]sdk.WorkflowNodeCondition, 0, len(e.Conditions.PlainConditions)),
+			Expression:      e.Conditions.Expression,
 			LuaScript:       e.Conditions.LuaScript,
 		}
 		for _, c := range e.Conditions.PlainConditions {
@@ -691,6 +731,16 @@ func (e *NodeEntry) getNode(name string, w *sdk.Workflow) (*sdk.Node, error) {
 		node.Context.Mutex = *e.OneAtATime
 	}
 
+	if e.Concurrency != nil {
+		if strings.TrimSpace(e.Concurrency.Group) == "" {
+			return nil, sdk.NewErrorFrom(sdk.ErrWrongRequest, "concurrency group of node %s should not be empty", name)
+		}
+		node.Context.Concurrency = &sdk.NodeConcurrency{
+			Group:            e.Concurrency.Group,
+			CancelInProgress: e.Concurrency.CancelInProgress,
+		}
+	}
+
 	if e.OutgoingHookModelName != "" {
 		node.Type = sdk.NodeTypeOutGoingHook
 		config := sdk.WorkflowNodeHookConfig{}
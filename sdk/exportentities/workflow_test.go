@@ -211,6 +211,8 @@ func TestWorkflow_GetWorkflow(t *testing.T) {
 		Permissions            map[string]int
 		HistoryLength          int64
 		OneAtATime             *bool
+		Concurrency            *exportentities.ConcurrencyEntry
+		RetentionPolicy        *sdk.WorkflowRetentionPolicy
 	}
 	tsts := []struct {
 		name    string
@@ -241,6 +243,77 @@ func TestWorkflow_GetWorkflow(t *testing.T) {
 			},
 		},
 		// pipeline
+		{
+			name: "Simple workflow with concurrency group should not raise an error",
+			fields: fields{
+				PipelineName: "pipeline",
+				Concurrency: &exportentities.ConcurrencyEntry{
+					Group:            "deploy-{{.cds.env.name}}",
+					CancelInProgress: true,
+				},
+			},
+			wantErr: false,
+			want: sdk.Workflow{
+				HistoryLength: sdk.DefaultHistoryLength,
+				WorkflowData: &sdk.WorkflowData{
+					Node: sdk.Node{
+						Name: "pipeline",
+						Type: "pipeline",
+						Context: &sdk.NodeContext{
+							PipelineName: "pipeline",
+							Concurrency: &sdk.NodeConcurrency{
+								Group:            "deploy-{{.cds.env.name}}",
+								CancelInProgress: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Simple workflow with empty concurrency group should raise an error",
+			fields: fields{
+				PipelineName: "pipeline",
+				Concurrency:  &exportentities.ConcurrencyEntry{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Simple workflow with retention policy should not raise an error",
+			fields: fields{
+				PipelineName: "pipeline",
+				RetentionPolicy: &sdk.WorkflowRetentionPolicy{
+					KeepPerBranch: 5,
+					KeepTags:      []string{"git.tag"},
+				},
+			},
+			wantErr: false,
+			want: sdk.Workflow{
+				HistoryLength: sdk.DefaultHistoryLength,
+				RetentionPolicy: &sdk.WorkflowRetentionPolicy{
+					KeepPerBranch: 5,
+					KeepTags:      []string{"git.tag"},
+				},
+				WorkflowData: &sdk.WorkflowData{
+					Node: sdk.Node{
+						Name: "pipeline",
+						Type: "pipeline",
+						Context: &sdk.NodeContext{
+							PipelineName: "pipeline",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Simple workflow with invalid retention policy should raise an error",
+			fields: fields{
+				PipelineName:    "pipeline",
+				RetentionPolicy: &sdk.WorkflowRetentionPolicy{KeepPerBranch: -1},
+			},
+			wantErr: true,
+		},
+		// pipeline
 		{
 			name: "Simple workflow should not raise an error",
 			fields: fields{
@@ -789,6 +862,8 @@ func TestWorkflow_GetWorkflow(t *testing.T) {
 				Permissions:            tt.fields.Permissions,
 				HistoryLength:          &tt.fields.HistoryLength,
 				OneAtATime:             tt.fields.OneAtATime,
+				Concurrency:            tt.fields.Concurrency,
+				RetentionPolicy:        tt.fields.RetentionPolicy,
 			}
 			got, err := w.GetWorkflow()
 			if (err != nil) != tt.wantErr {
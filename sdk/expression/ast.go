@@ -0,0 +1,337 @@
+package expression
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+type valueType int
+
+const (
+	typeString valueType = iota
+	typeBool
+	typeList
+)
+
+func (t valueType) String() string {
+	switch t {
+	case typeBool:
+		return "boolean"
+	case typeList:
+		return "list"
+	}
+	return "string"
+}
+
+type value struct {
+	t valueType
+	s string
+	b bool
+	l []string
+}
+
+type node interface {
+	typ() valueType
+	position() int
+	eval(vars map[string]string) (value, error)
+}
+
+type literalNode struct {
+	pos   int
+	value value
+}
+
+func (n *literalNode) typ() valueType { return n.value.t }
+func (n *literalNode) position() int  { return n.pos }
+func (n *literalNode) eval(map[string]string) (value, error) {
+	return n.value, nil
+}
+
+type variableNode struct {
+	pos  int
+	name string
+}
+
+func (n *variableNode) typ() valueType { return typeString }
+func (n *variableNode) position() int  { return n.pos }
+func (n *variableNode) eval(vars map[string]string) (value, error) {
+	return value{t: typeString, s: vars[n.name]}, nil
+}
+
+type definedNode struct {
+	pos  int
+	name string
+}
+
+func (n *definedNode) typ() valueType { return typeBool }
+func (n *definedNode) position() int  { return n.pos }
+func (n *definedNode) eval(vars map[string]string) (value, error) {
+	_, has := vars[n.name]
+	return value{t: typeBool, b: has}, nil
+}
+
+type listNode struct {
+	pos   int
+	items []node
+}
+
+func (n *listNode) typ() valueType { return typeList }
+func (n *listNode) position() int  { return n.pos }
+func (n *listNode) eval(vars map[string]string) (value, error) {
+	res := value{t: typeList, l: make([]string, 0, len(n.items))}
+	for _, i := range n.items {
+		v, err := i.eval(vars)
+		if err != nil {
+			return value{}, err
+		}
+		res.l = append(res.l, v.s)
+	}
+	return res, nil
+}
+
+type notNode struct {
+	pos     int
+	operand node
+}
+
+func (n *notNode) typ() valueType { return typeBool }
+func (n *notNode) position() int  { return n.pos }
+func (n *notNode) eval(vars map[string]string) (value, error) {
+	v, err := n.operand.eval(vars)
+	if err != nil {
+		return value{}, err
+	}
+	return value{t: typeBool, b: !v.b}, nil
+}
+
+type logicalNode struct {
+	pos         int
+	op          tokenKind
+	left, right node
+}
+
+func (n *logicalNode) typ() valueType { return typeBool }
+func (n *logicalNode) position() int  { return n.left.position() }
+func (n *logicalNode) eval(vars map[string]string) (value, error) {
+	l, err := n.left.eval(vars)
+	if err != nil {
+		return value{}, err
+	}
+	// Short-circuit evaluation
+	if n.op == tokenAnd && !l.b {
+		return l, nil
+	}
+	if n.op == tokenOr && l.b {
+		return l, nil
+	}
+	return n.right.eval(vars)
+}
+
+type compareNode struct {
+	pos         int
+	op          tokenKind
+	left, right node
+}
+
+func (n *compareNode) typ() valueType { return typeBool }
+func (n *compareNode) position() int  { return n.left.position() }
+func (n *compareNode) eval(vars map[string]string) (value, error) {
+	l, err := n.left.eval(vars)
+	if err != nil {
+		return value{}, err
+	}
+	r, err := n.right.eval(vars)
+	if err != nil {
+		return value{}, err
+	}
+
+	if l.t == typeBool {
+		eq := l.b == r.b
+		return value{t: typeBool, b: eq == (n.op == tokenEq)}, nil
+	}
+
+	c := compare(l.s, r.s)
+	var res bool
+	switch n.op {
+	case tokenEq:
+		res = c == 0
+	case tokenNe:
+		res = c != 0
+	case tokenLt:
+		res = c < 0
+	case tokenLe:
+		res = c <= 0
+	case tokenGt:
+		res = c > 0
+	case tokenGe:
+		res = c >= 0
+	}
+	return value{t: typeBool, b: res}, nil
+}
+
+type matchNode struct {
+	pos         int
+	negate      bool
+	left, right node
+	regexp      *regexp.Regexp
+}
+
+func (n *matchNode) typ() valueType { return typeBool }
+func (n *matchNode) position() int  { return n.left.position() }
+func (n *matchNode) eval(vars map[string]string) (value, error) {
+	l, err := n.left.eval(vars)
+	if err != nil {
+		return value{}, err
+	}
+	rx := n.regexp
+	if rx == nil {
+		r, err := n.right.eval(vars)
+		if err != nil {
+			return value{}, err
+		}
+		rx, err = regexp.Compile(r.s)
+		if err != nil {
+			return value{}, fmt.Errorf("invalid regular expression %q: %v", r.s, err)
+		}
+	}
+	return value{t: typeBool, b: rx.MatchString(l.s) != n.negate}, nil
+}
+
+// containsNode checks if a list contains an item or if a string contains a substring
+type containsNode struct {
+	pos         int
+	left, right node
+}
+
+func (n *containsNode) typ() valueType { return typeBool }
+func (n *containsNode) position() int  { return n.pos }
+func (n *containsNode) eval(vars map[string]string) (value, error) {
+	l, err := n.left.eval(vars)
+	if err != nil {
+		return value{}, err
+	}
+	r, err := n.right.eval(vars)
+	if err != nil {
+		return value{}, err
+	}
+	if l.t == typeString {
+		return value{t: typeBool, b: strings.Contains(l.s, r.s)}, nil
+	}
+	for _, s := range l.l {
+		if compare(s, r.s) == 0 {
+			return value{t: typeBool, b: true}, nil
+		}
+	}
+	return value{t: typeBool, b: false}, nil
+}
+
+type callNode struct {
+	pos      int
+	name     string
+	function function
+	args     []node
+}
+
+func (n *callNode) typ() valueType { return n.function.returns }
+func (n *callNode) position() int  { return n.pos }
+func (n *callNode) eval(vars map[string]string) (value, error) {
+	args := make([]value, len(n.args))
+	for i := range n.args {
+		var err error
+		args[i], err = n.args[i].eval(vars)
+		if err != nil {
+			return value{}, err
+		}
+	}
+	v, err := n.function.call(args)
+	if err != nil {
+		return value{}, fmt.Errorf("%s: %v", n.name, err)
+	}
+	return v, nil
+}
+
+func (n *callNode) isConstant() bool {
+	for _, a := range n.args {
+		switch x := a.(type) {
+		case *literalNode:
+		case *callNode:
+			if !x.isConstant() {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// decimalRegex matches the values compared as numbers, other notations accepted by strconv.ParseFloat
+// like "Inf", "NaN", "1e3" or "0x10" are compared as strings.
+var decimalRegex = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// compare compares two values as dotted numbers (1.9 < 1.10), then as decimal numbers, then as semantic versions
+// and finally as strings.
+func compare(a, b string) int {
+	if pa, ok := dottedNumber(a); ok {
+		if pb, ok := dottedNumber(b); ok {
+			return compareDottedNumbers(pa, pb)
+		}
+	}
+	if decimalRegex.MatchString(a) && decimalRegex.MatchString(b) {
+		fa, errA := strconv.ParseFloat(a, 64)
+		fb, errB := strconv.ParseFloat(b, 64)
+		if errA == nil && errB == nil {
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			}
+			return 0
+		}
+	}
+	if va, err := semver.ParseTolerant(a); err == nil {
+		if vb, err := semver.ParseTolerant(b); err == nil {
+			return va.Compare(vb)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// dottedNumber returns the components of a value made of integers separated by dots.
+func dottedNumber(s string) ([]uint64, bool) {
+	parts := strings.Split(s, ".")
+	res := make([]uint64, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		res[i] = n
+	}
+	return res, true
+}
+
+// compareDottedNumbers compares the components one at a time, missing components are zeros.
+func compareDottedNumbers(a, b []uint64) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y uint64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,107 @@
+// Package expression implements the condition expression language used to
+// decide if a workflow node, a stage or a hook has to be triggered.
+//
+// An expression is made of comparisons between variables (ex: git.branch,
+// cds.version) and literals combined with boolean operators:
+//
+//	git.branch == "master" && (cds.version > 9 || git.tag =~ "^v[0-9]+")
+//	not (git.author in ["bot", "renovate"]) and semver(git.tag) >= "1.2.0"
+//
+// Comparison operators are numeric when both operands are numbers, semver
+// when both operands are versions and lexical otherwise. Expressions are
+// type checked at parse time so that errors are reported before any run.
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyntaxError is returned by Parse when an expression is not valid.
+type SyntaxError struct {
+	Expression string
+	Offset     int
+	Line       int
+	Column     int
+	Message    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+func newSyntaxError(input string, offset int, msg string) *SyntaxError {
+	line, column := 1, 1
+	for i := 0; i < offset && i < len(input); i++ {
+		if input[i] == '\n' {
+			line++
+			column = 1
+			continue
+		}
+		column++
+	}
+	return &SyntaxError{
+		Expression: input,
+		Offset:     offset,
+		Line:       line,
+		Column:     column,
+		Message:    msg,
+	}
+}
+
+// Expression is a parsed and type checked condition expression.
+type Expression struct {
+	input string
+	root  node
+}
+
+// Parse parses and type checks the given expression.
+func Parse(input string) (*Expression, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, newSyntaxError(input, 0, "empty expression")
+	}
+
+	l := lexer{input: input}
+	tokens, err := l.all()
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{input: input, tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t.pos, "unexpected %s", t)
+	}
+	if root.typ() != typeBool {
+		return nil, p.errorf(root.position(), "expression must be a boolean but is a %s", root.typ())
+	}
+
+	return &Expression{input: input, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.input
+}
+
+// Eval evaluates the expression with given variables. Unknown variables
+// are evaluated as empty strings.
+func (e *Expression) Eval(vars map[string]string) (bool, error) {
+	v, err := e.root.eval(vars)
+	if err != nil {
+		return false, err
+	}
+	return v.b, nil
+}
+
+// Eval parses then evaluates given expression.
+func Eval(input string, vars map[string]string) (bool, error) {
+	e, err := Parse(input)
+	if err != nil {
+		return false, err
+	}
+	return e.Eval(vars)
+}
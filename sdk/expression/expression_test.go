@@ -0,0 +1,113 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]string{
+		"git.branch":         "master",
+		"git.tag":            "v1.10.0",
+		"git.author":         "john",
+		"cds.version":        "12",
+		"cds.env.name":       "production",
+		"cds.tags":           "a, b,c",
+		"workflow.pip-1.foo": "bar",
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`git.branch == "master"`, true},
+		{`git.branch != 'master'`, false},
+		{`cds.version > 9`, true},
+		{`cds.version >= 12.0`, true},
+		{`cds.version < 9`, false},
+		{`"1.9" < "1.10"`, true},
+		{`"1.10" > "1.9"`, true},
+		{`"1.10.0" == "1.10"`, true},
+		{`"2.0" > "1.10"`, true},
+		{`"-1.5" < "1"`, true},
+		{`"NaN" > "1"`, true},
+		{`"Inf" > "1"`, true},
+		{`"1e3" < "2"`, true},
+		{`git.tag > "v1.9.0"`, true},
+		{`semver(git.tag) >= semver("1.2")`, true},
+		{`git.branch == "master" && cds.version > 20`, false},
+		{`git.branch == "master" and (cds.version > 20 or git.author == "john")`, true},
+		{`!(git.branch == "master")`, false},
+		{`not git.branch == "dev"`, true},
+		{`git.author in ["john", "doe"]`, true},
+		{`git.author not in ["john", "doe"]`, false},
+		{`cds.version in [10, 12]`, true},
+		{`git.branch contains "ast"`, true},
+		{`split(cds.tags, ",") contains "b"`, true},
+		{`"c" in split(cds.tags, ",")`, true},
+		{`git.branch =~ "^mas"`, true},
+		{`git.branch !~ "^mas"`, false},
+		{`startsWith(cds.env.name, "prod") && endsWith(cds.env.name, "tion")`, true},
+		{`upper(git.author) == "JOHN"`, true},
+		{`defined(git.tag) && !defined(git.unknown)`, true},
+		{`empty(git.unknown)`, true},
+		{`len(git.author) == 4`, true},
+		{`workflow.pip-1.foo == "bar"`, true},
+		{`true`, true},
+		{`empty(git.author) == false`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := Eval(tt.expr, vars)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		line   int
+		column int
+		msg    string
+	}{
+		{``, 1, 1, "empty expression"},
+		{`git.branch = "master"`, 1, 12, "unexpected '=', did you mean '=='?"},
+		{`git.branch == "master`, 1, 15, "unterminated string"},
+		{`(git.branch == "master"`, 1, 24, "expected ')' but found end of expression"},
+		{`git.branch == "master" &&`, 1, 26, "expected a value but found end of expression"},
+		{`git.branch`, 1, 1, "expression must be a boolean but is a string"},
+		{`git.branch && true`, 1, 1, "operator && expects a boolean but found a string"},
+		{`git.branch in "master"`, 1, 15, "operator in expects a list but found a string"},
+		{`git.branch =~ "[a-"`, 1, 15, "invalid regular expression: error parsing regexp: missing closing ]: `[a-`"},
+		{`foo(git.branch)`, 1, 1, "unknown function 'foo'"},
+		{`startsWith(git.branch)`, 1, 1, "function startsWith expects 2 argument(s) but got 1"},
+		{`semver("master") > git.tag`, 1, 1, "semver: invalid version \"master\""},
+		{"git.branch == \"master\"\n  && git.tag == ", 2, 17, "expected a value but found end of expression"},
+		{`git.branch == "master" git.tag`, 1, 24, "unexpected identifier 'git.tag'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			require.Error(t, err)
+			e, ok := err.(*SyntaxError)
+			require.True(t, ok, "error should be a *SyntaxError: %v", err)
+			assert.Equal(t, tt.line, e.Line)
+			assert.Equal(t, tt.column, e.Column)
+			assert.Equal(t, tt.msg, e.Message)
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	_, err := Eval(`semver(git.tag) > "1.0.0"`, map[string]string{"git.tag": "master"})
+	assert.EqualError(t, err, "semver: invalid version \"master\"")
+
+	_, err = Eval(`git.branch =~ cds.regex`, map[string]string{"cds.regex": "[a-"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+type function struct {
+	args    []valueType
+	returns valueType
+	call    func(args []value) (value, error)
+}
+
+// functions available in expressions, defined() is handled by the parser
+var functions = map[string]function{
+	"startsWith": {
+		args:    []valueType{typeString, typeString},
+		returns: typeBool,
+		call: func(args []value) (value, error) {
+			return value{t: typeBool, b: strings.HasPrefix(args[0].s, args[1].s)}, nil
+		},
+	},
+	"endsWith": {
+		args:    []valueType{typeString, typeString},
+		returns: typeBool,
+		call: func(args []value) (value, error) {
+			return value{t: typeBool, b: strings.HasSuffix(args[0].s, args[1].s)}, nil
+		},
+	},
+	"empty": {
+		args:    []valueType{typeString},
+		returns: typeBool,
+		call: func(args []value) (value, error) {
+			return value{t: typeBool, b: args[0].s == ""}, nil
+		},
+	},
+	"lower": {
+		args:    []valueType{typeString},
+		returns: typeString,
+		call: func(args []value) (value, error) {
+			return value{t: typeString, s: strings.ToLower(args[0].s)}, nil
+		},
+	},
+	"upper": {
+		args:    []valueType{typeString},
+		returns: typeString,
+		call: func(args []value) (value, error) {
+			return value{t: typeString, s: strings.ToUpper(args[0].s)}, nil
+		},
+	},
+	"trim": {
+		args:    []valueType{typeString},
+		returns: typeString,
+		call: func(args []value) (value, error) {
+			return value{t: typeString, s: strings.TrimSpace(args[0].s)}, nil
+		},
+	},
+	"len": {
+		args:    []valueType{typeString},
+		returns: typeString,
+		call: func(args []value) (value, error) {
+			return value{t: typeString, s: strconv.Itoa(len(args[0].s))}, nil
+		},
+	},
+	"split": {
+		args:    []valueType{typeString, typeString},
+		returns: typeList,
+		call: func(args []value) (value, error) {
+			res := value{t: typeList}
+			if args[0].s == "" {
+				return res, nil
+			}
+			for _, s := range strings.Split(args[0].s, args[1].s) {
+				res.l = append(res.l, strings.TrimSpace(s))
+			}
+			return res, nil
+		},
+	},
+	// semver returns a normalized version (ex: v1.2 -> 1.2.0) so that it
+	// can't be compared as a number.
+	"semver": {
+		args:    []valueType{typeString},
+		returns: typeString,
+		call: func(args []value) (value, error) {
+			v, err := semver.ParseTolerant(args[0].s)
+			if err != nil {
+				return value{}, fmt.Errorf("invalid version %q", args[0].s)
+			}
+			return value{t: typeString, s: v.String()}, nil
+		},
+	},
+}
@@ -0,0 +1,254 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenEq
+	tokenNe
+	tokenLt
+	tokenLe
+	tokenGt
+	tokenGe
+	tokenMatch
+	tokenNotMatch
+	tokenIn
+	tokenContains
+	tokenTrue
+	tokenFalse
+)
+
+var tokenNames = map[tokenKind]string{
+	tokenEOF:      "end of expression",
+	tokenIdent:    "identifier",
+	tokenNumber:   "number",
+	tokenString:   "string",
+	tokenLParen:   "'('",
+	tokenRParen:   "')'",
+	tokenLBracket: "'['",
+	tokenRBracket: "']'",
+	tokenComma:    "','",
+	tokenAnd:      "'&&'",
+	tokenOr:       "'||'",
+	tokenNot:      "'!'",
+	tokenEq:       "'=='",
+	tokenNe:       "'!='",
+	tokenLt:       "'<'",
+	tokenLe:       "'<='",
+	tokenGt:       "'>'",
+	tokenGe:       "'>='",
+	tokenMatch:    "'=~'",
+	tokenNotMatch: "'!~'",
+	tokenIn:       "'in'",
+	tokenContains: "'contains'",
+	tokenTrue:     "'true'",
+	tokenFalse:    "'false'",
+}
+
+func (k tokenKind) String() string {
+	return tokenNames[k]
+}
+
+var keywords = map[string]tokenKind{
+	"and":      tokenAnd,
+	"or":       tokenOr,
+	"not":      tokenNot,
+	"in":       tokenIn,
+	"contains": tokenContains,
+	"true":     tokenTrue,
+	"false":    tokenFalse,
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return t.kind.String()
+	case tokenString:
+		return fmt.Sprintf("string %q", t.text)
+	case tokenIdent, tokenNumber:
+		return fmt.Sprintf("%s '%s'", t.kind, t.text)
+	}
+	return t.kind.String()
+}
+
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) errorf(pos int, format string, args ...interface{}) error {
+	return newSyntaxError(l.input, pos, fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) all() ([]token, error) {
+	var tokens []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+		if t.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(rune(l.input[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	c := l.input[l.pos]
+	switch {
+	case c == '"' || c == '\'':
+		return l.lexString(c)
+	case isDigit(c):
+		for l.pos < len(l.input) && (isDigit(l.input[l.pos]) || l.input[l.pos] == '.') {
+			l.pos++
+		}
+		return token{kind: tokenNumber, text: l.input[start:l.pos], pos: start}, nil
+	case isIdentStart(c):
+		for l.pos < len(l.input) && isIdentPart(l.input[l.pos]) {
+			l.pos++
+		}
+		text := l.input[start:l.pos]
+		if strings.HasSuffix(text, ".") {
+			return token{}, l.errorf(l.pos-1, "unexpected '.' at the end of identifier '%s'", text)
+		}
+		if k, ok := keywords[text]; ok {
+			return token{kind: k, text: text, pos: start}, nil
+		}
+		return token{kind: tokenIdent, text: text, pos: start}, nil
+	}
+
+	two := ""
+	if l.pos+1 < len(l.input) {
+		two = l.input[l.pos : l.pos+2]
+	}
+	switch two {
+	case "&&":
+		l.pos += 2
+		return token{kind: tokenAnd, text: two, pos: start}, nil
+	case "||":
+		l.pos += 2
+		return token{kind: tokenOr, text: two, pos: start}, nil
+	case "==":
+		l.pos += 2
+		return token{kind: tokenEq, text: two, pos: start}, nil
+	case "!=":
+		l.pos += 2
+		return token{kind: tokenNe, text: two, pos: start}, nil
+	case "<=":
+		l.pos += 2
+		return token{kind: tokenLe, text: two, pos: start}, nil
+	case ">=":
+		l.pos += 2
+		return token{kind: tokenGe, text: two, pos: start}, nil
+	case "=~":
+		l.pos += 2
+		return token{kind: tokenMatch, text: two, pos: start}, nil
+	case "!~":
+		l.pos += 2
+		return token{kind: tokenNotMatch, text: two, pos: start}, nil
+	}
+
+	l.pos++
+	switch c {
+	case '(':
+		return token{kind: tokenLParen, text: "(", pos: start}, nil
+	case ')':
+		return token{kind: tokenRParen, text: ")", pos: start}, nil
+	case '[':
+		return token{kind: tokenLBracket, text: "[", pos: start}, nil
+	case ']':
+		return token{kind: tokenRBracket, text: "]", pos: start}, nil
+	case ',':
+		return token{kind: tokenComma, text: ",", pos: start}, nil
+	case '!':
+		return token{kind: tokenNot, text: "!", pos: start}, nil
+	case '<':
+		return token{kind: tokenLt, text: "<", pos: start}, nil
+	case '>':
+		return token{kind: tokenGt, text: ">", pos: start}, nil
+	case '=':
+		return token{}, l.errorf(start, "unexpected '=', did you mean '=='?")
+	case '&':
+		return token{}, l.errorf(start, "unexpected '&', did you mean '&&'?")
+	case '|':
+		return token{}, l.errorf(start, "unexpected '|', did you mean '||'?")
+	}
+	return token{}, l.errorf(start, "unexpected character %q", c)
+}
+
+func (l *lexer) lexString(quote byte) (token, error) {
+	start := l.pos
+	l.pos++
+	var sb strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch c {
+		case quote:
+			l.pos++
+			return token{kind: tokenString, text: sb.String(), pos: start}, nil
+		case '\\':
+			if l.pos+1 >= len(l.input) {
+				return token{}, l.errorf(l.pos, "unterminated escape sequence")
+			}
+			l.pos++
+			switch e := l.input[l.pos]; e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '\'', '"':
+				sb.WriteByte(e)
+			default:
+				return token{}, l.errorf(l.pos-1, "unknown escape sequence '\\%c'", e)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+		l.pos++
+	}
+	return token{}, l.errorf(start, "unterminated string")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.' || c == '-'
+}
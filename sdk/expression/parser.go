@@ -0,0 +1,337 @@
+package expression
+
+import (
+	"fmt"
+	"regexp"
+)
+
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) error {
+	return newSyntaxError(p.input, pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekNext() token {
+	if p.pos+1 < len(p.tokens) {
+		return p.tokens[p.pos+1]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(k tokenKind) (token, error) {
+	t := p.advance()
+	if t.kind != k {
+		return t, p.errorf(t.pos, "expected %s but found %s", k, t)
+	}
+	return t, nil
+}
+
+func (p *parser) checkType(n node, expected valueType, context string) error {
+	if n.typ() != expected {
+		return p.errorf(n.position(), "%s expects a %s but found a %s", context, expected, n.typ())
+	}
+	return nil
+}
+
+// parseOr parses: and ( ( '||' | 'or' ) and )*
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		op := p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkType(left, typeBool, "operator "+op.text); err != nil {
+			return nil, err
+		}
+		if err := p.checkType(right, typeBool, "operator "+op.text); err != nil {
+			return nil, err
+		}
+		left = &logicalNode{pos: op.pos, op: tokenOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: not ( ( '&&' | 'and' ) not )*
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		op := p.advance()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkType(left, typeBool, "operator "+op.text); err != nil {
+			return nil, err
+		}
+		if err := p.checkType(right, typeBool, "operator "+op.text); err != nil {
+			return nil, err
+		}
+		left = &logicalNode{pos: op.pos, op: tokenAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseNot parses: ( '!' | 'not' ) not | comparison
+func (p *parser) parseNot() (node, error) {
+	if p.peek().kind == tokenNot {
+		op := p.advance()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkType(operand, typeBool, "operator "+op.text); err != nil {
+			return nil, err
+		}
+		return &notNode{pos: op.pos, operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses: primary ( operator primary )?
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.peek()
+	switch op.kind {
+	case tokenEq, tokenNe:
+		p.advance()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() == typeList || left.typ() != right.typ() {
+			return nil, p.errorf(op.pos, "operator %s cannot compare a %s with a %s", op.text, left.typ(), right.typ())
+		}
+		return &compareNode{pos: op.pos, op: op.kind, left: left, right: right}, nil
+
+	case tokenLt, tokenLe, tokenGt, tokenGe:
+		p.advance()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkType(left, typeString, "operator "+op.text); err != nil {
+			return nil, err
+		}
+		if err := p.checkType(right, typeString, "operator "+op.text); err != nil {
+			return nil, err
+		}
+		return &compareNode{pos: op.pos, op: op.kind, left: left, right: right}, nil
+
+	case tokenMatch, tokenNotMatch:
+		p.advance()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkType(left, typeString, "operator "+op.text); err != nil {
+			return nil, err
+		}
+		if err := p.checkType(right, typeString, "operator "+op.text); err != nil {
+			return nil, err
+		}
+		n := &matchNode{pos: op.pos, negate: op.kind == tokenNotMatch, left: left, right: right}
+		if lit, ok := right.(*literalNode); ok {
+			n.regexp, err = regexp.Compile(lit.value.s)
+			if err != nil {
+				return nil, p.errorf(right.position(), "invalid regular expression: %v", err)
+			}
+		}
+		return n, nil
+
+	case tokenIn:
+		p.advance()
+		return p.parseIn(op, left, false)
+
+	case tokenNot:
+		// left not in [...]
+		if p.peekNext().kind != tokenIn {
+			return left, nil
+		}
+		p.advance()
+		p.advance()
+		return p.parseIn(op, left, true)
+
+	case tokenContains:
+		p.advance()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() == typeBool {
+			return nil, p.errorf(left.position(), "operator contains expects a string or a list but found a %s", left.typ())
+		}
+		if err := p.checkType(right, typeString, "operator contains"); err != nil {
+			return nil, err
+		}
+		return &containsNode{pos: op.pos, left: left, right: right}, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseIn(op token, left node, negate bool) (node, error) {
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkType(left, typeString, "operator in"); err != nil {
+		return nil, err
+	}
+	if err := p.checkType(right, typeList, "operator in"); err != nil {
+		return nil, err
+	}
+	var n node = &containsNode{pos: op.pos, left: right, right: left}
+	if negate {
+		n = &notNode{pos: op.pos, operand: n}
+	}
+	return n, nil
+}
+
+// parsePrimary parses: '(' or ')' | '[' list ']' | literal | function call | variable
+func (p *parser) parsePrimary() (node, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokenLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen); err != nil {
+			return nil, err
+		}
+		return n, nil
+
+	case tokenLBracket:
+		return p.parseList(t)
+
+	case tokenString, tokenNumber:
+		return &literalNode{pos: t.pos, value: value{t: typeString, s: t.text}}, nil
+
+	case tokenTrue, tokenFalse:
+		return &literalNode{pos: t.pos, value: value{t: typeBool, b: t.kind == tokenTrue}}, nil
+
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(t)
+		}
+		return &variableNode{pos: t.pos, name: t.text}, nil
+	}
+	return nil, p.errorf(t.pos, "expected a value but found %s", t)
+}
+
+func (p *parser) parseList(open token) (node, error) {
+	n := &listNode{pos: open.pos}
+	if p.peek().kind == tokenRBracket {
+		p.advance()
+		return n, nil
+	}
+	for {
+		item, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkType(item, typeString, "list"); err != nil {
+			return nil, err
+		}
+		n.items = append(n.items, item)
+
+		t := p.advance()
+		switch t.kind {
+		case tokenComma:
+			continue
+		case tokenRBracket:
+			return n, nil
+		}
+		return nil, p.errorf(t.pos, "expected ',' or ']' but found %s", t)
+	}
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	if _, err := p.expect(tokenLParen); err != nil {
+		return nil, err
+	}
+
+	var args []node
+	if p.peek().kind == tokenRParen {
+		p.advance()
+	} else {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			t := p.advance()
+			if t.kind == tokenComma {
+				continue
+			}
+			if t.kind == tokenRParen {
+				break
+			}
+			return nil, p.errorf(t.pos, "expected ',' or ')' but found %s", t)
+		}
+	}
+
+	// defined() works on the variable itself, not on its value
+	if name.text == "defined" {
+		if len(args) != 1 {
+			return nil, p.errorf(name.pos, "function defined expects 1 argument but got %d", len(args))
+		}
+		v, ok := args[0].(*variableNode)
+		if !ok {
+			return nil, p.errorf(args[0].position(), "function defined expects a variable")
+		}
+		return &definedNode{pos: name.pos, name: v.name}, nil
+	}
+
+	f, ok := functions[name.text]
+	if !ok {
+		return nil, p.errorf(name.pos, "unknown function '%s'", name.text)
+	}
+	if len(args) != len(f.args) {
+		return nil, p.errorf(name.pos, "function %s expects %d argument(s) but got %d", name.text, len(f.args), len(args))
+	}
+	for i := range args {
+		if err := p.checkType(args[i], f.args[i], "function "+name.text); err != nil {
+			return nil, err
+		}
+	}
+
+	n := &callNode{pos: name.pos, name: name.text, function: f, args: args}
+	// Evaluate calls on literals at parse time to report errors as soon as possible
+	if n.isConstant() {
+		if _, err := n.eval(nil); err != nil {
+			return nil, p.errorf(name.pos, "%v", err)
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/ovh/cds/sdk/grpcplugin/hookplugin"
+)
+
+// ExamplePlugin sends an event every "interval" seconds
+type ExamplePlugin struct {
+	hookplugin.Common
+}
+
+func (e *ExamplePlugin) Manifest(ctx context.Context, _ *empty.Empty) (*hookplugin.HookPluginManifest, error) {
+	return &hookplugin.HookPluginManifest{
+		Name:        "Example Plugin",
+		Author:      "CDS",
+		Description: "This is an example hook plugin",
+		Version:     sdk.VERSION,
+	}, nil
+}
+
+func (e *ExamplePlugin) Listen(q *hookplugin.ListenQuery, stream hookplugin.HookPlugin_ListenServer) error {
+	interval, err := strconv.Atoi(q.Options["interval"])
+	if err != nil || interval <= 0 {
+		interval = 10
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case t := <-ticker.C:
+			if err := stream.Send(&hookplugin.HookEvent{
+				Payload: map[string]string{"example.tick": t.Format(time.RFC3339)},
+				Message: []byte(fmt.Sprintf(`{"hook": %q}`, q.Uuid)),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		e := ExamplePlugin{}
+		if err := hookplugin.Start(context.Background(), &e); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	//Server Part - BEGIN
+	var e *ExamplePlugin
+	go func() {
+		e = &ExamplePlugin{}
+		if err := hookplugin.Start(context.Background(), e); err != nil {
+			panic(err)
+		}
+	}()
+	//Server Part - END
+
+	time.Sleep(100 * time.Millisecond)
+
+	//Client Part - BEGIN
+	c, err := hookplugin.Client(context.Background(), e.Socket)
+	if err != nil {
+		panic(err)
+	}
+
+	manifest, err := c.Manifest(context.Background(), new(empty.Empty))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(manifest)
+
+	stream, err := c.Listen(context.Background(), &hookplugin.ListenQuery{Uuid: "example", Options: map[string]string{"interval": "1"}})
+	if err != nil {
+		panic(err)
+	}
+	event, err := stream.Recv()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(event)
+	//Client part - END
+}
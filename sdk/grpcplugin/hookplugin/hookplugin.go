@@ -0,0 +1,51 @@
+package hookplugin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk/grpcplugin"
+
+	"google.golang.org/grpc"
+)
+
+// Common is the common struct of hookplugin
+type Common struct {
+	grpcplugin.Common
+}
+
+// Start is useful to start grpcplugin
+func Start(ctx context.Context, srv HookPluginServer) error {
+	p, ok := srv.(grpcplugin.Plugin)
+	if !ok {
+		return fmt.Errorf("bad implementation")
+	}
+
+	c := p.Instance()
+	c.Srv = srv
+	c.Desc = &_HookPlugin_serviceDesc
+	return p.Start(ctx)
+}
+
+// Client gives us a grpcplugin client
+func Client(ctx context.Context, socket string) (HookPluginClient, error) {
+	conn, err := grpc.DialContext(ctx,
+		socket,
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(address string, timeout time.Duration) (net.Conn, error) {
+			if strings.Contains(socket, ".sock") {
+				return net.DialTimeout("unix", socket, timeout)
+			}
+			return net.DialTimeout("tcp", socket, timeout)
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewHookPluginClient(conn)
+	return c, nil
+}
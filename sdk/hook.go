@@ -1,41 +1,57 @@
 package sdk
 
+import (
+	"strings"
+	"time"
+)
+
 // These are constants about hooks
 const (
-	WebHookModelName              = "WebHook"
-	RepositoryWebHookModelName    = "RepositoryWebHook"
-	GerritHookModelName           = "GerritHook"
-	SchedulerModelName            = "Scheduler"
-	GitPollerModelName            = "Git Repository Poller"
-	KafkaHookModelName            = "Kafka hook"
-	RabbitMQHookModelName         = "RabbitMQ hook"
-	WorkflowModelName             = "Workflow"
-	HookConfigProject             = "project"
-	HookConfigWorkflow            = "workflow"
-	HookConfigTargetProject       = "target_project"
-	HookConfigTargetWorkflow      = "target_workflow"
-	HookConfigTargetHook          = "target_hook"
-	HookConfigWorkflowID          = "workflow_id"
-	HookConfigWebHookID           = "webHookID"
-	HookConfigVCSServer           = "vcsServer"
-	HookConfigEventFilter         = "eventFilter"
-	HookConfigRepoFullName        = "repoFullName"
-	HookConfigModelType           = "model_type"
-	HookConfigModelName           = "model_name"
-	HookConfigIcon                = "hookIcon"
-	WebHookModelConfigMethod      = "method"
-	RepositoryWebHookModelMethod  = "method"
-	SchedulerModelCron            = "cron"
-	SchedulerModelTimezone        = "timezone"
-	Payload                       = "payload"
-	HookModelIntegration          = "integration"
-	KafkaHookModelConsumerGroup   = "consumer group"
-	KafkaHookModelTopic           = "topic"
-	RabbitMQHookModelQueue        = "queue"
-	RabbitMQHookModelBindingKey   = "binding_key"
-	RabbitMQHookModelExchangeType = "exchange_type"
-	RabbitMQHookModelExchangeName = "exchange_name"
-	RabbitMQHookModelConsumerTag  = "consumer_tag"
+	WebHookModelName               = "WebHook"
+	RepositoryWebHookModelName     = "RepositoryWebHook"
+	GerritHookModelName            = "GerritHook"
+	MergeQueueHookModelName        = "MergeQueue"
+	PullRequestCommandModelName    = "PullRequestCommand"
+	SchedulerModelName             = "Scheduler"
+	GitPollerModelName             = "Git Repository Poller"
+	KafkaHookModelName             = "Kafka hook"
+	RabbitMQHookModelName          = "RabbitMQ hook"
+	WorkflowModelName              = "Workflow"
+	HookConfigProject              = "project"
+	HookConfigWorkflow             = "workflow"
+	HookConfigTargetProject        = "target_project"
+	HookConfigTargetWorkflow       = "target_workflow"
+	HookConfigTargetHook           = "target_hook"
+	HookConfigWorkflowID           = "workflow_id"
+	HookConfigWebHookID            = "webHookID"
+	HookConfigWebHookSecret        = "webHookSecret"
+	HookConfigVCSServer            = "vcsServer"
+	HookConfigEventFilter          = "eventFilter"
+	HookConfigRepoFullName         = "repoFullName"
+	HookConfigModelType            = "model_type"
+	HookConfigModelName            = "model_name"
+	HookConfigIcon                 = "hookIcon"
+	HookConfigPlugin               = "plugin"
+	WebHookModelConfigMethod       = "method"
+	RepositoryWebHookModelMethod   = "method"
+	SchedulerModelCron             = "cron"
+	SchedulerModelTimezone         = "timezone"
+	SchedulerModelBlackout         = "blackout"
+	SchedulerModelJitter           = "jitter"
+	SchedulerModelSkipIfRunning    = "skip_if_running"
+	SchedulerModelOnlyIfNewCommits = "only_if_new_commits"
+	Payload                        = "payload"
+	HookModelIntegration           = "integration"
+	KafkaHookModelConsumerGroup    = "consumer group"
+	KafkaHookModelTopic            = "topic"
+	RabbitMQHookModelQueue         = "queue"
+	RabbitMQHookModelBindingKey    = "binding_key"
+	RabbitMQHookModelExchangeType  = "exchange_type"
+	RabbitMQHookModelExchangeName  = "exchange_name"
+	RabbitMQHookModelConsumerTag   = "consumer_tag"
+	MergeQueueHookModelLabel       = "label"
+	MergeQueueHookModelApproval    = "on_approval"
+	PullRequestCommandModelList    = "commands"
 )
 
 // Here are the default hooks
@@ -49,6 +65,8 @@ var (
 		&RabbitMQHookModel,
 		&WorkflowModel,
 		&GerritHookModel,
+		&MergeQueueHookModel,
+		&PullRequestCommandModel,
 	}
 
 	BuiltinOutgoingHookModels = []*WorkflowHookModel{
@@ -146,6 +164,51 @@ var (
 		},
 	}
 
+	MergeQueueHookModel = WorkflowHookModel{
+		Author:     "CDS",
+		Type:       WorkflowHookModelBuiltin,
+		Identifier: "github.com/ovh/cds/hook/builtin/mergequeue",
+		Name:       MergeQueueHookModelName,
+		Icon:       "code branch",
+		DefaultConfig: WorkflowNodeHookConfig{
+			RepositoryWebHookModelMethod: {
+				Value:        "POST",
+				Configurable: false,
+				Type:         HookConfigTypeString,
+			},
+			MergeQueueHookModelLabel: {
+				Value:        "cds-merge",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			MergeQueueHookModelApproval: {
+				Value:        "false",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+		},
+	}
+
+	PullRequestCommandModel = WorkflowHookModel{
+		Author:     "CDS",
+		Type:       WorkflowHookModelBuiltin,
+		Identifier: "github.com/ovh/cds/hook/builtin/pullrequestcommand",
+		Name:       PullRequestCommandModelName,
+		Icon:       "comment",
+		DefaultConfig: WorkflowNodeHookConfig{
+			RepositoryWebHookModelMethod: {
+				Value:        "POST",
+				Configurable: false,
+				Type:         HookConfigTypeString,
+			},
+			PullRequestCommandModelList: {
+				Value:        "rerun",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+		},
+	}
+
 	GitPollerModel = WorkflowHookModel{
 		Author:     "CDS",
 		Type:       WorkflowHookModelBuiltin,
@@ -178,6 +241,26 @@ var (
 				Configurable: true,
 				Type:         HookConfigTypeString,
 			},
+			SchedulerModelBlackout: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			SchedulerModelJitter: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			SchedulerModelSkipIfRunning: {
+				Value:        "false",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			SchedulerModelOnlyIfNewCommits: {
+				Value:        "false",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 			Payload: {
 				Value:        "{}",
 				Configurable: true,
@@ -268,6 +351,10 @@ func GetDefaultHookModel(modelName string) WorkflowHookModel {
 		return SchedulerModel
 	case RepositoryWebHookModelName:
 		return RepositoryWebHookModel
+	case MergeQueueHookModelName:
+		return MergeQueueHookModel
+	case PullRequestCommandModelName:
+		return PullRequestCommandModel
 	case WebHookModelName:
 		return WebHookModel
 	case GitPollerModelName:
@@ -278,3 +365,91 @@ func GetDefaultHookModel(modelName string) WorkflowHookModel {
 
 	return WebHookModel
 }
+
+// NewHookPluginModel returns the workflow hook model served by a hook plugin,
+// the parameters of the plugin are the configuration of the hooks.
+func NewHookPluginModel(p GRPCPlugin) WorkflowHookModel {
+	config := WorkflowNodeHookConfig{
+		HookConfigPlugin: {
+			Value:        p.Name,
+			Configurable: false,
+			Type:         HookConfigTypeString,
+		},
+	}
+	for _, param := range p.Parameters {
+		config[param.Name] = WorkflowNodeHookConfigValue{
+			Value:        param.Value,
+			Configurable: true,
+			Type:         HookConfigTypeString,
+		}
+	}
+	return WorkflowHookModel{
+		Author:        p.Author,
+		Type:          WorkflowHookModelPlugin,
+		Identifier:    p.Name,
+		Name:          p.Name,
+		Description:   p.Description,
+		Icon:          "plug",
+		Command:       p.Name,
+		DefaultConfig: config,
+	}
+}
+
+const (
+	schedulerBlackoutDateFormat   = "2006-01-02"
+	schedulerBlackoutYearlyFormat = "01-02"
+)
+
+// ValidateSchedulerHookConfig checks the jitter and the blackout calendar of a scheduler hook.
+func ValidateSchedulerHookConfig(config WorkflowNodeHookConfig) error {
+	if jitter := strings.TrimSpace(config[SchedulerModelJitter].Value); jitter != "" {
+		d, err := time.ParseDuration(jitter)
+		if err != nil || d < 0 {
+			return NewErrorFrom(ErrWrongRequest, "invalid jitter %q, expected a duration like 10m", jitter)
+		}
+	}
+	if _, err := SchedulerBlackoutEntry(config[SchedulerModelBlackout].Value, time.Now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SchedulerBlackoutEntry returns the entry of the calendar that contains the given date, the entries are separated by ";"
+// and are days (2020-12-25), yearly days (12-25) or ranges of days (2020-12-20/2021-01-03, 12-24/12-26).
+func SchedulerBlackoutEntry(calendar string, date time.Time) (string, error) {
+	var res string
+	for _, entry := range strings.Split(calendar, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		from, to := entry, entry
+		if i := strings.Index(entry, "/"); i >= 0 {
+			from, to = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		}
+
+		format := schedulerBlackoutDateFormat
+		if len(from) == len(schedulerBlackoutYearlyFormat) {
+			format = schedulerBlackoutYearlyFormat
+		}
+		if _, err := time.Parse(format, from); err != nil {
+			return "", NewErrorFrom(ErrWrongRequest, "invalid blackout period %q", entry)
+		}
+		if _, err := time.Parse(format, to); err != nil {
+			return "", NewErrorFrom(ErrWrongRequest, "invalid blackout period %q", entry)
+		}
+		if res != "" {
+			continue
+		}
+
+		// Dates are compared as strings, a yearly range can span the end of the year
+		day := date.Format(format)
+		if from <= to && from <= day && day <= to {
+			res = entry
+		}
+		if from > to && format == schedulerBlackoutYearlyFormat && (from <= day || day <= to) {
+			res = entry
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerBlackoutEntry(t *testing.T) {
+	calendar := "2020-05-01; 12-25 ;2020-07-27/2020-08-14;12-31/01-01"
+	tests := []struct {
+		date  string
+		entry string
+	}{
+		{date: "2020-05-01", entry: "2020-05-01"},
+		{date: "2021-05-01"},
+		{date: "2021-12-25", entry: "12-25"},
+		{date: "2020-08-03", entry: "2020-07-27/2020-08-14"},
+		{date: "2020-08-15"},
+		{date: "2020-12-31", entry: "12-31/01-01"},
+		{date: "2021-01-01", entry: "12-31/01-01"},
+		{date: "2021-01-02"},
+	}
+	for _, tt := range tests {
+		date, err := time.Parse("2006-01-02", tt.date)
+		require.NoError(t, err)
+		entry, err := SchedulerBlackoutEntry(calendar, date)
+		require.NoError(t, err)
+		assert.Equal(t, tt.entry, entry, tt.date)
+	}
+
+	_, err := SchedulerBlackoutEntry("2020-13-01", time.Now())
+	assert.Error(t, err)
+	_, err = SchedulerBlackoutEntry("christmas", time.Now())
+	assert.Error(t, err)
+	// An invalid entry is reported even after the entry of the date
+	_, err = SchedulerBlackoutEntry("12-25;christmas", time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC))
+	assert.Error(t, err)
+}
+
+func TestValidateSchedulerHookConfig(t *testing.T) {
+	require.NoError(t, ValidateSchedulerHookConfig(WorkflowNodeHookConfig{}))
+	require.NoError(t, ValidateSchedulerHookConfig(WorkflowNodeHookConfig{
+		SchedulerModelJitter:   {Value: "10m"},
+		SchedulerModelBlackout: {Value: "12-24/12-26;2021-01-01"},
+	}))
+
+	for _, config := range []WorkflowNodeHookConfig{
+		{SchedulerModelJitter: {Value: "10 minutes"}},
+		{SchedulerModelJitter: {Value: "-5m"}},
+		{SchedulerModelBlackout: {Value: "12-25;christmas"}},
+	} {
+		err := ValidateSchedulerHookConfig(config)
+		require.Error(t, err)
+		assert.True(t, ErrorIs(err, ErrWrongRequest))
+	}
+}
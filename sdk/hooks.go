@@ -10,6 +10,7 @@ type Task struct {
 	Executions        []TaskExecution        `json:"executions"`
 	NbExecutionsTotal int                    `json:"nb_executions_total" cli:"nb_executions_total"`
 	NbExecutionsTodo  int                    `json:"nb_executions_todo" cli:"nb_executions_todo"`
+	NbDeadLetters     int                    `json:"nb_dead_letters" cli:"nb_dead_letters"`
 }
 
 // TaskExecution represents an execution instance of a task. It the task is a webhook; this represents the call of the webhook
@@ -27,6 +28,7 @@ type TaskExecution struct {
 	RabbitMQ            *RabbitMQTaskExecution  `json:"rabbitmq,omitempty" cli:"-"`
 	ScheduledTask       *ScheduledTaskExecution `json:"scheduled_task,omitempty" cli:"-"`
 	GerritEvent         *GerritEventExecution   `json:"gerrit,omitempty" cli:"-"`
+	Plugin              *PluginTaskExecution    `json:"plugin,omitempty" cli:"-"`
 	Status              string                  `json:"status" cli:"status"`
 }
 
@@ -53,7 +55,18 @@ type RabbitMQTaskExecution struct {
 	Message []byte `json:"message"`
 }
 
+// PluginTaskExecution contains specific data for an event of a hook plugin
+type PluginTaskExecution struct {
+	Payload map[string]string `json:"payload"`
+	Message []byte            `json:"message"`
+}
+
 // ScheduledTaskExecution contains specific data for a scheduled task execution
 type ScheduledTaskExecution struct {
-	DateScheduledExecution string `json:"date_scheduled_execution"`
+	DateScheduledExecution string   `json:"date_scheduled_execution"`
+	Jitter                 string   `json:"jitter,omitempty"`
+	Checks                 []string `json:"checks,omitempty"`
+	SkipReason             string   `json:"skip_reason,omitempty"`
+	GitBranch              string   `json:"git_branch,omitempty"`
+	GitHash                string   `json:"git_hash,omitempty"`
 }
@@ -4,18 +4,34 @@ package sdk
 const (
 	KafkaIntegrationModel         = "Kafka"
 	RabbitMQIntegrationModel      = "RabbitMQ"
+	NATSIntegrationModel          = "NATS"
+	WebhookIntegrationModel       = "Webhook"
 	OpenstackIntegrationModel     = "Openstack"
 	AWSIntegrationModel           = "AWS"
+	GCSIntegrationModel           = "GoogleCloudStorage"
+	AzureBlobIntegrationModel     = "AzureBlob"
 	DefaultStorageIntegrationName = "shared.infra"
 )
 
+// IntegrationConfigEventTypes is the configuration key used to filter the events sent to an event integration
+const IntegrationConfigEventTypes = "event types"
+
+var eventTypesIntegrationConfigValue = IntegrationConfigValue{
+	Type:        IntegrationConfigTypeString,
+	Description: "Comma separated list of event types to send (ie. sdk.EventRunWorkflow,sdk.EventRunWorkflowNode), all events are sent if empty",
+}
+
 // Here are the default plateform models
 var (
 	BuiltinIntegrationModels = []*IntegrationModel{
 		&KafkaIntegration,
 		&RabbitMQIntegration,
+		&NATSIntegration,
+		&WebhookIntegration,
 		&OpenstackIntegration,
 		&AWSIntegration,
+		&GCSIntegration,
+		&AzureBlobIntegration,
 	}
 	// KafkaIntegration represents a kafka integration
 	KafkaIntegration = IntegrationModel{
@@ -37,6 +53,7 @@ var (
 				Type:        IntegrationConfigTypeString,
 				Description: "This is mandatory only if you want to use Event Integration",
 			},
+			IntegrationConfigEventTypes: eventTypesIntegrationConfigValue,
 		},
 		Disabled: false,
 		Hook:     true,
@@ -58,9 +75,63 @@ var (
 			"password": IntegrationConfigValue{
 				Type: IntegrationConfigTypePassword,
 			},
+			"exchange": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "This is mandatory only if you want to use Event Integration",
+			},
+			"routing key": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Routing key used to publish events, only used for Event Integration",
+			},
+			IntegrationConfigEventTypes: eventTypesIntegrationConfigValue,
 		},
 		Disabled: false,
 		Hook:     true,
+		Event:    true,
+	}
+	// NATSIntegration represents a NATS integration
+	NATSIntegration = IntegrationModel{
+		Name:       NATSIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/nats",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Address of the NATS server, ie. nats://your-nats:4222 or tls://your-nats:4222",
+			},
+			"subject": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"username": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"password": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+			IntegrationConfigEventTypes: eventTypesIntegrationConfigValue,
+		},
+		Disabled: false,
+		Event:    true,
+	}
+	// WebhookIntegration represents a generic HTTP webhook integration
+	WebhookIntegration = IntegrationModel{
+		Name:       WebhookIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/webhook",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"url": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"secret": IntegrationConfigValue{
+				Type:        IntegrationConfigTypePassword,
+				Description: "Used to sign the body of the requests with HMAC-SHA256 in the X-CDS-Signature header",
+			},
+			IntegrationConfigEventTypes: eventTypesIntegrationConfigValue,
+		},
+		Disabled: false,
+		Event:    true,
 	}
 	// OpenstackIntegration represents an openstack integration
 	OpenstackIntegration = IntegrationModel{
@@ -134,6 +205,62 @@ var (
 		Disabled: false,
 		Hook:     false,
 	}
+	// GCSIntegration represents a google cloud storage integration
+	GCSIntegration = IntegrationModel{
+		Name:       GCSIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/gcs",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"bucket_name": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"prefix": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"credentials": IntegrationConfigValue{
+				Type:        IntegrationConfigTypePassword,
+				Description: "Service account JSON key, it is needed to generate temporary urls",
+			},
+			"endpoint": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+		},
+		Storage:  true,
+		Disabled: false,
+		Hook:     false,
+	}
+	// AzureBlobIntegration represents an azure blob storage integration
+	AzureBlobIntegration = IntegrationModel{
+		Name:       AzureBlobIntegrationModel,
+		Author:     "CDS",
+		Identifier: "github.com/ovh/cds/integration/builtin/azureblob",
+		Icon:       "",
+		DefaultConfig: IntegrationConfig{
+			"account_name": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"account_key": IntegrationConfigValue{
+				Type: IntegrationConfigTypePassword,
+			},
+			"container_name": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"prefix": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+			"static_container_name": IntegrationConfigValue{
+				Type:        IntegrationConfigTypeString,
+				Description: "Container with public read access used to serve static files",
+			},
+			"endpoint": IntegrationConfigValue{
+				Type: IntegrationConfigTypeString,
+			},
+		},
+		Storage:  true,
+		Disabled: false,
+		Hook:     false,
+	}
 )
 
 // IntegrationType represents all different type of integrations
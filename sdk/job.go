@@ -1,5 +1,14 @@
 package sdk
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
 // Job is the element of a stage
 type Job struct {
 	PipelineActionID int64                  `json:"pipeline_action_id"`
@@ -8,6 +17,10 @@ type Job struct {
 	LastModified     int64                  `json:"last_modified"`
 	Action           Action                 `json:"action"`
 	Warnings         []PipelineBuildWarning `json:"warnings"`
+	Matrix           *JobMatrix             `json:"matrix,omitempty"`
+	RetryPolicy      *JobRetryPolicy        `json:"retry_policy,omitempty"`
+	// MatrixValues is only set on jobs expanded from a matrix when a node is processed
+	MatrixValues map[string]string `json:"matrix_values,omitempty"`
 }
 
 // IsValid returns job's validity.
@@ -16,5 +29,245 @@ func (j Job) IsValid() error {
 		return NewErrorFrom(ErrWrongRequest, "invalid given stage id")
 	}
 
+	if j.Matrix != nil {
+		if err := j.Matrix.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	if j.RetryPolicy != nil {
+		if err := j.RetryPolicy.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	return j.Action.IsValid()
 }
+
+// MaxJobMatrixCombinations is the maximum number of jobs that can be expanded from a job matrix.
+const MaxJobMatrixCombinations = 256
+
+var jobMatrixAxisRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// JobMatrix describes the combinations of values a job will be run with.
+type JobMatrix struct {
+	Axes    map[string][]string `json:"axes,omitempty"`
+	Include []JobMatrixInclude  `json:"include,omitempty"`
+	Exclude []map[string]string `json:"exclude,omitempty"`
+}
+
+// JobMatrixInclude adds a combination to a job matrix or extends the existing ones that match
+// its values, requirements overrides the job's requirements with the same type or name.
+type JobMatrixInclude struct {
+	Values       map[string]string `json:"values"`
+	Requirements []Requirement     `json:"requirements,omitempty"`
+}
+
+// JobMatrixCombination is a set of values for a job matrix with its requirements overrides.
+type JobMatrixCombination struct {
+	Values       map[string]string
+	Requirements []Requirement
+}
+
+// Value returns driver.Value from JobMatrix.
+func (m JobMatrix) Value() (driver.Value, error) {
+	j, err := json.Marshal(m)
+	return j, WrapError(err, "cannot marshal JobMatrix")
+}
+
+// Scan job matrix.
+func (m *JobMatrix) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(json.Unmarshal(source, m), "cannot unmarshal JobMatrix")
+}
+
+// IsValid returns job matrix validity.
+func (m JobMatrix) IsValid() error {
+	if len(m.Axes) == 0 && len(m.Include) == 0 {
+		return NewErrorFrom(ErrInvalidData, "job matrix should contain at least one axis or include")
+	}
+	for name, values := range m.Axes {
+		if !jobMatrixAxisRegex.MatchString(name) {
+			return NewErrorFrom(ErrInvalidData, "invalid job matrix axis name %q, it should match %s", name, jobMatrixAxisRegex.String())
+		}
+		if len(values) == 0 {
+			return NewErrorFrom(ErrInvalidData, "job matrix axis %q should contain at least one value", name)
+		}
+	}
+	for _, e := range m.Exclude {
+		for k := range e {
+			if _, ok := m.Axes[k]; !ok {
+				return NewErrorFrom(ErrInvalidData, "invalid job matrix exclude, unknown axis %q", k)
+			}
+		}
+	}
+	for _, i := range m.Include {
+		for k := range i.Values {
+			if !jobMatrixAxisRegex.MatchString(k) {
+				return NewErrorFrom(ErrInvalidData, "invalid job matrix include value name %q, it should match %s", k, jobMatrixAxisRegex.String())
+			}
+		}
+		if err := RequirementList(i.Requirements).IsValid(); err != nil {
+			return err
+		}
+	}
+	// The size of the axes product is checked before building the combinations, it stops as soon as the maximum is exceeded
+	n := 1
+	for _, values := range m.Axes {
+		if n > MaxJobMatrixCombinations/len(values) {
+			return NewErrorFrom(ErrInvalidData, "job matrix axes expand to more than %d jobs", MaxJobMatrixCombinations)
+		}
+		n *= len(values)
+	}
+	if n := len(m.Combinations()); n > MaxJobMatrixCombinations {
+		return NewErrorFrom(ErrInvalidData, "job matrix expands to %d jobs, the maximum is %d", n, MaxJobMatrixCombinations)
+	}
+	return nil
+}
+
+// Combinations returns all the combinations of the matrix axes without the excluded ones
+// and with the included ones.
+func (m JobMatrix) Combinations() []JobMatrixCombination {
+	axes := make([]string, 0, len(m.Axes))
+	for k := range m.Axes {
+		axes = append(axes, k)
+	}
+	sort.Strings(axes)
+
+	var combinations []JobMatrixCombination
+	if len(axes) > 0 {
+		combinations = []JobMatrixCombination{{Values: map[string]string{}}}
+		for _, axis := range axes {
+			next := make([]JobMatrixCombination, 0, len(combinations)*len(m.Axes[axis]))
+			for _, c := range combinations {
+				for _, v := range m.Axes[axis] {
+					values := make(map[string]string, len(c.Values)+1)
+					for k := range c.Values {
+						values[k] = c.Values[k]
+					}
+					values[axis] = v
+					next = append(next, JobMatrixCombination{Values: values})
+				}
+			}
+			combinations = next
+		}
+	}
+
+	// Remove excluded combinations
+	filtered := combinations[:0]
+	for _, c := range combinations {
+		var excluded bool
+		for _, e := range m.Exclude {
+			if matchJobMatrixValues(c.Values, e, axes) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			filtered = append(filtered, c)
+		}
+	}
+	combinations = filtered
+
+	// Extends existing combinations or add new ones
+	for _, inc := range m.Include {
+		var matched bool
+		for i := range combinations {
+			c := &combinations[i]
+			if !matchJobMatrixValues(c.Values, inc.Values, axes) {
+				continue
+			}
+			matched = true
+			for k, v := range inc.Values {
+				c.Values[k] = v
+			}
+			c.Requirements = append(c.Requirements, inc.Requirements...)
+		}
+		if !matched {
+			values := make(map[string]string, len(inc.Values))
+			for k, v := range inc.Values {
+				values[k] = v
+			}
+			combinations = append(combinations, JobMatrixCombination{
+				Values:       values,
+				Requirements: inc.Requirements,
+			})
+		}
+	}
+
+	return combinations
+}
+
+// matchJobMatrixValues returns true if given values are the same for the matrix axes.
+func matchJobMatrixValues(combination, values map[string]string, axes []string) bool {
+	for _, axis := range axes {
+		v, ok := values[axis]
+		if ok && combination[axis] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ExpandMatrix returns one job per matrix combination, or the job itself if it has no matrix.
+// Each job is named with its combination values and its requirements are overridden
+// with the combination ones.
+func (j Job) ExpandMatrix() []Job {
+	if j.Matrix == nil {
+		return []Job{j}
+	}
+
+	combinations := j.Matrix.Combinations()
+	jobs := make([]Job, 0, len(combinations))
+	for _, c := range combinations {
+		leg := j
+		leg.Matrix = nil
+		leg.MatrixValues = c.Values
+		leg.Action.Name = fmt.Sprintf("%s (%s)", j.Action.Name, leg.MatrixKey())
+		leg.Action.Requirements = overrideJobMatrixRequirements(j.Action.Requirements, c.Requirements)
+		jobs = append(jobs, leg)
+	}
+	return jobs
+}
+
+// MatrixKey returns a string that identifies the matrix combination of the job.
+func (j Job) MatrixKey() string {
+	keys := make([]string, 0, len(j.MatrixValues))
+	for k := range j.MatrixValues {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = k + ": " + j.MatrixValues[k]
+	}
+	return strings.Join(values, ", ")
+}
+
+func overrideJobMatrixRequirements(requirements, overrides []Requirement) []Requirement {
+	res := make([]Requirement, len(requirements), len(requirements)+len(overrides))
+	copy(res, requirements)
+	for _, o := range overrides {
+		kept := res[:0]
+		for _, r := range res {
+			if r.Type == o.Type {
+				switch o.Type {
+				case ModelRequirement, HostnameRequirement, MemoryRequirement, OSArchRequirement:
+					continue
+				}
+				if r.Name == o.Name {
+					continue
+				}
+			}
+			kept = append(kept, r)
+		}
+		res = append(kept, o)
+	}
+	return res
+}
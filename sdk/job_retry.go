@@ -0,0 +1,128 @@
+package sdk
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Causes of a job failure that can be retried.
+const (
+	JobRetryOnWorkerLost = "worker-lost"
+	JobRetryOnTimeout    = "timeout"
+	JobRetryOnExitCode   = "exit-code"
+)
+
+// JobRetryOn contains all the failure causes that can be given to a job retry policy.
+var JobRetryOn = []string{JobRetryOnWorkerLost, JobRetryOnTimeout, JobRetryOnExitCode}
+
+// Job retry policy limits.
+const (
+	MaxJobRetryAttempts = 10
+	MaxJobRetryBackoff  = 1 * time.Hour
+)
+
+// JobRetryPolicy describes when and how a failed job should be put back in the queue.
+type JobRetryPolicy struct {
+	// MaxAttempts is the total number of runs allowed for the job, including the first one.
+	MaxAttempts int `json:"max_attempts"`
+	// Backoff is the delay in seconds before the first retry, it is doubled for each next attempt.
+	Backoff int64 `json:"backoff,omitempty"`
+	// On contains the failure causes for which the job should be retried, all causes if empty.
+	On []string `json:"on,omitempty"`
+	// ExitCodes restricts the exit-code cause to the given codes, any non-zero code if empty.
+	ExitCodes []int `json:"exit_codes,omitempty"`
+}
+
+// Value returns driver.Value from JobRetryPolicy.
+func (p JobRetryPolicy) Value() (driver.Value, error) {
+	j, err := json.Marshal(p)
+	return j, WrapError(err, "cannot marshal JobRetryPolicy")
+}
+
+// Scan job retry policy.
+func (p *JobRetryPolicy) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	source, ok := src.([]byte)
+	if !ok {
+		return WithStack(fmt.Errorf("type assertion .([]byte) failed (%T)", src))
+	}
+	return WrapError(json.Unmarshal(source, p), "cannot unmarshal JobRetryPolicy")
+}
+
+// IsValid returns job retry policy validity.
+func (p JobRetryPolicy) IsValid() error {
+	if p.MaxAttempts < 1 || p.MaxAttempts > MaxJobRetryAttempts {
+		return NewErrorFrom(ErrInvalidData, "job retry max attempts should be between 1 and %d", MaxJobRetryAttempts)
+	}
+	if p.Backoff < 0 {
+		return NewErrorFrom(ErrInvalidData, "job retry backoff should be a positive number of seconds")
+	}
+	for _, on := range p.On {
+		if !IsInArray(on, JobRetryOn) {
+			return NewErrorFrom(ErrInvalidData, "invalid job retry condition %q, it should be one of %v", on, JobRetryOn)
+		}
+	}
+	if len(p.ExitCodes) > 0 && len(p.On) > 0 && !IsInArray(JobRetryOnExitCode, p.On) {
+		return NewErrorFrom(ErrInvalidData, "job retry exit codes are given but %q is not a retry condition", JobRetryOnExitCode)
+	}
+	for _, c := range p.ExitCodes {
+		if c <= 0 {
+			return NewErrorFrom(ErrInvalidData, "invalid job retry exit code %d", c)
+		}
+	}
+	return nil
+}
+
+// ShouldRetry returns true if a job that failed for given cause should be put back in the queue,
+// retry is the number of times the job was already put back in the queue.
+func (p JobRetryPolicy) ShouldRetry(retry int, cause string, exitCode int) bool {
+	if retry+1 >= p.MaxAttempts {
+		return false
+	}
+	if len(p.On) > 0 && !IsInArray(cause, p.On) {
+		return false
+	}
+	if cause == JobRetryOnExitCode && len(p.ExitCodes) > 0 {
+		var found bool
+		for _, c := range p.ExitCodes {
+			if c == exitCode {
+				found = true
+				break
+			}
+		}
+		return found
+	}
+	return true
+}
+
+// Delay returns the time to wait before running given attempt of the job,
+// attempts are numbered from 1 so the first retry is the attempt 2.
+func (p JobRetryPolicy) Delay(attempt int) time.Duration {
+	if p.Backoff == 0 || attempt < 2 {
+		return 0
+	}
+	d := time.Duration(p.Backoff) * time.Second
+	for i := 2; i < attempt && d < MaxJobRetryBackoff; i++ {
+		d *= 2
+	}
+	if d > MaxJobRetryBackoff {
+		d = MaxJobRetryBackoff
+	}
+	return d
+}
+
+// JobFailureCause returns the cause of a failed job for its retry policy, timeout and
+// exit-code causes are set by the worker, an empty cause means that the failure was not classified.
+func JobFailureCause(res Result) string {
+	switch {
+	case res.TimedOut:
+		return JobRetryOnTimeout
+	case res.ExitCode != 0:
+		return JobRetryOnExitCode
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobRetryPolicyShouldRetry(t *testing.T) {
+	p := JobRetryPolicy{
+		MaxAttempts: 3,
+		On:          []string{JobRetryOnWorkerLost, JobRetryOnExitCode},
+		ExitCodes:   []int{2, 137},
+	}
+	assert.NoError(t, p.IsValid())
+
+	assert.True(t, p.ShouldRetry(0, JobRetryOnWorkerLost, 0))
+	assert.True(t, p.ShouldRetry(1, JobRetryOnExitCode, 137))
+	assert.False(t, p.ShouldRetry(2, JobRetryOnExitCode, 137), "max attempts reached")
+	assert.False(t, p.ShouldRetry(0, JobRetryOnExitCode, 1), "exit code not in policy")
+	assert.False(t, p.ShouldRetry(0, JobRetryOnTimeout, 0), "timeout not in policy")
+	assert.False(t, p.ShouldRetry(0, "", 0), "unclassified failure")
+
+	all := JobRetryPolicy{MaxAttempts: 2}
+	assert.True(t, all.ShouldRetry(0, JobRetryOnTimeout, 0))
+	assert.True(t, all.ShouldRetry(0, JobRetryOnExitCode, 1))
+	assert.True(t, all.ShouldRetry(0, "", 0))
+	assert.False(t, all.ShouldRetry(1, "", 0))
+}
+
+func TestJobRetryPolicyIsValid(t *testing.T) {
+	assert.Error(t, JobRetryPolicy{}.IsValid())
+	assert.Error(t, JobRetryPolicy{MaxAttempts: MaxJobRetryAttempts + 1}.IsValid())
+	assert.Error(t, JobRetryPolicy{MaxAttempts: 2, Backoff: -1}.IsValid())
+	assert.Error(t, JobRetryPolicy{MaxAttempts: 2, On: []string{"unknown"}}.IsValid())
+	assert.Error(t, JobRetryPolicy{MaxAttempts: 2, On: []string{JobRetryOnTimeout}, ExitCodes: []int{1}}.IsValid())
+	assert.Error(t, JobRetryPolicy{MaxAttempts: 2, ExitCodes: []int{0}}.IsValid())
+}
+
+func TestJobRetryPolicyDelay(t *testing.T) {
+	p := JobRetryPolicy{MaxAttempts: 10, Backoff: 30}
+	assert.Equal(t, time.Duration(0), p.Delay(1))
+	assert.Equal(t, 30*time.Second, p.Delay(2))
+	assert.Equal(t, time.Minute, p.Delay(3))
+	assert.Equal(t, 2*time.Minute, p.Delay(4))
+	assert.Equal(t, MaxJobRetryBackoff, p.Delay(10))
+	assert.Equal(t, time.Duration(0), JobRetryPolicy{MaxAttempts: 2}.Delay(2))
+}
+
+func TestJobFailureCause(t *testing.T) {
+	assert.Equal(t, JobRetryOnTimeout, JobFailureCause(Result{TimedOut: true, ExitCode: 1}))
+	assert.Equal(t, JobRetryOnExitCode, JobFailureCause(Result{ExitCode: 1}))
+	assert.Equal(t, "", JobFailureCause(Result{}))
+}
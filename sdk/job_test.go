@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobExpandMatrix(t *testing.T) {
+	j := Job{
+		Action: Action{
+			Name: "test",
+			Requirements: []Requirement{
+				{Name: "model", Type: ModelRequirement, Value: "golang"},
+				{Name: "git", Type: BinaryRequirement, Value: "git"},
+			},
+		},
+		Matrix: &JobMatrix{
+			Axes: map[string][]string{
+				"go": {"1.13", "1.14"},
+				"os": {"linux", "darwin"},
+			},
+			Exclude: []map[string]string{
+				{"go": "1.13", "os": "darwin"},
+			},
+			Include: []JobMatrixInclude{
+				{
+					Values:       map[string]string{"go": "1.14", "experimental": "true"},
+					Requirements: []Requirement{{Name: "model", Type: ModelRequirement, Value: "golang-1.14"}},
+				},
+				{
+					Values: map[string]string{"go": "1.15", "os": "linux"},
+				},
+			},
+		},
+	}
+	require.NoError(t, j.Matrix.IsValid())
+
+	jobs := j.ExpandMatrix()
+	require.Len(t, jobs, 4)
+
+	assert.Equal(t, "test (go: 1.13, os: linux)", jobs[0].Action.Name)
+	assert.Equal(t, map[string]string{"go": "1.13", "os": "linux"}, jobs[0].MatrixValues)
+	assert.Equal(t, j.Action.Requirements, jobs[0].Action.Requirements)
+	assert.Nil(t, jobs[0].Matrix)
+
+	assert.Equal(t, "test (experimental: true, go: 1.14, os: linux)", jobs[1].Action.Name)
+	assert.Equal(t, []Requirement{
+		{Name: "git", Type: BinaryRequirement, Value: "git"},
+		{Name: "model", Type: ModelRequirement, Value: "golang-1.14"},
+	}, jobs[1].Action.Requirements)
+
+	assert.Equal(t, "test (experimental: true, go: 1.14, os: darwin)", jobs[2].Action.Name)
+	assert.Equal(t, "test (go: 1.15, os: linux)", jobs[3].Action.Name)
+
+	// Job requirements should not have been modified
+	assert.Equal(t, "golang", j.Action.Requirements[0].Value)
+}
+
+func TestJobMatrixIsValid(t *testing.T) {
+	assert.Error(t, JobMatrix{}.IsValid())
+	assert.Error(t, JobMatrix{Axes: map[string][]string{"go version": {"1.13"}}}.IsValid())
+	assert.Error(t, JobMatrix{Axes: map[string][]string{"go": {}}}.IsValid())
+	assert.Error(t, JobMatrix{
+		Axes:    map[string][]string{"go": {"1.13"}},
+		Exclude: []map[string]string{{"os": "linux"}},
+	}.IsValid())
+
+	values := make([]string, 20)
+	assert.Error(t, JobMatrix{Axes: map[string][]string{"a": values, "b": values}}.IsValid())
+
+	// The combinations are not built when the axes expand to too many jobs
+	axes := map[string][]string{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		axes[name] = make([]string, 16)
+	}
+	err := JobMatrix{Axes: axes}.IsValid()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "job matrix axes expand to more than 256 jobs")
+}
@@ -0,0 +1,112 @@
+package sdk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Merge queue entry status.
+const (
+	MergeQueueStatusWaiting  = "Waiting"
+	MergeQueueStatusMerging  = "Merging"
+	MergeQueueStatusBuilding = "Building"
+	MergeQueueStatusMerged   = "Merged"
+	MergeQueueStatusFailed   = "Failed"
+)
+
+// MergeQueueHookEvents are the repository events that can add a pull request to a merge queue.
+var MergeQueueHookEvents = []string{
+	"pull_request",
+	"pull_request_review",
+	"Merge Request Hook",
+	"pr:reviewer:approved",
+}
+
+// MergeQueueEvents returns the given repository events that can add a pull request to a merge queue.
+func MergeQueueEvents(events []string) []string {
+	var res []string
+	for _, e := range events {
+		if IsInArray(e, MergeQueueHookEvents) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// MergeQueueEntry is a pull request waiting to be tested on a merge commit before being merged.
+type MergeQueueEntry struct {
+	ID                 int64     `json:"id" db:"id" cli:"id,key"`
+	ProjectID          int64     `json:"project_id" db:"project_id" cli:"-"`
+	WorkflowID         int64     `json:"workflow_id" db:"workflow_id" cli:"-"`
+	HookUUID           string    `json:"hook_uuid" db:"hook_uuid" cli:"-"`
+	ConsumerID         string    `json:"-" db:"consumer_id" cli:"-"`
+	VCSServer          string    `json:"vcs_server" db:"vcs_server" cli:"-"`
+	RepositoryFullname string    `json:"repository_fullname" db:"repository_fullname" cli:"repository"`
+	PullRequestID      int64     `json:"pull_request_id" db:"pull_request_id" cli:"pull_request"`
+	BaseBranch         string    `json:"base_branch" db:"base_branch" cli:"base"`
+	HeadBranch         string    `json:"head_branch" db:"head_branch" cli:"head"`
+	HeadCommit         string    `json:"head_commit" db:"head_commit" cli:"-"`
+	MergeBranch        string    `json:"merge_branch,omitempty" db:"merge_branch" cli:"-"`
+	MergeCommit        string    `json:"merge_commit,omitempty" db:"merge_commit" cli:"-"`
+	OperationUUID      string    `json:"operation_uuid,omitempty" db:"operation_uuid" cli:"-"`
+	WorkflowRunID      int64     `json:"workflow_run_id,omitempty" db:"workflow_run_id" cli:"-"`
+	WorkflowRunNumber  int64     `json:"workflow_run_number,omitempty" db:"workflow_run_number" cli:"run"`
+	Status             string    `json:"status" db:"status" cli:"status"`
+	Message            string    `json:"message,omitempty" db:"message" cli:"message"`
+	Created            time.Time `json:"created" db:"created" cli:"created"`
+	LastModified       time.Time `json:"last_modified" db:"last_modified" cli:"-"`
+}
+
+// IsValid returns an error if the entry can't be added to a merge queue.
+func (e MergeQueueEntry) IsValid() error {
+	if e.HookUUID == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid merge queue entry: missing hook uuid")
+	}
+	if e.RepositoryFullname == "" || e.PullRequestID == 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid merge queue entry: missing repository or pull request")
+	}
+	if e.BaseBranch == "" || e.HeadBranch == "" || e.HeadCommit == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid merge queue entry: missing base branch, head branch or head commit")
+	}
+	// Branches and commit are given to git commands
+	if strings.HasPrefix(e.BaseBranch, "-") || strings.HasPrefix(e.HeadBranch, "-") {
+		return NewErrorFrom(ErrWrongRequest, "invalid merge queue entry: invalid base branch or head branch")
+	}
+	if !IsCommitHash(e.HeadCommit) {
+		return NewErrorFrom(ErrWrongRequest, "invalid merge queue entry: invalid head commit %q", e.HeadCommit)
+	}
+	return nil
+}
+
+// IsFinal returns true if the entry left the merge queue.
+func (e MergeQueueEntry) IsFinal() bool {
+	return e.Status == MergeQueueStatusMerged || e.Status == MergeQueueStatusFailed
+}
+
+// QueueKey returns the key of the queue of the entry, pull requests targeting the same
+// branch of a repository are tested one after the other, whatever the workflow testing them.
+func (e MergeQueueEntry) QueueKey() string {
+	return fmt.Sprintf("%s/%s", e.RepositoryFullname, e.BaseBranch)
+}
+
+// MergeQueueNextEntries returns the waiting entries that can be started: the oldest waiting
+// entry of each queue that has no entry being merged or built. Entries should be sorted by id.
+func MergeQueueNextEntries(entries []MergeQueueEntry) []MergeQueueEntry {
+	busy := make(map[string]bool)
+	for _, e := range entries {
+		if e.Status == MergeQueueStatusMerging || e.Status == MergeQueueStatusBuilding {
+			busy[e.QueueKey()] = true
+		}
+	}
+
+	var res []MergeQueueEntry
+	for _, e := range entries {
+		if e.Status != MergeQueueStatusWaiting || busy[e.QueueKey()] {
+			continue
+		}
+		busy[e.QueueKey()] = true
+		res = append(res, e)
+	}
+	return res
+}
@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeQueueNextEntries(t *testing.T) {
+	entries := []MergeQueueEntry{
+		{ID: 1, WorkflowID: 1, RepositoryFullname: "foo/bar", BaseBranch: "master", Status: MergeQueueStatusMerged},
+		{ID: 2, WorkflowID: 1, RepositoryFullname: "foo/bar", BaseBranch: "master", Status: MergeQueueStatusBuilding},
+		{ID: 3, WorkflowID: 1, RepositoryFullname: "foo/bar", BaseBranch: "master", Status: MergeQueueStatusWaiting},
+		{ID: 4, WorkflowID: 1, RepositoryFullname: "foo/bar", BaseBranch: "develop", Status: MergeQueueStatusWaiting},
+		{ID: 5, WorkflowID: 1, RepositoryFullname: "foo/bar", BaseBranch: "develop", Status: MergeQueueStatusWaiting},
+		{ID: 6, WorkflowID: 2, RepositoryFullname: "foo/bar", BaseBranch: "master", Status: MergeQueueStatusWaiting},
+		{ID: 7, WorkflowID: 2, RepositoryFullname: "foo/baz", BaseBranch: "master", Status: MergeQueueStatusWaiting},
+	}
+
+	next := MergeQueueNextEntries(entries)
+	ids := make([]int64, len(next))
+	for i := range next {
+		ids[i] = next[i].ID
+	}
+	assert.Equal(t, []int64{4, 7}, ids)
+}
+
+func TestMergeQueueEntryIsValid(t *testing.T) {
+	e := MergeQueueEntry{
+		HookUUID:           "uuid",
+		RepositoryFullname: "foo/bar",
+		PullRequestID:      1,
+		BaseBranch:         "master",
+		HeadBranch:         "feat/foo",
+		HeadCommit:         "2c4a3d8e6f0b1a9c7d5e3f1b0a2c4e6d8f0a1b3c",
+	}
+	assert.NoError(t, e.IsValid())
+
+	invalid := e
+	invalid.HeadCommit = "--upload-pack=touch /tmp/foo"
+	assert.Error(t, invalid.IsValid())
+	invalid.HeadCommit = "master"
+	assert.Error(t, invalid.IsValid())
+
+	invalid = e
+	invalid.HeadBranch = "-b"
+	assert.Error(t, invalid.IsValid())
+}
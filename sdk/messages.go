@@ -72,6 +72,7 @@ var (
 	MsgSpawnInfoJobTakenWorkerVersion      = &Message{"MsgSpawnInfoJobTakenWorkerVersion", trad{FR: "Worker %s version:%s os:%s arch:%s", EN: "Worker %s version:%s os:%s arch:%s"}, nil}
 	MsgSpawnInfoWorkerForJob               = &Message{"MsgSpawnInfoWorkerForJob", trad{FR: "Ce worker %s a été créé pour lancer ce job", EN: "This worker %s was created to take this action"}, nil}
 	MsgSpawnInfoWorkerForJobError          = &Message{"MsgSpawnInfoWorkerForJobError", trad{FR: "⚠ Ce worker %s a été créé pour lancer ce job, mais ne possède pas tous les pré-requis. Vérifiez que les prérequis suivants:%s", EN: "⚠ This worker %s was created to take this action, but does not have all prerequisites. Please verify the following prerequisites:%s"}, nil}
+	MsgSpawnInfoJobRetry                   = &Message{"MsgSpawnInfoJobRetry", trad{FR: "↻ Le job a échoué (%s), il a été remis en file d'attente pour la tentative %d/%d dans %s", EN: "↻ Job failed (%s), it has been put back in queue for attempt %d/%d in %s"}, nil}
 	MsgSpawnInfoJobError                   = &Message{"MsgSpawnInfoJobError", trad{FR: "⚠ Impossible de lancer ce job : %s", EN: "⚠ Unable to run this job: %s"}, nil}
 	MsgWorkflowStarting                    = &Message{"MsgWorkflowStarting", trad{FR: "Le workflow %s#%s a été démarré", EN: "Workflow %s#%s has been started"}, nil}
 	MsgWorkflowError                       = &Message{"MsgWorkflowError", trad{FR: "⚠ Une erreur est survenue: %v", EN: "⚠ An error has occurred: %v"}, nil}
@@ -79,6 +80,9 @@ var (
 	MsgWorkflowNodeStop                    = &Message{"MsgWorkflowNodeStop", trad{FR: "Le pipeline a été arrété par %s", EN: "The pipeline has been stopped by %s"}, nil}
 	MsgWorkflowNodeMutex                   = &Message{"MsgWorkflowNodeMutex", trad{FR: "Le pipeline %s est mis en attente tant qu'il est en cours sur un autre run", EN: "The pipeline %s is waiting while it's running on another run"}, nil}
 	MsgWorkflowNodeMutexRelease            = &Message{"MsgWorkflowNodeMutexRelease", trad{FR: "Lancement du pipeline %s", EN: "Triggering pipeline %s"}, nil}
+	MsgWorkflowNodeConcurrency             = &Message{"MsgWorkflowNodeConcurrency", trad{FR: "Le pipeline %s est mis en attente tant que le groupe de concurrence %s est occupé", EN: "The pipeline %s is waiting for a slot in concurrency group %s"}, nil}
+	MsgWorkflowNodeConcurrencyRelease      = &Message{"MsgWorkflowNodeConcurrencyRelease", trad{FR: "Lancement du pipeline %s, le groupe de concurrence %s est libre", EN: "Triggering pipeline %s, concurrency group %s is free"}, nil}
+	MsgWorkflowNodeConcurrencyCancel       = &Message{"MsgWorkflowNodeConcurrencyCancel", trad{FR: "Le pipeline %s a été arrêté par un run plus récent (#%d) sur la branche %s dans le groupe de concurrence %s", EN: "The pipeline %s has been stopped by a newer run (#%d) on branch %s in concurrency group %s"}, nil}
 	MsgWorkflowImportedUpdated             = &Message{"MsgWorkflowImportedUpdated", trad{FR: "Le workflow %s a été mis à jour", EN: "Workflow %s has been updated"}, nil}
 	MsgWorkflowImportedInserted            = &Message{"MsgWorkflowImportedInserted", trad{FR: "Le workflow %s a été créé", EN: "Workflow %s has been created"}, nil}
 	MsgSpawnInfoHatcheryCannotStartJob     = &Message{"MsgSpawnInfoHatcheryCannotStart", trad{FR: "Aucune hatchery n'a pu démarrer de worker respectant vos pré-requis de job, merci de les vérifier.", EN: "No hatchery can spawn a worker corresponding your job's requirements. Please check your job's requirements."}, nil}
@@ -149,12 +153,16 @@ var Messages = map[string]*Message{
 	MsgSpawnInfoWorkerForJob.ID:               MsgSpawnInfoWorkerForJob,
 	MsgSpawnInfoWorkerForJobError.ID:          MsgSpawnInfoWorkerForJobError,
 	MsgSpawnInfoJobError.ID:                   MsgSpawnInfoJobError,
+	MsgSpawnInfoJobRetry.ID:                   MsgSpawnInfoJobRetry,
 	MsgWorkflowStarting.ID:                    MsgWorkflowStarting,
 	MsgWorkflowError.ID:                       MsgWorkflowError,
 	MsgWorkflowConditionError.ID:              MsgWorkflowConditionError,
 	MsgWorkflowNodeStop.ID:                    MsgWorkflowNodeStop,
 	MsgWorkflowNodeMutex.ID:                   MsgWorkflowNodeMutex,
 	MsgWorkflowNodeMutexRelease.ID:            MsgWorkflowNodeMutexRelease,
+	MsgWorkflowNodeConcurrency.ID:             MsgWorkflowNodeConcurrency,
+	MsgWorkflowNodeConcurrencyRelease.ID:      MsgWorkflowNodeConcurrencyRelease,
+	MsgWorkflowNodeConcurrencyCancel.ID:       MsgWorkflowNodeConcurrencyCancel,
 	MsgWorkflowImportedUpdated.ID:             MsgWorkflowImportedUpdated,
 	MsgWorkflowImportedInserted.ID:            MsgWorkflowImportedInserted,
 	MsgSpawnInfoHatcheryCannotStartJob.ID:     MsgSpawnInfoHatcheryCannotStartJob,
@@ -4,6 +4,7 @@ package sdk
 const (
 	GRPCPluginDeploymentIntegration = "integration-deploy_application"
 	GRPCPluginAction                = "action"
+	GRPCPluginHook                  = "hook"
 )
 
 // GRPCPlugin is the type representing a plugin over GRPC
@@ -0,0 +1,114 @@
+package sdk
+
+import "fmt"
+
+// Kinds of storage tracked by the project storage quotas.
+const (
+	StorageKindArtifacts   = "artifacts"
+	StorageKindStaticFiles = "static_files"
+	StorageKindCache       = "cache"
+)
+
+// StorageKinds lists all the kinds of storage tracked by the project storage quotas.
+var StorageKinds = []string{StorageKindArtifacts, StorageKindStaticFiles, StorageKindCache}
+
+// ProjectStorageQuota is the storage quota of a project in bytes for each kind of storage.
+// A zero value means that the default quota of the API is used, a negative value means unlimited.
+type ProjectStorageQuota struct {
+	ProjectID   int64 `json:"project_id,omitempty" db:"project_id"`
+	Artifacts   int64 `json:"artifacts" db:"artifacts"`
+	StaticFiles int64 `json:"static_files" db:"static_files"`
+	Cache       int64 `json:"cache" db:"cache"`
+}
+
+// WithDefaults returns the quota where zero values are replaced by given default values.
+func (q ProjectStorageQuota) WithDefaults(defaults ProjectStorageQuota) ProjectStorageQuota {
+	if q.Artifacts == 0 {
+		q.Artifacts = defaults.Artifacts
+	}
+	if q.StaticFiles == 0 {
+		q.StaticFiles = defaults.StaticFiles
+	}
+	if q.Cache == 0 {
+		q.Cache = defaults.Cache
+	}
+	return q
+}
+
+// Limit returns the quota for given kind of storage, zero or negative means unlimited.
+func (q ProjectStorageQuota) Limit(kind string) int64 {
+	switch kind {
+	case StorageKindArtifacts:
+		return q.Artifacts
+	case StorageKindStaticFiles:
+		return q.StaticFiles
+	case StorageKindCache:
+		return q.Cache
+	}
+	return 0
+}
+
+// ProjectStorageUsage is the storage used by a project in bytes with its effective quota.
+type ProjectStorageUsage struct {
+	ProjectID   int64               `json:"project_id" cli:"-"`
+	ProjectKey  string              `json:"project_key" cli:"project,key"`
+	Artifacts   int64               `json:"artifacts" cli:"artifacts"`
+	StaticFiles int64               `json:"static_files" cli:"static_files"`
+	Cache       int64               `json:"cache" cli:"cache"`
+	Total       int64               `json:"total" cli:"total"`
+	Quota       ProjectStorageQuota `json:"quota" cli:"-"`
+}
+
+// Used returns the storage used for given kind of storage.
+func (u ProjectStorageUsage) Used(kind string) int64 {
+	switch kind {
+	case StorageKindArtifacts:
+		return u.Artifacts
+	case StorageKindStaticFiles:
+		return u.StaticFiles
+	case StorageKindCache:
+		return u.Cache
+	}
+	return 0
+}
+
+// Add adds size bytes to the storage used for given kind of storage.
+func (u *ProjectStorageUsage) Add(kind string, size int64) {
+	switch kind {
+	case StorageKindArtifacts:
+		u.Artifacts += size
+	case StorageKindStaticFiles:
+		u.StaticFiles += size
+	case StorageKindCache:
+		u.Cache += size
+	default:
+		return
+	}
+	u.Total += size
+}
+
+// CheckQuota returns an error if storing size bytes of given kind exceeds the project quota.
+func (u ProjectStorageUsage) CheckQuota(kind string, size int64) error {
+	limit := u.Quota.Limit(kind)
+	if limit <= 0 {
+		return nil
+	}
+	if used := u.Used(kind); used+size > limit {
+		return NewErrorFrom(ErrStorageQuotaExceeded, "%s quota of project %s exceeded: %s used on %s, cannot store %s more",
+			kind, u.ProjectKey, formatStorageSize(used), formatStorageSize(limit), formatStorageSize(size))
+	}
+	return nil
+}
+
+func formatStorageSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit && exp < 3; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(size)/float64(div), "KMGT"[exp])
+}
@@ -0,0 +1,42 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectStorageUsageCheckQuota(t *testing.T) {
+	quota := ProjectStorageQuota{Artifacts: 1024, Cache: -1}.WithDefaults(ProjectStorageQuota{
+		Artifacts:   2048,
+		StaticFiles: 100,
+		Cache:       100,
+	})
+	assert.Equal(t, ProjectStorageQuota{Artifacts: 1024, StaticFiles: 100, Cache: -1}, quota)
+
+	usage := ProjectStorageUsage{
+		ProjectKey:  "MY_PROJECT",
+		Artifacts:   1000,
+		StaticFiles: 50,
+		Cache:       5000,
+		Quota:       quota,
+	}
+
+	assert.NoError(t, usage.CheckQuota(StorageKindArtifacts, 24))
+	assert.NoError(t, usage.CheckQuota(StorageKindCache, 1<<30))
+	assert.NoError(t, usage.CheckQuota(StorageKindStaticFiles, 50))
+
+	err := usage.CheckQuota(StorageKindArtifacts, 2048)
+	require.Error(t, err)
+	assert.True(t, ErrorIs(err, ErrStorageQuotaExceeded))
+	assert.Contains(t, err.Error(), "artifacts quota of project MY_PROJECT exceeded: 1000B used on 1.0KB, cannot store 2.0KB more")
+}
+
+func TestProjectStorageUsageAdd(t *testing.T) {
+	usage := ProjectStorageUsage{Artifacts: 10, Cache: 5, Total: 15}
+	usage.Add(StorageKindArtifacts, 20)
+	usage.Add(StorageKindCache, -5)
+	usage.Add("unknown", 100)
+	assert.Equal(t, ProjectStorageUsage{Artifacts: 30, Cache: 0, Total: 30}, usage)
+}
@@ -0,0 +1,213 @@
+package sdk
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Types of the provenance documents, see https://github.com/in-toto/attestation and https://slsa.dev/provenance/v0.2
+const (
+	ProvenancePayloadType       = "application/vnd.in-toto+json"
+	InTotoStatementType         = "https://in-toto.io/Statement/v0.1"
+	SLSAProvenancePredicateType = "https://slsa.dev/provenance/v0.2"
+	ProvenanceBuildType         = "https://ovh.github.io/cds/provenance/workflow-job/v1"
+)
+
+// ProvenanceEnvelope is a signed provenance statement, in the DSSE format (https://github.com/secure-systems-lab/dsse)
+type ProvenanceEnvelope struct {
+	PayloadType string                `json:"payloadType"`
+	Payload     string                `json:"payload"`
+	Signatures  []ProvenanceSignature `json:"signatures"`
+}
+
+// ProvenanceSignature is a signature of a provenance envelope
+type ProvenanceSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// ProvenanceStatement is an in-toto statement with a SLSA provenance predicate
+type ProvenanceStatement struct {
+	Type          string              `json:"_type"`
+	Subject       []ProvenanceSubject `json:"subject"`
+	PredicateType string              `json:"predicateType"`
+	Predicate     SLSAProvenance      `json:"predicate"`
+}
+
+// ProvenanceSubject is an artifact described by a provenance statement
+type ProvenanceSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// SLSAProvenance describes how an artifact was built
+type SLSAProvenance struct {
+	Builder struct {
+		ID string `json:"id"`
+	} `json:"builder"`
+	BuildType  string                   `json:"buildType"`
+	Invocation SLSAProvenanceInvocation `json:"invocation"`
+	Metadata   SLSAProvenanceMetadata   `json:"metadata"`
+	Materials  []ProvenanceMaterial     `json:"materials,omitempty"`
+}
+
+// SLSAProvenanceInvocation is the workflow, the parameters and the environment of the job that built an artifact
+type SLSAProvenanceInvocation struct {
+	ConfigSource ProvenanceConfigSource `json:"configSource"`
+	Parameters   map[string]string      `json:"parameters,omitempty"`
+	Environment  map[string]string      `json:"environment,omitempty"`
+}
+
+// ProvenanceConfigSource is the source of the build configuration
+type ProvenanceConfigSource struct {
+	URI        string            `json:"uri,omitempty"`
+	Digest     map[string]string `json:"digest,omitempty"`
+	EntryPoint string            `json:"entryPoint"`
+}
+
+// SLSAProvenanceMetadata gives the identifier and the dates of the build
+type SLSAProvenanceMetadata struct {
+	BuildInvocationID string     `json:"buildInvocationId"`
+	BuildStartedOn    *time.Time `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   *time.Time `json:"buildFinishedOn,omitempty"`
+	Completeness      struct {
+		Parameters  bool `json:"parameters"`
+		Environment bool `json:"environment"`
+		Materials   bool `json:"materials"`
+	} `json:"completeness"`
+	Reproducible bool `json:"reproducible"`
+}
+
+// ProvenanceMaterial is an input of the build, ie. the git repository
+type ProvenanceMaterial struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// WorkflowNodeRunArtifactProvenance is the signed provenance of an artifact
+type WorkflowNodeRunArtifactProvenance struct {
+	ID            int64              `json:"id" db:"id"`
+	ArtifactID    int64              `json:"artifact_id" db:"artifact_id"`
+	WorkflowRunID int64              `json:"workflow_run_id" db:"workflow_run_id"`
+	KeyID         string             `json:"key_id" db:"key_id"`
+	Created       time.Time          `json:"created" db:"created"`
+	Envelope      ProvenanceEnvelope `json:"envelope" db:"-"`
+}
+
+// ProvenancePublicKey is the public key used to verify the provenance of the artifacts of a project
+type ProvenancePublicKey struct {
+	KeyID     string `json:"key_id" cli:"key_id"`
+	PublicKey string `json:"public_key" cli:"public_key"`
+}
+
+// DSSEPreAuthEncoding returns the data signed for a DSSE envelope: "DSSEv1" SP LEN(type) SP type SP LEN(body) SP body
+func DSSEPreAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// ProvenanceKeyID returns the identifier of a public key: the sha256 checksum of its PKIX encoding
+func ProvenanceKeyID(pub *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", WithStack(err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ParseProvenancePublicKey parses a PEM encoded RSA public key
+func ParseProvenancePublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid public key: no PEM public key found")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid public key: %v", err)
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid public key: only RSA keys are supported")
+	}
+	return rsaPub, nil
+}
+
+// NewProvenanceEnvelope returns the envelope of a statement, signed by the given function
+func NewProvenanceEnvelope(statement ProvenanceStatement, keyID string, sign func(data []byte) ([]byte, error)) (*ProvenanceEnvelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, WithStack(err)
+	}
+	sig, err := sign(DSSEPreAuthEncoding(ProvenancePayloadType, payload))
+	if err != nil {
+		return nil, err
+	}
+	return &ProvenanceEnvelope{
+		PayloadType: ProvenancePayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []ProvenanceSignature{{KeyID: keyID, Sig: base64.StdEncoding.EncodeToString(sig)}},
+	}, nil
+}
+
+// Verify checks that the envelope is signed by the given key with RSA PKCS#1 v1.5 and SHA-256, and returns its statement
+func (e ProvenanceEnvelope) Verify(pub *rsa.PublicKey) (*ProvenanceStatement, error) {
+	if e.PayloadType != ProvenancePayloadType {
+		return nil, NewErrorFrom(ErrWrongRequest, "unsupported payload type %q", e.PayloadType)
+	}
+	keyID, err := ProvenanceKeyID(pub)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid payload: %v", err)
+	}
+
+	digest := sha256.Sum256(DSSEPreAuthEncoding(e.PayloadType, payload))
+	var verified bool
+	for _, s := range e.Signatures {
+		if s.KeyID != "" && s.KeyID != keyID {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, NewErrorFrom(ErrWrongRequest, "no valid signature found for key %s", keyID)
+	}
+
+	var statement ProvenanceStatement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid statement: %v", err)
+	}
+	if statement.Type != InTotoStatementType || statement.PredicateType != SLSAProvenancePredicateType {
+		return nil, NewErrorFrom(ErrWrongRequest, "unsupported statement %s with predicate %s", statement.Type, statement.PredicateType)
+	}
+	return &statement, nil
+}
+
+// CheckSubject returns the name of the artifact with the given sha512 checksum described by the statement,
+// or an error if there is none. The name is not checked as a downloaded artifact can be renamed.
+func (s ProvenanceStatement) CheckSubject(sha512sum string) (string, error) {
+	for _, subject := range s.Subject {
+		if strings.EqualFold(subject.Digest["sha512"], sha512sum) {
+			return subject.Name, nil
+		}
+	}
+	return "", NewErrorFrom(ErrWrongRequest, "the provenance doesn't describe an artifact with sha512 %s", sha512sum)
+}
@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvenanceEnvelope(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyID, err := ProvenanceKeyID(&key.PublicKey)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	pub, err := ParseProvenancePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+
+	statement := ProvenanceStatement{
+		Type:          InTotoStatementType,
+		Subject:       []ProvenanceSubject{{Name: "my-app.tar.gz", Digest: map[string]string{"sha512": "ABCD"}}},
+		PredicateType: SLSAProvenancePredicateType,
+	}
+	statement.Predicate.BuildType = ProvenanceBuildType
+	envelope, err := NewProvenanceEnvelope(statement, keyID, func(data []byte) ([]byte, error) {
+		digest := sha256.Sum256(data)
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	})
+	require.NoError(t, err)
+	assert.Equal(t, keyID, envelope.Signatures[0].KeyID)
+
+	verified, err := envelope.Verify(pub)
+	require.NoError(t, err)
+	assert.Equal(t, statement, *verified)
+	name, err := verified.CheckSubject("abcd")
+	require.NoError(t, err)
+	assert.Equal(t, "my-app.tar.gz", name)
+	_, err = verified.CheckSubject("ef01")
+	require.Error(t, err)
+
+	// The payload can't be changed
+	tampered := *envelope
+	tampered.Payload = base64.StdEncoding.EncodeToString([]byte(`{"_type": "https://in-toto.io/Statement/v0.1"}`))
+	_, err = tampered.Verify(pub)
+	require.Error(t, err)
+
+	// Nor verified with another key
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, err = envelope.Verify(&otherKey.PublicKey)
+	require.Error(t, err)
+}
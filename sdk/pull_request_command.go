@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"strings"
+)
+
+// PullRequestCommandPrefix starts the pull request comments that are CDS commands, ie. "/cds deploy staging".
+const PullRequestCommandPrefix = "/cds"
+
+// PullRequestCommandHookEvents are the repository events of a comment on a pull request.
+var PullRequestCommandHookEvents = []string{
+	"issue_comment",
+	"Note Hook",
+	"pr:comment:added",
+}
+
+// PullRequestCommandEvents returns the given repository events that are comments on a pull request.
+func PullRequestCommandEvents(events []string) []string {
+	var res []string
+	for _, e := range events {
+		if IsInArray(e, PullRequestCommandHookEvents) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// PullRequestCommand is a command written in a comment of a pull request.
+type PullRequestCommand struct {
+	HookUUID           string   `json:"hook_uuid"`
+	RepositoryFullname string   `json:"repository_fullname"`
+	PullRequestID      int64    `json:"pull_request_id"`
+	HeadBranch         string   `json:"head_branch,omitempty"`
+	HeadCommit         string   `json:"head_commit,omitempty"`
+	Author             string   `json:"author"`
+	Command            string   `json:"command"`
+	Args               []string `json:"args,omitempty"`
+}
+
+// IsValid returns an error if the command can't trigger a workflow.
+func (c PullRequestCommand) IsValid() error {
+	if c.HookUUID == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid pull request command: missing hook uuid")
+	}
+	if c.RepositoryFullname == "" || c.PullRequestID == 0 {
+		return NewErrorFrom(ErrWrongRequest, "invalid pull request command: missing repository or pull request")
+	}
+	if c.Author == "" || c.Command == "" {
+		return NewErrorFrom(ErrWrongRequest, "invalid pull request command: missing author or command")
+	}
+	return nil
+}
+
+// String returns the command as written in the comment.
+func (c PullRequestCommand) String() string {
+	return strings.Join(append([]string{PullRequestCommandPrefix, c.Command}, c.Args...), " ")
+}
+
+// ParsePullRequestCommand returns the command and its arguments from the first line of a comment
+// starting with "/cds", if the command is one of the given commands.
+func ParsePullRequestCommand(comment string, commands []string) (string, []string, bool) {
+	for _, line := range strings.Split(comment, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != PullRequestCommandPrefix {
+			continue
+		}
+		if len(fields) < 2 || !IsInArray(fields[1], commands) {
+			return "", nil, false
+		}
+		return fields[1], fields[2:], true
+	}
+	return "", nil, false
+}
@@ -0,0 +1,36 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePullRequestCommand(t *testing.T) {
+	commands := []string{"rerun", "deploy"}
+	tests := []struct {
+		comment string
+		command string
+		args    []string
+		found   bool
+	}{
+		{comment: "/cds rerun", command: "rerun", args: []string{}, found: true},
+		{comment: "LGTM\n\n  /cds   deploy staging eu-west  \nthanks", command: "deploy", args: []string{"staging", "eu-west"}, found: true},
+		{comment: "/cds deploy\n/cds rerun", command: "deploy", args: []string{}, found: true},
+		{comment: "/cds destroy production", found: false},
+		{comment: "/cds", found: false},
+		{comment: "please /cds rerun", found: false},
+		{comment: "/cdsrerun", found: false},
+	}
+	for _, tt := range tests {
+		command, args, found := ParsePullRequestCommand(tt.comment, commands)
+		assert.Equal(t, tt.found, found, tt.comment)
+		assert.Equal(t, tt.command, command, tt.comment)
+		if tt.found {
+			assert.Equal(t, tt.args, args, tt.comment)
+		}
+	}
+
+	c := PullRequestCommand{Command: "deploy", Args: []string{"staging"}}
+	assert.Equal(t, "/cds deploy staging", c.String())
+}
@@ -1,9 +1,17 @@
 package sdk
 
 import (
+	"regexp"
 	"time"
 )
 
+var commitHashRegexp = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// IsCommitHash returns true if the given string is a full SHA-1 or SHA-256 git commit hash.
+func IsCommitHash(s string) bool {
+	return commitHashRegexp.MatchString(s)
+}
+
 // RepositoryEvents group all repository events
 type RepositoryEvents struct {
 	PushEvents        []VCSPushEvent        `json:"push_events" db:"-"`
@@ -93,6 +101,15 @@ type VCSPullRequest struct {
 	Title  string       `json:"title"`
 	Merged bool         `json:"merged"`
 	Closed bool         `json:"closed"`
+	// Labels and Approved are only given for a single pull request
+	Labels   []string `json:"labels,omitempty"`
+	Approved bool     `json:"approved"`
+}
+
+//VCSPullRequestComment represents a comment of a pull request
+type VCSPullRequestComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
 }
 
 //VCSPushEvent represents a push events for polling
@@ -134,6 +151,7 @@ type VCSHook struct {
 	Disable     bool     `json:"disable"`
 	InsecureSSL bool     `json:"insecure_ssl"`
 	Workflow    bool     `json:"workflow"`
+	Secret      string   `json:"secret,omitempty"`
 }
 
 // VCSCommitStatus represents a status on a VCS repository
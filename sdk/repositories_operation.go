@@ -27,8 +27,10 @@ type Operation struct {
 
 // OperationSetup is the setup for an operation basically its a checkout
 type OperationSetup struct {
-	Checkout OperationCheckout `json:"checkout,omitempty"`
-	Push     OperationPush     `json:"push,omitempty"`
+	Checkout     OperationCheckout     `json:"checkout,omitempty"`
+	Push         OperationPush         `json:"push,omitempty"`
+	Merge        OperationMerge        `json:"merge,omitempty"`
+	DeleteBranch OperationDeleteBranch `json:"delete_branch,omitempty"`
 }
 
 // OperationRepositoryInfo represents global information about the repository
@@ -60,6 +62,25 @@ type OperationPush struct {
 	Update     bool   `json:"update,omitempty"`
 }
 
+// OperationMerge represents information about a merge operation, it merges a commit of head branch
+// on base branch and pushes the result on a new branch
+type OperationMerge struct {
+	BaseBranch string `json:"base_branch,omitempty"`
+	HeadBranch string `json:"head_branch,omitempty"`
+	HeadCommit string `json:"head_commit,omitempty"`
+	// PullRequestID is given to fetch the head commit from the pull request, its branch may be on a fork
+	PullRequestID int64  `json:"pull_request_id,omitempty"`
+	ToBranch      string `json:"to_branch,omitempty"`
+	Message       string `json:"message,omitempty"`
+	// Commit is the merge commit pushed on ToBranch
+	Commit string `json:"commit,omitempty"`
+}
+
+// OperationDeleteBranch represents information about a delete branch operation
+type OperationDeleteBranch struct {
+	Branch string `json:"branch,omitempty"`
+}
+
 // OperationStatus is the status of an operation
 type OperationStatus int
 
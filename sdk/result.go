@@ -11,4 +11,6 @@ type Result struct {
 	RemoteTime   time.Time  `json:"remoteTime,omitempty"`
 	Duration     string     `json:"duration,omitempty"`
 	NewVariables []Variable `json:"new_variables,omitempty"`
+	ExitCode     int        `json:"exit_code,omitempty"`
+	TimedOut     bool       `json:"timed_out,omitempty"`
 }
@@ -0,0 +1,307 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Formats of the Software Bill of Materials accepted by CDS
+const (
+	SBOMFormatSPDX      = "spdx"
+	SBOMFormatCycloneDX = "cyclonedx"
+)
+
+// SBOMMaxSize is the maximum size of a SBOM uploaded by a worker
+const SBOMMaxSize = 50 * 1024 * 1024
+
+// WorkflowNodeRunSBOM is a Software Bill of Materials attached to a node run. The document is stored in
+// the shared object store and its components are indexed to be searched.
+type WorkflowNodeRunSBOM struct {
+	ID                int64     `json:"id" db:"id" cli:"id,key"`
+	ProjectID         int64     `json:"project_id" db:"project_id" cli:"-"`
+	ApplicationID     int64     `json:"application_id,omitempty" db:"application_id" cli:"-"`
+	WorkflowID        int64     `json:"workflow_id" db:"workflow_id" cli:"-"`
+	WorkflowRunID     int64     `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowNodeRunID int64     `json:"workflow_node_run_id" db:"workflow_node_run_id" cli:"-"`
+	WorkflowNodeName  string    `json:"workflow_node_name" db:"workflow_node_name" cli:"node"`
+	Num               int64     `json:"num" db:"workflow_number" cli:"-"`
+	Branch            string    `json:"branch" db:"branch" cli:"branch"`
+	Name              string    `json:"name" db:"name" cli:"name"`
+	Format            string    `json:"format" db:"format" cli:"format"`
+	SpecVersion       string    `json:"spec_version" db:"spec_version" cli:"spec_version"`
+	SHA256            string    `json:"sha256" db:"sha256" cli:"-"`
+	Size              int64     `json:"size" db:"size" cli:"size"`
+	Components        int64     `json:"components" db:"components" cli:"components"`
+	Created           time.Time `json:"created" db:"created" cli:"created"`
+}
+
+// GetName returns the name of the SBOM in the object store
+func (s *WorkflowNodeRunSBOM) GetName() string {
+	return fmt.Sprintf("%d-%s", s.WorkflowNodeRunID, s.SHA256)
+}
+
+// GetPath returns the path of the SBOM in the object store
+func (s *WorkflowNodeRunSBOM) GetPath() string {
+	return fmt.Sprintf("sbom-%d-%d", s.ProjectID, s.WorkflowRunID)
+}
+
+// SBOMComponent is a component listed in a SBOM
+type SBOMComponent struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	PURL    string `json:"purl,omitempty"`
+}
+
+// SBOMPURLBase returns the package URL without its qualifiers and subpath.
+func SBOMPURLBase(purl string) string {
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		return purl[:i]
+	}
+	return purl
+}
+
+// SBOMDocument is the result of the parsing of a SBOM
+type SBOMDocument struct {
+	Format      string
+	SpecVersion string
+	Components  []SBOMComponent
+}
+
+// SBOMSearchResult is a node run with a SBOM that contains a searched component
+type SBOMSearchResult struct {
+	SBOMID           int64  `json:"sbom_id" db:"sbom_id" cli:"-"`
+	WorkflowName     string `json:"workflow_name" db:"workflow_name" cli:"workflow"`
+	ApplicationName  string `json:"application_name" db:"application_name" cli:"application"`
+	Num              int64  `json:"num" db:"workflow_number" cli:"run"`
+	WorkflowNodeName string `json:"workflow_node_name" db:"workflow_node_name" cli:"node"`
+	Branch           string `json:"branch" db:"branch" cli:"branch"`
+	SBOMName         string `json:"sbom_name" db:"sbom_name" cli:"sbom"`
+	Name             string `json:"name" db:"name" cli:"component"`
+	Version          string `json:"version" db:"version" cli:"version"`
+	PURL             string `json:"purl" db:"purl" cli:"purl"`
+}
+
+// ParseSBOM detects the format of a SBOM and returns its components. Accepted documents are
+// CycloneDX in JSON or XML, and SPDX in JSON or tag-value.
+func ParseSBOM(data []byte) (*SBOMDocument, error) {
+	data = bytes.TrimSpace(data)
+	var doc *SBOMDocument
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte("{")):
+		doc, err = parseJSONSBOM(data)
+	case bytes.HasPrefix(data, []byte("<")):
+		doc, err = parseCycloneDXXML(data)
+	default:
+		doc, err = parseSPDXTagValue(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc.Components = dedupSBOMComponents(doc.Components)
+	return doc, nil
+}
+
+func dedupSBOMComponents(components []SBOMComponent) []SBOMComponent {
+	res := make([]SBOMComponent, 0, len(components))
+	known := make(map[SBOMComponent]struct{}, len(components))
+	for _, c := range components {
+		if c.Name == "" {
+			continue
+		}
+		if _, ok := known[c]; ok {
+			continue
+		}
+		known[c] = struct{}{}
+		res = append(res, c)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Name != res[j].Name {
+			return res[i].Name < res[j].Name
+		}
+		return res[i].Version < res[j].Version
+	})
+	return res
+}
+
+type cycloneDXJSONComponent struct {
+	Group      string                   `json:"group"`
+	Name       string                   `json:"name"`
+	Version    string                   `json:"version"`
+	PURL       string                   `json:"purl"`
+	Components []cycloneDXJSONComponent `json:"components"`
+}
+
+type jsonSBOM struct {
+	// CycloneDX
+	BOMFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	Metadata    struct {
+		Component *cycloneDXJSONComponent `json:"component"`
+	} `json:"metadata"`
+	Components []cycloneDXJSONComponent `json:"components"`
+	// SPDX
+	SPDXVersion string `json:"spdxVersion"`
+	Packages    []struct {
+		Name         string `json:"name"`
+		VersionInfo  string `json:"versionInfo"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+func parseJSONSBOM(data []byte) (*SBOMDocument, error) {
+	var s jsonSBOM
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid SBOM: %v", err)
+	}
+
+	switch {
+	case s.BOMFormat == "CycloneDX":
+		doc := SBOMDocument{Format: SBOMFormatCycloneDX, SpecVersion: s.SpecVersion}
+		var walk func(cs []cycloneDXJSONComponent)
+		walk = func(cs []cycloneDXJSONComponent) {
+			for _, c := range cs {
+				doc.Components = append(doc.Components, cycloneDXComponent(c.Group, c.Name, c.Version, c.PURL))
+				walk(c.Components)
+			}
+		}
+		if s.Metadata.Component != nil {
+			walk([]cycloneDXJSONComponent{*s.Metadata.Component})
+		}
+		walk(s.Components)
+		return &doc, nil
+	case strings.HasPrefix(s.SPDXVersion, "SPDX-"):
+		doc := SBOMDocument{Format: SBOMFormatSPDX, SpecVersion: strings.TrimPrefix(s.SPDXVersion, "SPDX-")}
+		for _, p := range s.Packages {
+			c := SBOMComponent{Name: p.Name, Version: p.VersionInfo}
+			for _, r := range p.ExternalRefs {
+				if r.ReferenceType == "purl" {
+					c.PURL = r.ReferenceLocator
+					break
+				}
+			}
+			doc.Components = append(doc.Components, c)
+		}
+		return &doc, nil
+	}
+	return nil, NewErrorFrom(ErrWrongRequest, "unsupported SBOM format, expected a CycloneDX or SPDX document")
+}
+
+func cycloneDXComponent(group, name, version, purl string) SBOMComponent {
+	if group != "" {
+		name = group + "/" + name
+	}
+	return SBOMComponent{Name: name, Version: version, PURL: purl}
+}
+
+type cycloneDXXMLComponent struct {
+	Group      string                  `xml:"group"`
+	Name       string                  `xml:"name"`
+	Version    string                  `xml:"version"`
+	PURL       string                  `xml:"purl"`
+	Components []cycloneDXXMLComponent `xml:"components>component"`
+}
+
+type cycloneDXXML struct {
+	XMLName  xml.Name `xml:"bom"`
+	Metadata struct {
+		Component *cycloneDXXMLComponent `xml:"component"`
+	} `xml:"metadata"`
+	Components []cycloneDXXMLComponent `xml:"components>component"`
+}
+
+func parseCycloneDXXML(data []byte) (*SBOMDocument, error) {
+	var bom cycloneDXXML
+	if err := xml.Unmarshal(data, &bom); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid SBOM: %v", err)
+	}
+	// The version of the specification is given by the namespace: http://cyclonedx.org/schema/bom/1.4
+	const namespace = "http://cyclonedx.org/schema/bom/"
+	if !strings.HasPrefix(bom.XMLName.Space, namespace) {
+		return nil, NewErrorFrom(ErrWrongRequest, "unsupported SBOM format, expected a CycloneDX or SPDX document")
+	}
+
+	doc := SBOMDocument{Format: SBOMFormatCycloneDX, SpecVersion: strings.TrimPrefix(bom.XMLName.Space, namespace)}
+	var walk func(cs []cycloneDXXMLComponent)
+	walk = func(cs []cycloneDXXMLComponent) {
+		for _, c := range cs {
+			doc.Components = append(doc.Components, cycloneDXComponent(c.Group, c.Name, c.Version, c.PURL))
+			walk(c.Components)
+		}
+	}
+	if bom.Metadata.Component != nil {
+		walk([]cycloneDXXMLComponent{*bom.Metadata.Component})
+	}
+	walk(bom.Components)
+	return &doc, nil
+}
+
+// parseSPDXTagValue parses a SPDX document in the tag-value format, each package starts with a PackageName tag
+func parseSPDXTagValue(data []byte) (*SBOMDocument, error) {
+	var doc SBOMDocument
+	var current *SBOMComponent
+	flush := func() {
+		if current != nil {
+			doc.Components = append(doc.Components, *current)
+			current = nil
+		}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	var inText bool
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Multi-line values are enclosed in <text></text>
+		if inText {
+			inText = !strings.Contains(line, "</text>")
+			continue
+		}
+		i := strings.Index(line, ":")
+		if i < 0 || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		tag, value := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		if strings.HasPrefix(value, "<text>") && !strings.Contains(value, "</text>") {
+			inText = true
+			continue
+		}
+
+		switch tag {
+		case "SPDXVersion":
+			doc.Format = SBOMFormatSPDX
+			doc.SpecVersion = strings.TrimPrefix(value, "SPDX-")
+		case "PackageName":
+			flush()
+			current = &SBOMComponent{Name: value}
+		case "PackageVersion":
+			if current != nil {
+				current.Version = value
+			}
+		case "ExternalRef":
+			// ExternalRef: PACKAGE-MANAGER purl pkg:golang/github.com/ovh/cds@v0.1.0
+			fields := strings.Fields(value)
+			if current != nil && len(fields) == 3 && fields[1] == "purl" && current.PURL == "" {
+				current.PURL = fields[2]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid SBOM: %v", err)
+	}
+	flush()
+
+	if doc.Format != SBOMFormatSPDX {
+		return nil, NewErrorFrom(ErrWrongRequest, "unsupported SBOM format, expected a CycloneDX or SPDX document")
+	}
+	return &doc, nil
+}
@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCycloneDXJSON = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "metadata": {"component": {"name": "my-app", "version": "1.0.0"}},
+  "components": [
+    {"group": "org.apache.logging.log4j", "name": "log4j-core", "version": "2.14.1",
+     "purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+     "components": [{"name": "log4j-api", "version": "2.14.1"}]},
+    {"name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20"},
+    {"name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20"}
+  ]
+}`
+
+const testCycloneDXXML = `<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.3" version="1">
+  <components>
+    <component type="library">
+      <name>lodash</name>
+      <version>4.17.21</version>
+      <purl>pkg:npm/lodash@4.17.21</purl>
+    </component>
+  </components>
+</bom>`
+
+const testSPDXJSON = `{
+  "spdxVersion": "SPDX-2.2",
+  "packages": [
+    {"name": "github.com/ovh/cds", "versionInfo": "v0.1.0",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/ovh/cds@v0.1.0"}]},
+    {"name": "github.com/sirupsen/logrus", "versionInfo": "v1.8.1"}
+  ]
+}`
+
+const testSPDXTagValue = `SPDXVersion: SPDX-2.2
+DataLicense: CC0-1.0
+DocumentComment: <text>A comment
+PackageName: not-a-package
+</text>
+
+PackageName: openssl
+PackageVersion: 1.1.1k
+ExternalRef: PACKAGE-MANAGER purl pkg:deb/debian/openssl@1.1.1k
+
+PackageName: zlib
+PackageVersion: 1.2.11
+`
+
+func TestParseSBOM(t *testing.T) {
+	doc, err := ParseSBOM([]byte(testCycloneDXJSON))
+	require.NoError(t, err)
+	assert.Equal(t, SBOMFormatCycloneDX, doc.Format)
+	assert.Equal(t, "1.4", doc.SpecVersion)
+	assert.Equal(t, []SBOMComponent{
+		{Name: "lodash", Version: "4.17.20", PURL: "pkg:npm/lodash@4.17.20"},
+		{Name: "log4j-api", Version: "2.14.1"},
+		{Name: "my-app", Version: "1.0.0"},
+		{Name: "org.apache.logging.log4j/log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+	}, doc.Components)
+
+	doc, err = ParseSBOM([]byte(testCycloneDXXML))
+	require.NoError(t, err)
+	assert.Equal(t, SBOMFormatCycloneDX, doc.Format)
+	assert.Equal(t, "1.3", doc.SpecVersion)
+	assert.Equal(t, []SBOMComponent{{Name: "lodash", Version: "4.17.21", PURL: "pkg:npm/lodash@4.17.21"}}, doc.Components)
+
+	doc, err = ParseSBOM([]byte(testSPDXJSON))
+	require.NoError(t, err)
+	assert.Equal(t, SBOMFormatSPDX, doc.Format)
+	assert.Equal(t, "2.2", doc.SpecVersion)
+	assert.Equal(t, []SBOMComponent{
+		{Name: "github.com/ovh/cds", Version: "v0.1.0", PURL: "pkg:golang/github.com/ovh/cds@v0.1.0"},
+		{Name: "github.com/sirupsen/logrus", Version: "v1.8.1"},
+	}, doc.Components)
+
+	doc, err = ParseSBOM([]byte(testSPDXTagValue))
+	require.NoError(t, err)
+	assert.Equal(t, SBOMFormatSPDX, doc.Format)
+	assert.Equal(t, []SBOMComponent{
+		{Name: "openssl", Version: "1.1.1k", PURL: "pkg:deb/debian/openssl@1.1.1k"},
+		{Name: "zlib", Version: "1.2.11"},
+	}, doc.Components)
+
+	_, err = ParseSBOM([]byte(`{"foo": "bar"}`))
+	require.Error(t, err)
+	_, err = ParseSBOM([]byte(`<project></project>`))
+	require.Error(t, err)
+	_, err = ParseSBOM([]byte(`hello: world`))
+	require.Error(t, err)
+}
+
+func TestSBOMPURLBase(t *testing.T) {
+	assert.Equal(t, "pkg:npm/lodash@4.17.20", SBOMPURLBase("pkg:npm/lodash@4.17.20"))
+	assert.Equal(t, "pkg:deb/debian/openssl@1.1.1k", SBOMPURLBase("pkg:deb/debian/openssl@1.1.1k?arch=amd64#docs"))
+	assert.Equal(t, "pkg:golang/github.com/ovh/cds", SBOMPURLBase("pkg:golang/github.com/ovh/cds#sdk"))
+	assert.Equal(t, "", SBOMPURLBase(""))
+}
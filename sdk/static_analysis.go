@@ -0,0 +1,263 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Levels of static analysis findings, as defined by SARIF
+const (
+	StaticAnalysisLevelError   = "error"
+	StaticAnalysisLevelWarning = "warning"
+	StaticAnalysisLevelNote    = "note"
+)
+
+var staticAnalysisLevels = map[string]int{
+	StaticAnalysisLevelNote:    1,
+	StaticAnalysisLevelWarning: 2,
+	StaticAnalysisLevelError:   3,
+}
+
+// IsValidStaticAnalysisLevel returns true if the given level is a known finding level
+func IsValidStaticAnalysisLevel(level string) bool {
+	_, ok := staticAnalysisLevels[level]
+	return ok
+}
+
+// StaticAnalysisLevelAtLeast returns true if level is greater or equal to the threshold
+func StaticAnalysisLevelAtLeast(level, threshold string) bool {
+	l, ok := staticAnalysisLevels[level]
+	t, okT := staticAnalysisLevels[threshold]
+	return ok && okT && l >= t
+}
+
+// StaticAnalysisFinding is a result of a static analysis tool
+type StaticAnalysisFinding struct {
+	// Fingerprint identifies a finding across runs, even if the lines of the file moved
+	Fingerprint string `json:"fingerprint"`
+	Tool        string `json:"tool"`
+	RuleID      string `json:"rule_id"`
+	Level       string `json:"level"`
+	Message     string `json:"message"`
+	Path        string `json:"path"`
+	Line        int64  `json:"line"`
+}
+
+// StaticAnalysisWorkerReport is the static analysis report sent by the worker
+type StaticAnalysisWorkerReport struct {
+	Findings []StaticAnalysisFinding `json:"findings"`
+}
+
+// WorkflowNodeRunStaticAnalysisReport represents the static analysis report of a node run
+type WorkflowNodeRunStaticAnalysisReport struct {
+	ID                int64                         `json:"id" db:"id"`
+	ApplicationID     int64                         `json:"application_id" db:"application_id"`
+	WorkflowID        int64                         `json:"workflow_id" db:"workflow_id"`
+	WorkflowRunID     int64                         `json:"workflow_run_id" db:"workflow_run_id"`
+	WorkflowNodeRunID int64                         `json:"workflow_node_run_id" db:"workflow_node_run_id"`
+	WorkflowNodeName  string                        `json:"workflow_node_name" db:"workflow_node_name"`
+	Num               int64                         `json:"num" db:"workflow_number"`
+	Branch            string                        `json:"branch" db:"branch"`
+	Report            WorkflowNodeRunStaticAnalysis `json:"report" db:"-"`
+}
+
+// WorkflowNodeRunStaticAnalysis is the content of the static analysis report of a node run. New and
+// fixed findings are computed against the latest report of the default branch, or against the
+// previous run for a run on the default branch.
+type WorkflowNodeRunStaticAnalysis struct {
+	Findings      []StaticAnalysisFinding `json:"findings"`
+	Summary       map[string]int64        `json:"summary"`
+	ComparedTo    string                  `json:"compared_to,omitempty"`
+	NewFindings   []StaticAnalysisFinding `json:"new_findings"`
+	FixedFindings []StaticAnalysisFinding `json:"fixed_findings"`
+}
+
+// StaticAnalysisSummary returns the count of findings by level
+func StaticAnalysisSummary(findings []StaticAnalysisFinding) map[string]int64 {
+	summary := make(map[string]int64)
+	for _, f := range findings {
+		summary[f.Level]++
+	}
+	return summary
+}
+
+// MergeStaticAnalysisFindings returns the findings of both lists, findings with the same fingerprint are kept once
+func MergeStaticAnalysisFindings(findings []StaticAnalysisFinding, others []StaticAnalysisFinding) []StaticAnalysisFinding {
+	res := make([]StaticAnalysisFinding, 0, len(findings)+len(others))
+	known := make(map[string]struct{}, len(findings)+len(others))
+	for _, list := range [][]StaticAnalysisFinding{findings, others} {
+		for _, f := range list {
+			if _, ok := known[f.Fingerprint]; ok {
+				continue
+			}
+			known[f.Fingerprint] = struct{}{}
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// DiffStaticAnalysisFindings returns the findings that are not in the base findings and the base findings that disappeared
+func DiffStaticAnalysisFindings(current, base []StaticAnalysisFinding) ([]StaticAnalysisFinding, []StaticAnalysisFinding) {
+	currentFingerprints := make(map[string]struct{}, len(current))
+	for _, f := range current {
+		currentFingerprints[f.Fingerprint] = struct{}{}
+	}
+	baseFingerprints := make(map[string]struct{}, len(base))
+	for _, f := range base {
+		baseFingerprints[f.Fingerprint] = struct{}{}
+	}
+
+	newFindings := []StaticAnalysisFinding{}
+	for _, f := range current {
+		if _, ok := baseFingerprints[f.Fingerprint]; !ok {
+			newFindings = append(newFindings, f)
+		}
+	}
+	fixedFindings := []StaticAnalysisFinding{}
+	for _, f := range base {
+		if _, ok := currentFingerprints[f.Fingerprint]; !ok {
+			fixedFindings = append(fixedFindings, f)
+		}
+	}
+	return newFindings, fixedFindings
+}
+
+type sarifLog struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Name  string `json:"name"`
+				Rules []struct {
+					ID                   string `json:"id"`
+					DefaultConfiguration *struct {
+						Level string `json:"level"`
+					} `json:"defaultConfiguration"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			RuleIndex *int   `json:"ruleIndex"`
+			Kind      string `json:"kind"`
+			Level     string `json:"level"`
+			Message   struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region struct {
+						StartLine int64 `json:"startLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+			Fingerprints        map[string]string `json:"fingerprints"`
+			PartialFingerprints map[string]string `json:"partialFingerprints"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+// ParseSARIF returns the findings of a SARIF 2.1.0 log. Results that are not failures (kind pass,
+// informational...) or with level none are ignored.
+func ParseSARIF(data []byte) ([]StaticAnalysisFinding, error) {
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, NewErrorFrom(ErrWrongRequest, "invalid SARIF file: %v", err)
+	}
+	if !strings.HasPrefix(log.Version, "2.") {
+		return nil, NewErrorFrom(ErrWrongRequest, "unsupported SARIF version %q", log.Version)
+	}
+
+	var findings []StaticAnalysisFinding
+	occurrences := make(map[string]int)
+	for _, run := range log.Runs {
+		rules := run.Tool.Driver.Rules
+		for _, r := range run.Results {
+			if r.Kind != "" && r.Kind != "fail" {
+				continue
+			}
+
+			f := StaticAnalysisFinding{
+				Tool:    run.Tool.Driver.Name,
+				RuleID:  r.RuleID,
+				Level:   r.Level,
+				Message: r.Message.Text,
+			}
+			if r.RuleIndex != nil && *r.RuleIndex >= 0 && *r.RuleIndex < len(rules) {
+				rule := rules[*r.RuleIndex]
+				if f.RuleID == "" {
+					f.RuleID = rule.ID
+				}
+				if f.Level == "" && rule.DefaultConfiguration != nil {
+					f.Level = rule.DefaultConfiguration.Level
+				}
+			} else if f.Level == "" {
+				for _, rule := range rules {
+					if rule.ID == f.RuleID && rule.DefaultConfiguration != nil {
+						f.Level = rule.DefaultConfiguration.Level
+						break
+					}
+				}
+			}
+			if f.Level == "" {
+				f.Level = StaticAnalysisLevelWarning
+			}
+			if f.Level == "none" {
+				continue
+			}
+			if !IsValidStaticAnalysisLevel(f.Level) {
+				return nil, NewErrorFrom(ErrWrongRequest, "invalid SARIF level %q for rule %s", f.Level, f.RuleID)
+			}
+
+			if len(r.Locations) > 0 {
+				loc := r.Locations[0].PhysicalLocation
+				f.Path = strings.TrimPrefix(loc.ArtifactLocation.URI, "file://")
+				f.Line = loc.Region.StartLine
+			}
+
+			// Use the fingerprints computed by the tool if any, lines are not used
+			// so findings are still the same when the code around them changes
+			fingerprint := sarifFingerprint(r.Fingerprints)
+			if fingerprint == "" {
+				fingerprint = sarifFingerprint(r.PartialFingerprints)
+			}
+			if fingerprint == "" {
+				fingerprint = f.Message
+			}
+			key := strings.Join([]string{f.Tool, f.RuleID, f.Path, fingerprint}, "\n")
+			occurrences[key]++
+			if n := occurrences[key]; n > 1 {
+				key = fmt.Sprintf("%s\n%d", key, n)
+			}
+			sum := sha256.Sum256([]byte(key))
+			f.Fingerprint = hex.EncodeToString(sum[:])
+
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
+
+func sarifFingerprint(fingerprints map[string]string) string {
+	if len(fingerprints) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(fingerprints))
+	for k := range fingerprints {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = k + "=" + fingerprints[k]
+	}
+	return strings.Join(values, ",")
+}
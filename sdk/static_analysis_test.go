@@ -0,0 +1,67 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSARIF = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "gosec", "rules": [
+      {"id": "G101", "defaultConfiguration": {"level": "error"}},
+      {"id": "G104"}
+    ]}},
+    "results": [
+      {"ruleId": "G101", "ruleIndex": 0, "message": {"text": "Potential hardcoded credentials"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "file://engine/api/foo.go"}, "region": {"startLine": 12}}}]},
+      {"ruleIndex": 1, "level": "note", "message": {"text": "Errors unhandled"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "engine/api/bar.go"}, "region": {"startLine": 3}}}]},
+      {"ruleIndex": 1, "message": {"text": "Errors unhandled"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "engine/api/bar.go"}, "region": {"startLine": 8}}}]},
+      {"ruleId": "G104", "kind": "pass", "message": {"text": "ok"}},
+      {"ruleId": "G104", "level": "none", "message": {"text": "ignored"}}
+    ]
+  }]
+}`
+
+func TestParseSARIF(t *testing.T) {
+	findings, err := ParseSARIF([]byte(testSARIF))
+	require.NoError(t, err)
+	require.Len(t, findings, 3)
+
+	assert.Equal(t, "gosec", findings[0].Tool)
+	assert.Equal(t, "G101", findings[0].RuleID)
+	assert.Equal(t, StaticAnalysisLevelError, findings[0].Level)
+	assert.Equal(t, "engine/api/foo.go", findings[0].Path)
+	assert.Equal(t, int64(12), findings[0].Line)
+
+	assert.Equal(t, "G104", findings[1].RuleID)
+	assert.Equal(t, StaticAnalysisLevelNote, findings[1].Level)
+	assert.Equal(t, StaticAnalysisLevelWarning, findings[2].Level)
+	// Same rule, file and message: findings are still distinct
+	assert.NotEqual(t, findings[1].Fingerprint, findings[2].Fingerprint)
+
+	assert.Equal(t, map[string]int64{"error": 1, "note": 1, "warning": 1}, StaticAnalysisSummary(findings))
+
+	_, err = ParseSARIF([]byte(`{"version": "1.0.0"}`))
+	require.Error(t, err)
+}
+
+func TestDiffStaticAnalysisFindings(t *testing.T) {
+	a := StaticAnalysisFinding{Fingerprint: "a"}
+	b := StaticAnalysisFinding{Fingerprint: "b"}
+	c := StaticAnalysisFinding{Fingerprint: "c"}
+
+	newFindings, fixedFindings := DiffStaticAnalysisFindings([]StaticAnalysisFinding{a, b}, []StaticAnalysisFinding{b, c})
+	assert.Equal(t, []StaticAnalysisFinding{a}, newFindings)
+	assert.Equal(t, []StaticAnalysisFinding{c}, fixedFindings)
+
+	assert.Equal(t, []StaticAnalysisFinding{a, b, c}, MergeStaticAnalysisFindings([]StaticAnalysisFinding{a, b}, []StaticAnalysisFinding{b, c}))
+
+	assert.True(t, StaticAnalysisLevelAtLeast(StaticAnalysisLevelError, StaticAnalysisLevelWarning))
+	assert.True(t, StaticAnalysisLevelAtLeast(StaticAnalysisLevelWarning, StaticAnalysisLevelWarning))
+	assert.False(t, StaticAnalysisLevelAtLeast(StaticAnalysisLevelNote, StaticAnalysisLevelWarning))
+}
@@ -19,6 +19,7 @@ type StaticFiles struct {
 	PublicURL            string    `json:"public_url" db:"public_url" cli:"public_url"`
 	Created              time.Time `json:"created" db:"created" cli:"created"`
 	ProjectIntegrationID *int64    `json:"project_integration_id" db:"project_integration_id"`
+	Size                 int64     `json:"size,omitempty" db:"size"`
 
 	TempURL   string `json:"temp_url,omitempty" db:"-"`
 	SecretKey string `json:"secret_key,omitempty" db:"-"`
@@ -57,12 +57,16 @@ type VCSAuthorizedClient interface {
 	Commits(ctx context.Context, repo, branch, since, until string) ([]VCSCommit, error)
 	Commit(ctx context.Context, repo, hash string) (VCSCommit, error)
 	CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]VCSCommit, error)
+	DiffBetweenRefs(ctx context.Context, repo, base, head string) ([]VCSFileDiff, error)
 
 	// PullRequests
 	PullRequest(context.Context, string, int) (VCSPullRequest, error)
 	PullRequests(context.Context, string) ([]VCSPullRequest, error)
 	PullRequestComment(context.Context, string, int, string) error
+	PullRequestComments(ctx context.Context, repo string, id int) ([]VCSPullRequestComment, error)
+	PullRequestCommentUpdate(ctx context.Context, repo string, id int, comment VCSPullRequestComment) error
 	PullRequestCreate(context.Context, string, VCSPullRequest) (VCSPullRequest, error)
+	PullRequestMerge(ctx context.Context, repo string, id int, headHash string) error
 
 	//Hooks
 	CreateHook(ctx context.Context, repo string, hook *VCSHook) error
@@ -90,6 +94,7 @@ type VCSAuthorizedClient interface {
 
 	// Permissions
 	GrantWritePermission(ctx context.Context, repo string) error
+	UserHasWritePermission(ctx context.Context, repo, username string) (bool, error)
 
 	// Access Token
 	GetAccessToken(ctx context.Context) string
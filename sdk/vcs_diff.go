@@ -0,0 +1,94 @@
+package sdk
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+)
+
+// VCSFileDiff represents the lines added or modified in a file between two refs
+type VCSFileDiff struct {
+	Path       string  `json:"path"`
+	AddedLines []int64 `json:"added_lines"`
+}
+
+// ParseUnifiedDiff returns the lines added in each file of a unified diff (as printed by git diff).
+// Deleted files are ignored, line numbers are the ones of the new version of the files.
+func ParseUnifiedDiff(diff string) []VCSFileDiff {
+	var files []VCSFileDiff
+	var current *VCSFileDiff
+	// line number in the new file and count of lines remaining in the current hunk
+	var line, oldLeft, newLeft int64
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		s := scanner.Text()
+
+		// Inside a hunk, lines are content lines whatever their prefix
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(s, "+"):
+				if current != nil {
+					current.AddedLines = append(current.AddedLines, line)
+				}
+				line++
+				newLeft--
+			case strings.HasPrefix(s, "-"):
+				oldLeft--
+			case strings.HasPrefix(s, `\`):
+				// \ No newline at end of file
+			default:
+				line++
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(s, "diff "):
+			current = nil
+		case strings.HasPrefix(s, "+++ "):
+			path := strings.TrimPrefix(s, "+++ ")
+			if i := strings.IndexByte(path, '\t'); i >= 0 {
+				path = path[:i]
+			}
+			if path == "/dev/null" {
+				current = nil
+				continue
+			}
+			files = append(files, VCSFileDiff{Path: strings.TrimPrefix(path, "b/")})
+			current = &files[len(files)-1]
+		case strings.HasPrefix(s, "@@ "):
+			// @@ -l,s +l,s @@ optional section heading
+			fields := strings.Fields(s)
+			if len(fields) < 3 {
+				continue
+			}
+			_, oldLeft = parseHunkRange(fields[1])
+			line, newLeft = parseHunkRange(fields[2])
+		}
+	}
+
+	// Remove files without added lines, ie. renamed files
+	res := make([]VCSFileDiff, 0, len(files))
+	for _, f := range files {
+		if len(f.AddedLines) > 0 {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// parseHunkRange returns start and count from a hunk range like -12,7 or +3
+func parseHunkRange(s string) (int64, int64) {
+	s = strings.TrimLeft(s, "+-")
+	count := int64(1)
+	if i := strings.IndexByte(s, ','); i >= 0 {
+		count, _ = strconv.ParseInt(s[i+1:], 10, 64)
+		s = s[:i]
+	}
+	start, _ := strconv.ParseInt(s, 10, 64)
+	return start, count
+}
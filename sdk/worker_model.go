@@ -163,6 +163,55 @@ type ModelDocker struct {
 	Envs     map[string]string `json:"envs,omitempty"`
 	Shell    string            `json:"shell,omitempty"`
 	Cmd      string            `json:"cmd,omitempty"`
+	PodSpec  *ModelPodSpec     `json:"pod_spec,omitempty"`
+}
+
+// ModelPodSpec is merged into the pods spawned by the kubernetes hatchery for a docker worker model
+type ModelPodSpec struct {
+	NodeSelector       map[string]string        `json:"node_selector,omitempty" yaml:"node_selector,omitempty"`
+	Tolerations        []ModelPodToleration     `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
+	Resources          *ModelPodResources       `json:"resources,omitempty" yaml:"resources,omitempty"`
+	ServiceAccountName string                   `json:"service_account_name,omitempty" yaml:"service_account_name,omitempty"`
+	SecurityContext    *ModelPodSecurityContext `json:"security_context,omitempty" yaml:"security_context,omitempty"`
+	Volumes            []ModelPodVolume         `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+}
+
+// ModelPodToleration allows the pod to be scheduled on tainted nodes
+type ModelPodToleration struct {
+	Key               string `json:"key,omitempty" yaml:"key,omitempty"`
+	Operator          string `json:"operator,omitempty" yaml:"operator,omitempty"`
+	Value             string `json:"value,omitempty" yaml:"value,omitempty"`
+	Effect            string `json:"effect,omitempty" yaml:"effect,omitempty"`
+	TolerationSeconds *int64 `json:"toleration_seconds,omitempty" yaml:"toleration_seconds,omitempty"`
+}
+
+// ModelPodResources are the requests and the limits of the worker container, ie. "cpu: 500m" or "memory: 2Gi"
+type ModelPodResources struct {
+	Requests map[string]string `json:"requests,omitempty" yaml:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty" yaml:"limits,omitempty"`
+}
+
+// ModelPodSecurityContext is the security context of the worker container
+type ModelPodSecurityContext struct {
+	RunAsUser                *int64 `json:"run_as_user,omitempty" yaml:"run_as_user,omitempty"`
+	RunAsGroup               *int64 `json:"run_as_group,omitempty" yaml:"run_as_group,omitempty"`
+	RunAsNonRoot             *bool  `json:"run_as_non_root,omitempty" yaml:"run_as_non_root,omitempty"`
+	FSGroup                  *int64 `json:"fs_group,omitempty" yaml:"fs_group,omitempty"`
+	Privileged               *bool  `json:"privileged,omitempty" yaml:"privileged,omitempty"`
+	AllowPrivilegeEscalation *bool  `json:"allow_privilege_escalation,omitempty" yaml:"allow_privilege_escalation,omitempty"`
+	ReadOnlyRootFilesystem   *bool  `json:"read_only_root_filesystem,omitempty" yaml:"read_only_root_filesystem,omitempty"`
+}
+
+// ModelPodVolume is a volume mounted in the worker container. Only one source must be set.
+type ModelPodVolume struct {
+	Name                  string `json:"name" yaml:"name"`
+	MountPath             string `json:"mount_path" yaml:"mount_path"`
+	ReadOnly              bool   `json:"read_only,omitempty" yaml:"read_only,omitempty"`
+	EmptyDir              bool   `json:"empty_dir,omitempty" yaml:"empty_dir,omitempty"`
+	ConfigMap             string `json:"config_map,omitempty" yaml:"config_map,omitempty"`
+	Secret                string `json:"secret,omitempty" yaml:"secret,omitempty"`
+	PersistentVolumeClaim string `json:"persistent_volume_claim,omitempty" yaml:"persistent_volume_claim,omitempty"`
+	HostPath              string `json:"host_path,omitempty" yaml:"host_path,omitempty"`
 }
 
 // ModelPattern represent patterns for users and admin when creating a worker model
@@ -34,6 +34,7 @@ type Workflow struct {
 	Usage                   *Usage                       `json:"usage,omitempty" db:"-" cli:"-"`
 	HistoryLength           int64                        `json:"history_length" db:"history_length" cli:"-"`
 	PurgeTags               []string                     `json:"purge_tags,omitempty" db:"-" cli:"-"`
+	RetentionPolicy         *WorkflowRetentionPolicy     `json:"retention_policy,omitempty" db:"-" cli:"-"`
 	Notifications           []WorkflowNotification       `json:"notifications,omitempty" db:"-" cli:"-"`
 	FromRepository          string                       `json:"from_repository,omitempty" db:"from_repository" cli:"from"`
 	DerivedFromWorkflowID   int64                        `json:"derived_from_workflow_id,omitempty" db:"derived_from_workflow_id" cli:"-"`
@@ -201,12 +202,18 @@ func (w *Workflow) ValidateType() error {
 	return nil
 }
 
-//WorkflowNodeConditions is either an array of WorkflowNodeCondition or a lua script
+//WorkflowNodeConditions is either an array of WorkflowNodeCondition with an optional expression or a lua script
 type WorkflowNodeConditions struct {
 	PlainConditions []WorkflowNodeCondition `json:"plain,omitempty" yaml:"check,omitempty"`
+	Expression      string                  `json:"expression,omitempty" yaml:"expression,omitempty"`
 	LuaScript       string                  `json:"lua_script,omitempty" yaml:"script,omitempty"`
 }
 
+// IsEmpty returns true if there is no condition to check.
+func (w WorkflowNodeConditions) IsEmpty() bool {
+	return len(w.PlainConditions) == 0 && w.Expression == "" && w.LuaScript == ""
+}
+
 // Value returns driver.Value from WorkflowNodeConditions request.
 func (w WorkflowNodeConditions) Value() (driver.Value, error) {
 	j, err := json.Marshal(w)
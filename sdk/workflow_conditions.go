@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/ovh/cds/sdk/expression"
 	"github.com/ovh/cds/sdk/interpolate"
 )
 
@@ -37,13 +38,9 @@ func WorkflowCheckConditions(conditions []WorkflowNodeCondition, params []Parame
 	if len(conditions) == 0 {
 		return true, nil
 	}
-	mapParams := ParametersToMap(params)
-	for k, v := range mapParams {
-		var err error
-		mapParams[k], err = interpolate.Do(v, mapParams)
-		if err != nil {
-			return false, fmt.Errorf("Unable to interpolate %s (%v)", v, err)
-		}
+	mapParams, err := interpolateConditionsParameters(params)
+	if err != nil {
+		return false, err
 	}
 
 	var conditionsOK = true
@@ -84,3 +81,44 @@ func WorkflowCheckConditions(conditions []WorkflowNodeCondition, params []Parame
 
 	return conditionsOK, nil
 }
+
+// WorkflowCheckConditionsExpression evaluates a condition expression given a list of parameters
+func WorkflowCheckConditionsExpression(expr string, params []Parameter) (bool, error) {
+	if expr == "" {
+		return true, nil
+	}
+	e, err := expression.Parse(expr)
+	if err != nil {
+		return false, fmt.Errorf("Invalid condition expression %s (%v)", expr, err)
+	}
+	mapParams, err := interpolateConditionsParameters(params)
+	if err != nil {
+		return false, err
+	}
+	ok, err := e.Eval(mapParams)
+	if err != nil {
+		return false, fmt.Errorf("Unable to evaluate condition expression %s (%v)", expr, err)
+	}
+	return ok, nil
+}
+
+// WorkflowCheckPlainConditionsAndExpression checks both plain conditions and expression of given conditions
+func WorkflowCheckPlainConditionsAndExpression(conditions WorkflowNodeConditions, params []Parameter) (bool, error) {
+	ok, err := WorkflowCheckConditions(conditions.PlainConditions, params)
+	if err != nil || !ok {
+		return ok, err
+	}
+	return WorkflowCheckConditionsExpression(conditions.Expression, params)
+}
+
+func interpolateConditionsParameters(params []Parameter) (map[string]string, error) {
+	mapParams := ParametersToMap(params)
+	for k, v := range mapParams {
+		var err error
+		mapParams[k], err = interpolate.Do(v, mapParams)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to interpolate %s (%v)", v, err)
+		}
+	}
+	return mapParams, nil
+}
@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Coverage report formats handled by the coverage action
+const (
+	CoverageFormatLcov         = "lcov"
+	CoverageFormatCobertura    = "cobertura"
+	CoverageFormatClover       = "clover"
+	CoverageFormatCoverprofile = "coverprofile"
+	CoverageFormatJacoco       = "jacoco"
+)
+
+// WorkflowNodeRunCoverageFile is the line coverage of a file of a coverage report
+type WorkflowNodeRunCoverageFile struct {
+	Path           string  `json:"path"`
+	CoveredLines   []int64 `json:"covered_lines"`
+	UncoveredLines []int64 `json:"uncovered_lines"`
+}
+
+// WorkflowNodeRunDiffCoverage is the coverage of the lines changed between the base branch and the commit of a run
+type WorkflowNodeRunDiffCoverage struct {
+	Base         string                            `json:"base"`
+	Head         string                            `json:"head"`
+	TotalLines   int                               `json:"total_lines"`
+	CoveredLines int                               `json:"covered_lines"`
+	Files        []WorkflowNodeRunDiffCoverageFile `json:"files"`
+}
+
+// WorkflowNodeRunDiffCoverageFile is the coverage of the lines changed in a file
+type WorkflowNodeRunDiffCoverageFile struct {
+	Path           string  `json:"path"`
+	TotalLines     int     `json:"total_lines"`
+	CoveredLines   int     `json:"covered_lines"`
+	UncoveredLines []int64 `json:"uncovered_lines"`
+}
+
+// Percent returns the percentage of changed lines that are covered, 100 if no changed line is instrumented
+func (d WorkflowNodeRunDiffCoverage) Percent() float64 {
+	if d.TotalLines == 0 {
+		return 100
+	}
+	return float64(d.CoveredLines) * 100 / float64(d.TotalLines)
+}
+
+// Markdown returns the diff coverage as a markdown text that can be posted on a pull request
+func (d WorkflowNodeRunDiffCoverage) Markdown(title string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s: %.2f%% (%d/%d changed lines covered)\n", title, d.Percent(), d.CoveredLines, d.TotalLines)
+	if len(d.Files) == 0 {
+		return b.String()
+	}
+	b.WriteString("\n| File | Coverage | Uncovered lines |\n|---|---|---|\n")
+	for _, f := range d.Files {
+		fmt.Fprintf(&b, "| %s | %.2f%% (%d/%d) | %s |\n", f.Path, float64(f.CoveredLines)*100/float64(f.TotalLines),
+			f.CoveredLines, f.TotalLines, formatLineRanges(f.UncoveredLines))
+	}
+	return b.String()
+}
+
+// formatLineRanges returns sorted lines as ranges, ie. 3-5, 8
+func formatLineRanges(lines []int64) string {
+	var ranges []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, fmt.Sprintf("%d", lines[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", lines[i], lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ", ")
+}
+
+// ComputeDiffCoverage returns the coverage of the lines added in the diff. Changed lines
+// that are not instrumented (ie. comments or declarations) are not taken into account.
+func ComputeDiffCoverage(files []WorkflowNodeRunCoverageFile, diff []VCSFileDiff) WorkflowNodeRunDiffCoverage {
+	var res WorkflowNodeRunDiffCoverage
+	for _, d := range diff {
+		i := matchCoverageFile(files, d.Path)
+		if i < 0 {
+			continue
+		}
+
+		covered := make(map[int64]struct{}, len(files[i].CoveredLines))
+		for _, l := range files[i].CoveredLines {
+			covered[l] = struct{}{}
+		}
+		uncovered := make(map[int64]struct{}, len(files[i].UncoveredLines))
+		for _, l := range files[i].UncoveredLines {
+			uncovered[l] = struct{}{}
+		}
+
+		f := WorkflowNodeRunDiffCoverageFile{Path: d.Path}
+		for _, l := range d.AddedLines {
+			if _, ok := covered[l]; ok {
+				f.TotalLines++
+				f.CoveredLines++
+			} else if _, ok := uncovered[l]; ok {
+				f.TotalLines++
+				f.UncoveredLines = append(f.UncoveredLines, l)
+			}
+		}
+		if f.TotalLines == 0 {
+			continue
+		}
+		sort.Slice(f.UncoveredLines, func(i, j int) bool { return f.UncoveredLines[i] < f.UncoveredLines[j] })
+
+		res.TotalLines += f.TotalLines
+		res.CoveredLines += f.CoveredLines
+		res.Files = append(res.Files, f)
+	}
+	sort.Slice(res.Files, func(i, j int) bool { return res.Files[i].Path < res.Files[j].Path })
+	return res
+}
+
+// matchCoverageFile returns the index of the coverage file for a path of the repository, -1 if not found.
+// Coverage reports can contain absolute paths, import paths (Go) or paths relative to a source
+// folder (Java), so paths match if one is a suffix of the other.
+func matchCoverageFile(files []WorkflowNodeRunCoverageFile, path string) int {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "./")
+	best, bestLen := -1, 0
+	for i := range files {
+		p := strings.TrimPrefix(filepath.ToSlash(files[i].Path), "./")
+		var l int
+		switch {
+		case p == path:
+			return i
+		case strings.HasSuffix(p, "/"+path):
+			l = len(path)
+		case strings.HasSuffix(path, "/"+p):
+			l = len(p)
+		default:
+			continue
+		}
+		if l > bestLen || (l == bestLen && len(p) < len(files[best].Path)) {
+			best, bestLen = i, l
+		}
+	}
+	return best
+}
@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUnifiedDiff = `diff --git a/engine/api/foo.go b/engine/api/foo.go
+index 83db48f..bf269f4 100644
+--- a/engine/api/foo.go
++++ b/engine/api/foo.go
+@@ -10,5 +10,7 @@ func foo() {
+ 	a := 1
+ 	b := 2
+-	c := 3
++	c := a + b
++	// comment
+++++ not a file header
+ 	return c
+ }
+@@ -40 +42 @@ func bar() {
+-	return 1
++	return 2
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package old
+-
+diff --git a/README.md b/README.md
+new file mode 100644
+--- /dev/null
++++ b/README.md
+@@ -0,0 +1,2 @@
++# Title
++text
+\ No newline at end of file
+`
+
+func TestParseUnifiedDiff(t *testing.T) {
+	diff := ParseUnifiedDiff(testUnifiedDiff)
+	require.Len(t, diff, 2)
+	assert.Equal(t, VCSFileDiff{Path: "engine/api/foo.go", AddedLines: []int64{12, 13, 14, 42}}, diff[0])
+	assert.Equal(t, VCSFileDiff{Path: "README.md", AddedLines: []int64{1, 2}}, diff[1])
+}
+
+func TestComputeDiffCoverage(t *testing.T) {
+	files := []WorkflowNodeRunCoverageFile{
+		{Path: "github.com/ovh/cds/engine/api/foo.go", CoveredLines: []int64{10, 11, 12, 42}, UncoveredLines: []int64{14, 15, 16}},
+		{Path: "github.com/ovh/cds/engine/hooks/foo.go", CoveredLines: []int64{12, 13, 14, 42}},
+		{Path: "com/ovh/Bar.java", CoveredLines: []int64{3}, UncoveredLines: []int64{4, 5, 6}},
+		{Path: "other.go", CoveredLines: []int64{1}},
+	}
+	diff := []VCSFileDiff{
+		{Path: "engine/api/foo.go", AddedLines: []int64{12, 13, 14, 15, 42}},
+		{Path: "src/main/java/com/ovh/Bar.java", AddedLines: []int64{1, 4, 5, 7}},
+		{Path: "README.md", AddedLines: []int64{1, 2}},
+	}
+
+	res := ComputeDiffCoverage(files, diff)
+	assert.Equal(t, 6, res.TotalLines)
+	assert.Equal(t, 2, res.CoveredLines)
+	require.Len(t, res.Files, 2)
+	assert.Equal(t, WorkflowNodeRunDiffCoverageFile{Path: "engine/api/foo.go", TotalLines: 4, CoveredLines: 2, UncoveredLines: []int64{14, 15}}, res.Files[0])
+	assert.Equal(t, WorkflowNodeRunDiffCoverageFile{Path: "src/main/java/com/ovh/Bar.java", TotalLines: 2, CoveredLines: 0, UncoveredLines: []int64{4, 5}}, res.Files[1])
+	assert.InDelta(t, 33.33, res.Percent(), 0.01)
+	assert.Contains(t, res.Markdown("Diff coverage"), "| engine/api/foo.go | 50.00% (2/4) | 14-15 |")
+
+	assert.Equal(t, float64(100), ComputeDiffCoverage(files, nil).Percent())
+}
@@ -89,6 +89,9 @@ func (w *Workflow) FilterHooksConfig(s ...string) {
 // WorkflowHookModelBuiltin is a constant for the builtin hook models
 const WorkflowHookModelBuiltin = "builtin"
 
+// WorkflowHookModelPlugin is a constant for the hook models served by a hook plugin
+const WorkflowHookModelPlugin = "plugin"
+
 //WorkflowNodeHookConfig represents the configguration for a WorkflowNodeHook
 type WorkflowNodeHookConfig map[string]WorkflowNodeHookConfigValue
 
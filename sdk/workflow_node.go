@@ -55,6 +55,15 @@ type NodeContext struct {
 	DefaultPipelineParameters []Parameter            `json:"default_pipeline_parameters" db:"-"`
 	Conditions                WorkflowNodeConditions `json:"conditions" db:"-"`
 	Mutex                     bool                   `json:"mutex" db:"mutex"`
+	Concurrency               *NodeConcurrency       `json:"concurrency,omitempty" db:"-"`
+}
+
+// NodeConcurrency limits the execution of the node to one run at a time for all the nodes of a project
+// with the same concurrency group. The group is interpolated with the node run build parameters.
+type NodeConcurrency struct {
+	Group string `json:"group"`
+	// CancelInProgress stops the previous runs of the group on the same branch when a new one starts
+	CancelInProgress bool `json:"cancel_in_progress,omitempty"`
 }
 
 // FilterHooksConfig filter all hooks configuration and remove somme configuration key
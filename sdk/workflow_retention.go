@@ -0,0 +1,40 @@
+package sdk
+
+// WorkflowRetentionPolicy describes which workflow runs are kept by the purge. Without policy the
+// purge only keeps the last runs of the workflow given by its history length.
+type WorkflowRetentionPolicy struct {
+	// KeepPerBranch is the number of runs kept for each branch, replaces the workflow history length
+	KeepPerBranch int64 `json:"keep_per_branch,omitempty" yaml:"keep_per_branch,omitempty"`
+	// KeepTags are the run tags that protect a run from the purge, ex: git.tag
+	KeepTags []string `json:"keep_tags,omitempty" yaml:"keep_tags,omitempty"`
+	// DeletedBranchRetentionDays is the number of days the runs of a deleted branch are kept
+	DeletedBranchRetentionDays int64 `json:"deleted_branch_retention_days,omitempty" yaml:"deleted_branch_retention_days,omitempty"`
+	// KeepLastSuccessPerEnvironment keeps the last successful run on each environment of the workflow
+	KeepLastSuccessPerEnvironment bool `json:"keep_last_success_per_environment,omitempty" yaml:"keep_last_success_per_environment,omitempty"`
+}
+
+// IsValid returns an error if the retention policy is invalid.
+func (p WorkflowRetentionPolicy) IsValid() error {
+	if p.KeepPerBranch < 0 {
+		return NewErrorFrom(ErrWrongRequest, "retention policy: keep_per_branch should be positive")
+	}
+	if p.DeletedBranchRetentionDays < 0 {
+		return NewErrorFrom(ErrWrongRequest, "retention policy: deleted_branch_retention_days should be positive")
+	}
+	for _, t := range p.KeepTags {
+		if t == "" {
+			return NewErrorFrom(ErrWrongRequest, "retention policy: keep_tags should not contain empty tag")
+		}
+	}
+	return nil
+}
+
+// WorkflowRetentionResult is the decision of the retention policy for a workflow run.
+type WorkflowRetentionResult struct {
+	ID     int64  `json:"id" cli:"-"`
+	Number int64  `json:"num" cli:"number,key"`
+	Branch string `json:"branch" cli:"branch"`
+	Status string `json:"status" cli:"status"`
+	Keep   bool   `json:"keep" cli:"keep"`
+	Reason string `json:"reason" cli:"reason"`
+}
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"encoding/hex"
 	"fmt"
 	"net/url"
 	"sort"
@@ -224,6 +225,7 @@ type WorkflowNodeRun struct {
 	StaticFiles            []StaticFiles                        `json:"static_files,omitempty"`
 	Coverage               WorkflowNodeRunCoverage              `json:"coverage,omitempty"`
 	VulnerabilitiesReport  WorkflowNodeRunVulnerabilityReport   `json:"vulnerabilities_report,omitempty"`
+	StaticAnalysisReport   *WorkflowNodeRunStaticAnalysisReport `json:"static_analysis_report,omitempty"`
 	Tests                  *venom.Tests                         `json:"tests,omitempty"`
 	Commits                []VCSCommit                          `json:"commits,omitempty"`
 	TriggersRun            map[int64]WorkflowNodeTriggerRun     `json:"triggers_run,omitempty"`
@@ -240,6 +242,7 @@ type WorkflowNodeRun struct {
 	HookExecutionID        string                               `json:"execution_id,omitempty"`
 	Callback               *WorkflowNodeOutgoingHookRunCallback `json:"callback,omitempty"`
 	VCSReport              string                               `json:"vcs_report,omitempty"`
+	ConcurrencyGroup       string                               `json:"concurrency_group,omitempty"`
 }
 
 // WorkflowNodeOutgoingHookRunCallback is the callback coming from hooks uservice avec an outgoing hook execution
@@ -283,6 +286,7 @@ type WorkflowNodeRunCoverage struct {
 	Branch            string                        `json:"branch" db:"branch"`
 	Report            coverage.Report               `json:"report" db:"-"`
 	Trend             WorkflowNodeRunCoverageTrends `json:"trend" db:"-"`
+	Diff              *WorkflowNodeRunDiffCoverage  `json:"diff,omitempty" db:"-"`
 }
 
 // WorkflowNodeRunCoverageTrends represents code coverage trend with current branch and default branch
@@ -325,6 +329,39 @@ type WorkflowNodeRunArtifact struct {
 	TempURL              string    `json:"temp_url,omitempty" db:"-"`
 	TempURLSecretKey     string    `json:"-" db:"-"`
 	ProjectIntegrationID *int64    `json:"project_integration_id" db:"project_integration_id"`
+	// BlobContainer is set when the artifact content is stored in a blob shared by all the artifacts with the same content
+	BlobContainer string `json:"-" db:"blob_container"`
+}
+
+// WorkflowNodeRunArtifactBlob is the content of artifacts stored once by project and storage integration,
+// it is deleted when no more artifact references it.
+type WorkflowNodeRunArtifactBlob struct {
+	ID                   int64     `json:"id" db:"id"`
+	ProjectID            int64     `json:"project_id" db:"project_id"`
+	ProjectIntegrationID int64     `json:"project_integration_id" db:"project_integration_id"`
+	SHA512sum            string    `json:"sha512sum" db:"sha512sum"`
+	Size                 int64     `json:"size" db:"size"`
+	RefCount             int64     `json:"ref_count" db:"ref_count"`
+	Created              time.Time `json:"created" db:"created"`
+}
+
+// GetName returns the name of the blob
+func (b *WorkflowNodeRunArtifactBlob) GetName() string {
+	return b.SHA512sum
+}
+
+// GetPath returns the path of the blob
+func (b *WorkflowNodeRunArtifactBlob) GetPath() string {
+	return fmt.Sprintf("blobs-%d", b.ProjectID)
+}
+
+// IsValidSHA512sum returns true if given string is a lowercase sha512 hex checksum.
+func IsValidSHA512sum(s string) bool {
+	if len(s) != 128 || strings.ToLower(s) != s {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
 }
 
 // Equal returns true if w WorkflowNodeRunArtifact equals c
@@ -437,11 +474,17 @@ type WorkflowNodeRunManual struct {
 
 //GetName returns the name the artifact
 func (w *WorkflowNodeRunArtifact) GetName() string {
+	if w.BlobContainer != "" {
+		return w.SHA512sum
+	}
 	return w.Name
 }
 
 //GetPath returns the path of the artifact
 func (w *WorkflowNodeRunArtifact) GetPath() string {
+	if w.BlobContainer != "" {
+		return w.BlobContainer
+	}
 	ref := w.Ref
 	if ref == "" {
 		ref = w.Tag
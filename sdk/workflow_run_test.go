@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -238,3 +239,19 @@ func TestWorkflowQueue_Sort(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflowNodeRunArtifactBlob(t *testing.T) {
+	sha512sum := "ac87e0b3a8fb9a87c7c1f0c4d4a0e3a0f7d06c0d9dd8d2d6b0d8c1e8d7b4f6e2ac87e0b3a8fb9a87c7c1f0c4d4a0e3a0f7d06c0d9dd8d2d6b0d8c1e8d7b4f6e2"
+	assert.True(t, IsValidSHA512sum(sha512sum))
+	assert.False(t, IsValidSHA512sum(""))
+	assert.False(t, IsValidSHA512sum(strings.ToUpper(sha512sum)))
+	assert.False(t, IsValidSHA512sum(sha512sum[:127]+"z"))
+
+	art := WorkflowNodeRunArtifact{Name: "foo", Tag: "1", WorkflowID: 1, WorkflowNodeRunID: 2, SHA512sum: sha512sum}
+	assert.Equal(t, "foo", art.GetName())
+
+	blob := WorkflowNodeRunArtifactBlob{ProjectID: 3, SHA512sum: sha512sum}
+	art.BlobContainer = blob.GetPath()
+	assert.Equal(t, "blobs-3", art.GetPath())
+	assert.Equal(t, sha512sum, art.GetName())
+}
@@ -0,0 +1,206 @@
+package sdk
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ovh/venom"
+)
+
+// Status of a test case result
+const (
+	TestResultStatusSuccess = "Success"
+	TestResultStatusFail    = "Fail"
+	TestResultStatusSkipped = "Skipped"
+)
+
+// WorkflowTestResult is the result of a test case in a workflow node run, the results are kept
+// with the workflow runs to compute the history of each test.
+type WorkflowTestResult struct {
+	ID                int64     `json:"id" db:"id" cli:"-"`
+	WorkflowID        int64     `json:"workflow_id" db:"workflow_id" cli:"-"`
+	WorkflowRunID     int64     `json:"workflow_run_id" db:"workflow_run_id" cli:"-"`
+	WorkflowNodeRunID int64     `json:"workflow_node_run_id" db:"workflow_node_run_id" cli:"-"`
+	RunNumber         int64     `json:"run_number" db:"run_number" cli:"run,key"`
+	NodeName          string    `json:"node_name" db:"node_name" cli:"node"`
+	Suite             string    `json:"suite" db:"suite" cli:"suite"`
+	Name              string    `json:"name" db:"name" cli:"name"`
+	Status            string    `json:"status" db:"status" cli:"status"`
+	Duration          float64   `json:"duration" db:"duration" cli:"duration"`
+	VCSBranch         string    `json:"vcs_branch" db:"vcs_branch" cli:"branch"`
+	VCSHash           string    `json:"vcs_hash" db:"vcs_hash" cli:"hash"`
+	Created           time.Time `json:"created" db:"created" cli:"created"`
+}
+
+func (r WorkflowTestResult) key() string {
+	return r.Suite + "\x00" + r.Name
+}
+
+// NewWorkflowTestResults returns the results of all the test cases of a tests report.
+func NewWorkflowTestResults(tests venom.Tests) []WorkflowTestResult {
+	var res []WorkflowTestResult
+	for _, ts := range tests.TestSuites {
+		for _, tc := range ts.TestCases {
+			r := WorkflowTestResult{
+				Suite:  ts.Name,
+				Name:   tc.Name,
+				Status: TestResultStatusSuccess,
+			}
+			switch {
+			case len(tc.Failures) > 0 || len(tc.Errors) > 0:
+				r.Status = TestResultStatusFail
+			case len(tc.Skipped) > 0:
+				r.Status = TestResultStatusSkipped
+			}
+			r.Duration, _ = strconv.ParseFloat(tc.Time, 64)
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// WorkflowTestFlakiness is the flaky score of a test: the rate of status changes between the runs of a same commit.
+type WorkflowTestFlakiness struct {
+	Suite         string  `json:"suite" cli:"suite"`
+	Name          string  `json:"name" cli:"name,key"`
+	Runs          int     `json:"runs" cli:"runs"`
+	Failures      int     `json:"failures" cli:"failures"`
+	Flips         int     `json:"flips" cli:"flips"`
+	Score         float64 `json:"score" cli:"score"`
+	LastStatus    string  `json:"last_status" cli:"last_status"`
+	LastRunNumber int64   `json:"last_run_number" cli:"last_run"`
+}
+
+// ComputeTestsFlakiness returns the tests that passed and failed on a same commit, from the flakiest to the least flaky.
+// The score of a test is its number of status changes divided by the number of runs that could have changed it,
+// ie. runs of a commit already tested.
+func ComputeTestsFlakiness(results []WorkflowTestResult) []WorkflowTestFlakiness {
+	byTest := map[string][]WorkflowTestResult{}
+	var keys []string
+	for _, r := range results {
+		if r.Status == TestResultStatusSkipped {
+			continue
+		}
+		k := r.key()
+		if _, ok := byTest[k]; !ok {
+			keys = append(keys, k)
+		}
+		byTest[k] = append(byTest[k], r)
+	}
+
+	res := []WorkflowTestFlakiness{}
+	for _, k := range keys {
+		rs := byTest[k]
+		sort.Slice(rs, func(i, j int) bool {
+			if rs[i].RunNumber != rs[j].RunNumber {
+				return rs[i].RunNumber < rs[j].RunNumber
+			}
+			return rs[i].WorkflowNodeRunID < rs[j].WorkflowNodeRunID
+		})
+
+		f := WorkflowTestFlakiness{
+			Suite:         rs[0].Suite,
+			Name:          rs[0].Name,
+			Runs:          len(rs),
+			LastStatus:    rs[len(rs)-1].Status,
+			LastRunNumber: rs[len(rs)-1].RunNumber,
+		}
+		var retries int
+		lastStatusByCommit := map[string]string{}
+		for _, r := range rs {
+			if r.Status == TestResultStatusFail {
+				f.Failures++
+			}
+			if r.VCSHash == "" {
+				continue
+			}
+			if previous, ok := lastStatusByCommit[r.VCSHash]; ok {
+				retries++
+				if previous != r.Status {
+					f.Flips++
+				}
+			}
+			lastStatusByCommit[r.VCSHash] = r.Status
+		}
+		if f.Flips == 0 {
+			continue
+		}
+		f.Score = float64(f.Flips) / float64(retries)
+		res = append(res, f)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		return res[i].Flips > res[j].Flips
+	})
+	return res
+}
+
+// WorkflowTestsDiff compares the failing tests of a run with the previous run on the same branch.
+type WorkflowTestsDiff struct {
+	RunNumber         int64                `json:"run_number"`
+	PreviousRunNumber int64                `json:"previous_run_number,omitempty"`
+	Branch            string               `json:"branch"`
+	NewlyFailing      []WorkflowTestResult `json:"newly_failing"`
+	AlreadyFailing    []WorkflowTestResult `json:"already_failing"`
+	Fixed             []WorkflowTestResult `json:"fixed"`
+}
+
+// latestTestResults keeps the latest result of each test, ie. the result of the last restart of a node.
+// A test run by several jobs of the same node run is failing if it failed in one of them.
+func latestTestResults(results []WorkflowTestResult) ([]string, map[string]WorkflowTestResult) {
+	var keys []string
+	latest := map[string]WorkflowTestResult{}
+	for _, r := range results {
+		k := r.key()
+		l, ok := latest[k]
+		if !ok {
+			keys = append(keys, k)
+		}
+		if !ok || r.WorkflowNodeRunID > l.WorkflowNodeRunID ||
+			(r.WorkflowNodeRunID == l.WorkflowNodeRunID && l.Status != TestResultStatusFail) {
+			latest[k] = r
+		}
+	}
+	return keys, latest
+}
+
+// DiffTestResults returns the tests failing in the current results that were not failing in the previous ones,
+// the tests that were already failing and the tests that are fixed.
+func DiffTestResults(current, previous []WorkflowTestResult) WorkflowTestsDiff {
+	diff := WorkflowTestsDiff{
+		NewlyFailing:   []WorkflowTestResult{},
+		AlreadyFailing: []WorkflowTestResult{},
+		Fixed:          []WorkflowTestResult{},
+	}
+	_, previousResults := latestTestResults(previous)
+	keys, currentResults := latestTestResults(current)
+	for _, k := range keys {
+		r := currentResults[k]
+		p, ok := previousResults[k]
+		previousFailed := ok && p.Status == TestResultStatusFail
+		switch {
+		case r.Status == TestResultStatusFail && previousFailed:
+			diff.AlreadyFailing = append(diff.AlreadyFailing, r)
+		case r.Status == TestResultStatusFail:
+			diff.NewlyFailing = append(diff.NewlyFailing, r)
+		case r.Status == TestResultStatusSuccess && previousFailed:
+			diff.Fixed = append(diff.Fixed, r)
+		}
+	}
+	return diff
+}
+
+// WorkflowTestsTrend is the summary of the tests results of a workflow run.
+type WorkflowTestsTrend struct {
+	RunNumber int64   `json:"run_number" db:"run_number" cli:"run,key"`
+	Branch    string  `json:"branch" db:"vcs_branch" cli:"branch"`
+	Total     int     `json:"total" db:"total" cli:"total"`
+	OK        int     `json:"ok" db:"ok" cli:"ok"`
+	KO        int     `json:"ko" db:"ko" cli:"ko"`
+	Skipped   int     `json:"skipped" db:"skipped" cli:"skipped"`
+	Duration  float64 `json:"duration" db:"duration" cli:"duration"`
+}
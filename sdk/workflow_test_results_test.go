@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/ovh/venom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWorkflowTestResults(t *testing.T) {
+	res := NewWorkflowTestResults(venom.Tests{
+		TestSuites: []venom.TestSuite{{
+			Name: "pkg",
+			TestCases: []venom.TestCase{
+				{Name: "TestOK", Time: "1.5"},
+				{Name: "TestKO", Failures: []venom.Failure{{Value: "failed"}}},
+				{Name: "TestSkipped", Skipped: []venom.Skipped{{Value: "skipped"}}},
+			},
+		}},
+	})
+	require.Len(t, res, 3)
+	assert.Equal(t, WorkflowTestResult{Suite: "pkg", Name: "TestOK", Status: TestResultStatusSuccess, Duration: 1.5}, res[0])
+	assert.Equal(t, TestResultStatusFail, res[1].Status)
+	assert.Equal(t, TestResultStatusSkipped, res[2].Status)
+}
+
+func TestComputeTestsFlakiness(t *testing.T) {
+	result := func(num, nodeRunID int64, name, hash, status string) WorkflowTestResult {
+		return WorkflowTestResult{RunNumber: num, WorkflowNodeRunID: nodeRunID, Suite: "pkg", Name: name, VCSHash: hash, Status: status}
+	}
+	res := ComputeTestsFlakiness([]WorkflowTestResult{
+		// TestFlaky fails then passes on the same commit
+		result(1, 1, "TestFlaky", "aaa", TestResultStatusFail),
+		result(1, 2, "TestFlaky", "aaa", TestResultStatusSuccess),
+		result(2, 3, "TestFlaky", "bbb", TestResultStatusSuccess),
+		result(2, 4, "TestFlaky", "bbb", TestResultStatusSuccess),
+		// TestBroken fails on a commit and is fixed on the next one
+		result(1, 1, "TestBroken", "aaa", TestResultStatusFail),
+		result(1, 2, "TestBroken", "aaa", TestResultStatusFail),
+		result(2, 3, "TestBroken", "bbb", TestResultStatusSuccess),
+		// TestVeryFlaky changes on each run
+		result(3, 5, "TestVeryFlaky", "ccc", TestResultStatusSuccess),
+		result(4, 6, "TestVeryFlaky", "ccc", TestResultStatusFail),
+		result(5, 7, "TestVeryFlaky", "ccc", TestResultStatusSkipped),
+		result(6, 8, "TestVeryFlaky", "ccc", TestResultStatusSuccess),
+	})
+	require.Len(t, res, 2)
+	assert.Equal(t, WorkflowTestFlakiness{Suite: "pkg", Name: "TestVeryFlaky", Runs: 3, Failures: 1, Flips: 2, Score: 1, LastStatus: TestResultStatusSuccess, LastRunNumber: 6}, res[0])
+	assert.Equal(t, WorkflowTestFlakiness{Suite: "pkg", Name: "TestFlaky", Runs: 4, Failures: 1, Flips: 1, Score: 0.5, LastStatus: TestResultStatusSuccess, LastRunNumber: 2}, res[1])
+}
+
+func TestDiffTestResults(t *testing.T) {
+	result := func(nodeRunID int64, name, status string) WorkflowTestResult {
+		return WorkflowTestResult{WorkflowNodeRunID: nodeRunID, Suite: "pkg", Name: name, Status: status}
+	}
+	previous := []WorkflowTestResult{
+		result(1, "TestAlreadyKO", TestResultStatusFail),
+		result(1, "TestNewKO", TestResultStatusSuccess),
+		result(1, "TestFixed", TestResultStatusFail),
+		result(1, "TestOK", TestResultStatusSuccess),
+	}
+	current := []WorkflowTestResult{
+		result(2, "TestAlreadyKO", TestResultStatusFail),
+		result(2, "TestNewKO", TestResultStatusFail),
+		result(2, "TestFixed", TestResultStatusSuccess),
+		result(2, "TestOK", TestResultStatusSuccess),
+		result(2, "TestAdded", TestResultStatusFail),
+		// The node was restarted and the test passed
+		result(2, "TestRestarted", TestResultStatusFail),
+		result(3, "TestRestarted", TestResultStatusSuccess),
+		// The test is run by two jobs of the node run and fails in one of them
+		result(2, "TestTwoJobs", TestResultStatusFail),
+		result(2, "TestTwoJobs", TestResultStatusSuccess),
+	}
+
+	diff := DiffTestResults(current, previous)
+	assert.Equal(t, []WorkflowTestResult{current[1], current[4], current[7]}, diff.NewlyFailing)
+	assert.Equal(t, []WorkflowTestResult{current[0]}, diff.AlreadyFailing)
+	assert.Equal(t, []WorkflowTestResult{current[2]}, diff.Fixed)
+}